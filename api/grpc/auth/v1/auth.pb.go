@@ -74,6 +74,8 @@ type AuthNRes struct {
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	UserRole      uint32                 `protobuf:"varint,3,opt,name=user_role,json=userRole,proto3" json:"user_role,omitempty"`
 	Verified      bool                   `protobuf:"varint,4,opt,name=verified,proto3" json:"verified,omitempty"`
+	KeyType       uint32                 `protobuf:"varint,5,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
+	Scopes        []string               `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -136,6 +138,20 @@ func (x *AuthNRes) GetVerified() bool {
 	return false
 }
 
+func (x *AuthNRes) GetKeyType() uint32 {
+	if x != nil {
+		return x.KeyType
+	}
+	return 0
+}
+
+func (x *AuthNRes) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
 type PolicyReq struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Domain          string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
@@ -432,18 +448,64 @@ func (x *AuthZRes) GetId() string {
 	return ""
 }
 
+type StreamRes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policies      []string               `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRes) Reset() {
+	*x = StreamRes{}
+	mi := &file_auth_v1_auth_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRes) ProtoMessage() {}
+
+func (x *StreamRes) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_v1_auth_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRes.ProtoReflect.Descriptor instead.
+func (*StreamRes) Descriptor() ([]byte, []int) {
+	return file_auth_v1_auth_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StreamRes) GetPolicies() []string {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
 var File_auth_v1_auth_proto protoreflect.FileDescriptor
 
 const file_auth_v1_auth_proto_rawDesc = "" +
 	"\n" +
 	"\x12auth/v1/auth.proto\x12\aauth.v1\" \n" +
 	"\bAuthNReq\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"l\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x9f\x01\n" +
 	"\bAuthNRes\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
 	"\tuser_role\x18\x03 \x01(\rR\buserRole\x12\x1a\n" +
-	"\bverified\x18\x04 \x01(\bR\bverified\"\xa3\x02\n" +
+	"\bverified\x18\x04 \x01(\bR\bverified\x12\x19\n" +
+	"\bkey_type\x18\x05 \x01(\rR\akeyType\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\"\xa3\x02\n" +
 	"\tPolicyReq\x12\x16\n" +
 	"\x06domain\x18\x01 \x01(\tR\x06domain\x12!\n" +
 	"\fsubject_type\x18\x02 \x01(\tR\vsubjectType\x12!\n" +
@@ -475,10 +537,14 @@ const file_auth_v1_auth_proto_rawDesc = "" +
 	"\n" +
 	"authorized\x18\x01 \x01(\bR\n" +
 	"authorized\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id2z\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"'\n" +
+	"\tStreamRes\x12\x1a\n" +
+	"\bpolicies\x18\x01 \x03(\tR\bpolicies2\xf5\x01\n" +
 	"\vAuthService\x123\n" +
 	"\tAuthorize\x12\x11.auth.v1.AuthZReq\x1a\x11.auth.v1.AuthZRes\"\x00\x126\n" +
-	"\fAuthenticate\x12\x11.auth.v1.AuthNReq\x1a\x11.auth.v1.AuthNRes\"\x00B-Z+github.com/absmach/supermq/api/grpc/auth/v1b\x06proto3"
+	"\fAuthenticate\x12\x11.auth.v1.AuthNReq\x1a\x11.auth.v1.AuthNRes\"\x00\x12;\n" +
+	"\rStreamObjects\x12\x12.auth.v1.PolicyReq\x1a\x12.auth.v1.StreamRes\"\x000\x01\x12<\n" +
+	"\x0eStreamSubjects\x12\x12.auth.v1.PolicyReq\x1a\x12.auth.v1.StreamRes\"\x000\x01B-Z+github.com/absmach/supermq/api/grpc/auth/v1b\x06proto3"
 
 var (
 	file_auth_v1_auth_proto_rawDescOnce sync.Once
@@ -492,7 +558,7 @@ func file_auth_v1_auth_proto_rawDescGZIP() []byte {
 	return file_auth_v1_auth_proto_rawDescData
 }
 
-var file_auth_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_auth_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_auth_v1_auth_proto_goTypes = []any{
 	(*AuthNReq)(nil),  // 0: auth.v1.AuthNReq
 	(*AuthNRes)(nil),  // 1: auth.v1.AuthNRes
@@ -500,16 +566,21 @@ var file_auth_v1_auth_proto_goTypes = []any{
 	(*PATReq)(nil),    // 3: auth.v1.PATReq
 	(*AuthZReq)(nil),  // 4: auth.v1.AuthZReq
 	(*AuthZRes)(nil),  // 5: auth.v1.AuthZRes
+	(*StreamRes)(nil), // 6: auth.v1.StreamRes
 }
 var file_auth_v1_auth_proto_depIdxs = []int32{
 	2, // 0: auth.v1.AuthZReq.policy_req:type_name -> auth.v1.PolicyReq
 	3, // 1: auth.v1.AuthZReq.pat_req:type_name -> auth.v1.PATReq
 	4, // 2: auth.v1.AuthService.Authorize:input_type -> auth.v1.AuthZReq
 	0, // 3: auth.v1.AuthService.Authenticate:input_type -> auth.v1.AuthNReq
-	5, // 4: auth.v1.AuthService.Authorize:output_type -> auth.v1.AuthZRes
-	1, // 5: auth.v1.AuthService.Authenticate:output_type -> auth.v1.AuthNRes
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
+	2, // 4: auth.v1.AuthService.StreamObjects:input_type -> auth.v1.PolicyReq
+	2, // 5: auth.v1.AuthService.StreamSubjects:input_type -> auth.v1.PolicyReq
+	5, // 6: auth.v1.AuthService.Authorize:output_type -> auth.v1.AuthZRes
+	1, // 7: auth.v1.AuthService.Authenticate:output_type -> auth.v1.AuthNRes
+	6, // 8: auth.v1.AuthService.StreamObjects:output_type -> auth.v1.StreamRes
+	6, // 9: auth.v1.AuthService.StreamSubjects:output_type -> auth.v1.StreamRes
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
 	2, // [2:2] is the sub-list for extension extendee
 	0, // [0:2] is the sub-list for field type_name
@@ -527,7 +598,7 @@ func file_auth_v1_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_v1_auth_proto_rawDesc), len(file_auth_v1_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
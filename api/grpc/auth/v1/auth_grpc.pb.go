@@ -22,8 +22,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Authorize_FullMethodName    = "/auth.v1.AuthService/Authorize"
-	AuthService_Authenticate_FullMethodName = "/auth.v1.AuthService/Authenticate"
+	AuthService_Authorize_FullMethodName      = "/auth.v1.AuthService/Authorize"
+	AuthService_Authenticate_FullMethodName   = "/auth.v1.AuthService/Authenticate"
+	AuthService_StreamObjects_FullMethodName  = "/auth.v1.AuthService/StreamObjects"
+	AuthService_StreamSubjects_FullMethodName = "/auth.v1.AuthService/StreamSubjects"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -35,6 +37,8 @@ const (
 type AuthServiceClient interface {
 	Authorize(ctx context.Context, in *AuthZReq, opts ...grpc.CallOption) (*AuthZRes, error)
 	Authenticate(ctx context.Context, in *AuthNReq, opts ...grpc.CallOption) (*AuthNRes, error)
+	StreamObjects(ctx context.Context, in *PolicyReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamRes], error)
+	StreamSubjects(ctx context.Context, in *PolicyReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamRes], error)
 }
 
 type authServiceClient struct {
@@ -65,6 +69,44 @@ func (c *authServiceClient) Authenticate(ctx context.Context, in *AuthNReq, opts
 	return out, nil
 }
 
+func (c *authServiceClient) StreamObjects(ctx context.Context, in *PolicyReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamRes], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AuthService_ServiceDesc.Streams[0], AuthService_StreamObjects_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PolicyReq, StreamRes]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuthService_StreamObjectsClient = grpc.ServerStreamingClient[StreamRes]
+
+func (c *authServiceClient) StreamSubjects(ctx context.Context, in *PolicyReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamRes], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AuthService_ServiceDesc.Streams[1], AuthService_StreamSubjects_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PolicyReq, StreamRes]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuthService_StreamSubjectsClient = grpc.ServerStreamingClient[StreamRes]
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -74,6 +116,8 @@ func (c *authServiceClient) Authenticate(ctx context.Context, in *AuthNReq, opts
 type AuthServiceServer interface {
 	Authorize(context.Context, *AuthZReq) (*AuthZRes, error)
 	Authenticate(context.Context, *AuthNReq) (*AuthNRes, error)
+	StreamObjects(*PolicyReq, grpc.ServerStreamingServer[StreamRes]) error
+	StreamSubjects(*PolicyReq, grpc.ServerStreamingServer[StreamRes]) error
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -90,6 +134,12 @@ func (UnimplementedAuthServiceServer) Authorize(context.Context, *AuthZReq) (*Au
 func (UnimplementedAuthServiceServer) Authenticate(context.Context, *AuthNReq) (*AuthNRes, error) {
 	return nil, status.Error(codes.Unimplemented, "method Authenticate not implemented")
 }
+func (UnimplementedAuthServiceServer) StreamObjects(*PolicyReq, grpc.ServerStreamingServer[StreamRes]) error {
+	return status.Error(codes.Unimplemented, "method StreamObjects not implemented")
+}
+func (UnimplementedAuthServiceServer) StreamSubjects(*PolicyReq, grpc.ServerStreamingServer[StreamRes]) error {
+	return status.Error(codes.Unimplemented, "method StreamSubjects not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -147,6 +197,28 @@ func _AuthService_Authenticate_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_StreamObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PolicyReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuthServiceServer).StreamObjects(m, &grpc.GenericServerStream[PolicyReq, StreamRes]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuthService_StreamObjectsServer = grpc.ServerStreamingServer[StreamRes]
+
+func _AuthService_StreamSubjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PolicyReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuthServiceServer).StreamSubjects(m, &grpc.GenericServerStream[PolicyReq, StreamRes]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuthService_StreamSubjectsServer = grpc.ServerStreamingServer[StreamRes]
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -163,6 +235,17 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _AuthService_Authenticate_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamObjects",
+			Handler:       _AuthService_StreamObjects_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamSubjects",
+			Handler:       _AuthService_StreamSubjects_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "auth/v1/auth.proto",
 }
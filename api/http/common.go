@@ -26,6 +26,7 @@ const (
 	OrderKey  = "order"
 	LimitKey  = "limit"
 	OnlyTotal = "only_total"
+	ApproxKey = "approx_count"
 
 	NameOrder      = "name"
 	IDOrder        = "id"
@@ -34,17 +35,22 @@ const (
 	UpdatedAtOrder = "updated_at"
 	CreatedAtOrder = "created_at"
 
-	MetadataKey = "metadata"
-	NameKey     = "name"
-	TagKey      = "tag"
-	TagsKey     = "tags"
-	StatusKey   = "status"
+	MetadataKey       = "metadata"
+	MetadataFilterKey = "metadata_filter"
+	IDsKey            = "ids"
+	NameKey           = "name"
+	NamePrefixKey     = "name_prefix"
+	TagKey            = "tag"
+	TagsKey           = "tags"
+	StatusKey         = "status"
 
-	ClientKey   = "client"
-	ChannelKey  = "channel"
-	ConnTypeKey = "connection_type"
-	GroupKey    = "group"
-	DomainKey   = "domain"
+	ClientKey       = "client"
+	ChannelKey      = "channel"
+	ConnTypeKey     = "connection_type"
+	DisconnectedKey = "disconnected"
+	GroupKey        = "group"
+	DomainKey       = "domain"
+	UserScopedKey   = "user_scoped"
 
 	StartLevelKey = "start_level"
 	EndLevelKey   = "end_level"
@@ -221,6 +227,30 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 		return
+	case *errors.ConflictError:
+		w.WriteHeader(http.StatusConflict)
+		if err := json.NewEncoder(w).Encode(retErr); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	case *errors.PayloadTooLargeError:
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		if err := json.NewEncoder(w).Encode(retErr); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	case *errors.PreconditionError:
+		w.WriteHeader(http.StatusPreconditionFailed)
+		if err := json.NewEncoder(w).Encode(retErr); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	case *errors.TimeoutError:
+		w.WriteHeader(http.StatusGatewayTimeout)
+		if err := json.NewEncoder(w).Encode(retErr); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
 	case *errors.InternalError:
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -15,6 +15,7 @@ import (
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/stretchr/testify/assert"
 )
@@ -254,6 +255,24 @@ func TestEncodeError(t *testing.T) {
 			code:    http.StatusNotFound,
 			hasBody: true,
 		},
+		{
+			desc:    "PreconditionError - Precondition Failed",
+			err:     errors.Wrap(svcerr.ErrUpdateEntity, repoerr.ErrPreconditionFailed),
+			code:    http.StatusPreconditionFailed,
+			hasBody: true,
+		},
+		{
+			desc:    "TimeoutError - Gateway Timeout",
+			err:     errors.Wrap(svcerr.ErrViewEntity, errors.Wrap(repoerr.ErrDeadlineExceeded, context.DeadlineExceeded)),
+			code:    http.StatusGatewayTimeout,
+			hasBody: true,
+		},
+		{
+			desc:    "ConflictError - Conflict",
+			err:     errors.Wrap(svcerr.ErrCreateEntity, errors.NewConflictError("name already exists")),
+			code:    http.StatusConflict,
+			hasBody: true,
+		},
 		{
 			desc:    "AuthNError - Authentication Failed",
 			err:     svcerr.ErrAuthentication,
@@ -278,6 +297,12 @@ func TestEncodeError(t *testing.T) {
 			code:    http.StatusUnsupportedMediaType,
 			hasBody: true,
 		},
+		{
+			desc:    "PayloadTooLargeError - Request Too Large",
+			err:     apiutil.ErrRequestTooLarge,
+			code:    http.StatusRequestEntityTooLarge,
+			hasBody: true,
+		},
 		{
 			desc:    "ServiceError - Create Entity Failed",
 			err:     svcerr.ErrCreateEntity,
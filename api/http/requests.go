@@ -4,24 +4,98 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"mime"
 	"net/http"
+	"slices"
 
 	"github.com/absmach/supermq"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
+// RequestIDHeader is the HTTP header used to pass the request ID between
+// the client and the service.
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLen = 128
+
+// RequestIDMiddleware stores the request ID in the request context, so it
+// is logged by the service logging middleware. The ID is taken from the
+// X-Request-ID header if the client provided a valid one, otherwise a new
+// one is generated. The ID is returned in the X-Request-ID response header,
+// so a failed request can be correlated with the service logs.
 func RequestIDMiddleware(idp supermq.IDProvider) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			requestID, err := idp.ID()
-			if err != nil {
-				EncodeError(r.Context(), err, w)
-				return
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID(requestID) {
+				id, err := idp.ID()
+				if err != nil {
+					EncodeError(r.Context(), err, w)
+					return
+				}
+				requestID = id
 			}
 
+			w.Header().Set(RequestIDHeader, requestID)
 			ctx := context.WithValue(r.Context(), middleware.RequestIDKey, requestID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// validRequestID reports whether the client provided request ID can be
+// safely written to logs, i.e. it is short and has only printable ASCII.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LimitBodyMiddleware rejects requests with a body larger than maxSize
+// bytes or with a Content-Type which is not one of contentTypes. The body
+// is read before calling the next handler, so the size error is reported
+// instead of a failed decoding. GET and HEAD requests are passed through.
+func LimitBodyMiddleware(maxSize int64, contentTypes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || !slices.Contains(contentTypes, mediaType) {
+				EncodeError(r.Context(), apiutil.ErrUnsupportedContentType, w)
+				return
+			}
+			if r.ContentLength > maxSize {
+				EncodeError(r.Context(), apiutil.ErrRequestTooLarge, w)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+			if err != nil {
+				if _, ok := err.(*http.MaxBytesError); ok {
+					EncodeError(r.Context(), apiutil.ErrRequestTooLarge, w)
+					return
+				}
+				EncodeError(r.Context(), errors.Wrap(apiutil.ErrMalformedRequestBody, err), w)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	api "github.com/absmach/supermq/api/http"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	cases := []struct {
+		desc      string
+		requestID string
+		generated bool
+	}{
+		{
+			desc:      "request without request ID",
+			requestID: "",
+			generated: true,
+		},
+		{
+			desc:      "request with valid request ID",
+			requestID: "5f2c1e4a-client-request",
+			generated: false,
+		},
+		{
+			desc:      "request with request ID containing spaces",
+			requestID: "invalid request id",
+			generated: true,
+		},
+		{
+			desc:      "request with too long request ID",
+			requestID: strings.Repeat("a", 129),
+			generated: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var ctxID string
+			handler := api.RequestIDMiddleware(uuid.NewMock())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxID = middleware.GetReqID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.requestID != "" {
+				req.Header.Set(api.RequestIDHeader, tc.requestID)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			respID := rec.Header().Get(api.RequestIDHeader)
+			assert.NotEmpty(t, respID, fmt.Sprintf("%s: expected request ID in response header", tc.desc))
+			assert.Equal(t, respID, ctxID, fmt.Sprintf("%s: expected context request ID %s got %s", tc.desc, respID, ctxID))
+			if !tc.generated {
+				assert.Equal(t, tc.requestID, respID, fmt.Sprintf("%s: expected request ID %s got %s", tc.desc, tc.requestID, respID))
+			}
+			if tc.generated {
+				assert.NotEqual(t, tc.requestID, respID, fmt.Sprintf("%s: expected generated request ID", tc.desc))
+			}
+		})
+	}
+}
+
+func TestLimitBodyMiddleware(t *testing.T) {
+	const (
+		maxSize  = 16
+		formType = "application/x-www-form-urlencoded"
+	)
+
+	cases := []struct {
+		desc        string
+		method      string
+		contentType string
+		body        io.Reader
+		chunked     bool
+		status      int
+	}{
+		{
+			desc:        "post body within limit",
+			method:      http.MethodPost,
+			contentType: formType,
+			body:        strings.NewReader("code=1&state=2"),
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "post body with content type parameters",
+			method:      http.MethodPost,
+			contentType: formType + "; charset=utf-8",
+			body:        strings.NewReader("code=1"),
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "post body over limit",
+			method:      http.MethodPost,
+			contentType: formType,
+			body:        strings.NewReader(strings.Repeat("a", maxSize+1)),
+			status:      http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:        "post body over limit without content length",
+			method:      http.MethodPost,
+			contentType: formType,
+			body:        strings.NewReader(strings.Repeat("a", maxSize+1)),
+			chunked:     true,
+			status:      http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:        "post body with unsupported content type",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        strings.NewReader("{}"),
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "post body without content type",
+			method:      http.MethodPost,
+			body:        strings.NewReader("code=1"),
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:   "get request is passed through",
+			method: http.MethodGet,
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var body string
+			handler := api.LimitBodyMiddleware(maxSize, formType)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+			}))
+
+			req := httptest.NewRequest(tc.method, "/", tc.body)
+			if tc.chunked {
+				req.ContentLength = -1
+			}
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code, fmt.Sprintf("%s: expected status %d got %d", tc.desc, tc.status, rec.Code))
+			if tc.status == http.StatusOK && tc.body != nil {
+				assert.NotEmpty(t, body, fmt.Sprintf("%s: expected body to be passed to the handler", tc.desc))
+			}
+		})
+	}
+}
@@ -162,6 +162,9 @@ var (
 	// ErrInvalidAPIKey indicates an invalid API key type.
 	ErrInvalidAPIKey = errors.NewRequestError("invalid api key type")
 
+	// ErrInvalidAPIKeyScope indicates an invalid API key scope.
+	ErrInvalidAPIKeyScope = errors.NewRequestError("invalid api key scope")
+
 	// ErrInvitationState indicates an invalid invitation state.
 	ErrInvitationState = errors.NewRequestError("invalid invitation state")
 
@@ -195,6 +198,9 @@ var (
 	// ErrUnsupportedContentType indicates unacceptable or lack of Content-Type.
 	ErrUnsupportedContentType = errors.NewMediaTypeError("unsupported content type")
 
+	// ErrRequestTooLarge indicates request body larger than allowed.
+	ErrRequestTooLarge = errors.NewPayloadTooLargeError("request body too large")
+
 	// ErrRollbackTx indicates failed to rollback transaction.
 	ErrRollbackTx = errors.NewRequestError("failed to rollback transaction")
 
@@ -275,6 +281,15 @@ var (
 	// ErrEmailNotVerified indicates invalid email not verified.
 	ErrEmailNotVerified = errors.NewRequestError("email not verified")
 
+	// ErrTokenTypeNotAllowed indicates that the token type is not accepted by the endpoint.
+	ErrTokenTypeNotAllowed = errors.NewAuthNError("token type not allowed")
+
+	// ErrScopeNotAllowed indicates that the API key scopes do not cover the endpoint.
+	ErrScopeNotAllowed = errors.NewAuthZError("operation not allowed by API key scopes")
+
+	// ErrInvalidUnmodifiedSince indicates invalid If-Unmodified-Since header.
+	ErrInvalidUnmodifiedSince = errors.NewRequestError("invalid If-Unmodified-Since header")
+
 	// ErrMalformedRequest indicates malformed request body.
 	ErrMalformedRequestBody = errors.NewRequestError("request body is not a valid JSON, expecting a valid JSON")
 )
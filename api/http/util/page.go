@@ -0,0 +1,19 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+// PageMetadata represents the offset and limit of the requested page.
+type PageMetadata struct {
+	Offset uint64
+	Limit  uint64
+}
+
+// ValidatePageMetadata validates that the page limit is within [1, maxLimit].
+func ValidatePageMetadata(pm PageMetadata, maxLimit uint64) error {
+	if pm.Limit < 1 || pm.Limit > maxLimit {
+		return ErrLimitSize
+	}
+
+	return nil
+}
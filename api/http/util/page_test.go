@@ -0,0 +1,51 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"fmt"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePageMetadata(t *testing.T) {
+	maxLimit := uint64(100)
+
+	cases := []struct {
+		desc string
+		pm   apiutil.PageMetadata
+		err  error
+	}{
+		{
+			desc: "valid page metadata",
+			pm:   apiutil.PageMetadata{Offset: 10, Limit: 10},
+			err:  nil,
+		},
+		{
+			desc: "page metadata with max limit",
+			pm:   apiutil.PageMetadata{Limit: maxLimit},
+			err:  nil,
+		},
+		{
+			desc: "page metadata with zero limit",
+			pm:   apiutil.PageMetadata{Limit: 0},
+			err:  apiutil.ErrLimitSize,
+		},
+		{
+			desc: "page metadata with limit exceeding max limit",
+			pm:   apiutil.PageMetadata{Limit: 10_000_000},
+			err:  apiutil.ErrLimitSize,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := apiutil.ValidatePageMetadata(tc.pm, maxLimit)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		})
+	}
+}
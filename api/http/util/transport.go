@@ -6,9 +6,12 @@ package util
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/absmach/supermq/pkg/errors"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -38,6 +41,34 @@ func ReadStringQuery(r *http.Request, key, def string) (string, error) {
 	return vals[0], nil
 }
 
+// MaxSliceQueryLen is the maximum number of values accepted by ReadStringSliceQuery.
+const MaxSliceQueryLen = 100
+
+// ReadStringSliceQuery reads the value of a string http query parameter for a given
+// key and splits it into values by the separator. Empty values are rejected.
+func ReadStringSliceQuery(r *http.Request, key, sep string) ([]string, error) {
+	val, err := ReadStringQuery(r, key, "")
+	if err != nil {
+		return nil, err
+	}
+	if val == "" {
+		return nil, nil
+	}
+
+	vals := strings.Split(val, sep)
+	if len(vals) > MaxSliceQueryLen {
+		return nil, errors.Wrap(ErrInvalidQueryParams, fmt.Errorf("%s exceeds %d values", key, MaxSliceQueryLen))
+	}
+	for i, v := range vals {
+		vals[i] = strings.TrimSpace(v)
+		if vals[i] == "" {
+			return nil, errors.Wrap(ErrInvalidQueryParams, fmt.Errorf("%s contains an empty value", key))
+		}
+	}
+
+	return vals, nil
+}
+
 // ReadMetadataQuery reads the value of json http query parameters for a given key.
 func ReadMetadataQuery(r *http.Request, key string, def map[string]any) (map[string]any, error) {
 	vals := r.URL.Query()[key]
@@ -77,6 +108,28 @@ func ReadBoolQuery(r *http.Request, key string, def bool) (bool, error) {
 	return b, nil
 }
 
+// ReadUnmodifiedSince reads the If-Unmodified-Since header of the given http request.
+// Besides the HTTP date format, RFC3339 timestamps are accepted so that clients can
+// pass the exact updated_at value of the entity they are modifying.
+func ReadUnmodifiedSince(r *http.Request) (time.Time, error) {
+	val := r.Header.Get("If-Unmodified-Since")
+	if val == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, val); err == nil {
+		return t, nil
+	}
+	t, err := http.ParseTime(val)
+	if err != nil {
+		return time.Time{}, errors.Wrap(ErrInvalidUnmodifiedSince, err)
+	}
+
+	// HTTP dates have a one second resolution, so treat the
+	// header as the end of the given second.
+	return t.Add(time.Second - time.Microsecond), nil
+}
+
 type number interface {
 	int64 | float64 | uint16 | uint64
 }
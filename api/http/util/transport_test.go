@@ -9,7 +9,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqlog "github.com/absmach/supermq/logger"
@@ -62,6 +64,71 @@ func TestReadStringQuery(t *testing.T) {
 	}
 }
 
+func TestReadStringSliceQuery(t *testing.T) {
+	cases := []struct {
+		desc string
+		url  string
+		key  string
+		ret  []string
+		err  error
+	}{
+		{
+			desc: "valid string slice query",
+			url:  "http://localhost:8080/?key=a,b,c",
+			key:  "key",
+			ret:  []string{"a", "b", "c"},
+			err:  nil,
+		},
+		{
+			desc: "string slice query with spaces",
+			url:  "http://localhost:8080/?key=a,%20b",
+			key:  "key",
+			ret:  []string{"a", "b"},
+			err:  nil,
+		},
+		{
+			desc: "empty string slice query",
+			url:  "http://localhost:8080/",
+			key:  "key",
+			ret:  nil,
+			err:  nil,
+		},
+		{
+			desc: "string slice query with empty value",
+			url:  "http://localhost:8080/?key=a,,b",
+			key:  "key",
+			ret:  nil,
+			err:  apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc: "string slice query with too many values",
+			url:  "http://localhost:8080/?key=" + strings.Repeat("a,", apiutil.MaxSliceQueryLen) + "a",
+			key:  "key",
+			ret:  nil,
+			err:  apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc: "multiple string slice query",
+			url:  "http://localhost:8080/?key=a&key=b",
+			key:  "key",
+			ret:  nil,
+			err:  apiutil.ErrInvalidQueryParams,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			parsedURL, err := url.Parse(c.url)
+			assert.NoError(t, err)
+
+			r := &http.Request{URL: parsedURL}
+			ret, err := apiutil.ReadStringSliceQuery(r, c.key, ",")
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected: %v, got: %v", c.err, err))
+			assert.Equal(t, c.ret, ret)
+		})
+	}
+}
+
 func TestReadMetadataQuery(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -333,6 +400,54 @@ func TestReadNumQuery(t *testing.T) {
 	}
 }
 
+func TestReadUnmodifiedSince(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+
+	cases := []struct {
+		desc   string
+		header string
+		ret    time.Time
+		err    error
+	}{
+		{
+			desc:   "valid RFC3339 header",
+			header: ts.Format(time.RFC3339Nano),
+			ret:    ts,
+			err:    nil,
+		},
+		{
+			desc:   "valid HTTP date header",
+			header: ts.Format(http.TimeFormat),
+			ret:    ts.Truncate(time.Second).Add(time.Second - time.Microsecond),
+			err:    nil,
+		},
+		{
+			desc:   "empty header",
+			header: "",
+			ret:    time.Time{},
+			err:    nil,
+		},
+		{
+			desc:   "invalid header",
+			header: "invalid",
+			ret:    time.Time{},
+			err:    apiutil.ErrInvalidUnmodifiedSince,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "http://localhost:8080/", nil)
+			if c.header != "" {
+				r.Header.Set("If-Unmodified-Since", c.header)
+			}
+			ret, err := apiutil.ReadUnmodifiedSince(r)
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected error %v, got %v", c.err, err))
+			assert.True(t, c.ret.Equal(ret), fmt.Sprintf("expected %v, got %v", c.ret, ret))
+		})
+	}
+}
+
 func TestLoggingErrorEncoder(t *testing.T) {
 	cases := []struct {
 		desc string
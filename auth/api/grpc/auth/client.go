@@ -5,6 +5,7 @@ package auth
 
 import (
 	"context"
+	"io"
 	"time"
 
 	grpcAuthV1 "github.com/absmach/supermq/api/grpc/auth/v1"
@@ -20,13 +21,14 @@ const authSvcName = "auth.v1.AuthService"
 type authGrpcClient struct {
 	authenticate endpoint.Endpoint
 	authorize    endpoint.Endpoint
-	timeout      time.Duration
+	streams      grpcAuthV1.AuthServiceClient
+	timeouts     grpcapi.Timeouts
 }
 
 var _ grpcAuthV1.AuthServiceClient = (*authGrpcClient)(nil)
 
 // NewAuthClient returns new auth gRPC client instance.
-func NewAuthClient(conn *grpc.ClientConn, timeout time.Duration) grpcAuthV1.AuthServiceClient {
+func NewAuthClient(conn *grpc.ClientConn, timeout time.Duration, opts ...grpcapi.Option) grpcAuthV1.AuthServiceClient {
 	return &authGrpcClient{
 		authenticate: kitgrpc.NewClient(
 			conn,
@@ -44,12 +46,13 @@ func NewAuthClient(conn *grpc.ClientConn, timeout time.Duration) grpcAuthV1.Auth
 			decodeAuthorizeResponse,
 			grpcAuthV1.AuthZRes{},
 		).Endpoint(),
-		timeout: timeout,
+		streams:  grpcAuthV1.NewAuthServiceClient(conn),
+		timeouts: grpcapi.NewTimeouts(timeout, opts...),
 	}
 }
 
 func (client authGrpcClient) Authenticate(ctx context.Context, token *grpcAuthV1.AuthNReq, _ ...grpc.CallOption) (*grpcAuthV1.AuthNRes, error) {
-	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	ctx, cancel := context.WithTimeout(ctx, client.timeouts.Timeout("Authenticate"))
 	defer cancel()
 
 	res, err := client.authenticate(ctx, authenticateReq{token: token.GetToken()})
@@ -57,7 +60,7 @@ func (client authGrpcClient) Authenticate(ctx context.Context, token *grpcAuthV1
 		return &grpcAuthV1.AuthNRes{}, grpcapi.DecodeError(err)
 	}
 	ir := res.(authenticateRes)
-	return &grpcAuthV1.AuthNRes{Id: ir.id, UserId: ir.userID, UserRole: uint32(ir.userRole), Verified: ir.verified}, nil
+	return &grpcAuthV1.AuthNRes{Id: ir.id, UserId: ir.userID, UserRole: uint32(ir.userRole), Verified: ir.verified, KeyType: uint32(ir.keyType), Scopes: ir.scopes}, nil
 }
 
 func encodeIdentifyRequest(_ context.Context, grpcReq any) (any, error) {
@@ -67,11 +70,11 @@ func encodeIdentifyRequest(_ context.Context, grpcReq any) (any, error) {
 
 func decodeIdentifyResponse(_ context.Context, grpcRes any) (any, error) {
 	res := grpcRes.(*grpcAuthV1.AuthNRes)
-	return authenticateRes{id: res.GetId(), userID: res.GetUserId(), userRole: auth.Role(res.UserRole), verified: res.GetVerified()}, nil
+	return authenticateRes{id: res.GetId(), userID: res.GetUserId(), userRole: auth.Role(res.UserRole), verified: res.GetVerified(), keyType: auth.KeyType(res.GetKeyType()), scopes: res.GetScopes()}, nil
 }
 
 func (client authGrpcClient) Authorize(ctx context.Context, req *grpcAuthV1.AuthZReq, _ ...grpc.CallOption) (r *grpcAuthV1.AuthZRes, err error) {
-	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	ctx, cancel := context.WithTimeout(ctx, client.timeouts.Timeout("Authorize"))
 	defer cancel()
 
 	var authReqData authReq
@@ -145,3 +148,42 @@ func encodeAuthorizeRequest(_ context.Context, grpcReq any) (any, error) {
 
 	return authZReq, nil
 }
+
+// StreamObjects opens a server stream of objects on which the subject has the
+// permission. Streams are not bounded by the client timeout, so the caller
+// controls their lifetime through ctx.
+func (client authGrpcClient) StreamObjects(ctx context.Context, req *grpcAuthV1.PolicyReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[grpcAuthV1.StreamRes], error) {
+	stream, err := client.streams.StreamObjects(ctx, req, opts...)
+	if err != nil {
+		return nil, grpcapi.DecodeError(err)
+	}
+
+	return stream, nil
+}
+
+// StreamSubjects opens a server stream of subjects which have the permission
+// on the object. Streams are not bounded by the client timeout, so the caller
+// controls their lifetime through ctx.
+func (client authGrpcClient) StreamSubjects(ctx context.Context, req *grpcAuthV1.PolicyReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[grpcAuthV1.StreamRes], error) {
+	stream, err := client.streams.StreamSubjects(ctx, req, opts...)
+	if err != nil {
+		return nil, grpcapi.DecodeError(err)
+	}
+
+	return stream, nil
+}
+
+// Collect reads the stream until it is exhausted and returns all received IDs.
+func Collect(stream grpc.ServerStreamingClient[grpcAuthV1.StreamRes]) ([]string, error) {
+	var ids []string
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return ids, nil
+		}
+		if err != nil {
+			return nil, grpcapi.DecodeError(err)
+		}
+		ids = append(ids, res.GetPolicies()...)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	grpcAuthV1 "github.com/absmach/supermq/api/grpc/auth/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/auth"
+	authgrpcapi "github.com/absmach/supermq/auth/api/grpc"
 	grpcapi "github.com/absmach/supermq/auth/api/grpc/auth"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/errors"
@@ -22,6 +23,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -44,7 +46,7 @@ var (
 
 func startGRPCServer(svc auth.Service, port int) *grpc.Server {
 	listener, _ := net.Listen("tcp", fmt.Sprintf(":%d", port))
-	server := grpc.NewServer()
+	server := grpc.NewServer(grpc.UnaryInterceptor(authgrpcapi.TokenInterceptor))
 	grpcAuthV1.RegisterAuthServiceServer(server, grpcapi.NewAuthServer(svc))
 	go func() {
 		err := server.Serve(listener)
@@ -75,6 +77,13 @@ func TestIdentify(t *testing.T) {
 			idt:   &grpcAuthV1.AuthNRes{UserId: id, UserRole: uint32(auth.UserRole)},
 			err:   nil,
 		},
+		{
+			desc:  "authenticate user with valid recovery token",
+			token: validToken,
+			key:   auth.Key{Type: auth.RecoveryKey, Subject: id, Role: auth.UserRole},
+			idt:   &grpcAuthV1.AuthNRes{UserId: id, UserRole: uint32(auth.UserRole), KeyType: uint32(auth.RecoveryKey)},
+			err:   nil,
+		},
 		{
 			desc:   "authenticate user with invalid user token",
 			token:  "invalid",
@@ -93,7 +102,7 @@ func TestIdentify(t *testing.T) {
 			desc:  "authenticate user with valid PAT token",
 			token: "pat_" + validPATToken,
 			key:   auth.Key{ID: id, Type: auth.PersonalAccessToken, Subject: clientID, Role: auth.UserRole},
-			idt:   &grpcAuthV1.AuthNRes{Id: id, UserId: clientID, UserRole: uint32(auth.UserRole)},
+			idt:   &grpcAuthV1.AuthNRes{Id: id, UserId: clientID, UserRole: uint32(auth.UserRole), KeyType: uint32(auth.PersonalAccessToken)},
 			err:   nil,
 		},
 		{
@@ -119,6 +128,54 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestIdentifyMetadataToken(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	defer conn.Close()
+	grpcClient := grpcAuthV1.NewAuthServiceClient(conn)
+
+	cases := []struct {
+		desc     string
+		token    string
+		mdToken  string
+		svcToken string
+		err      error
+	}{
+		{
+			desc:     "authenticate with token in metadata",
+			mdToken:  validToken,
+			svcToken: validToken,
+		},
+		{
+			desc:     "authenticate with token in request and metadata",
+			token:    validToken,
+			mdToken:  inValidToken,
+			svcToken: validToken,
+		},
+		{
+			desc:  "authenticate without token",
+			token: "",
+			err:   apiutil.ErrBearerToken,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("Identify", mock.Anything, tc.svcToken).Return(auth.Key{Subject: id, Role: auth.UserRole}, nil)
+			ctx := context.Background()
+			if tc.mdToken != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+tc.mdToken)
+			}
+			_, err := grpcClient.Authenticate(ctx, &grpcAuthV1.AuthNReq{Token: tc.token})
+			assert.True(t, errors.Contains(authgrpcapi.DecodeError(err), tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				svc.AssertCalled(t, "Identify", mock.Anything, tc.svcToken)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestAuthorize(t *testing.T) {
 	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
@@ -5,6 +5,7 @@ package auth
 
 import (
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/policies"
 )
 
 type authenticateReq struct {
@@ -68,3 +69,45 @@ func (req authReq) validate() error {
 
 	return nil
 }
+
+// streamReq represents a request to stream all subjects or objects
+// matching the policy.
+type streamReq struct {
+	Domain          string
+	SubjectType     string
+	SubjectKind     string
+	SubjectRelation string
+	Subject         string
+	Permission      string
+	ObjectType      string
+	Object          string
+}
+
+func (req streamReq) validate() error {
+	if req.SubjectType == "" {
+		return apiutil.ErrMissingPolicySub
+	}
+
+	if req.ObjectType == "" {
+		return apiutil.ErrMissingPolicyObj
+	}
+
+	if req.Permission == "" {
+		return apiutil.ErrMalformedPolicyPer
+	}
+
+	return nil
+}
+
+func (req streamReq) policy() policies.Policy {
+	return policies.Policy{
+		Domain:          req.Domain,
+		SubjectType:     req.SubjectType,
+		SubjectKind:     req.SubjectKind,
+		SubjectRelation: req.SubjectRelation,
+		Subject:         req.Subject,
+		Permission:      req.Permission,
+		ObjectType:      req.ObjectType,
+		Object:          req.Object,
+	}
+}
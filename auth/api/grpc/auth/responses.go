@@ -10,6 +10,8 @@ type authenticateRes struct {
 	userID   string
 	userRole smqauth.Role
 	verified bool
+	keyType  smqauth.KeyType
+	scopes   []string
 }
 
 type authorizeRes struct {
@@ -9,6 +9,7 @@ import (
 	grpcAuthV1 "github.com/absmach/supermq/api/grpc/auth/v1"
 	"github.com/absmach/supermq/auth"
 	grpcapi "github.com/absmach/supermq/auth/api/grpc"
+	"github.com/absmach/supermq/pkg/errors"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 )
 
@@ -18,6 +19,7 @@ type authGrpcServer struct {
 	grpcAuthV1.UnimplementedAuthServiceServer
 	authorize    kitgrpc.Handler
 	authenticate kitgrpc.Handler
+	svc          auth.Service
 }
 
 // NewAuthServer returns new AuthnServiceServer instance.
@@ -33,7 +35,9 @@ func NewAuthServer(svc auth.Service) grpcAuthV1.AuthServiceServer {
 			(authenticateEndpoint(svc)),
 			decodeAuthenticateRequest,
 			encodeAuthenticateResponse,
+			kitgrpc.ServerBefore(grpcapi.ClientIPToContext),
 		),
+		svc: svc,
 	}
 }
 
@@ -53,14 +57,65 @@ func (s *authGrpcServer) Authorize(ctx context.Context, req *grpcAuthV1.AuthZReq
 	return res.(*grpcAuthV1.AuthZRes), nil
 }
 
-func decodeAuthenticateRequest(_ context.Context, grpcReq any) (any, error) {
+func (s *authGrpcServer) StreamObjects(req *grpcAuthV1.PolicyReq, stream grpcAuthV1.AuthService_StreamObjectsServer) error {
+	sr := decodeStreamRequest(req)
+	if err := sr.validate(); err != nil {
+		return grpcapi.EncodeError(errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	err := s.svc.StreamObjects(stream.Context(), sr.policy(), func(objects []string) error {
+		return stream.Send(&grpcAuthV1.StreamRes{Policies: objects})
+	})
+	if err != nil {
+		return grpcapi.EncodeError(err)
+	}
+
+	return nil
+}
+
+func (s *authGrpcServer) StreamSubjects(req *grpcAuthV1.PolicyReq, stream grpcAuthV1.AuthService_StreamSubjectsServer) error {
+	sr := decodeStreamRequest(req)
+	if err := sr.validate(); err != nil {
+		return grpcapi.EncodeError(errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	err := s.svc.StreamSubjects(stream.Context(), sr.policy(), func(subjects []string) error {
+		return stream.Send(&grpcAuthV1.StreamRes{Policies: subjects})
+	})
+	if err != nil {
+		return grpcapi.EncodeError(err)
+	}
+
+	return nil
+}
+
+func decodeStreamRequest(req *grpcAuthV1.PolicyReq) streamReq {
+	return streamReq{
+		Domain:          req.GetDomain(),
+		SubjectType:     req.GetSubjectType(),
+		SubjectKind:     req.GetSubjectKind(),
+		SubjectRelation: req.GetSubjectRelation(),
+		Subject:         req.GetSubject(),
+		Permission:      req.GetPermission(),
+		ObjectType:      req.GetObjectType(),
+		Object:          req.GetObject(),
+	}
+}
+
+func decodeAuthenticateRequest(ctx context.Context, grpcReq any) (any, error) {
 	req := grpcReq.(*grpcAuthV1.AuthNReq)
-	return authenticateReq{token: req.GetToken()}, nil
+	token := req.GetToken()
+	if token == "" {
+		// Fall back to the bearer token sent in the call metadata.
+		token, _ = grpcapi.TokenFromContext(ctx)
+	}
+
+	return authenticateReq{token: token}, nil
 }
 
 func encodeAuthenticateResponse(_ context.Context, grpcRes any) (any, error) {
 	res := grpcRes.(authenticateRes)
-	return &grpcAuthV1.AuthNRes{Id: res.id, UserId: res.userID, UserRole: uint32(res.userRole), Verified: res.verified}, nil
+	return &grpcAuthV1.AuthNRes{Id: res.id, UserId: res.userID, UserRole: uint32(res.userRole), Verified: res.verified, KeyType: uint32(res.keyType), Scopes: res.scopes}, nil
 }
 
 func decodeAuthorizeRequest(_ context.Context, grpcReq any) (any, error) {
@@ -0,0 +1,88 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationKey = "authorization"
+	bearerPrefix     = "Bearer "
+)
+
+type tokenKey struct{}
+
+// RecoveryInterceptor returns an interceptor which recovers from panics in
+// the handler, so a single failing call is reported with the Internal code
+// instead of crashing the server.
+func RecoveryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("gRPC handler panicked",
+					slog.String("method", info.FullMethod),
+					slog.String("panic", fmt.Sprint(r)),
+					slog.String("stack", string(debug.Stack())),
+				)
+				res, err = nil, status.Error(codes.Internal, "internal server error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// LoggingInterceptor returns an interceptor which logs the method, the
+// duration and the status code of each call.
+func LoggingInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		begin := time.Now()
+		res, err := handler(ctx, req)
+		args := []any{
+			slog.String("method", info.FullMethod),
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("code", status.Code(err).String()),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			logger.Warn("gRPC call failed", args...)
+			return res, err
+		}
+		logger.Debug("gRPC call completed successfully", args...)
+
+		return res, err
+	}
+}
+
+// TokenInterceptor stores the bearer token sent in the authorization
+// metadata in the context. Calls without the token are passed through
+// unchanged, since most methods carry the token in the request itself.
+func TokenInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(authorizationKey); len(values) > 0 {
+			if token := strings.TrimSpace(strings.TrimPrefix(values[0], bearerPrefix)); token != "" {
+				ctx = context.WithValue(ctx, tokenKey{}, token)
+			}
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+// TokenFromContext returns the token extracted by TokenInterceptor.
+func TokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenKey{}).(string)
+	return token, ok
+}
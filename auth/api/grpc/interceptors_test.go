@@ -0,0 +1,101 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	grpcapi "github.com/absmach/supermq/auth/api/grpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryInterceptor(t *testing.T) {
+	interceptor := grpcapi.RecoveryInterceptor(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	info := &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AuthService/Authenticate"}
+
+	cases := []struct {
+		desc    string
+		handler grpc.UnaryHandler
+		res     any
+		code    codes.Code
+	}{
+		{
+			desc: "handler returns response",
+			handler: func(_ context.Context, req any) (any, error) {
+				return req, nil
+			},
+			res:  "request",
+			code: codes.OK,
+		},
+		{
+			desc: "handler returns error",
+			handler: func(_ context.Context, _ any) (any, error) {
+				return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "handler panics",
+			handler: func(_ context.Context, _ any) (any, error) {
+				var m map[string]any
+				m["key"] = "value"
+				return m, nil
+			},
+			code: codes.Internal,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := interceptor(context.Background(), "request", info, tc.handler)
+			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+			assert.Equal(t, tc.code, status.Code(err), fmt.Sprintf("%s: expected code %s got %s\n", tc.desc, tc.code, status.Code(err)))
+		})
+	}
+}
+
+func TestTokenInterceptor(t *testing.T) {
+	cases := []struct {
+		desc  string
+		md    metadata.MD
+		token string
+		ok    bool
+	}{
+		{
+			desc:  "bearer token in metadata",
+			md:    metadata.Pairs("authorization", "Bearer token"),
+			token: "token",
+			ok:    true,
+		},
+		{
+			desc: "empty bearer token in metadata",
+			md:   metadata.Pairs("authorization", "Bearer "),
+		},
+		{
+			desc: "no token in metadata",
+			md:   metadata.MD{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+			_, err := grpcapi.TokenInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+				token, ok := grpcapi.TokenFromContext(ctx)
+				assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.ok, ok))
+				assert.Equal(t, tc.token, token, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.token, token))
+				return nil, nil
+			})
+			assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s\n", tc.desc, err))
+		})
+	}
+}
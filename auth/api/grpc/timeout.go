@@ -0,0 +1,47 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import "time"
+
+// Timeouts contains the default and the per-method timeouts of a gRPC client.
+type Timeouts struct {
+	timeout time.Duration
+	methods map[string]time.Duration
+}
+
+// Option configures the gRPC client timeouts.
+type Option func(*Timeouts)
+
+// WithMethodTimeouts overrides the default timeout for the gRPC methods with
+// the given names, e.g. {"Authorize": time.Second}.
+func WithMethodTimeouts(methods map[string]time.Duration) Option {
+	return func(t *Timeouts) {
+		for method, timeout := range methods {
+			t.methods[method] = timeout
+		}
+	}
+}
+
+// NewTimeouts returns timeouts with the given default timeout and options applied.
+func NewTimeouts(timeout time.Duration, opts ...Option) Timeouts {
+	t := Timeouts{
+		timeout: timeout,
+		methods: make(map[string]time.Duration),
+	}
+	for _, opt := range opts {
+		opt(&t)
+	}
+
+	return t
+}
+
+// Timeout returns the timeout of the gRPC method with the given name.
+func (t Timeouts) Timeout(method string) time.Duration {
+	if timeout, ok := t.methods[method]; ok {
+		return timeout
+	}
+
+	return t.timeout
+}
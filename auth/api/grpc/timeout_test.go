@@ -0,0 +1,43 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	grpcapi "github.com/absmach/supermq/auth/api/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout(t *testing.T) {
+	timeouts := grpcapi.NewTimeouts(time.Second, grpcapi.WithMethodTimeouts(map[string]time.Duration{
+		"ListUserRefreshTokens": time.Minute,
+	}))
+
+	cases := []struct {
+		desc     string
+		method   string
+		expected time.Duration
+	}{
+		{
+			desc:     "default timeout",
+			method:   "Authorize",
+			expected: time.Second,
+		},
+		{
+			desc:     "method timeout",
+			method:   "ListUserRefreshTokens",
+			expected: time.Minute,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			timeout := timeouts.Timeout(tc.method)
+			assert.Equal(t, tc.expected, timeout, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.expected, timeout))
+		})
+	}
+}
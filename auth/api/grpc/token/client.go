@@ -22,13 +22,13 @@ type tokenGrpcClient struct {
 	refresh               endpoint.Endpoint
 	revoke                endpoint.Endpoint
 	listUserRefreshTokens endpoint.Endpoint
-	timeout               time.Duration
+	timeouts              grpcapi.Timeouts
 }
 
 var _ grpcTokenV1.TokenServiceClient = (*tokenGrpcClient)(nil)
 
 // NewTokenClient returns new token gRPC client instance.
-func NewTokenClient(conn *grpc.ClientConn, timeout time.Duration) grpcTokenV1.TokenServiceClient {
+func NewTokenClient(conn *grpc.ClientConn, timeout time.Duration, opts ...grpcapi.Option) grpcTokenV1.TokenServiceClient {
 	return &tokenGrpcClient{
 		issue: kitgrpc.NewClient(
 			conn,
@@ -62,12 +62,12 @@ func NewTokenClient(conn *grpc.ClientConn, timeout time.Duration) grpcTokenV1.To
 			decodeListUserRefreshTokensResponse,
 			grpcTokenV1.ListUserRefreshTokensRes{},
 		).Endpoint(),
-		timeout: timeout,
+		timeouts: grpcapi.NewTimeouts(timeout, opts...),
 	}
 }
 
 func (client tokenGrpcClient) Issue(ctx context.Context, req *grpcTokenV1.IssueReq, _ ...grpc.CallOption) (*grpcTokenV1.Token, error) {
-	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	ctx, cancel := context.WithTimeout(ctx, client.timeouts.Timeout("Issue"))
 	defer cancel()
 
 	res, err := client.issue(ctx, issueReq{
@@ -99,7 +99,7 @@ func decodeIssueResponse(_ context.Context, grpcRes any) (any, error) {
 }
 
 func (client tokenGrpcClient) Refresh(ctx context.Context, req *grpcTokenV1.RefreshReq, _ ...grpc.CallOption) (*grpcTokenV1.Token, error) {
-	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	ctx, cancel := context.WithTimeout(ctx, client.timeouts.Timeout("Refresh"))
 	defer cancel()
 
 	res, err := client.refresh(ctx, refreshReq{refreshToken: req.GetRefreshToken(), verified: req.GetVerified()})
@@ -119,7 +119,7 @@ func decodeRefreshResponse(_ context.Context, grpcRes any) (any, error) {
 }
 
 func (client tokenGrpcClient) Revoke(ctx context.Context, req *grpcTokenV1.RevokeReq, _ ...grpc.CallOption) (*grpcTokenV1.RevokeRes, error) {
-	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	ctx, cancel := context.WithTimeout(ctx, client.timeouts.Timeout("Revoke"))
 	defer cancel()
 
 	res, err := client.revoke(ctx, revokeReq{userID: req.GetUserId(), tokenID: req.GetTokenId()})
@@ -139,7 +139,7 @@ func decodeRevokeResponse(_ context.Context, grpcRes any) (any, error) {
 }
 
 func (client tokenGrpcClient) ListUserRefreshTokens(ctx context.Context, req *grpcTokenV1.ListUserRefreshTokensReq, _ ...grpc.CallOption) (*grpcTokenV1.ListUserRefreshTokensRes, error) {
-	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	ctx, cancel := context.WithTimeout(ctx, client.timeouts.Timeout("ListUserRefreshTokens"))
 	defer cancel()
 
 	res, err := client.listUserRefreshTokens(ctx, listUserRefreshTokensReq{userID: req.GetUserId()})
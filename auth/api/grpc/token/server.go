@@ -29,16 +29,19 @@ func NewTokenServer(svc auth.Service) grpcTokenV1.TokenServiceServer {
 			(issueEndpoint(svc)),
 			decodeIssueRequest,
 			encodeIssueResponse,
+			kitgrpc.ServerBefore(grpcapi.ClientIPToContext),
 		),
 		refresh: kitgrpc.NewServer(
 			(refreshEndpoint(svc)),
 			decodeRefreshRequest,
 			encodeIssueResponse,
+			kitgrpc.ServerBefore(grpcapi.ClientIPToContext),
 		),
 		revoke: kitgrpc.NewServer(
 			(revokeEndpoint(svc)),
 			decodeRevokeRequest,
 			encodeRevokeResponse,
+			kitgrpc.ServerBefore(grpcapi.ClientIPToContext),
 		),
 		listUserRefreshTokens: kitgrpc.NewServer(
 			(listUserRefreshTokensEndpoint(svc)),
@@ -4,13 +4,17 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
+	"net"
 
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/auth"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -72,3 +76,19 @@ func DecodeError(err error) error {
 	}
 	return err
 }
+
+// ClientIPToContext is a server before function which stores the address of
+// the peer service in the context, since end client addresses are not
+// propagated through gRPC calls.
+func ClientIPToContext(ctx context.Context, _ metadata.MD) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ctx
+	}
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	return auth.WithClientIP(ctx, ip)
+}
@@ -22,6 +22,7 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 		newKey := auth.Key{
 			IssuedAt: now,
 			Type:     req.Type,
+			Scopes:   req.Scopes,
 		}
 
 		duration := time.Duration(req.Duration * time.Second)
@@ -61,6 +62,7 @@ func retrieveEndpoint(svc auth.Service) endpoint.Endpoint {
 			Subject:  key.Subject,
 			Type:     key.Type,
 			IssuedAt: key.IssuedAt,
+			Scopes:   key.Scopes,
 		}
 		if !key.ExpiresAt.IsZero() {
 			ret.ExpiresAt = &key.ExpiresAt
@@ -78,7 +80,7 @@ func revokeEndpoint(svc auth.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.Revoke(ctx, req.token, req.id); err != nil {
+		if _, err := svc.Revoke(ctx, req.token, req.id); err != nil {
 			return nil, err
 		}
 
@@ -86,6 +88,23 @@ func revokeEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func revokeAllEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(revokeAllReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		revoked, err := svc.RevokeAll(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return revokeAllKeysRes{Revoked: revoked}, nil
+	}
+}
+
 func retrieveJWKSEndpoint(svc auth.Service, jwksCacheMaxAge, jwksCacheStaleWhileRevalidate int) endpoint.Endpoint {
 	return func(ctx context.Context, request any) (any, error) {
 		jwks := svc.RetrieveJWKS()
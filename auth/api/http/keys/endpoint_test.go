@@ -37,6 +37,7 @@ var Token = auth.Token{
 type issueRequest struct {
 	Duration time.Duration `json:"duration,omitempty"`
 	Type     uint32        `json:"type,omitempty"`
+	Scopes   []string      `json:"scopes,omitempty"`
 }
 
 type testRequest struct {
@@ -66,7 +67,7 @@ func (tr testRequest) make() (*http.Response, error) {
 
 func newServer() (*httptest.Server, *mocks.Service) {
 	svc := new(mocks.Service)
-	mux := httpapi.MakeHandler(svc, smqlog.NewMock(), "", 900, 60)
+	mux := httpapi.MakeHandler(svc, smqlog.NewMock(), "", 900, 60, nil)
 
 	return httptest.NewServer(mux), svc
 }
@@ -87,6 +88,7 @@ func TestIssue(t *testing.T) {
 	lk := issueRequest{Type: uint32(auth.AccessKey)}
 	ak := issueRequest{Type: uint32(auth.APIKey), Duration: time.Hour}
 	rk := issueRequest{Type: uint32(auth.RecoveryKey)}
+	sk := issueRequest{Type: uint32(auth.APIKey), Duration: time.Hour, Scopes: []string{"clients:read"}}
 
 	cases := []struct {
 		desc   string
@@ -112,6 +114,28 @@ func TestIssue(t *testing.T) {
 			status: http.StatusCreated,
 			svcRes: Token,
 		},
+		{
+			desc:   "issue scoped API key",
+			req:    toJSON(sk),
+			ct:     contentType,
+			token:  accessToken,
+			status: http.StatusCreated,
+			svcRes: Token,
+		},
+		{
+			desc:   "issue API key with invalid scope",
+			req:    toJSON(issueRequest{Type: uint32(auth.APIKey), Scopes: []string{"clients:delete"}}),
+			ct:     contentType,
+			token:  accessToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "issue recovery key with scopes",
+			req:    toJSON(issueRequest{Type: uint32(auth.RecoveryKey), Scopes: []string{"clients:read"}}),
+			ct:     contentType,
+			token:  accessToken,
+			status: http.StatusBadRequest,
+		},
 		{
 			desc:   "issue recovery key",
 			req:    toJSON(rk),
@@ -303,7 +327,7 @@ func TestRevoke(t *testing.T) {
 			url:    fmt.Sprintf("%s/keys/%s", ts.URL, tc.id),
 			token:  tc.token,
 		}
-		svcCall := svc.On("Revoke", mock.Anything, tc.token, tc.id).Return(tc.svcErr)
+		svcCall := svc.On("Revoke", mock.Anything, tc.token, tc.id).Return(auth.Key{}, tc.svcErr)
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -311,6 +335,60 @@ func TestRevoke(t *testing.T) {
 	}
 }
 
+func TestRevokeAll(t *testing.T) {
+	ts, svc := newServer()
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc    string
+		token   string
+		status  int
+		revoked uint64
+		svcErr  error
+	}{
+		{
+			desc:    "revoke all keys",
+			token:   accessToken,
+			revoked: 2,
+			status:  http.StatusOK,
+		},
+		{
+			desc:   "revoke all keys with invalid token",
+			token:  "wrong",
+			svcErr: svcerr.ErrAuthentication,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "revoke all keys with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/keys", ts.URL),
+			token:  tc.token,
+		}
+		svcCall := svc.On("RevokeAll", mock.Anything, tc.token).Return(tc.revoked, tc.svcErr)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body struct {
+				Revoked uint64 `json:"revoked"`
+			}
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.revoked, body.Revoked, fmt.Sprintf("%s: expected %d revoked keys got %d", tc.desc, tc.revoked, body.Revoked))
+		}
+		svcCall.Unset()
+	}
+}
+
 func TestRetrieveJWKS(t *testing.T) {
 	ts, svc := newServer()
 	defer ts.Close()
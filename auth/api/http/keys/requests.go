@@ -8,12 +8,15 @@ import (
 
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
 )
 
 type issueKeyReq struct {
 	token    string
 	Type     auth.KeyType  `json:"type,omitempty"`
 	Duration time.Duration `json:"duration,omitempty"`
+	Scopes   []string      `json:"scopes,omitempty"`
 }
 
 // It is not possible to issue Reset key using HTTP API.
@@ -28,6 +31,14 @@ func (req issueKeyReq) validate() error {
 		return apiutil.ErrInvalidAPIKey
 	}
 
+	// Only API keys can be limited to scopes.
+	if len(req.Scopes) > 0 && req.Type != auth.APIKey {
+		return apiutil.ErrInvalidAPIKeyScope
+	}
+	if err := authz.ValidateScopes(req.Scopes); err != nil {
+		return errors.Wrap(apiutil.ErrInvalidAPIKeyScope, err)
+	}
+
 	return nil
 }
 
@@ -47,4 +58,16 @@ func (req keyReq) validate() error {
 	return nil
 }
 
+type revokeAllReq struct {
+	token string
+}
+
+func (req revokeAllReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
 type jwksReq struct{}
@@ -16,6 +16,7 @@ import (
 var (
 	_ supermq.Response = (*issueKeyRes)(nil)
 	_ supermq.Response = (*revokeKeyRes)(nil)
+	_ supermq.Response = (*revokeAllKeysRes)(nil)
 	_ supermq.Response = (*retrieveKeyRes)(nil)
 	_ supermq.Response = (*retrieveJWKSRes)(nil)
 )
@@ -46,6 +47,7 @@ type retrieveKeyRes struct {
 	Type      auth.KeyType `json:"type,omitempty"`
 	IssuedAt  time.Time    `json:"issued_at,omitempty"`
 	ExpiresAt *time.Time   `json:"expires_at,omitempty"`
+	Scopes    []string     `json:"scopes,omitempty"`
 }
 
 func (res retrieveKeyRes) Code() int {
@@ -74,6 +76,22 @@ func (res revokeKeyRes) Empty() bool {
 	return true
 }
 
+type revokeAllKeysRes struct {
+	Revoked uint64 `json:"revoked"`
+}
+
+func (res revokeAllKeysRes) Code() int {
+	return http.StatusOK
+}
+
+func (res revokeAllKeysRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revokeAllKeysRes) Empty() bool {
+	return false
+}
+
 type retrieveJWKSRes struct {
 	Keys                      []auth.PublicKeyInfo `json:"-"`
 	CacheMaxAge               int                  `json:"-"`
@@ -7,7 +7,9 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/netip"
 	"strings"
 
 	api "github.com/absmach/supermq/api/http"
@@ -21,9 +23,11 @@ import (
 const contentType = "application/json"
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger, jwksCacheMaxAge, jwksCacheStaleWhileRevalidate int) *chi.Mux {
+// X-Forwarded-For is honoured only for requests coming from trustedProxies.
+func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger, jwksCacheMaxAge, jwksCacheStaleWhileRevalidate int, trustedProxies []netip.Prefix) *chi.Mux {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(clientIPToContext(trustedProxies)),
 	}
 	mux.Route("/keys", func(r chi.Router) {
 		r.Post("/", kithttp.NewServer(
@@ -33,6 +37,13 @@ func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger, jwksCacheM
 			opts...,
 		).ServeHTTP)
 
+		r.Delete("/", kithttp.NewServer(
+			revokeAllEndpoint(svc),
+			decodeRevokeAllReq,
+			api.EncodeResponse,
+			opts...,
+		).ServeHTTP)
+
 		r.Get("/{id}", kithttp.NewServer(
 			(retrieveEndpoint(svc)),
 			decodeKeyReq,
@@ -78,7 +89,60 @@ func decodeKeyReq(_ context.Context, r *http.Request) (any, error) {
 	return req, nil
 }
 
+func decodeRevokeAllReq(_ context.Context, r *http.Request) (any, error) {
+	req := revokeAllReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+	return req, nil
+}
+
 func decodeJWKSReq(_ context.Context, _ *http.Request) (any, error) {
 	req := jwksReq{}
 	return req, nil
 }
+
+// clientIPToContext returns a request function which stores the address of
+// the client which made the request in the context. The X-Forwarded-For header
+// is set by the client itself unless a proxy overwrites it, so it is used only
+// for requests coming from a trusted proxy. Its addresses are then walked from
+// the right, and the first one which isn't a trusted proxy is the client.
+func clientIPToContext(trustedProxies []netip.Prefix) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		if !isTrusted(ip, trustedProxies) {
+			return auth.WithClientIP(ctx, ip)
+		}
+
+		hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop == "" {
+				continue
+			}
+			ip = hop
+			if !isTrusted(hop, trustedProxies) {
+				break
+			}
+		}
+
+		return auth.WithClientIP(ctx, ip)
+	}
+}
+
+func isTrusted(ip string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package keys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIPToContext(t *testing.T) {
+	trusted := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.1/32"),
+	}
+
+	cases := []struct {
+		desc       string
+		remoteAddr string
+		forwarded  string
+		proxies    []netip.Prefix
+		ip         string
+	}{
+		{
+			desc:       "direct request",
+			remoteAddr: "203.0.113.7:4321",
+			proxies:    trusted,
+			ip:         "203.0.113.7",
+		},
+		{
+			desc:       "forwarded header from untrusted client",
+			remoteAddr: "203.0.113.7:4321",
+			forwarded:  "198.51.100.1",
+			proxies:    trusted,
+			ip:         "203.0.113.7",
+		},
+		{
+			desc:       "forwarded header without trusted proxies",
+			remoteAddr: "10.0.0.2:4321",
+			forwarded:  "198.51.100.1",
+			ip:         "10.0.0.2",
+		},
+		{
+			desc:       "forwarded header from trusted proxy",
+			remoteAddr: "10.0.0.2:4321",
+			forwarded:  "198.51.100.1",
+			proxies:    trusted,
+			ip:         "198.51.100.1",
+		},
+		{
+			desc:       "spoofed address before the client address",
+			remoteAddr: "10.0.0.2:4321",
+			forwarded:  "1.2.3.4, 198.51.100.1",
+			proxies:    trusted,
+			ip:         "198.51.100.1",
+		},
+		{
+			desc:       "chain of trusted proxies",
+			remoteAddr: "10.0.0.2:4321",
+			forwarded:  "198.51.100.1, 192.168.1.1, 10.0.0.3",
+			proxies:    trusted,
+			ip:         "198.51.100.1",
+		},
+		{
+			desc:       "trusted proxy without forwarded header",
+			remoteAddr: "10.0.0.2:4321",
+			proxies:    trusted,
+			ip:         "10.0.0.2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/keys", nil)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			req.RemoteAddr = tc.remoteAddr
+			if tc.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwarded)
+			}
+			ctx := clientIPToContext(tc.proxies)(context.Background(), req)
+			ip := auth.ClientIP(ctx)
+			assert.Equal(t, tc.ip, ip, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.ip, ip))
+		})
+	}
+}
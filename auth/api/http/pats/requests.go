@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/auth"
 	"github.com/absmach/supermq/pkg/errors"
@@ -117,7 +118,7 @@ func (req listPatsReq) validate() (err error) {
 	if req.token == "" {
 		return apiutil.ErrBearerToken
 	}
-	return nil
+	return apiutil.ValidatePageMetadata(apiutil.PageMetadata{Offset: req.offset, Limit: req.limit}, api.MaxLimitSize)
 }
 
 type deletePatReq struct {
@@ -283,5 +284,5 @@ func (req listScopesReq) validate() (err error) {
 	if req.patID == "" {
 		return apiutil.ErrMissingPATID
 	}
-	return nil
+	return apiutil.ValidatePageMetadata(apiutil.PageMetadata{Offset: req.offset, Limit: req.limit}, api.MaxLimitSize)
 }
@@ -5,6 +5,7 @@ package http
 import (
 	"log/slog"
 	"net/http"
+	"net/netip"
 
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/auth"
@@ -14,14 +15,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc auth.Service, logger *slog.Logger, instanceID string, jwksCacheMaxAge, jwksCacheStaleWhileRevalidate int) http.Handler {
+// MakeHandler returns a HTTP handler for API endpoints. The readiness checks
+// are evaluated by the /readiness endpoint.
+func MakeHandler(svc auth.Service, logger *slog.Logger, instanceID string, jwksCacheMaxAge, jwksCacheStaleWhileRevalidate int, trustedProxies []netip.Prefix, checks ...supermq.ReadinessCheck) http.Handler {
 	mux := chi.NewRouter()
 
-	mux = keys.MakeHandler(svc, mux, logger, jwksCacheMaxAge, jwksCacheStaleWhileRevalidate)
+	mux = keys.MakeHandler(svc, mux, logger, jwksCacheMaxAge, jwksCacheStaleWhileRevalidate, trustedProxies)
 	mux = pats.MakeHandler(svc, mux, logger)
 
 	mux.Get("/health", supermq.Health("auth", instanceID))
+	mux.Get("/readiness", supermq.Readiness("auth", instanceID, checks...))
 	mux.Handle("/metrics", promhttp.Handler())
 
 	return mux
@@ -0,0 +1,53 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Audited operations.
+const (
+	AuditIssue    = "issue"
+	AuditIdentify = "identify"
+	AuditRevoke   = "revoke"
+)
+
+type clientIPKey struct{}
+
+// AuditEvent represents a record of a token issuance, identification or
+// revocation attempt.
+type AuditEvent struct {
+	Operation  string    `json:"operation"`
+	KeyID      string    `json:"key_id,omitempty"`
+	Subject    string    `json:"subject,omitempty"`
+	KeyType    KeyType   `json:"key_type"`
+	Success    bool      `json:"success"`
+	ErrorCode  string    `json:"error_code,omitempty"`
+	ClientIP   string    `json:"client_ip,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// AuditLogger represents a sink of audit events.
+type AuditLogger interface {
+	// Record stores the audit events.
+	Record(ctx context.Context, events ...AuditEvent) error
+
+	// RemoveBefore removes the audit events which occurred before the given
+	// time and returns the number of removed events.
+	RemoveBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WithClientIP returns a copy of the context carrying the IP address of the
+// client which made the request.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns the IP address of the client stored in the context.
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
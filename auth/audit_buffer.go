@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const auditBatchSize = 100
+
+// ErrAuditBufferFull indicates that the audit event was dropped because the
+// buffer of events waiting to be written is full.
+var ErrAuditBufferFull = errors.New("audit buffer is full")
+
+var _ AuditLogger = (*bufferedAuditLogger)(nil)
+
+type bufferedAuditLogger struct {
+	AuditLogger
+	events        chan AuditEvent
+	flushInterval time.Duration
+	logger        *slog.Logger
+}
+
+// NewBufferedAuditLogger returns an audit logger which queues events in a
+// buffer of the given size instead of writing them on the request path.
+// A goroutine writes the queued events to the audit logger in batches, once
+// a batch is full or every flush interval, until the context is canceled.
+// Record does not block and returns ErrAuditBufferFull if the buffer is full.
+func NewBufferedAuditLogger(ctx context.Context, audit AuditLogger, size int, flushInterval time.Duration, logger *slog.Logger) AuditLogger {
+	bl := &bufferedAuditLogger{
+		AuditLogger:   audit,
+		events:        make(chan AuditEvent, size),
+		flushInterval: flushInterval,
+		logger:        logger,
+	}
+
+	go bl.run(ctx)
+
+	return bl
+}
+
+func (bl *bufferedAuditLogger) Record(_ context.Context, events ...AuditEvent) error {
+	for _, event := range events {
+		select {
+		case bl.events <- event:
+		default:
+			return ErrAuditBufferFull
+		}
+	}
+
+	return nil
+}
+
+func (bl *bufferedAuditLogger) run(ctx context.Context) {
+	ticker := time.NewTicker(bl.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]AuditEvent, 0, auditBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			// Write the events queued before the cancellation.
+			for range len(bl.events) {
+				batch = append(batch, <-bl.events)
+			}
+			bl.flush(context.WithoutCancel(ctx), batch)
+			return
+		case event := <-bl.events:
+			batch = append(batch, event)
+			if len(batch) >= auditBatchSize {
+				batch = bl.flush(ctx, batch)
+			}
+		case <-ticker.C:
+			batch = bl.flush(ctx, batch)
+		}
+	}
+}
+
+func (bl *bufferedAuditLogger) flush(ctx context.Context, batch []AuditEvent) []AuditEvent {
+	if len(batch) == 0 {
+		return batch
+	}
+	if err := bl.AuditLogger.Record(ctx, batch...); err != nil {
+		bl.logger.Warn("failed to record audit events", slog.Int("count", len(batch)), slog.Any("error", err))
+	}
+
+	return batch[:0]
+}
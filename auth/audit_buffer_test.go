@@ -0,0 +1,84 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+type auditRecorder struct {
+	mu      sync.Mutex
+	batches [][]auth.AuditEvent
+	block   chan struct{}
+}
+
+func (ar *auditRecorder) Record(_ context.Context, events ...auth.AuditEvent) error {
+	if ar.block != nil {
+		<-ar.block
+	}
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	ar.batches = append(ar.batches, events)
+
+	return nil
+}
+
+func (ar *auditRecorder) RemoveBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (ar *auditRecorder) recorded() int {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	count := 0
+	for _, batch := range ar.batches {
+		count += len(batch)
+	}
+
+	return count
+}
+
+func TestBufferedAuditLoggerFlush(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	recorder := &auditRecorder{}
+	audit := auth.NewBufferedAuditLogger(ctx, recorder, 10, 10*time.Millisecond, smqlog.NewMock())
+
+	for range 3 {
+		err := audit.Record(context.Background(), auth.AuditEvent{Operation: auth.AuditIdentify})
+		assert.Nil(t, err, "recording audit event: unexpected error")
+	}
+
+	assert.Eventually(t, func() bool { return recorder.recorded() == 3 }, time.Second, 5*time.Millisecond, "expected queued audit events to be written")
+	recorder.mu.Lock()
+	assert.Len(t, recorder.batches, 1, "expected queued audit events to be written in one batch")
+	recorder.mu.Unlock()
+}
+
+func TestBufferedAuditLoggerFull(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The writer is blocked, so the second event stays in the buffer and
+	// the third one does not fit.
+	recorder := &auditRecorder{block: make(chan struct{})}
+	defer close(recorder.block)
+	audit := auth.NewBufferedAuditLogger(ctx, recorder, 1, time.Millisecond, smqlog.NewMock())
+
+	err := audit.Record(context.Background(), auth.AuditEvent{Operation: auth.AuditIssue})
+	assert.Nil(t, err, "recording audit event: unexpected error")
+	time.Sleep(20 * time.Millisecond)
+	err = audit.Record(context.Background(), auth.AuditEvent{Operation: auth.AuditIssue})
+	assert.Nil(t, err, "recording audit event: unexpected error")
+	err = audit.Record(context.Background(), auth.AuditEvent{Operation: auth.AuditIssue})
+	assert.Equal(t, auth.ErrAuditBufferFull, err, "recording audit event: expected buffer full error")
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// The AuditRetentionHandler is a cron job that runs periodically to remove
+// audit events older than the retention period, so that the audit log does
+// not grow without bound.
+
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type auditRetentionHandler struct {
+	audit         AuditLogger
+	checkInterval time.Duration
+	retention     time.Duration
+	logger        *slog.Logger
+}
+
+// NewAuditRetentionHandler starts a goroutine that removes audit events older
+// than retention every check interval until the context is canceled.
+func NewAuditRetentionHandler(ctx context.Context, audit AuditLogger, checkInterval, retention time.Duration, logger *slog.Logger) {
+	handler := &auditRetentionHandler{
+		audit:         audit,
+		checkInterval: checkInterval,
+		retention:     retention,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *auditRetentionHandler) handle(ctx context.Context) {
+	removed, err := h.audit.RemoveBefore(ctx, time.Now().UTC().Add(-h.retention))
+	if err != nil {
+		h.logger.Error("failed to remove old audit events", slog.Any("error", err))
+		return
+	}
+
+	h.logger.Info("old audit events removed", slog.Int64("count", removed))
+}
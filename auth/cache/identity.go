@@ -0,0 +1,112 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+)
+
+var _ auth.IdentityCache = (*identityCache)(nil)
+
+type identityEntry struct {
+	hash   [sha256.Size]byte
+	key    auth.Key
+	expiry time.Time
+}
+
+type identityCache struct {
+	mu       sync.Mutex
+	size     int
+	duration time.Duration
+	order    *list.List
+	entries  map[[sha256.Size]byte]*list.Element
+	keys     map[string]map[[sha256.Size]byte]struct{}
+}
+
+// NewIdentityCache returns an in-memory LRU identity cache holding at most
+// size entries. Entries live for the given duration or until the key
+// expires, whichever comes first.
+func NewIdentityCache(size int, duration time.Duration) auth.IdentityCache {
+	return &identityCache{
+		size:     size,
+		duration: duration,
+		order:    list.New(),
+		entries:  make(map[[sha256.Size]byte]*list.Element),
+		keys:     make(map[string]map[[sha256.Size]byte]struct{}),
+	}
+}
+
+func (ic *identityCache) Save(token string, key auth.Key) {
+	expiry := time.Now().Add(ic.duration)
+	if !key.ExpiresAt.IsZero() && key.ExpiresAt.Before(expiry) {
+		expiry = key.ExpiresAt
+	}
+	if !expiry.After(time.Now()) {
+		return
+	}
+	hash := sha256.Sum256([]byte(token))
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	if el, ok := ic.entries[hash]; ok {
+		ic.remove(el)
+	}
+	ic.entries[hash] = ic.order.PushFront(&identityEntry{hash: hash, key: key, expiry: expiry})
+	if ic.keys[key.ID] == nil {
+		ic.keys[key.ID] = make(map[[sha256.Size]byte]struct{})
+	}
+	ic.keys[key.ID][hash] = struct{}{}
+
+	for ic.order.Len() > ic.size {
+		ic.remove(ic.order.Back())
+	}
+}
+
+func (ic *identityCache) Get(token string) (auth.Key, bool) {
+	hash := sha256.Sum256([]byte(token))
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	el, ok := ic.entries[hash]
+	if !ok {
+		return auth.Key{}, false
+	}
+	entry := el.Value.(*identityEntry)
+	if !time.Now().Before(entry.expiry) {
+		ic.remove(el)
+		return auth.Key{}, false
+	}
+	ic.order.MoveToFront(el)
+
+	return entry.key, true
+}
+
+func (ic *identityCache) Remove(keyID string) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	for hash := range ic.keys[keyID] {
+		if el, ok := ic.entries[hash]; ok {
+			ic.remove(el)
+		}
+	}
+}
+
+func (ic *identityCache) remove(el *list.Element) {
+	entry := ic.order.Remove(el).(*identityEntry)
+	delete(ic.entries, entry.hash)
+	if hashes, ok := ic.keys[entry.key.ID]; ok {
+		delete(hashes, entry.hash)
+		if len(hashes) == 0 {
+			delete(ic.keys, entry.key.ID)
+		}
+	}
+}
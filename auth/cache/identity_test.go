@@ -0,0 +1,106 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityCache(t *testing.T) {
+	key := auth.Key{
+		ID:        "key",
+		Type:      auth.AccessKey,
+		Subject:   "user",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	expired := auth.Key{
+		ID:        "expired",
+		Type:      auth.AccessKey,
+		Subject:   "user",
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+
+	cases := []struct {
+		desc  string
+		setup func(ic auth.IdentityCache)
+		token string
+		key   auth.Key
+		ok    bool
+	}{
+		{
+			desc:  "get saved identity",
+			setup: func(ic auth.IdentityCache) { ic.Save("token", key) },
+			token: "token",
+			key:   key,
+			ok:    true,
+		},
+		{
+			desc:  "get identity that was not saved",
+			setup: func(ic auth.IdentityCache) {},
+			token: "token",
+		},
+		{
+			desc:  "get identity of expired key",
+			setup: func(ic auth.IdentityCache) { ic.Save("token", expired) },
+			token: "token",
+		},
+		{
+			desc: "get removed identity",
+			setup: func(ic auth.IdentityCache) {
+				ic.Save("token", key)
+				ic.Remove(key.ID)
+			},
+			token: "token",
+		},
+		{
+			desc: "get evicted identity",
+			setup: func(ic auth.IdentityCache) {
+				ic.Save("token", key)
+				ic.Save("token-1", auth.Key{ID: "key-1"})
+				ic.Save("token-2", auth.Key{ID: "key-2"})
+			},
+			token: "token",
+		},
+		{
+			desc: "get recently used identity after eviction",
+			setup: func(ic auth.IdentityCache) {
+				ic.Save("token", key)
+				ic.Save("token-1", auth.Key{ID: "key-1"})
+				ic.Get("token")
+				ic.Save("token-2", auth.Key{ID: "key-2"})
+			},
+			token: "token",
+			key:   key,
+			ok:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ic := cache.NewIdentityCache(2, time.Minute)
+			tc.setup(ic)
+			key, ok := ic.Get(tc.token)
+			assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.ok, ok))
+			assert.Equal(t, tc.key, key, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.key, key))
+		})
+	}
+}
+
+func TestIdentityCacheDuration(t *testing.T) {
+	ic := cache.NewIdentityCache(10, 10*time.Millisecond)
+	ic.Save("token", auth.Key{ID: "key", Type: auth.APIKey})
+
+	_, ok := ic.Get("token")
+	assert.True(t, ok, "expected identity to be cached")
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok = ic.Get("token")
+	assert.False(t, ok, "expected identity to expire after cache duration")
+}
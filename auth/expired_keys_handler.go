@@ -0,0 +1,54 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// The ExpiredKeysHandler is a cron job that runs periodically to remove expired keys.
+// Expired keys are otherwise removed only when presented to Identify, so keys
+// which are never used again would stay in the database forever.
+
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type expiredKeysHandler struct {
+	keys          KeyRepository
+	checkInterval time.Duration
+	logger        *slog.Logger
+}
+
+// NewExpiredKeysHandler starts a goroutine that removes expired keys every
+// check interval until the context is canceled.
+func NewExpiredKeysHandler(ctx context.Context, keys KeyRepository, checkInterval time.Duration, logger *slog.Logger) {
+	handler := &expiredKeysHandler{
+		keys:          keys,
+		checkInterval: checkInterval,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *expiredKeysHandler) handle(ctx context.Context) {
+	removed, err := h.keys.RemoveExpired(ctx, time.Now().UTC())
+	if err != nil {
+		h.logger.Error("failed to remove expired keys", slog.Any("error", err))
+		return
+	}
+
+	h.logger.Info("expired keys removed", slog.Int64("count", removed))
+}
@@ -64,6 +64,21 @@ type UserActiveTokensCache interface {
 	RemoveActive(ctx context.Context, userID, tokenID string) error
 }
 
+// IdentityCache represents an in-process cache of identities of already parsed tokens.
+// It is not shared between service instances, so Remove only affects the
+// instance that revoked the key; other instances keep serving the cached
+// identity until the entry expires.
+type IdentityCache interface {
+	// Save stores the identity of the token until the key expires.
+	Save(token string, key Key)
+
+	// Get returns the cached identity of the token.
+	Get(token string) (Key, bool)
+
+	// Remove removes cached identities of the key with the given ID.
+	Remove(keyID string)
+}
+
 // TokenInfo represents information about an active refresh token.
 type TokenInfo struct {
 	ID          string `json:"id"`
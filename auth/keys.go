@@ -90,6 +90,7 @@ type Key struct {
 	ExpiresAt   time.Time `json:"expires_at,omitempty"`
 	Verified    bool      `json:"verified,omitempty"`
 	Description string    `json:"description,omitempty"` // Optional description for refresh tokens
+	Scopes      []string  `json:"scopes,omitempty"`      // Optional scopes limiting API keys, e.g. "clients:read"
 }
 
 func (key Key) String() string {
@@ -99,9 +100,10 @@ func (key Key) String() string {
 	issuer_id: %s,
 	subject: %s,
 	role: %s,
+	scopes: %v,
 	iat: %v,
 	eat: %v
-}`, key.ID, key.Type, key.Issuer, key.Subject, key.Role, key.IssuedAt, key.ExpiresAt)
+}`, key.ID, key.Type, key.Issuer, key.Subject, key.Role, key.Scopes, key.IssuedAt, key.ExpiresAt)
 }
 
 // Expired verifies if the key is expired.
@@ -112,6 +114,20 @@ func (key Key) Expired() bool {
 	return key.ExpiresAt.UTC().Before(time.Now().UTC())
 }
 
+// KeyPageMeta contains page metadata that helps navigation.
+type KeyPageMeta struct {
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+// KeyPage contains page related metadata as well as a list of keys.
+type KeyPage struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Keys   []Key  `json:"keys"`
+}
+
 // KeyRepository specifies Key persistence API.
 type KeyRepository interface {
 	// Save persists the Key. A non-nil error is returned to indicate
@@ -121,6 +137,13 @@ type KeyRepository interface {
 	// Retrieve retrieves Key by its unique identifier.
 	Retrieve(ctx context.Context, issuer string, id string) (key Key, err error)
 
+	// RetrieveAllByIssuer retrieves a page of Keys issued by the provided issuer.
+	RetrieveAllByIssuer(ctx context.Context, issuer string, pm KeyPageMeta) (KeyPage, error)
+
 	// Remove removes Key with provided ID.
 	Remove(ctx context.Context, issuer string, id string) error
+
+	// RemoveExpired removes all Keys that expired before the provided time
+	// and returns the number of removed Keys.
+	RemoveExpired(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,92 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var _ auth.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	auth.Service
+	audit  auth.AuditLogger
+	logger *slog.Logger
+}
+
+// NewAudit records every token issuance, identification and revocation
+// attempt, successful or not, to the audit logger. Failing to record an
+// event is logged and does not fail the operation.
+func NewAudit(svc auth.Service, audit auth.AuditLogger, logger *slog.Logger) auth.Service {
+	return &auditMiddleware{
+		Service: svc,
+		audit:   audit,
+		logger:  logger,
+	}
+}
+
+func (am *auditMiddleware) Issue(ctx context.Context, token string, key auth.Key) (tkn auth.Token, err error) {
+	defer func() {
+		am.record(ctx, auth.AuditEvent{
+			Operation: auth.AuditIssue,
+			Subject:   key.Subject,
+			KeyType:   key.Type,
+		}, err)
+	}()
+
+	return am.Service.Issue(ctx, token, key)
+}
+
+func (am *auditMiddleware) Revoke(ctx context.Context, token, id string) (key auth.Key, err error) {
+	defer func() {
+		am.record(ctx, auth.AuditEvent{
+			Operation: auth.AuditRevoke,
+			KeyID:     id,
+			Subject:   key.Subject,
+			KeyType:   key.Type,
+		}, err)
+	}()
+
+	return am.Service.Revoke(ctx, token, id)
+}
+
+func (am *auditMiddleware) Identify(ctx context.Context, token string) (key auth.Key, err error) {
+	defer func() {
+		am.record(ctx, auth.AuditEvent{
+			Operation: auth.AuditIdentify,
+			KeyID:     key.ID,
+			Subject:   key.Subject,
+			KeyType:   key.Type,
+		}, err)
+	}()
+
+	return am.Service.Identify(ctx, token)
+}
+
+func (am *auditMiddleware) record(ctx context.Context, event auth.AuditEvent, err error) {
+	event.Success = err == nil
+	event.ClientIP = auth.ClientIP(ctx)
+	event.OccurredAt = time.Now().UTC()
+	if err != nil {
+		// The outermost error message is stable, unlike the wrapped details.
+		event.ErrorCode = err.Error()
+		if e, ok := err.(errors.Error); ok {
+			event.ErrorCode = e.Msg()
+		}
+	}
+
+	if err := am.audit.Record(context.WithoutCancel(ctx), event); err != nil {
+		am.logger.Warn("Failed to record audit event",
+			slog.String("operation", event.Operation),
+			slog.String("error", err.Error()),
+		)
+	}
+}
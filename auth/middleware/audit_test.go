@@ -0,0 +1,161 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/middleware"
+	"github.com/absmach/supermq/auth/mocks"
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	token    = "token"
+	keyID    = "key-id"
+	subject  = "subject"
+	clientIP = "203.0.113.7"
+)
+
+var (
+	// errIdentify mirrors the error auth service returns for invalid tokens.
+	errIdentify = errors.Wrap(svcerr.ErrAuthentication, errors.Wrap(errors.New("failed to validate token"), errors.New("token is malformed")))
+	errRecord   = errors.New("failed to record")
+)
+
+type auditRecorder struct {
+	mu     sync.Mutex
+	events []auth.AuditEvent
+	err    error
+}
+
+func (ar *auditRecorder) Record(_ context.Context, events ...auth.AuditEvent) error {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	ar.events = append(ar.events, events...)
+
+	return ar.err
+}
+
+func (ar *auditRecorder) RemoveBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestAuditIdentify(t *testing.T) {
+	cases := []struct {
+		desc      string
+		key       auth.Key
+		svcErr    error
+		recordErr error
+		event     auth.AuditEvent
+		err       error
+	}{
+		{
+			desc: "identify successfully",
+			key:  auth.Key{ID: keyID, Subject: subject, Type: auth.APIKey},
+			event: auth.AuditEvent{
+				Operation: auth.AuditIdentify,
+				KeyID:     keyID,
+				Subject:   subject,
+				KeyType:   auth.APIKey,
+				Success:   true,
+				ClientIP:  clientIP,
+			},
+		},
+		{
+			desc:   "identify with invalid token",
+			svcErr: errIdentify,
+			err:    svcerr.ErrAuthentication,
+			event: auth.AuditEvent{
+				Operation: auth.AuditIdentify,
+				Success:   false,
+				ErrorCode: svcerr.ErrAuthentication.Error(),
+				ClientIP:  clientIP,
+			},
+		},
+		{
+			desc:      "identify with failed recording",
+			key:       auth.Key{ID: keyID, Subject: subject, Type: auth.APIKey},
+			recordErr: errRecord,
+			event: auth.AuditEvent{
+				Operation: auth.AuditIdentify,
+				KeyID:     keyID,
+				Subject:   subject,
+				KeyType:   auth.APIKey,
+				Success:   true,
+				ClientIP:  clientIP,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := new(mocks.Service)
+			recorder := &auditRecorder{err: tc.recordErr}
+			asvc := middleware.NewAudit(svc, recorder, smqlog.NewMock())
+			ctx := auth.WithClientIP(context.Background(), clientIP)
+			svc.On("Identify", ctx, token).Return(tc.key, tc.svcErr)
+
+			key, err := asvc.Identify(ctx, token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.key, key, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.key, key))
+			assert.Len(t, recorder.events, 1, fmt.Sprintf("%s: expected one audit event", tc.desc))
+			if len(recorder.events) == 1 {
+				event := recorder.events[0]
+				assert.False(t, event.OccurredAt.IsZero(), fmt.Sprintf("%s: expected occurrence time to be set", tc.desc))
+				event.OccurredAt = tc.event.OccurredAt
+				assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.event, event))
+			}
+		})
+	}
+}
+
+func TestAuditIssueAndRevoke(t *testing.T) {
+	svc := new(mocks.Service)
+	recorder := &auditRecorder{}
+	asvc := middleware.NewAudit(svc, recorder, smqlog.NewMock())
+	ctx := auth.WithClientIP(context.Background(), clientIP)
+	key := auth.Key{Subject: subject, Type: auth.AccessKey}
+	svc.On("Issue", ctx, token, key).Return(auth.Token{}, svcerr.ErrAuthorization)
+	svc.On("Revoke", ctx, token, keyID).Return(auth.Key{ID: keyID, Subject: subject, Type: auth.APIKey}, nil)
+
+	_, err := asvc.Issue(ctx, token, key)
+	assert.True(t, errors.Contains(err, svcerr.ErrAuthorization), fmt.Sprintf("issue: expected %s got %s", svcerr.ErrAuthorization, err))
+	_, err = asvc.Revoke(ctx, token, keyID)
+	assert.Nil(t, err, fmt.Sprintf("revoke: unexpected error %s", err))
+
+	expected := []auth.AuditEvent{
+		{
+			Operation: auth.AuditIssue,
+			Subject:   subject,
+			KeyType:   auth.AccessKey,
+			Success:   false,
+			ErrorCode: svcerr.ErrAuthorization.Error(),
+			ClientIP:  clientIP,
+		},
+		{
+			Operation: auth.AuditRevoke,
+			KeyID:     keyID,
+			Subject:   subject,
+			KeyType:   auth.APIKey,
+			Success:   true,
+			ClientIP:  clientIP,
+		},
+	}
+	assert.Len(t, recorder.events, len(expected), fmt.Sprintf("expected %d audit events got %d", len(expected), len(recorder.events)))
+	for i := range recorder.events {
+		recorder.events[i].OccurredAt = expected[i].OccurredAt
+	}
+	assert.Equal(t, expected, recorder.events)
+}
@@ -46,11 +46,15 @@ func (lm *loggingMiddleware) Issue(ctx context.Context, token string, key auth.K
 	return lm.svc.Issue(ctx, token, key)
 }
 
-func (lm *loggingMiddleware) Revoke(ctx context.Context, token, id string) (err error) {
+func (lm *loggingMiddleware) Revoke(ctx context.Context, token, id string) (key auth.Key, err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
-			slog.String("key_id", id),
+			slog.Group("key",
+				slog.String("id", id),
+				slog.String("subject", key.Subject),
+				slog.String("type", key.Type.String()),
+			),
 		}
 		if err != nil {
 			args = append(args, slog.String("error", err.Error()))
@@ -63,6 +67,23 @@ func (lm *loggingMiddleware) Revoke(ctx context.Context, token, id string) (err
 	return lm.svc.Revoke(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) RevokeAll(ctx context.Context, token string) (revoked uint64, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Uint64("revoked", revoked),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Revoke all keys failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke all keys completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RevokeAll(ctx, token)
+}
+
 func (lm *loggingMiddleware) RetrieveKey(ctx context.Context, token, id string) (key auth.Key, err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -183,6 +204,54 @@ func (lm *loggingMiddleware) Authorize(ctx context.Context, pr policies.Policy,
 	return lm.svc.Authorize(ctx, pr, patAuthz)
 }
 
+func (lm *loggingMiddleware) StreamObjects(ctx context.Context, pr policies.Policy, fn func(ids []string) error) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("object",
+				slog.String("id", pr.Object),
+				slog.String("type", pr.ObjectType),
+			),
+			slog.Group("subject",
+				slog.String("id", pr.Subject),
+				slog.String("type", pr.SubjectType),
+			),
+			slog.String("permission", pr.Permission),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Stream objects failed", args...)
+			return
+		}
+		lm.logger.Info("Stream objects completed successfully", args...)
+	}(time.Now())
+	return lm.svc.StreamObjects(ctx, pr, fn)
+}
+
+func (lm *loggingMiddleware) StreamSubjects(ctx context.Context, pr policies.Policy, fn func(ids []string) error) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("object",
+				slog.String("id", pr.Object),
+				slog.String("type", pr.ObjectType),
+			),
+			slog.Group("subject",
+				slog.String("id", pr.Subject),
+				slog.String("type", pr.SubjectType),
+			),
+			slog.String("permission", pr.Permission),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Stream subjects failed", args...)
+			return
+		}
+		lm.logger.Info("Stream subjects completed successfully", args...)
+	}(time.Now())
+	return lm.svc.StreamSubjects(ctx, pr, fn)
+}
+
 func (lm *loggingMiddleware) CreatePAT(ctx context.Context, token, name, description string, duration time.Duration) (pa auth.PAT, err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -49,7 +49,7 @@ func (ms *metricsMiddleware) RevokeToken(ctx context.Context, userID, tokenID st
 	return ms.svc.RevokeToken(ctx, userID, tokenID)
 }
 
-func (ms *metricsMiddleware) Revoke(ctx context.Context, token, id string) error {
+func (ms *metricsMiddleware) Revoke(ctx context.Context, token, id string) (auth.Key, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "revoke_key").Add(1)
 		ms.latency.With("method", "revoke_key").Observe(time.Since(begin).Seconds())
@@ -58,6 +58,15 @@ func (ms *metricsMiddleware) Revoke(ctx context.Context, token, id string) error
 	return ms.svc.Revoke(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) RevokeAll(ctx context.Context, token string) (uint64, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_all_keys").Add(1)
+		ms.latency.With("method", "revoke_all_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeAll(ctx, token)
+}
+
 func (ms *metricsMiddleware) RetrieveKey(ctx context.Context, token, id string) (auth.Key, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "retrieve_key").Add(1)
@@ -101,6 +110,22 @@ func (ms *metricsMiddleware) Authorize(ctx context.Context, pr policies.Policy,
 	return ms.svc.Authorize(ctx, pr, patAuthz)
 }
 
+func (ms *metricsMiddleware) StreamObjects(ctx context.Context, pr policies.Policy, fn func(ids []string) error) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "stream_objects").Add(1)
+		ms.latency.With("method", "stream_objects").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.StreamObjects(ctx, pr, fn)
+}
+
+func (ms *metricsMiddleware) StreamSubjects(ctx context.Context, pr policies.Policy, fn func(ids []string) error) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "stream_subjects").Add(1)
+		ms.latency.With("method", "stream_subjects").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.StreamSubjects(ctx, pr, fn)
+}
+
 func (ms *metricsMiddleware) CreatePAT(ctx context.Context, token, name, description string, duration time.Duration) (auth.PAT, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "create_pat").Add(1)
@@ -36,7 +36,7 @@ func (tm *tracingMiddleware) Issue(ctx context.Context, token string, key auth.K
 	return tm.svc.Issue(ctx, token, key)
 }
 
-func (tm *tracingMiddleware) Revoke(ctx context.Context, token, id string) error {
+func (tm *tracingMiddleware) Revoke(ctx context.Context, token, id string) (auth.Key, error) {
 	ctx, span := tm.tracer.Start(ctx, "revoke", trace.WithAttributes(
 		attribute.String("id", id),
 	))
@@ -45,6 +45,13 @@ func (tm *tracingMiddleware) Revoke(ctx context.Context, token, id string) error
 	return tm.svc.Revoke(ctx, token, id)
 }
 
+func (tm *tracingMiddleware) RevokeAll(ctx context.Context, token string) (uint64, error) {
+	ctx, span := tm.tracer.Start(ctx, "revoke_all")
+	defer span.End()
+
+	return tm.svc.RevokeAll(ctx, token)
+}
+
 func (tm *tracingMiddleware) RetrieveKey(ctx context.Context, token, id string) (auth.Key, error) {
 	ctx, span := tm.tracer.Start(ctx, "retrieve_key", trace.WithAttributes(
 		attribute.String("id", id),
@@ -112,6 +119,34 @@ func (tm *tracingMiddleware) Authorize(ctx context.Context, pr policies.Policy,
 	return tm.svc.Authorize(ctx, pr, patAuthz)
 }
 
+func (tm *tracingMiddleware) StreamObjects(ctx context.Context, pr policies.Policy, fn func(ids []string) error) error {
+	ctx, span := tm.tracer.Start(ctx, "stream_objects", trace.WithAttributes(
+		attribute.String("subject", pr.Subject),
+		attribute.String("subject_type", pr.SubjectType),
+		attribute.String("subject_relation", pr.SubjectRelation),
+		attribute.String("object", pr.Object),
+		attribute.String("object_type", pr.ObjectType),
+		attribute.String("permission", pr.Permission),
+	))
+	defer span.End()
+
+	return tm.svc.StreamObjects(ctx, pr, fn)
+}
+
+func (tm *tracingMiddleware) StreamSubjects(ctx context.Context, pr policies.Policy, fn func(ids []string) error) error {
+	ctx, span := tm.tracer.Start(ctx, "stream_subjects", trace.WithAttributes(
+		attribute.String("subject", pr.Subject),
+		attribute.String("subject_type", pr.SubjectType),
+		attribute.String("subject_relation", pr.SubjectRelation),
+		attribute.String("object", pr.Object),
+		attribute.String("object_type", pr.ObjectType),
+		attribute.String("permission", pr.Permission),
+	))
+	defer span.End()
+
+	return tm.svc.StreamSubjects(ctx, pr, fn)
+}
+
 func (tm *tracingMiddleware) CreatePAT(ctx context.Context, token, name, description string, duration time.Duration) (auth.PAT, error) {
 	ctx, span := tm.tracer.Start(ctx, "create_pat", trace.WithAttributes(
 		attribute.String("name", name),
@@ -105,3 +105,129 @@ func (_c *Authz_Authorize_Call) RunAndReturn(run func(ctx context.Context, pr po
 	_c.Call.Return(run)
 	return _c
 }
+
+// StreamObjects provides a mock function for the type Authz
+func (_mock *Authz) StreamObjects(ctx context.Context, pr policies.Policy, fn func(objects []string) error) error {
+	ret := _mock.Called(ctx, pr, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamObjects")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, policies.Policy, func(objects []string) error) error); ok {
+		r0 = returnFunc(ctx, pr, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Authz_StreamObjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamObjects'
+type Authz_StreamObjects_Call struct {
+	*mock.Call
+}
+
+// StreamObjects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr policies.Policy
+//   - fn func(objects []string) error
+func (_e *Authz_Expecter) StreamObjects(ctx interface{}, pr interface{}, fn interface{}) *Authz_StreamObjects_Call {
+	return &Authz_StreamObjects_Call{Call: _e.mock.On("StreamObjects", ctx, pr, fn)}
+}
+
+func (_c *Authz_StreamObjects_Call) Run(run func(ctx context.Context, pr policies.Policy, fn func(objects []string) error)) *Authz_StreamObjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 policies.Policy
+		if args[1] != nil {
+			arg1 = args[1].(policies.Policy)
+		}
+		var arg2 func(objects []string) error
+		if args[2] != nil {
+			arg2 = args[2].(func(objects []string) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Authz_StreamObjects_Call) Return(err error) *Authz_StreamObjects_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Authz_StreamObjects_Call) RunAndReturn(run func(ctx context.Context, pr policies.Policy, fn func(objects []string) error) error) *Authz_StreamObjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamSubjects provides a mock function for the type Authz
+func (_mock *Authz) StreamSubjects(ctx context.Context, pr policies.Policy, fn func(subjects []string) error) error {
+	ret := _mock.Called(ctx, pr, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamSubjects")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, policies.Policy, func(subjects []string) error) error); ok {
+		r0 = returnFunc(ctx, pr, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Authz_StreamSubjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamSubjects'
+type Authz_StreamSubjects_Call struct {
+	*mock.Call
+}
+
+// StreamSubjects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr policies.Policy
+//   - fn func(subjects []string) error
+func (_e *Authz_Expecter) StreamSubjects(ctx interface{}, pr interface{}, fn interface{}) *Authz_StreamSubjects_Call {
+	return &Authz_StreamSubjects_Call{Call: _e.mock.On("StreamSubjects", ctx, pr, fn)}
+}
+
+func (_c *Authz_StreamSubjects_Call) Run(run func(ctx context.Context, pr policies.Policy, fn func(subjects []string) error)) *Authz_StreamSubjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 policies.Policy
+		if args[1] != nil {
+			arg1 = args[1].(policies.Policy)
+		}
+		var arg2 func(subjects []string) error
+		if args[2] != nil {
+			arg2 = args[2].(func(subjects []string) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Authz_StreamSubjects_Call) Return(err error) *Authz_StreamSubjects_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Authz_StreamSubjects_Call) RunAndReturn(run func(ctx context.Context, pr policies.Policy, fn func(subjects []string) error) error) *Authz_StreamSubjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -10,6 +10,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/auth"
 	mock "github.com/stretchr/testify/mock"
@@ -105,6 +106,72 @@ func (_c *KeyRepository_Remove_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// RemoveExpired provides a mock function for the type KeyRepository
+func (_mock *KeyRepository) RemoveExpired(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// KeyRepository_RemoveExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveExpired'
+type KeyRepository_RemoveExpired_Call struct {
+	*mock.Call
+}
+
+// RemoveExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *KeyRepository_Expecter) RemoveExpired(ctx interface{}, before interface{}) *KeyRepository_RemoveExpired_Call {
+	return &KeyRepository_RemoveExpired_Call{Call: _e.mock.On("RemoveExpired", ctx, before)}
+}
+
+func (_c *KeyRepository_RemoveExpired_Call) Run(run func(ctx context.Context, before time.Time)) *KeyRepository_RemoveExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *KeyRepository_RemoveExpired_Call) Return(v int64, err error) *KeyRepository_RemoveExpired_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *KeyRepository_RemoveExpired_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *KeyRepository_RemoveExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Retrieve provides a mock function for the type KeyRepository
 func (_mock *KeyRepository) Retrieve(ctx context.Context, issuer string, id string) (auth.Key, error) {
 	ret := _mock.Called(ctx, issuer, id)
@@ -177,6 +244,78 @@ func (_c *KeyRepository_Retrieve_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// RetrieveAllByIssuer provides a mock function for the type KeyRepository
+func (_mock *KeyRepository) RetrieveAllByIssuer(ctx context.Context, issuer string, pm auth.KeyPageMeta) (auth.KeyPage, error) {
+	ret := _mock.Called(ctx, issuer, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAllByIssuer")
+	}
+
+	var r0 auth.KeyPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, auth.KeyPageMeta) (auth.KeyPage, error)); ok {
+		return returnFunc(ctx, issuer, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, auth.KeyPageMeta) auth.KeyPage); ok {
+		r0 = returnFunc(ctx, issuer, pm)
+	} else {
+		r0 = ret.Get(0).(auth.KeyPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, auth.KeyPageMeta) error); ok {
+		r1 = returnFunc(ctx, issuer, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// KeyRepository_RetrieveAllByIssuer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveAllByIssuer'
+type KeyRepository_RetrieveAllByIssuer_Call struct {
+	*mock.Call
+}
+
+// RetrieveAllByIssuer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issuer string
+//   - pm auth.KeyPageMeta
+func (_e *KeyRepository_Expecter) RetrieveAllByIssuer(ctx interface{}, issuer interface{}, pm interface{}) *KeyRepository_RetrieveAllByIssuer_Call {
+	return &KeyRepository_RetrieveAllByIssuer_Call{Call: _e.mock.On("RetrieveAllByIssuer", ctx, issuer, pm)}
+}
+
+func (_c *KeyRepository_RetrieveAllByIssuer_Call) Run(run func(ctx context.Context, issuer string, pm auth.KeyPageMeta)) *KeyRepository_RetrieveAllByIssuer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 auth.KeyPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(auth.KeyPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *KeyRepository_RetrieveAllByIssuer_Call) Return(keyPage auth.KeyPage, err error) *KeyRepository_RetrieveAllByIssuer_Call {
+	_c.Call.Return(keyPage, err)
+	return _c
+}
+
+func (_c *KeyRepository_RetrieveAllByIssuer_Call) RunAndReturn(run func(ctx context.Context, issuer string, pm auth.KeyPageMeta) (auth.KeyPage, error)) *KeyRepository_RetrieveAllByIssuer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function for the type KeyRepository
 func (_mock *KeyRepository) Save(ctx context.Context, key auth.Key) (string, error) {
 	ret := _mock.Called(ctx, key)
@@ -1293,20 +1293,29 @@ func (_c *Service_RetrievePAT_Call) RunAndReturn(run func(ctx context.Context, u
 }
 
 // Revoke provides a mock function for the type Service
-func (_mock *Service) Revoke(ctx context.Context, token string, id string) error {
+func (_mock *Service) Revoke(ctx context.Context, token string, id string) (auth.Key, error) {
 	ret := _mock.Called(ctx, token, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Revoke")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+	var r0 auth.Key
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (auth.Key, error)); ok {
+		return returnFunc(ctx, token, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) auth.Key); ok {
 		r0 = returnFunc(ctx, token, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(auth.Key)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, token, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // Service_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
@@ -1345,12 +1354,78 @@ func (_c *Service_Revoke_Call) Run(run func(ctx context.Context, token string, i
 	return _c
 }
 
-func (_c *Service_Revoke_Call) Return(err error) *Service_Revoke_Call {
-	_c.Call.Return(err)
+func (_c *Service_Revoke_Call) Return(key auth.Key, err error) *Service_Revoke_Call {
+	_c.Call.Return(key, err)
 	return _c
 }
 
-func (_c *Service_Revoke_Call) RunAndReturn(run func(ctx context.Context, token string, id string) error) *Service_Revoke_Call {
+func (_c *Service_Revoke_Call) RunAndReturn(run func(ctx context.Context, token string, id string) (auth.Key, error)) *Service_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAll provides a mock function for the type Service
+func (_mock *Service) RevokeAll(ctx context.Context, token string) (uint64, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAll")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (uint64, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) uint64); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_RevokeAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAll'
+type Service_RevokeAll_Call struct {
+	*mock.Call
+}
+
+// RevokeAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *Service_Expecter) RevokeAll(ctx interface{}, token interface{}) *Service_RevokeAll_Call {
+	return &Service_RevokeAll_Call{Call: _e.mock.On("RevokeAll", ctx, token)}
+}
+
+func (_c *Service_RevokeAll_Call) Run(run func(ctx context.Context, token string)) *Service_RevokeAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RevokeAll_Call) Return(v uint64, err error) *Service_RevokeAll_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *Service_RevokeAll_Call) RunAndReturn(run func(ctx context.Context, token string) (uint64, error)) *Service_RevokeAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1481,6 +1556,132 @@ func (_c *Service_RevokeToken_Call) RunAndReturn(run func(ctx context.Context, u
 	return _c
 }
 
+// StreamObjects provides a mock function for the type Service
+func (_mock *Service) StreamObjects(ctx context.Context, pr policies.Policy, fn func(objects []string) error) error {
+	ret := _mock.Called(ctx, pr, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamObjects")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, policies.Policy, func(objects []string) error) error); ok {
+		r0 = returnFunc(ctx, pr, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_StreamObjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamObjects'
+type Service_StreamObjects_Call struct {
+	*mock.Call
+}
+
+// StreamObjects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr policies.Policy
+//   - fn func(objects []string) error
+func (_e *Service_Expecter) StreamObjects(ctx interface{}, pr interface{}, fn interface{}) *Service_StreamObjects_Call {
+	return &Service_StreamObjects_Call{Call: _e.mock.On("StreamObjects", ctx, pr, fn)}
+}
+
+func (_c *Service_StreamObjects_Call) Run(run func(ctx context.Context, pr policies.Policy, fn func(objects []string) error)) *Service_StreamObjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 policies.Policy
+		if args[1] != nil {
+			arg1 = args[1].(policies.Policy)
+		}
+		var arg2 func(objects []string) error
+		if args[2] != nil {
+			arg2 = args[2].(func(objects []string) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_StreamObjects_Call) Return(err error) *Service_StreamObjects_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_StreamObjects_Call) RunAndReturn(run func(ctx context.Context, pr policies.Policy, fn func(objects []string) error) error) *Service_StreamObjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamSubjects provides a mock function for the type Service
+func (_mock *Service) StreamSubjects(ctx context.Context, pr policies.Policy, fn func(subjects []string) error) error {
+	ret := _mock.Called(ctx, pr, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamSubjects")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, policies.Policy, func(subjects []string) error) error); ok {
+		r0 = returnFunc(ctx, pr, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_StreamSubjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamSubjects'
+type Service_StreamSubjects_Call struct {
+	*mock.Call
+}
+
+// StreamSubjects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr policies.Policy
+//   - fn func(subjects []string) error
+func (_e *Service_Expecter) StreamSubjects(ctx interface{}, pr interface{}, fn interface{}) *Service_StreamSubjects_Call {
+	return &Service_StreamSubjects_Call{Call: _e.mock.On("StreamSubjects", ctx, pr, fn)}
+}
+
+func (_c *Service_StreamSubjects_Call) Run(run func(ctx context.Context, pr policies.Policy, fn func(subjects []string) error)) *Service_StreamSubjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 policies.Policy
+		if args[1] != nil {
+			arg1 = args[1].(policies.Policy)
+		}
+		var arg2 func(subjects []string) error
+		if args[2] != nil {
+			arg2 = args[2].(func(subjects []string) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_StreamSubjects_Call) Return(err error) *Service_StreamSubjects_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_StreamSubjects_Call) RunAndReturn(run func(ctx context.Context, pr policies.Policy, fn func(subjects []string) error) error) *Service_StreamSubjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdatePATDescription provides a mock function for the type Service
 func (_mock *Service) UpdatePATDescription(ctx context.Context, token string, patID string, description string) (auth.PAT, error) {
 	ret := _mock.Called(ctx, token, patID, description)
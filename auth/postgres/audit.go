@@ -0,0 +1,87 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+var (
+	errSaveAuditEvent   = errors.New("failed to save audit event in database")
+	errRemoveAuditEvent = errors.New("failed to remove audit events from database")
+)
+
+var _ auth.AuditLogger = (*auditRepo)(nil)
+
+type auditRepo struct {
+	db postgres.Database
+}
+
+// NewAuditRepo instantiates a PostgreSQL implementation of audit logger.
+func NewAuditRepo(db postgres.Database) auth.AuditLogger {
+	return &auditRepo{
+		db: db,
+	}
+}
+
+func (ar *auditRepo) Record(ctx context.Context, events ...auth.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	q := `INSERT INTO audit_log (operation, key_id, subject, key_type, success, error_code, client_ip, occurred_at)
+		VALUES (:operation, :key_id, :subject, :key_type, :success, :error_code, :client_ip, :occurred_at)`
+
+	dbEvents := make([]dbAuditEvent, len(events))
+	for i, event := range events {
+		dbEvents[i] = toDBAuditEvent(event)
+	}
+	if _, err := ar.db.NamedExecContext(ctx, q, dbEvents); err != nil {
+		return postgres.HandleError(errSaveAuditEvent, err)
+	}
+
+	return nil
+}
+
+func (ar *auditRepo) RemoveBefore(ctx context.Context, before time.Time) (int64, error) {
+	q := `DELETE FROM audit_log WHERE occurred_at < $1`
+	res, err := ar.db.ExecContext(ctx, q, before)
+	if err != nil {
+		return 0, postgres.HandleError(errRemoveAuditEvent, err)
+	}
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return 0, postgres.HandleError(errRemoveAuditEvent, err)
+	}
+
+	return removed, nil
+}
+
+type dbAuditEvent struct {
+	Operation  string    `db:"operation"`
+	KeyID      string    `db:"key_id"`
+	Subject    string    `db:"subject"`
+	KeyType    uint32    `db:"key_type"`
+	Success    bool      `db:"success"`
+	ErrorCode  string    `db:"error_code"`
+	ClientIP   string    `db:"client_ip"`
+	OccurredAt time.Time `db:"occurred_at"`
+}
+
+func toDBAuditEvent(event auth.AuditEvent) dbAuditEvent {
+	return dbAuditEvent{
+		Operation:  event.Operation,
+		KeyID:      event.KeyID,
+		Subject:    event.Subject,
+		KeyType:    uint32(event.KeyType),
+		Success:    event.Success,
+		ErrorCode:  event.ErrorCode,
+		ClientIP:   event.ClientIP,
+		OccurredAt: event.OccurredAt,
+	}
+}
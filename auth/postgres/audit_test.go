@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRecord(t *testing.T) {
+	repo := postgres.NewAuditRepo(database)
+
+	cases := []struct {
+		desc  string
+		event auth.AuditEvent
+		err   error
+	}{
+		{
+			desc: "record successful issue",
+			event: auth.AuditEvent{
+				Operation:  auth.AuditIssue,
+				Subject:    generateID(t),
+				KeyType:    auth.AccessKey,
+				Success:    true,
+				ClientIP:   "127.0.0.1",
+				OccurredAt: time.Now().UTC(),
+			},
+			err: nil,
+		},
+		{
+			desc: "record failed identify",
+			event: auth.AuditEvent{
+				Operation:  auth.AuditIdentify,
+				Success:    false,
+				ErrorCode:  "authentication failed",
+				OccurredAt: time.Now().UTC(),
+			},
+			err: nil,
+		},
+		{
+			desc: "record revoke",
+			event: auth.AuditEvent{
+				Operation:  auth.AuditRevoke,
+				KeyID:      generateID(t),
+				Subject:    generateID(t),
+				Success:    true,
+				OccurredAt: time.Now().UTC(),
+			},
+			err: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Record(context.Background(), tc.event)
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestAuditRemoveBefore(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM audit_log")
+		require.Nil(t, err, fmt.Sprintf("clean audit log unexpected error: %s", err))
+	})
+	repo := postgres.NewAuditRepo(database)
+
+	now := time.Now().UTC()
+	events := []auth.AuditEvent{
+		{Operation: auth.AuditIssue, Success: true, OccurredAt: now.Add(-48 * time.Hour)},
+		{Operation: auth.AuditIdentify, Success: true, OccurredAt: now.Add(-25 * time.Hour)},
+		{Operation: auth.AuditRevoke, Success: true, OccurredAt: now},
+	}
+	err := repo.Record(context.Background(), events...)
+	require.Nil(t, err, fmt.Sprintf("record audit events unexpected error: %s", err))
+
+	removed, err := repo.RemoveBefore(context.Background(), now.Add(-24*time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("remove audit events unexpected error: %s", err))
+	assert.GreaterOrEqual(t, removed, int64(2), fmt.Sprintf("expected at least 2 removed audit events got %d", removed))
+
+	var count int
+	err = db.Get(&count, "SELECT COUNT(*) FROM audit_log WHERE occurred_at < $1", now.Add(-24*time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("count audit events unexpected error: %s", err))
+	assert.Equal(t, 0, count, "expected old audit events to be removed")
+}
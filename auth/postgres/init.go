@@ -143,6 +143,57 @@ func Migration() *migrate.MemoryMigrationSource {
 					`DROP INDEX IF EXISTS idx_pats_user_id;`,
 				},
 			},
+			{
+				Id: "auth_9",
+				Up: []string{
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS scopes TEXT[];`,
+				},
+				Down: []string{
+					`ALTER TABLE keys DROP COLUMN IF EXISTS scopes;`,
+				},
+			},
+			{
+				Id: "auth_10",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS audit_log (
+						id			BIGSERIAL PRIMARY KEY,
+						operation	VARCHAR(32) NOT NULL,
+						key_id		VARCHAR(254),
+						subject		VARCHAR(254),
+						key_type	SMALLINT,
+						success		BOOLEAN NOT NULL,
+						error_code	TEXT,
+						client_ip	VARCHAR(64),
+						occurred_at	TIMESTAMPTZ NOT NULL
+					);`,
+					`CREATE INDEX IF NOT EXISTS idx_audit_log_subject_occurred_at ON audit_log(subject, occurred_at);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS audit_log;`,
+				},
+			},
+			{
+				Id: "auth_11",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS spicedb_schema_versions (
+						hash		CHAR(64) PRIMARY KEY,
+						token		TEXT NOT NULL,
+						applied_at	TIMESTAMPTZ NOT NULL
+					);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS spicedb_schema_versions;`,
+				},
+			},
+			{
+				Id: "auth_12",
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log(occurred_at);`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_audit_log_occurred_at;`,
+				},
+			},
 		},
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/lib/pq"
 )
 
 var (
@@ -33,8 +34,8 @@ func New(db postgres.Database) auth.KeyRepository {
 }
 
 func (kr *repo) Save(ctx context.Context, key auth.Key) (string, error) {
-	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at)
-	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at)`
+	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at, scopes)
+	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at, :scopes)`
 
 	dbKey := toDBKey(key)
 	if _, err := kr.db.NamedExecContext(ctx, q, dbKey); err != nil {
@@ -45,9 +46,10 @@ func (kr *repo) Save(ctx context.Context, key auth.Key) (string, error) {
 }
 
 func (kr *repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, error) {
-	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at FROM keys WHERE issuer_id = $1 AND id = $2`
+	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at, scopes FROM keys WHERE issuer_id = $1 AND id = $2`
 	key := dbKey{}
-	if err := kr.db.QueryRowxContext(ctx, q, issuerID, id).StructScan(&key); err != nil {
+	// Revoked keys must stop authenticating at once, so the primary is used.
+	if err := kr.db.QueryRowxContext(postgres.WithPrimary(ctx), q, issuerID, id).StructScan(&key); err != nil {
 		if err == sql.ErrNoRows {
 			return auth.Key{}, repoerr.ErrNotFound
 		}
@@ -58,6 +60,43 @@ func (kr *repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, er
 	return toKey(key), nil
 }
 
+func (kr *repo) RetrieveAllByIssuer(ctx context.Context, issuerID string, pm auth.KeyPageMeta) (auth.KeyPage, error) {
+	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at, scopes, COUNT(*) OVER() AS total_count
+		FROM keys WHERE issuer_id = $1 ORDER BY issued_at LIMIT $2 OFFSET $3`
+
+	rows, err := kr.db.QueryxContext(ctx, q, issuerID, pm.Limit, pm.Offset)
+	if err != nil {
+		return auth.KeyPage{}, postgres.HandleError(errRetrieve, err)
+	}
+	defer rows.Close()
+
+	page := auth.KeyPage{
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		Keys:   []auth.Key{},
+	}
+	for rows.Next() {
+		key := dbKey{}
+		if err := rows.StructScan(&key); err != nil {
+			return auth.KeyPage{}, postgres.HandleError(errRetrieve, err)
+		}
+		page.Total = key.TotalCount
+		page.Keys = append(page.Keys, toKey(key))
+	}
+	if err := rows.Err(); err != nil {
+		return auth.KeyPage{}, postgres.HandleError(errRetrieve, err)
+	}
+
+	if len(page.Keys) == 0 {
+		cq := `SELECT COUNT(*) FROM keys WHERE issuer_id = $1`
+		if err := kr.db.QueryRowxContext(ctx, cq, issuerID).Scan(&page.Total); err != nil {
+			return auth.KeyPage{}, postgres.HandleError(errRetrieve, err)
+		}
+	}
+
+	return page, nil
+}
+
 func (kr *repo) Remove(ctx context.Context, issuerID, id string) error {
 	q := `DELETE FROM keys WHERE issuer_id = :issuer_id AND id = :id`
 	key := dbKey{
@@ -71,13 +110,29 @@ func (kr *repo) Remove(ctx context.Context, issuerID, id string) error {
 	return nil
 }
 
+func (kr *repo) RemoveExpired(ctx context.Context, before time.Time) (int64, error) {
+	q := `DELETE FROM keys WHERE expires_at IS NOT NULL AND expires_at < $1`
+	res, err := kr.db.ExecContext(ctx, q, before)
+	if err != nil {
+		return 0, errors.Wrap(errDelete, err)
+	}
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(errDelete, err)
+	}
+
+	return removed, nil
+}
+
 type dbKey struct {
-	ID        string       `db:"id"`
-	Type      uint32       `db:"type"`
-	Issuer    string       `db:"issuer_id"`
-	Subject   string       `db:"subject"`
-	IssuedAt  time.Time    `db:"issued_at"`
-	ExpiresAt sql.NullTime `db:"expires_at,omitempty"`
+	ID         string         `db:"id"`
+	Type       uint32         `db:"type"`
+	Issuer     string         `db:"issuer_id"`
+	Subject    string         `db:"subject"`
+	IssuedAt   time.Time      `db:"issued_at"`
+	ExpiresAt  sql.NullTime   `db:"expires_at,omitempty"`
+	Scopes     pq.StringArray `db:"scopes"`
+	TotalCount uint64         `db:"total_count,omitempty"`
 }
 
 func toDBKey(key auth.Key) dbKey {
@@ -87,6 +142,7 @@ func toDBKey(key auth.Key) dbKey {
 		Issuer:   key.Issuer,
 		Subject:  key.Subject,
 		IssuedAt: key.IssuedAt,
+		Scopes:   key.Scopes,
 	}
 	if !key.ExpiresAt.IsZero() {
 		ret.ExpiresAt = sql.NullTime{Time: key.ExpiresAt, Valid: true}
@@ -102,6 +158,7 @@ func toKey(key dbKey) auth.Key {
 		Issuer:   key.Issuer,
 		Subject:  key.Subject,
 		IssuedAt: key.IssuedAt,
+		Scopes:   key.Scopes,
 	}
 	if key.ExpiresAt.Valid {
 		ret.ExpiresAt = key.ExpiresAt.Time
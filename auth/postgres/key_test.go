@@ -207,6 +207,78 @@ func TestKeyRetrieve(t *testing.T) {
 	}
 }
 
+func TestKeyRetrieveAllByIssuer(t *testing.T) {
+	repo := postgres.New(database)
+
+	issuer := generateID(t)
+	otherIssuer := generateID(t)
+	num := 5
+
+	var keys []auth.Key
+	for i := range num {
+		for _, iss := range []string{issuer, otherIssuer} {
+			key := auth.Key{
+				ID:        generateID(t),
+				Type:      auth.APIKey,
+				Issuer:    iss,
+				Subject:   generateID(t),
+				IssuedAt:  time.Now().Add(time.Duration(i) * time.Second).UTC().Truncate(time.Microsecond),
+				ExpiresAt: expTime.UTC().Truncate(time.Microsecond),
+			}
+			_, err := repo.Save(context.Background(), key)
+			require.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+			if iss == issuer {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	cases := []struct {
+		desc   string
+		issuer string
+		pm     auth.KeyPageMeta
+		page   auth.KeyPage
+	}{
+		{
+			desc:   "retrieve all keys of issuer",
+			issuer: issuer,
+			pm:     auth.KeyPageMeta{Limit: 10},
+			page:   auth.KeyPage{Total: uint64(num), Limit: 10, Keys: keys},
+		},
+		{
+			desc:   "retrieve keys of issuer with offset and limit",
+			issuer: issuer,
+			pm:     auth.KeyPageMeta{Offset: 1, Limit: 2},
+			page:   auth.KeyPage{Total: uint64(num), Offset: 1, Limit: 2, Keys: keys[1:3]},
+		},
+		{
+			desc:   "retrieve keys of issuer with offset out of range",
+			issuer: issuer,
+			pm:     auth.KeyPageMeta{Offset: 10, Limit: 2},
+			page:   auth.KeyPage{Total: uint64(num), Offset: 10, Limit: 2, Keys: []auth.Key{}},
+		},
+		{
+			desc:   "retrieve keys of issuer without keys",
+			issuer: generateID(t),
+			pm:     auth.KeyPageMeta{Limit: 10},
+			page:   auth.KeyPage{Limit: 10, Keys: []auth.Key{}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAllByIssuer(context.Background(), tc.issuer, tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.page.Total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.page.Total, page.Total))
+			assert.Equal(t, len(tc.page.Keys), len(page.Keys), fmt.Sprintf("%s: expected %d keys got %d", tc.desc, len(tc.page.Keys), len(page.Keys)))
+			for i, key := range page.Keys {
+				assert.Equal(t, tc.issuer, key.Issuer, fmt.Sprintf("%s: expected issuer %s got %s", tc.desc, tc.issuer, key.Issuer))
+				assert.Equal(t, tc.page.Keys[i].ID, key.ID, fmt.Sprintf("%s: expected key %s got %s", tc.desc, tc.page.Keys[i].ID, key.ID))
+			}
+		})
+	}
+}
+
 func TestKeyRemove(t *testing.T) {
 	repo := postgres.New(database)
 
@@ -269,3 +341,47 @@ func TestKeyRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestKeyRemoveExpired(t *testing.T) {
+	repo := postgres.New(database)
+
+	now := time.Now().UTC()
+	expired := auth.Key{
+		ID:        generateID(t),
+		Type:      auth.APIKey,
+		Issuer:    generateID(t),
+		Subject:   generateID(t),
+		IssuedAt:  now.Add(-2 * time.Hour),
+		ExpiresAt: now.Add(-time.Hour),
+	}
+	valid := auth.Key{
+		ID:        generateID(t),
+		Type:      auth.APIKey,
+		Issuer:    generateID(t),
+		Subject:   generateID(t),
+		IssuedAt:  now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	unlimited := auth.Key{
+		ID:       generateID(t),
+		Type:     auth.APIKey,
+		Issuer:   generateID(t),
+		Subject:  generateID(t),
+		IssuedAt: now,
+	}
+	for _, key := range []auth.Key{expired, valid, unlimited} {
+		_, err := repo.Save(context.Background(), key)
+		require.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+	}
+
+	removed, err := repo.RemoveExpired(context.Background(), now)
+	assert.Nil(t, err, fmt.Sprintf("removing expired keys: unexpected error %s", err))
+	assert.GreaterOrEqual(t, removed, int64(1), fmt.Sprintf("expected at least 1 removed key got %d", removed))
+
+	_, err = repo.Retrieve(context.Background(), expired.Issuer, expired.ID)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieving expired key: expected %s got %s", repoerr.ErrNotFound, err))
+	for _, key := range []auth.Key{valid, unlimited} {
+		_, err = repo.Retrieve(context.Background(), key.Issuer, key.ID)
+		assert.Nil(t, err, fmt.Sprintf("retrieving non-expired key: unexpected error %s", err))
+	}
+}
@@ -168,7 +168,8 @@ func (pr *patRepo) RetrieveSecretAndRevokeStatus(ctx context.Context, userID, pa
 		Timestamp: time.Now().UTC(),
 	}
 
-	rows, err := pr.db.NamedQueryContext(ctx, q, dbPage)
+	// Like API keys, revoked PATs must stop authenticating at once.
+	rows, err := pr.db.NamedQueryContext(postgres.WithPrimary(ctx), q, dbPage)
 	if err != nil {
 		return "", true, true, postgres.HandleError(repoerr.ErrNotFound, err)
 	}
@@ -539,7 +540,7 @@ func (pr *patRepo) CheckScope(ctx context.Context, userID, patID string, entityT
 		EntityID:   entityID,
 	}
 
-	rows, err := pr.db.NamedQueryContext(ctx, q, scope)
+	rows, err := pr.db.NamedQueryContext(postgres.WithPrimary(ctx), q, scope)
 	if err != nil {
 		return errors.Wrap(repoerr.ErrViewEntity, err)
 	}
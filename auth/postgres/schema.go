@@ -0,0 +1,88 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/policies/spicedb"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+var (
+	errSaveSchemaVersion     = errors.New("failed to save schema version in database")
+	errRetrieveSchemaVersion = errors.New("failed to retrieve schema version from database")
+)
+
+var _ spicedb.SchemaVersionRepository = (*schemaVersionRepo)(nil)
+
+type schemaVersionRepo struct {
+	db postgres.Database
+}
+
+// NewSchemaVersionRepo instantiates a PostgreSQL implementation of SpiceDB
+// schema version repository.
+func NewSchemaVersionRepo(db postgres.Database) spicedb.SchemaVersionRepository {
+	return &schemaVersionRepo{
+		db: db,
+	}
+}
+
+func (sr *schemaVersionRepo) Save(ctx context.Context, version spicedb.SchemaVersion) error {
+	q := `INSERT INTO spicedb_schema_versions (hash, token, applied_at) VALUES (:hash, :token, :applied_at)
+		ON CONFLICT (hash) DO UPDATE SET token = EXCLUDED.token, applied_at = EXCLUDED.applied_at`
+
+	if _, err := sr.db.NamedExecContext(ctx, q, toDBSchemaVersion(version)); err != nil {
+		return postgres.HandleError(errSaveSchemaVersion, err)
+	}
+
+	return nil
+}
+
+func (sr *schemaVersionRepo) Retrieve(ctx context.Context, hash string) (spicedb.SchemaVersion, error) {
+	q := `SELECT hash, token, applied_at FROM spicedb_schema_versions WHERE hash = $1`
+
+	return sr.retrieve(ctx, q, hash)
+}
+
+func (sr *schemaVersionRepo) RetrieveLatest(ctx context.Context) (spicedb.SchemaVersion, error) {
+	q := `SELECT hash, token, applied_at FROM spicedb_schema_versions ORDER BY applied_at DESC LIMIT 1`
+
+	return sr.retrieve(ctx, q)
+}
+
+func (sr *schemaVersionRepo) retrieve(ctx context.Context, q string, args ...any) (spicedb.SchemaVersion, error) {
+	var version dbSchemaVersion
+	if err := sr.db.QueryRowxContext(postgres.WithPrimary(ctx), q, args...).StructScan(&version); err != nil {
+		if err == sql.ErrNoRows {
+			return spicedb.SchemaVersion{}, repoerr.ErrNotFound
+		}
+
+		return spicedb.SchemaVersion{}, postgres.HandleError(errRetrieveSchemaVersion, err)
+	}
+
+	return spicedb.SchemaVersion{
+		Hash:      version.Hash,
+		Token:     version.Token,
+		AppliedAt: version.AppliedAt,
+	}, nil
+}
+
+type dbSchemaVersion struct {
+	Hash      string    `db:"hash"`
+	Token     string    `db:"token"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+func toDBSchemaVersion(version spicedb.SchemaVersion) dbSchemaVersion {
+	return dbSchemaVersion{
+		Hash:      version.Hash,
+		Token:     version.Token,
+		AppliedAt: version.AppliedAt,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/pkg/authz"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
@@ -23,6 +24,7 @@ const (
 	randStr            = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890!@#$%^&&*|+-="
 	patPrefix          = "pat"
 	patSecretSeparator = "_"
+	revokeAllPageSize  = 100
 )
 
 var (
@@ -60,6 +62,14 @@ type Authz interface {
 	// no relation on the object (which simply means the operation is
 	// denied).
 	Authorize(ctx context.Context, pr policies.Policy, patAuthz *PATAuthz) error
+
+	// StreamObjects calls fn with consecutive pages of the objects on which
+	// the subject has the given permission.
+	StreamObjects(ctx context.Context, pr policies.Policy, fn func(objects []string) error) error
+
+	// StreamSubjects calls fn with consecutive pages of the subjects which
+	// have the given permission on the object.
+	StreamSubjects(ctx context.Context, pr policies.Policy, fn func(subjects []string) error) error
 }
 
 // Authn specifies an API that must be fulfilled by the domain service
@@ -74,8 +84,14 @@ type Authn interface {
 	RevokeToken(ctx context.Context, userID, tokenID string) error
 
 	// Revoke removes the Key with the provided id that is
-	// issued by the user identified by the provided key.
-	Revoke(ctx context.Context, token, id string) error
+	// issued by the user identified by the provided key, and returns the
+	// removed Key. Revoking a Key which no longer exists succeeds, and
+	// returns only its ID.
+	Revoke(ctx context.Context, token, id string) (Key, error)
+
+	// RevokeAll removes all non-access Keys issued by the user identified
+	// by the provided key and returns the number of removed Keys.
+	RevokeAll(ctx context.Context, token string) (uint64, error)
 
 	// RetrieveKey retrieves data for the Key identified by the provided
 	// ID, that is issued by the user identified by the provided key.
@@ -110,6 +126,7 @@ type service struct {
 	pats               PATSRepository
 	cache              Cache
 	tokensCache        UserActiveTokensCache
+	identityCache      IdentityCache
 	hasher             Hasher
 	idProvider         supermq.IDProvider
 	evaluator          policies.Evaluator
@@ -118,16 +135,23 @@ type service struct {
 	loginDuration      time.Duration
 	refreshDuration    time.Duration
 	invitationDuration time.Duration
+	clock              supermq.Clock
 }
 
-// New instantiates the auth service implementation.
-func New(keys KeyRepository, pats PATSRepository, cache Cache, tokensCache UserActiveTokensCache, hasher Hasher, idp supermq.IDProvider, tokenizer Tokenizer, policyEvaluator policies.Evaluator, policyService policies.Service, loginDuration, refreshDuration, invitationDuration time.Duration) Service {
+// New instantiates the auth service implementation. Identity cache is
+// optional, if nil, every token is parsed and verified on Identify. If clock
+// is nil, the system time is used.
+func New(keys KeyRepository, pats PATSRepository, cache Cache, tokensCache UserActiveTokensCache, identityCache IdentityCache, hasher Hasher, idp supermq.IDProvider, tokenizer Tokenizer, policyEvaluator policies.Evaluator, policyService policies.Service, loginDuration, refreshDuration, invitationDuration time.Duration, clock supermq.Clock) Service {
+	if clock == nil {
+		clock = supermq.NewClock()
+	}
 	return &service{
 		tokenizer:          tokenizer,
 		keys:               keys,
 		pats:               pats,
 		cache:              cache,
 		tokensCache:        tokensCache,
+		identityCache:      identityCache,
 		hasher:             hasher,
 		idProvider:         idp,
 		evaluator:          policyEvaluator,
@@ -135,11 +159,12 @@ func New(keys KeyRepository, pats PATSRepository, cache Cache, tokensCache UserA
 		loginDuration:      loginDuration,
 		refreshDuration:    refreshDuration,
 		invitationDuration: invitationDuration,
+		clock:              clock,
 	}
 }
 
 func (svc service) Issue(ctx context.Context, token string, key Key) (Token, error) {
-	key.IssuedAt = time.Now().UTC()
+	key.IssuedAt = svc.clock.Now().UTC()
 	switch key.Type {
 	case APIKey:
 		return svc.userKey(ctx, token, key)
@@ -158,19 +183,70 @@ func (svc service) RevokeToken(ctx context.Context, userID, tokenID string) erro
 	if err := svc.tokensCache.RemoveActive(ctx, userID, tokenID); err != nil {
 		return errors.Wrap(errRevokeRefreshKey, err)
 	}
+	if svc.identityCache != nil {
+		svc.identityCache.Remove(tokenID)
+	}
 
 	return nil
 }
 
-func (svc service) Revoke(ctx context.Context, token, id string) error {
+func (svc service) Revoke(ctx context.Context, token, id string) (Key, error) {
 	issuerID, _, err := svc.authenticate(ctx, token)
 	if err != nil {
-		return errors.Wrap(errRevoke, err)
+		return Key{}, errors.Wrap(errRevoke, err)
+	}
+	key, err := svc.keys.Retrieve(ctx, issuerID, id)
+	switch {
+	case errors.Contains(err, repoerr.ErrNotFound):
+		key = Key{ID: id}
+	case err != nil:
+		return Key{}, errors.Wrap(errRevoke, err)
 	}
 	if err := svc.keys.Remove(ctx, issuerID, id); err != nil {
-		return errors.Wrap(errRevoke, err)
+		return Key{}, errors.Wrap(errRevoke, err)
 	}
-	return nil
+	if svc.identityCache != nil {
+		svc.identityCache.Remove(id)
+	}
+	return key, nil
+}
+
+func (svc service) RevokeAll(ctx context.Context, token string) (uint64, error) {
+	issuerID, _, err := svc.authenticate(ctx, token)
+	if err != nil {
+		return 0, errors.Wrap(errRevoke, err)
+	}
+
+	var ids []string
+	pm := KeyPageMeta{Limit: revokeAllPageSize}
+	for {
+		page, err := svc.keys.RetrieveAllByIssuer(ctx, issuerID, pm)
+		if err != nil {
+			return 0, errors.Wrap(errRevoke, err)
+		}
+		for _, key := range page.Keys {
+			if key.Type != AccessKey {
+				ids = append(ids, key.ID)
+			}
+		}
+		pm.Offset += uint64(len(page.Keys))
+		if len(page.Keys) == 0 || pm.Offset >= page.Total {
+			break
+		}
+	}
+
+	var revoked uint64
+	for _, id := range ids {
+		if err := svc.keys.Remove(ctx, issuerID, id); err != nil {
+			return revoked, errors.Wrap(errRevoke, err)
+		}
+		if svc.identityCache != nil {
+			svc.identityCache.Remove(id)
+		}
+		revoked++
+	}
+
+	return revoked, nil
 }
 
 func (svc service) RetrieveKey(ctx context.Context, token, id string) (Key, error) {
@@ -187,6 +263,12 @@ func (svc service) RetrieveKey(ctx context.Context, token, id string) (Key, erro
 }
 
 func (svc service) Identify(ctx context.Context, token string) (Key, error) {
+	if svc.identityCache != nil {
+		if key, ok := svc.identityCache.Get(token); ok {
+			return key, nil
+		}
+	}
+
 	key, err := svc.tokenizer.Parse(ctx, token)
 	if errors.Contains(err, ErrExpiry) {
 		err = svc.keys.Remove(ctx, key.Issuer, key.ID)
@@ -204,18 +286,26 @@ func (svc service) Identify(ctx context.Context, token string) (Key, error) {
 		}
 		return Key{ID: res.ID, Type: PersonalAccessToken, Subject: res.User, Role: res.Role}, nil
 	case RecoveryKey, AccessKey, InvitationKey, RefreshKey:
+		svc.cacheIdentity(token, key)
 		return key, nil
 	case APIKey:
 		_, err := svc.keys.Retrieve(ctx, key.Issuer, key.ID)
 		if err != nil {
 			return Key{}, svcerr.ErrAuthentication
 		}
+		svc.cacheIdentity(token, key)
 		return key, nil
 	default:
 		return Key{}, svcerr.ErrAuthentication
 	}
 }
 
+func (svc service) cacheIdentity(token string, key Key) {
+	if svc.identityCache != nil {
+		svc.identityCache.Save(token, key)
+	}
+}
+
 func (svc service) RetrieveJWKS() []PublicKeyInfo {
 	keys, err := svc.tokenizer.RetrieveJWKS()
 	if err != nil {
@@ -250,6 +340,22 @@ func (svc service) Authorize(ctx context.Context, pr policies.Policy, patAuthz *
 	return nil
 }
 
+func (svc service) StreamObjects(ctx context.Context, pr policies.Policy, fn func(objects []string) error) error {
+	if err := svc.PolicyValidation(pr); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	return svc.policysvc.StreamObjects(ctx, pr, fn)
+}
+
+func (svc service) StreamSubjects(ctx context.Context, pr policies.Policy, fn func(subjects []string) error) error {
+	if err := svc.PolicyValidation(pr); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	return svc.policysvc.StreamSubjects(ctx, pr, fn)
+}
+
 func (svc service) checkPolicy(ctx context.Context, pr policies.Policy) error {
 	if err := svc.evaluator.CheckPolicy(ctx, pr); err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
@@ -265,7 +371,7 @@ func (svc service) PolicyValidation(pr policies.Policy) error {
 }
 
 func (svc service) tmpKey(ctx context.Context, duration time.Duration, key Key) (Token, error) {
-	key.ExpiresAt = time.Now().UTC().Add(duration)
+	key.ExpiresAt = svc.clock.Now().UTC().Add(duration)
 	if err := svc.checkUserRole(ctx, key); err != nil {
 		return Token{}, errors.Wrap(errIssueTmp, err)
 	}
@@ -280,7 +386,7 @@ func (svc service) tmpKey(ctx context.Context, duration time.Duration, key Key)
 func (svc service) accessKey(ctx context.Context, key Key) (Token, error) {
 	var err error
 	key.Type = AccessKey
-	key.ExpiresAt = time.Now().UTC().Add(svc.loginDuration)
+	key.ExpiresAt = svc.clock.Now().UTC().Add(svc.loginDuration)
 
 	if err := svc.checkUserRole(ctx, key); err != nil {
 		return Token{}, errors.Wrap(errIssueUser, err)
@@ -291,7 +397,7 @@ func (svc service) accessKey(ctx context.Context, key Key) (Token, error) {
 		return Token{}, errors.Wrap(errIssueTmp, err)
 	}
 
-	key.ExpiresAt = time.Now().UTC().Add(svc.refreshDuration)
+	key.ExpiresAt = svc.clock.Now().UTC().Add(svc.refreshDuration)
 	key.Type = RefreshKey
 	id, err := svc.idProvider.ID()
 	if err != nil {
@@ -302,7 +408,7 @@ func (svc service) accessKey(ctx context.Context, key Key) (Token, error) {
 	if err != nil {
 		return Token{}, errors.Wrap(errIssueTmp, err)
 	}
-	if key.Subject != "" && key.ExpiresAt.After(time.Now()) {
+	if key.Subject != "" && key.ExpiresAt.After(svc.clock.Now()) {
 		if err := svc.tokensCache.SaveActive(ctx, key.Subject, key.ID, key.Description, key.ExpiresAt); err != nil {
 			return Token{}, errors.Wrap(errSaveRefreshKey, err)
 		}
@@ -314,7 +420,7 @@ func (svc service) accessKey(ctx context.Context, key Key) (Token, error) {
 func (svc service) invitationKey(ctx context.Context, key Key) (Token, error) {
 	var err error
 	key.Type = InvitationKey
-	key.ExpiresAt = time.Now().UTC().Add(svc.invitationDuration)
+	key.ExpiresAt = svc.clock.Now().UTC().Add(svc.invitationDuration)
 
 	if err := svc.checkUserRole(ctx, key); err != nil {
 		return Token{}, errors.Wrap(errIssueTmp, err)
@@ -352,7 +458,7 @@ func (svc service) refreshKey(ctx context.Context, token string, key Key) (Token
 	}
 	key.Role = k.Role
 
-	key.ExpiresAt = time.Now().UTC().Add(svc.loginDuration)
+	key.ExpiresAt = svc.clock.Now().UTC().Add(svc.loginDuration)
 	access, err := svc.tokenizer.Issue(key)
 	if err != nil {
 		return Token{}, errors.Wrap(errIssueTmp, err)
@@ -422,6 +528,9 @@ func (svc service) userKey(ctx context.Context, token string, key Key) (Token, e
 	if key.Subject == "" {
 		key.Subject = sub
 	}
+	if err := authz.ValidateScopes(key.Scopes); err != nil {
+		return Token{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
 	if err := svc.checkUserRole(ctx, key); err != nil {
 		return Token{}, errors.Wrap(errIssueUser, err)
 	}
@@ -515,7 +624,7 @@ func (svc service) CreatePAT(ctx context.Context, token, name, description strin
 		return PAT{}, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
 
-	now := time.Now().UTC()
+	now := svc.clock.Now().UTC()
 	pat := PAT{
 		ID:          id,
 		User:        key.Subject,
@@ -611,7 +720,7 @@ func (svc service) ResetPATSecret(ctx context.Context, token, patID string, dura
 		return PAT{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
 
-	pat, err := svc.pats.UpdateTokenHash(ctx, key.Subject, patID, hash, time.Now().UTC().Add(duration))
+	pat, err := svc.pats.UpdateTokenHash(ctx, key.Subject, patID, hash, svc.clock.Now().UTC().Add(duration))
 	if err != nil {
 		return PAT{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
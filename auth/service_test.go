@@ -78,7 +78,59 @@ func newService(t *testing.T) (auth.Service, string) {
 	token, _, err := signToken(t, issuerName, accessKey, false)
 	assert.Nil(t, err, fmt.Sprintf("Issuing access key expected to succeed: %s", err))
 
-	return auth.New(krepo, patsrepo, cache, tokensCache, hasher, idProvider, tokenizer, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration), token
+	return auth.New(krepo, patsrepo, cache, tokensCache, nil, hasher, idProvider, tokenizer, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration, nil), token
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestIssueWithClock(t *testing.T) {
+	newService(t)
+	clock := &testClock{now: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	svc := auth.New(krepo, patsrepo, cache, tokensCache, nil, hasher, uuid.NewMock(), tokenizer, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration, clock)
+
+	cases := []struct {
+		desc    string
+		advance time.Duration
+	}{
+		{
+			desc: "issue access key at the clock time",
+		},
+		{
+			desc:    "issue access key after advancing the clock",
+			advance: 2 * loginDuration,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			clock.advance(tc.advance)
+			var issued auth.Key
+			tokenizerCall := tokenizer.On("Issue", mock.Anything).Run(func(args mock.Arguments) {
+				if key := args.Get(0).(auth.Key); key.Type == auth.AccessKey {
+					issued = key
+				}
+			}).Return("token", nil)
+			cacheCall := tokensCache.On("SaveActive", mock.Anything, userID, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			policyCall := pEvaluator.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
+			_, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, Subject: userID, Role: auth.UserRole})
+			assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s\n", tc.desc, err))
+			assert.Equal(t, clock.Now(), issued.IssuedAt, fmt.Sprintf("%s: expected issued at %s got %s\n", tc.desc, clock.Now(), issued.IssuedAt))
+			assert.Equal(t, clock.Now().Add(loginDuration), issued.ExpiresAt, fmt.Sprintf("%s: expected expires at %s got %s\n", tc.desc, clock.Now().Add(loginDuration), issued.ExpiresAt))
+			tokenizerCall.Unset()
+			cacheCall.Unset()
+			policyCall.Unset()
+		})
+	}
 }
 
 func TestIssue(t *testing.T) {
@@ -230,6 +282,32 @@ func TestIssue(t *testing.T) {
 			parseRes: accesskey,
 			err:      nil,
 		},
+		{
+			desc: "issue scoped API key",
+			key: auth.Key{
+				Type:     auth.APIKey,
+				Subject:  userID,
+				Role:     auth.UserRole,
+				IssuedAt: time.Now(),
+				Scopes:   []string{"clients:read"},
+			},
+			token:    accessToken,
+			parseRes: accesskey,
+			err:      nil,
+		},
+		{
+			desc: "issue API key with invalid scope",
+			key: auth.Key{
+				Type:     auth.APIKey,
+				Subject:  userID,
+				Role:     auth.UserRole,
+				IssuedAt: time.Now(),
+				Scopes:   []string{"things:read"},
+			},
+			token:    accessToken,
+			parseRes: accesskey,
+			err:      svcerr.ErrMalformedEntity,
+		},
 		{
 			desc: "issue API key with an invalid token",
 			key: auth.Key{
@@ -438,25 +516,42 @@ func TestRevoke(t *testing.T) {
 	apiToken, _, err := signToken(t, issuerName, apikey, false)
 	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
 
+	revokedKey := auth.Key{
+		ID:      validID,
+		Type:    auth.APIKey,
+		Issuer:  userID,
+		Subject: userID,
+	}
+
 	cases := []struct {
-		desc     string
-		id       string
-		token    string
-		parseRes auth.Key
-		parseErr error
-		err      error
+		desc        string
+		id          string
+		token       string
+		parseRes    auth.Key
+		parseErr    error
+		retrieveRes auth.Key
+		retrieveErr error
+		removeErr   error
+		key         auth.Key
+		err         error
 	}{
 		{
-			desc:     "revoke login key",
-			token:    apiToken,
-			parseRes: accesskey,
-			err:      nil,
+			desc:        "revoke login key",
+			id:          validID,
+			token:       apiToken,
+			parseRes:    accesskey,
+			retrieveRes: revokedKey,
+			key:         revokedKey,
+			err:         nil,
 		},
 		{
-			desc:     "revoke non-existing login key",
-			token:    apiToken,
-			parseRes: accesskey,
-			err:      nil,
+			desc:        "revoke non-existing login key",
+			id:          validID,
+			token:       apiToken,
+			parseRes:    accesskey,
+			retrieveErr: repoerr.ErrNotFound,
+			key:         auth.Key{ID: validID},
+			err:         nil,
 		},
 		{
 			desc:     "revoke with empty login key",
@@ -466,26 +561,146 @@ func TestRevoke(t *testing.T) {
 			err:      svcerr.ErrAuthentication,
 		},
 		{
-			desc:     "revoke login key with failed to remove",
-			id:       "invalidID",
-			token:    apiToken,
-			parseRes: accesskey,
-			err:      svcerr.ErrNotFound,
+			desc:        "revoke login key with failed to retrieve",
+			id:          validID,
+			token:       apiToken,
+			parseRes:    accesskey,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         repoerr.ErrViewEntity,
+		},
+		{
+			desc:        "revoke login key with failed to remove",
+			id:          "invalidID",
+			token:       apiToken,
+			parseRes:    accesskey,
+			retrieveRes: revokedKey,
+			removeErr:   svcerr.ErrNotFound,
+			err:         svcerr.ErrNotFound,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			tokenizerCall := tokenizer.On("Parse", mock.Anything, tc.token).Return(tc.parseRes, tc.parseErr)
-			repoCall := krepo.On("Remove", mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
-			err := svc.Revoke(context.Background(), tc.token, tc.id)
+			retrieveCall := krepo.On("Retrieve", mock.Anything, mock.Anything, tc.id).Return(tc.retrieveRes, tc.retrieveErr)
+			repoCall := krepo.On("Remove", mock.Anything, mock.Anything, mock.Anything).Return(tc.removeErr)
+			key, err := svc.Revoke(context.Background(), tc.token, tc.id)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.key, key, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.key, key))
 			tokenizerCall.Unset()
+			retrieveCall.Unset()
 			repoCall.Unset()
 		})
 	}
 }
 
+func TestRevokeTwice(t *testing.T) {
+	svc, accessToken := newService(t)
+
+	key := auth.Key{
+		ID:      validID,
+		Type:    auth.APIKey,
+		Issuer:  userID,
+		Subject: userID,
+	}
+
+	tokenizerCall := tokenizer.On("Parse", mock.Anything, accessToken).Return(accessKey, nil)
+	krepo.On("Retrieve", mock.Anything, mock.Anything, validID).Return(key, nil).Once()
+	krepo.On("Retrieve", mock.Anything, mock.Anything, validID).Return(auth.Key{}, repoerr.ErrNotFound).Once()
+	repoCall := krepo.On("Remove", mock.Anything, mock.Anything, validID).Return(nil)
+	defer func() {
+		tokenizerCall.Unset()
+		repoCall.Unset()
+	}()
+
+	revoked, err := svc.Revoke(context.Background(), accessToken, validID)
+	assert.Nil(t, err, fmt.Sprintf("revoking key: expected nil got %s", err))
+	assert.Equal(t, key, revoked, fmt.Sprintf("revoking key: expected %v got %v", key, revoked))
+
+	revoked, err = svc.Revoke(context.Background(), accessToken, validID)
+	assert.Nil(t, err, fmt.Sprintf("revoking already revoked key: expected nil got %s", err))
+	assert.Equal(t, auth.Key{ID: validID}, revoked, fmt.Sprintf("revoking already revoked key: expected %v got %v", auth.Key{ID: validID}, revoked))
+}
+
+func TestRevokeAll(t *testing.T) {
+	svc, accessToken := newService(t)
+
+	accessKey := auth.Key{
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(refreshDuration),
+		Subject:   userID,
+		Type:      auth.AccessKey,
+		Role:      auth.UserRole,
+		Issuer:    issuerName,
+	}
+	keys := []auth.Key{
+		{ID: "key-1", Type: auth.APIKey, Issuer: issuerName, Subject: userID},
+		{ID: "key-2", Type: auth.APIKey, Issuer: issuerName, Subject: userID},
+		{ID: "key-3", Type: auth.RecoveryKey, Issuer: issuerName, Subject: userID},
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		parseRes    auth.Key
+		parseErr    error
+		retrieveRes auth.KeyPage
+		retrieveErr error
+		removeErr   error
+		revoked     uint64
+		err         error
+	}{
+		{
+			desc:        "revoke all keys",
+			token:       accessToken,
+			parseRes:    accessKey,
+			retrieveRes: auth.KeyPage{Total: uint64(len(keys)), Keys: keys},
+			revoked:     uint64(len(keys)),
+		},
+		{
+			desc:        "revoke all keys without issued keys",
+			token:       accessToken,
+			parseRes:    accessKey,
+			retrieveRes: auth.KeyPage{Keys: []auth.Key{}},
+		},
+		{
+			desc:     "revoke all keys with invalid token",
+			token:    inValidToken,
+			parseErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "revoke all keys with failed to retrieve",
+			token:       accessToken,
+			parseRes:    accessKey,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         repoerr.ErrViewEntity,
+		},
+		{
+			desc:        "revoke all keys with failed to remove",
+			token:       accessToken,
+			parseRes:    accessKey,
+			retrieveRes: auth.KeyPage{Total: uint64(len(keys)), Keys: keys},
+			removeErr:   repoerr.ErrRemoveEntity,
+			err:         repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tokenizerCall := tokenizer.On("Parse", mock.Anything, tc.token).Return(tc.parseRes, tc.parseErr)
+			retrieveCall := krepo.On("RetrieveAllByIssuer", mock.Anything, issuerName, mock.Anything).Return(tc.retrieveRes, tc.retrieveErr)
+			removeCall := krepo.On("Remove", mock.Anything, issuerName, mock.Anything).Return(tc.removeErr)
+			revoked, err := svc.RevokeAll(context.Background(), tc.token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.revoked, revoked, fmt.Sprintf("%s expected %d revoked keys got %d\n", tc.desc, tc.revoked, revoked))
+			tokenizerCall.Unset()
+			retrieveCall.Unset()
+			removeCall.Unset()
+		})
+	}
+}
+
 func TestRetrieve(t *testing.T) {
 	svc, accessToken := newService(t)
 
@@ -1092,6 +1307,73 @@ func TestAuthorize(t *testing.T) {
 	}
 }
 
+func TestStreamObjects(t *testing.T) {
+	svc, _ := newService(t)
+
+	cases := []struct {
+		desc      string
+		policyReq policies.Policy
+		pages     [][]string
+		svcErr    error
+		err       error
+	}{
+		{
+			desc: "stream objects successfully",
+			policyReq: policies.Policy{
+				SubjectType: policies.UserType,
+				Subject:     userID,
+				ObjectType:  policies.ClientType,
+				Permission:  policies.ViewPermission,
+			},
+			pages: [][]string{{validID}, {testsutil.GenerateUUID(t)}},
+			err:   nil,
+		},
+		{
+			desc: "stream objects with invalid platform object",
+			policyReq: policies.Policy{
+				SubjectType: policies.UserType,
+				Subject:     userID,
+				Object:      validID,
+				ObjectType:  policies.PlatformType,
+				Permission:  policies.AdminPermission,
+			},
+			err: svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "stream objects with failed policy service",
+			policyReq: policies.Policy{
+				SubjectType: policies.UserType,
+				Subject:     userID,
+				ObjectType:  policies.ClientType,
+				Permission:  policies.ViewPermission,
+			},
+			svcErr: svcerr.ErrViewEntity,
+			err:    svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			policyCall := pService.On("StreamObjects", mock.Anything, tc.policyReq, mock.Anything).Return(tc.svcErr).Run(func(args mock.Arguments) {
+				fn := args.Get(2).(func([]string) error)
+				for _, page := range tc.pages {
+					_ = fn(page)
+				}
+			})
+			var got [][]string
+			err := svc.StreamObjects(context.Background(), tc.policyReq, func(objects []string) error {
+				got = append(got, objects)
+				return nil
+			})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.pages, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.pages, got))
+			}
+			policyCall.Unset()
+		})
+	}
+}
+
 func TestSwitchToPermission(t *testing.T) {
 	cases := []struct {
 		desc     string
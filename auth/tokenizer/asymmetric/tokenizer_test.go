@@ -388,6 +388,7 @@ func TestSignAndVerifyRoundTrip(t *testing.T) {
 		IssuedAt:  time.Now().UTC().Truncate(time.Second),
 		ExpiresAt: time.Now().Add(1 * time.Hour).UTC().Truncate(time.Second),
 		Verified:  true,
+		Scopes:    []string{"clients:read", "channels:write"},
 	}
 
 	token, err := km.Issue(originalKey)
@@ -401,6 +402,7 @@ func TestSignAndVerifyRoundTrip(t *testing.T) {
 	assert.Equal(t, originalKey.Subject, verifiedKey.Subject)
 	assert.Equal(t, originalKey.Role, verifiedKey.Role)
 	assert.Equal(t, originalKey.Verified, verifiedKey.Verified)
+	assert.Equal(t, originalKey.Scopes, verifiedKey.Scopes)
 	assert.WithinDuration(t, originalKey.IssuedAt, verifiedKey.IssuedAt, time.Second)
 	assert.WithinDuration(t, originalKey.ExpiresAt, verifiedKey.ExpiresAt, time.Second)
 }
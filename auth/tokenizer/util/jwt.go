@@ -31,6 +31,7 @@ const (
 	TokenType     = "type"
 	RoleField     = "role"
 	VerifiedField = "verified"
+	ScopesField   = "scopes"
 	PatPrefix     = "pat"
 )
 
@@ -106,6 +107,9 @@ func BuildToken(key auth.Key) (jwt.Token, error) {
 	if key.Subject != "" {
 		builder.Subject(key.Subject)
 	}
+	if len(key.Scopes) > 0 {
+		builder.Claim(ScopesField, key.Scopes)
+	}
 	if key.ID != "" {
 		builder.JwtID(key.ID)
 	}
@@ -302,6 +302,20 @@ func decodeDisconnectChannelClientsRequest(_ context.Context, r *http.Request) (
 	return req, nil
 }
 
+func decodeDisconnectAllRequest(_ context.Context, r *http.Request) (any, error) {
+	req := disconnectAllRequest{
+		channelID: chi.URLParam(r, "channelID"),
+	}
+	return req, nil
+}
+
+func decodeListConnectionsRequest(_ context.Context, r *http.Request) (any, error) {
+	req := listConnectionsReq{
+		channelID: chi.URLParam(r, "channelID"),
+	}
+	return req, nil
+}
+
 func decodeConnectRequest(_ context.Context, r *http.Request) (any, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -1829,7 +1829,7 @@ func TestConnectChannelClientEndpoint(t *testing.T) {
 				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
 			}
 			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
-			svcCall := svc.On("Connect", mock.Anything, tc.session, []string{tc.id}, []string{validID}, []connections.ConnType{1}).Return(tc.svcErr)
+			svcCall := svc.On("Connect", mock.Anything, tc.session, []string{tc.id}, []string{validID}, []connections.ConnType{1}, channels.Metadata(nil)).Return(tc.svcErr)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -1952,6 +1952,7 @@ func TestConnectEndpoint(t *testing.T) {
 		domainID   string
 		clientIDs  []string
 		types      []connections.ConnType
+		metadata   channels.Metadata
 		session    smqauthn.Session
 		svcErr     error
 		status     int
@@ -1969,6 +1970,18 @@ func TestConnectEndpoint(t *testing.T) {
 			status:     http.StatusCreated,
 			err:        nil,
 		},
+		{
+			desc:       "connect successfully with metadata",
+			token:      validToken,
+			domainID:   validID,
+			channelIDs: []string{validID},
+			clientIDs:  []string{validID},
+			types:      []connections.ConnType{1},
+			metadata:   channels.Metadata{"alias": "sensor"},
+			svcErr:     nil,
+			status:     http.StatusCreated,
+			err:        nil,
+		},
 		{
 			desc:       "connect with invalid token",
 			token:      invalidToken,
@@ -2055,13 +2068,14 @@ func TestConnectEndpoint(t *testing.T) {
 					"channel_ids": tc.channelIDs,
 					"client_ids":  tc.clientIDs,
 					"types":       tc.types,
+					"metadata":    tc.metadata,
 				})),
 			}
 			if tc.token == validToken {
 				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
 			}
 			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
-			svcCall := svc.On("Connect", mock.Anything, tc.session, tc.channelIDs, tc.clientIDs, tc.types).Return(tc.svcErr)
+			svcCall := svc.On("Connect", mock.Anything, tc.session, tc.channelIDs, tc.clientIDs, tc.types, tc.metadata).Return(tc.svcErr)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -2284,6 +2298,192 @@ func TestDeleteChannelEndpoint(t *testing.T) {
 	}
 }
 
+func TestDisconnectAllEndpoint(t *testing.T) {
+	gs, svc, authn := newChannelsServer()
+	defer gs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		domainID string
+		session  smqauthn.Session
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:     "disconnect all clients from channel successfully",
+			token:    validToken,
+			domainID: validID,
+			id:       validID,
+			svcErr:   nil,
+			status:   http.StatusNoContent,
+			err:      nil,
+		},
+		{
+			desc:     "disconnect all clients from channel with invalid token",
+			token:    invalidToken,
+			session:  smqauthn.Session{},
+			domainID: validID,
+			id:       validID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "disconnect all clients from channel with empty token",
+			token:    "",
+			session:  smqauthn.Session{},
+			domainID: validID,
+			id:       validID,
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "disconnect all clients from channel with empty domainID",
+			token:  validToken,
+			id:     validID,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrMissingDomainID,
+		},
+		{
+			desc:     "disconnect all clients from channel with service error",
+			token:    validToken,
+			id:       validID,
+			domainID: validID,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: gs.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/channels/%s/disconnect-all", gs.URL, tc.domainID, tc.id),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("DisconnectAll", mock.Anything, tc.session, tc.id).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListConnectionsEndpoint(t *testing.T) {
+	gs, svc, authn := newChannelsServer()
+	defer gs.Close()
+
+	conns := []channels.Connection{
+		{
+			ClientID:  validID,
+			ChannelID: validID,
+			DomainID:  validID,
+			Type:      connections.Publish,
+			Metadata:  channels.Metadata{"alias": "sensor"},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		domainID string
+		session  smqauthn.Session
+		svcRes   []channels.Connection
+		svcErr   error
+		response []channels.Connection
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:     "list channel connections successfully",
+			token:    validToken,
+			domainID: validID,
+			id:       validID,
+			svcRes:   conns,
+			response: conns,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "list channel connections with invalid token",
+			token:    invalidToken,
+			domainID: validID,
+			id:       validID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "list channel connections with empty token",
+			token:    "",
+			domainID: validID,
+			id:       validID,
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "list channel connections with service error",
+			token:    validToken,
+			domainID: validID,
+			id:       validID,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: gs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/channels/%s/connections", gs.URL, tc.domainID, tc.id),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ListConnections", mock.Anything, tc.session, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody struct {
+				Total       uint64                `json:"total"`
+				Connections []channels.Connection `json:"connections"`
+				Err         string                `json:"error"`
+				Message     string                `json:"message"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.err == nil {
+				assert.Equal(t, uint64(len(tc.response)), resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, len(tc.response), resBody.Total))
+				assert.Equal(t, tc.response, resBody.Connections, fmt.Sprintf("%s: expected connections %v got %v", tc.desc, tc.response, resBody.Connections))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 type testRequest struct {
 	client      *http.Client
 	method      string
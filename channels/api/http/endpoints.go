@@ -275,7 +275,7 @@ func connectChannelClientEndpoint(svc channels.Service) endpoint.Endpoint {
 			return nil, svcerr.ErrAuthentication
 		}
 
-		if err := svc.Connect(ctx, session, []string{req.channelID}, req.ClientIDs, req.Types); err != nil {
+		if err := svc.Connect(ctx, session, []string{req.channelID}, req.ClientIDs, req.Types, req.Metadata); err != nil {
 			return nil, err
 		}
 
@@ -315,7 +315,7 @@ func connectEndpoint(svc channels.Service) endpoint.Endpoint {
 			return nil, svcerr.ErrAuthentication
 		}
 
-		if err := svc.Connect(ctx, session, req.ChannelIds, req.ClientIds, req.Types); err != nil {
+		if err := svc.Connect(ctx, session, req.ChannelIds, req.ClientIds, req.Types, req.Metadata); err != nil {
 			return nil, err
 		}
 
@@ -343,6 +343,52 @@ func disconnectEndpoint(svc channels.Service) endpoint.Endpoint {
 	}
 }
 
+func disconnectAllEndpoint(svc channels.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(disconnectAllRequest)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		if err := svc.DisconnectAll(ctx, session, req.channelID); err != nil {
+			return nil, err
+		}
+
+		return disconnectAllRes{}, nil
+	}
+}
+
+func listConnectionsEndpoint(svc channels.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(listConnectionsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		conns, err := svc.ListConnections(ctx, session, req.channelID)
+		if err != nil {
+			return nil, err
+		}
+
+		return listConnectionsRes{
+			pageRes: pageRes{
+				Total: uint64(len(conns)),
+			},
+			Connections: conns,
+		}, nil
+	}
+}
+
 func deleteChannelEndpoint(svc channels.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request any) (any, error) {
 		req := request.(deleteChannelReq)
@@ -103,6 +103,12 @@ func (req listChannelsReq) validate() error {
 		return apiutil.ErrInvalidDirection
 	}
 
+	if req.Client != "" {
+		if err := api.ValidateUUID(req.Client); err != nil {
+			return err
+		}
+	}
+
 	if req.ConnectionType != "" {
 		if _, err := connections.ParseConnType(req.ConnectionType); err != nil {
 			return apiutil.ErrValidation
@@ -187,6 +193,7 @@ type connectChannelClientsRequest struct {
 	channelID string
 	ClientIDs []string               `json:"client_ids,omitempty"`
 	Types     []connections.ConnType `json:"types,omitempty"`
+	Metadata  channels.Metadata      `json:"metadata,omitempty"`
 }
 
 func (req *connectChannelClientsRequest) validate() error {
@@ -247,6 +254,7 @@ type connectRequest struct {
 	ChannelIds []string               `json:"channel_ids,omitempty"`
 	ClientIds  []string               `json:"client_ids,omitempty"`
 	Types      []connections.ConnType `json:"types,omitempty"`
+	Metadata   channels.Metadata      `json:"metadata,omitempty"`
 }
 
 func (req *connectRequest) validate() error {
@@ -309,6 +317,28 @@ func (req *disconnectRequest) validate() error {
 	return nil
 }
 
+type disconnectAllRequest struct {
+	channelID string
+}
+
+func (req disconnectAllRequest) validate() error {
+	if req.channelID == "" {
+		return apiutil.ErrMissingID
+	}
+	return nil
+}
+
+type listConnectionsReq struct {
+	channelID string
+}
+
+func (req listConnectionsReq) validate() error {
+	if req.channelID == "" {
+		return apiutil.ErrMissingID
+	}
+	return nil
+}
+
 type deleteChannelReq struct {
 	id string
 }
@@ -197,6 +197,20 @@ func TestListChannelsReqValidation(t *testing.T) {
 			},
 			err: apiutil.ErrNameSize,
 		},
+		{
+			desc: "valid client ID",
+			req: listChannelsReq{
+				Page: channels.Page{Limit: 10, Client: testsutil.GenerateUUID(t)},
+			},
+			err: nil,
+		},
+		{
+			desc: "invalid client ID",
+			req: listChannelsReq{
+				Page: channels.Page{Limit: 10, Client: "invalid"},
+			},
+			err: apiutil.ErrInvalidIDFormat,
+		},
 	}
 	for _, tc := range cases {
 		err := tc.req.validate()
@@ -600,6 +614,33 @@ func TestDisconnectReqValidate(t *testing.T) {
 	}
 }
 
+func TestListConnectionsReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  listConnectionsReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: listConnectionsReq{
+				channelID: valid,
+			},
+			err: nil,
+		},
+		{
+			desc: "missing channel ID",
+			req: listConnectionsReq{
+				channelID: "",
+			},
+			err: apiutil.ErrMissingID,
+		},
+	}
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestDeleteChannelReqValidate(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -21,6 +21,8 @@ var (
 	_ supermq.Response = (*disconnectChannelClientsRes)(nil)
 	_ supermq.Response = (*connectRes)(nil)
 	_ supermq.Response = (*disconnectRes)(nil)
+	_ supermq.Response = (*disconnectAllRes)(nil)
+	_ supermq.Response = (*listConnectionsRes)(nil)
 	_ supermq.Response = (*changeChannelStatusRes)(nil)
 )
 
@@ -206,6 +208,20 @@ func (res connectRes) Empty() bool {
 	return true
 }
 
+type disconnectAllRes struct{}
+
+func (res disconnectAllRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res disconnectAllRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res disconnectAllRes) Empty() bool {
+	return true
+}
+
 type disconnectRes struct{}
 
 func (res disconnectRes) Code() int {
@@ -219,3 +235,20 @@ func (res disconnectRes) Headers() map[string]string {
 func (res disconnectRes) Empty() bool {
 	return true
 }
+
+type listConnectionsRes struct {
+	pageRes
+	Connections []channels.Connection `json:"connections"`
+}
+
+func (res listConnectionsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listConnectionsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listConnectionsRes) Empty() bool {
+	return false
+}
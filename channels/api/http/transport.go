@@ -11,6 +11,7 @@ import (
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/channels"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/policies"
 	roleManagerHttp "github.com/absmach/supermq/pkg/roles/rolemanager/api"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -27,7 +28,7 @@ func MakeHandler(svc channels.Service, authn smqauthn.AuthNMiddleware, mux *chi.
 	d := roleManagerHttp.NewDecoder("channelID")
 
 	mux.Route("/{domainID}/channels", func(r chi.Router) {
-		r.Use(authn.Middleware())
+		r.Use(authn.WithOptions(smqauthn.WithScopeEntityType(policies.ChannelType)).Middleware())
 		r.Use(api.RequestIDMiddleware(idp))
 
 		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
@@ -138,6 +139,20 @@ func MakeHandler(svc channels.Service, authn smqauthn.AuthNMiddleware, mux *chi.
 				opts...,
 			), "disconnect_channel_client").ServeHTTP)
 
+			r.Post("/disconnect-all", otelhttp.NewHandler(kithttp.NewServer(
+				disconnectAllEndpoint(svc),
+				decodeDisconnectAllRequest,
+				api.EncodeResponse,
+				opts...,
+			), "disconnect_all").ServeHTTP)
+
+			r.Get("/connections", otelhttp.NewHandler(kithttp.NewServer(
+				listConnectionsEndpoint(svc),
+				decodeListConnectionsRequest,
+				api.EncodeResponse,
+				opts...,
+			), "list_channel_connections").ServeHTTP)
+
 			roleManagerHttp.EntityRoleMangerRouter(svc, d, r, opts)
 		})
 	})
@@ -111,10 +111,11 @@ type ChannelsPage struct {
 }
 
 type Connection struct {
-	ClientID  string
-	ChannelID string
-	DomainID  string
-	Type      connections.ConnType
+	ClientID  string               `json:"client_id"`
+	ChannelID string               `json:"channel_id"`
+	DomainID  string               `json:"domain_id"`
+	Type      connections.ConnType `json:"type"`
+	Metadata  Metadata             `json:"metadata,omitempty"`
 }
 
 type AuthzReq struct {
@@ -155,11 +156,19 @@ type Service interface {
 	RemoveChannel(ctx context.Context, session authn.Session, id string) error
 
 	// Connect adds clients to the channels list of connected clients.
-	Connect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connType []connections.ConnType) error
+	// The metadata is stored with each of the created connections.
+	Connect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connType []connections.ConnType, meta Metadata) error
 
 	// Disconnect removes clients from the channels list of connected clients.
 	Disconnect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connType []connections.ConnType) error
 
+	// DisconnectAll removes all clients from the channel list of connected clients.
+	DisconnectAll(ctx context.Context, session authn.Session, id string) error
+
+	// ListConnections retrieves all connections of the channel along with
+	// the metadata stored with them.
+	ListConnections(ctx context.Context, session authn.Session, id string) ([]Connection, error)
+
 	SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) error
 
 	RemoveParentGroup(ctx context.Context, session authn.Session, id string) error
@@ -220,6 +229,9 @@ type Repository interface {
 
 	RemoveChannelConnections(ctx context.Context, channelID string) error
 
+	// RetrieveChannelConnections retrieves all connections of the channel along with their metadata.
+	RetrieveChannelConnections(ctx context.Context, channelID string) ([]Connection, error)
+
 	RetrieveParentGroupChannels(ctx context.Context, parentGroupID string) ([]Channel, error)
 
 	UnsetParentGroupFromChannels(ctx context.Context, parentGroupID string) error
@@ -14,20 +14,22 @@ import (
 )
 
 const (
-	channelPrefix       = "channel."
-	channelCreate       = channelPrefix + "create"
-	channelUpdate       = channelPrefix + "update"
-	channelUpdateTags   = channelPrefix + "update_tags"
-	channelEnable       = channelPrefix + "enable"
-	channelDisable      = channelPrefix + "disable"
-	channelRemove       = channelPrefix + "remove"
-	channelView         = channelPrefix + "view"
-	channelList         = channelPrefix + "list"
-	channelListByUser   = channelPrefix + "list_by_user"
-	channelConnect      = channelPrefix + "connect"
-	channelDisconnect   = channelPrefix + "disconnect"
-	channelSetParent    = channelPrefix + "set_parent"
-	channelRemoveParent = channelPrefix + "remove_parent"
+	channelPrefix        = "channel."
+	channelCreate        = channelPrefix + "create"
+	channelUpdate        = channelPrefix + "update"
+	channelUpdateTags    = channelPrefix + "update_tags"
+	channelEnable        = channelPrefix + "enable"
+	channelDisable       = channelPrefix + "disable"
+	channelRemove        = channelPrefix + "remove"
+	channelView          = channelPrefix + "view"
+	channelList          = channelPrefix + "list"
+	channelListByUser    = channelPrefix + "list_by_user"
+	channelConnect       = channelPrefix + "connect"
+	channelDisconnect    = channelPrefix + "disconnect"
+	channelDisconnectAll = channelPrefix + "disconnect_all"
+	channelListConns     = channelPrefix + "list_connections"
+	channelSetParent     = channelPrefix + "set_parent"
+	channelRemoveParent  = channelPrefix + "remove_parent"
 )
 
 var (
@@ -39,6 +41,8 @@ var (
 	_ events.Event = (*removeChannelEvent)(nil)
 	_ events.Event = (*connectEvent)(nil)
 	_ events.Event = (*disconnectEvent)(nil)
+	_ events.Event = (*disconnectAllEvent)(nil)
+	_ events.Event = (*listConnectionsEvent)(nil)
 )
 
 type createChannelEvent struct {
@@ -345,6 +349,44 @@ func (de disconnectEvent) Encode() (map[string]any, error) {
 	}, nil
 }
 
+type disconnectAllEvent struct {
+	id string
+	authn.Session
+	requestID string
+}
+
+func (dae disconnectAllEvent) Encode() (map[string]any, error) {
+	return map[string]any{
+		"operation":   channelDisconnectAll,
+		"id":          dae.id,
+		"domain":      dae.DomainID,
+		"user_id":     dae.UserID,
+		"token_type":  dae.Type.String(),
+		"super_admin": dae.SuperAdmin,
+		"request_id":  dae.requestID,
+	}, nil
+}
+
+type listConnectionsEvent struct {
+	id    string
+	total int
+	authn.Session
+	requestID string
+}
+
+func (lce listConnectionsEvent) Encode() (map[string]any, error) {
+	return map[string]any{
+		"operation":   channelListConns,
+		"id":          lce.id,
+		"total":       lce.total,
+		"domain":      lce.DomainID,
+		"user_id":     lce.UserID,
+		"token_type":  lce.Type.String(),
+		"super_admin": lce.SuperAdmin,
+		"request_id":  lce.requestID,
+	}, nil
+}
+
 type setParentGroupEvent struct {
 	id            string
 	parentGroupID string
@@ -17,20 +17,22 @@ import (
 )
 
 const (
-	supermqPrefix      = "supermq."
-	createStream       = supermqPrefix + channelCreate
-	updateStream       = supermqPrefix + channelUpdate
-	updateTagsStream   = supermqPrefix + channelUpdateTags
-	enableStream       = supermqPrefix + channelEnable
-	disableStream      = supermqPrefix + channelDisable
-	removeStream       = supermqPrefix + channelRemove
-	viewStream         = supermqPrefix + channelView
-	listStream         = supermqPrefix + channelList
-	listByUserStream   = supermqPrefix + channelListByUser
-	connectStream      = supermqPrefix + channelConnect
-	disconnectStream   = supermqPrefix + channelDisconnect
-	setParentStream    = supermqPrefix + channelSetParent
-	removeParentStream = supermqPrefix + channelRemoveParent
+	supermqPrefix       = "supermq."
+	createStream        = supermqPrefix + channelCreate
+	updateStream        = supermqPrefix + channelUpdate
+	updateTagsStream    = supermqPrefix + channelUpdateTags
+	enableStream        = supermqPrefix + channelEnable
+	disableStream       = supermqPrefix + channelDisable
+	removeStream        = supermqPrefix + channelRemove
+	viewStream          = supermqPrefix + channelView
+	listStream          = supermqPrefix + channelList
+	listByUserStream    = supermqPrefix + channelListByUser
+	connectStream       = supermqPrefix + channelConnect
+	disconnectStream    = supermqPrefix + channelDisconnect
+	disconnectAllStream = supermqPrefix + channelDisconnectAll
+	listConnsStream     = supermqPrefix + channelListConns
+	setParentStream     = supermqPrefix + channelSetParent
+	removeParentStream  = supermqPrefix + channelRemoveParent
 )
 
 var _ channels.Service = (*eventStore)(nil)
@@ -222,8 +224,8 @@ func (es *eventStore) RemoveChannel(ctx context.Context, session authn.Session,
 	return nil
 }
 
-func (es *eventStore) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
-	if err := es.svc.Connect(ctx, session, chIDs, thIDs, connTypes); err != nil {
+func (es *eventStore) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, meta channels.Metadata) error {
+	if err := es.svc.Connect(ctx, session, chIDs, thIDs, connTypes, meta); err != nil {
 		return err
 	}
 
@@ -262,6 +264,43 @@ func (es *eventStore) Disconnect(ctx context.Context, session authn.Session, chI
 	return nil
 }
 
+func (es *eventStore) DisconnectAll(ctx context.Context, session authn.Session, id string) error {
+	if err := es.svc.DisconnectAll(ctx, session, id); err != nil {
+		return err
+	}
+
+	event := disconnectAllEvent{
+		id:        id,
+		Session:   session,
+		requestID: middleware.GetReqID(ctx),
+	}
+
+	if err := es.Publish(ctx, disconnectAllStream, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (es *eventStore) ListConnections(ctx context.Context, session authn.Session, id string) ([]channels.Connection, error) {
+	conns, err := es.svc.ListConnections(ctx, session, id)
+	if err != nil {
+		return conns, err
+	}
+
+	event := listConnectionsEvent{
+		id:        id,
+		total:     len(conns),
+		Session:   session,
+		requestID: middleware.GetReqID(ctx),
+	}
+	if err := es.Publish(ctx, listConnsStream, event); err != nil {
+		return conns, err
+	}
+
+	return conns, nil
+}
+
 func (es *eventStore) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) (err error) {
 	if err := es.svc.SetParentGroup(ctx, session, parentGroupID, id); err != nil {
 		return err
@@ -207,7 +207,7 @@ func (am *authorizationMiddleware) RemoveChannel(ctx context.Context, session au
 	return am.svc.RemoveChannel(ctx, session, id)
 }
 
-func (am *authorizationMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
+func (am *authorizationMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, meta channels.Metadata) error {
 	for _, chID := range chIDs {
 		if err := am.authorize(ctx, session, policies.ChannelType, operations.OpConnectClient, smqauthz.PolicyReq{
 			Domain:      session.DomainID,
@@ -232,7 +232,7 @@ func (am *authorizationMiddleware) Connect(ctx context.Context, session authn.Se
 		}
 	}
 
-	return am.svc.Connect(ctx, session, chIDs, thIDs, connTypes)
+	return am.svc.Connect(ctx, session, chIDs, thIDs, connTypes, meta)
 }
 
 func (am *authorizationMiddleware) Disconnect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
@@ -263,6 +263,37 @@ func (am *authorizationMiddleware) Disconnect(ctx context.Context, session authn
 	return am.svc.Disconnect(ctx, session, chIDs, thIDs, connTypes)
 }
 
+// DisconnectAll requires the permission to delete the channel, since it
+// affects all clients connected to it.
+func (am *authorizationMiddleware) DisconnectAll(ctx context.Context, session authn.Session, id string) error {
+	if err := am.authorize(ctx, session, policies.ChannelType, operations.OpDeleteChannel, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ChannelType,
+		Object:      id,
+	}); err != nil {
+		return errors.Wrap(err, errDisconnect)
+	}
+
+	return am.svc.DisconnectAll(ctx, session, id)
+}
+
+// ListConnections requires the permission to view the channel.
+func (am *authorizationMiddleware) ListConnections(ctx context.Context, session authn.Session, id string) ([]channels.Connection, error) {
+	if err := am.authorize(ctx, session, policies.ChannelType, operations.OpViewChannel, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ChannelType,
+		Object:      id,
+	}); err != nil {
+		return nil, errors.Wrap(err, errView)
+	}
+
+	return am.svc.ListConnections(ctx, session, id)
+}
+
 func (am *authorizationMiddleware) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) error {
 	if err := am.authorize(ctx, session, policies.ChannelType, operations.OpSetParentGroup, smqauthz.PolicyReq{
 		Domain:      session.DomainID,
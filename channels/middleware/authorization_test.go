@@ -0,0 +1,179 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/channels"
+	"github.com/absmach/supermq/channels/middleware"
+	"github.com/absmach/supermq/channels/mocks"
+	channelsOps "github.com/absmach/supermq/channels/operations"
+	clientsOps "github.com/absmach/supermq/clients/operations"
+	domainsOps "github.com/absmach/supermq/domains/operations"
+	groupsOps "github.com/absmach/supermq/groups/operations"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authzmocks "github.com/absmach/supermq/pkg/authz/mocks"
+	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/permissions"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/roles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const permissionsFile = "../../docker/permission.yaml"
+
+func newAuthorization(t *testing.T, svc channels.Service, authz smqauthz.Authorization) channels.Service {
+	permConfig, err := permissions.ParsePermissionsFile(permissionsFile)
+	require.Nil(t, err, fmt.Sprintf("parsing permissions file: unexpected error %s", err))
+
+	entitiesPerms := permissions.EntitiesPermission{}
+	var channelRoleOps map[string]permissions.Permission
+	entities := map[string]string{
+		policies.ChannelType: "channels",
+		policies.DomainType:  "domains",
+		policies.GroupType:   "groups",
+		policies.ClientType:  "clients",
+	}
+	for entity, name := range entities {
+		ops, roleOps, err := permConfig.GetEntityPermissions(name)
+		require.Nil(t, err, fmt.Sprintf("getting %s permissions: unexpected error %s", entity, err))
+		entitiesPerms[entity] = ops
+		if entity == policies.ChannelType {
+			channelRoleOps = roleOps
+		}
+	}
+
+	entitiesOps, err := permissions.NewEntitiesOperations(
+		entitiesPerms,
+		permissions.EntitiesOperationDetails[permissions.Operation]{
+			policies.ChannelType: channelsOps.OperationDetails(),
+			policies.DomainType:  domainsOps.OperationDetails(),
+			policies.GroupType:   groupsOps.OperationDetails(),
+			policies.ClientType:  clientsOps.OperationDetails(),
+		},
+	)
+	require.Nil(t, err, fmt.Sprintf("creating entities operations: unexpected error %s", err))
+	roleOps, err := permissions.NewOperations(roles.Operations(), channelRoleOps)
+	require.Nil(t, err, fmt.Sprintf("creating role operations: unexpected error %s", err))
+
+	am, err := middleware.NewAuthorization(policies.ChannelType, svc, authz, new(mocks.Repository), entitiesOps, roleOps)
+	require.Nil(t, err, fmt.Sprintf("creating authorization middleware: unexpected error %s", err))
+
+	return am
+}
+
+func TestConnectAuthorization(t *testing.T) {
+	svc := new(mocks.Service)
+	authz := new(authzmocks.Authorization)
+	am := newAuthorization(t, svc, authz)
+
+	session := authn.Session{UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
+	session.DomainUserID = session.DomainID + "_" + session.UserID
+	clientID := testsutil.GenerateUUID(t)
+	ownChannelID := testsutil.GenerateUUID(t)
+	foreignChannelID := testsutil.GenerateUUID(t)
+	connTypes := []connections.ConnType{connections.Publish}
+
+	// The user manages the client and its own channel, but not the foreign one.
+	authz.On("Authorize", mock.Anything, mock.MatchedBy(func(req smqauthz.PolicyReq) bool {
+		return req.Object == foreignChannelID
+	}), mock.Anything).Return(svcerr.ErrAuthorization)
+	authz.On("Authorize", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	cases := []struct {
+		desc       string
+		channelIDs []string
+		err        error
+	}{
+		{
+			desc:       "connect client to own channel",
+			channelIDs: []string{ownChannelID},
+			err:        nil,
+		},
+		{
+			desc:       "connect client to foreign channel",
+			channelIDs: []string{foreignChannelID},
+			err:        svcerr.ErrAuthorization,
+		},
+		{
+			desc:       "connect client to own and foreign channel",
+			channelIDs: []string{ownChannelID, foreignChannelID},
+			err:        svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			connectCall := svc.On("Connect", mock.Anything, session, tc.channelIDs, []string{clientID}, connTypes, channels.Metadata(nil)).Return(nil)
+			disconnectCall := svc.On("Disconnect", mock.Anything, session, tc.channelIDs, []string{clientID}, connTypes).Return(nil)
+
+			err := am.Connect(context.Background(), session, tc.channelIDs, []string{clientID}, connTypes, nil)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected connect error %s got %s", tc.desc, tc.err, err))
+			err = am.Disconnect(context.Background(), session, tc.channelIDs, []string{clientID}, connTypes)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected disconnect error %s got %s", tc.desc, tc.err, err))
+			if tc.err != nil {
+				svc.AssertNotCalled(t, "Connect", mock.Anything, session, tc.channelIDs, []string{clientID}, connTypes, channels.Metadata(nil))
+				svc.AssertNotCalled(t, "Disconnect", mock.Anything, session, tc.channelIDs, []string{clientID}, connTypes)
+			}
+
+			connectCall.Unset()
+			disconnectCall.Unset()
+		})
+	}
+}
+
+func TestDisconnectAllAuthorization(t *testing.T) {
+	svc := new(mocks.Service)
+	authz := new(authzmocks.Authorization)
+	am := newAuthorization(t, svc, authz)
+
+	session := authn.Session{UserID: testsutil.GenerateUUID(t), DomainID: testsutil.GenerateUUID(t)}
+	session.DomainUserID = session.DomainID + "_" + session.UserID
+	ownChannelID := testsutil.GenerateUUID(t)
+	foreignChannelID := testsutil.GenerateUUID(t)
+
+	// Disconnecting all clients requires the permission to delete the channel.
+	authz.On("Authorize", mock.Anything, mock.MatchedBy(func(req smqauthz.PolicyReq) bool {
+		return req.Object == ownChannelID && req.Permission == "delete_permission"
+	}), mock.Anything).Return(nil)
+	authz.On("Authorize", mock.Anything, mock.Anything, mock.Anything).Return(svcerr.ErrAuthorization)
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "disconnect all clients from own channel",
+			id:   ownChannelID,
+			err:  nil,
+		},
+		{
+			desc: "disconnect all clients from foreign channel",
+			id:   foreignChannelID,
+			err:  svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("DisconnectAll", mock.Anything, session, tc.id).Return(nil)
+			err := am.DisconnectAll(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			if tc.err != nil {
+				svc.AssertNotCalled(t, "DisconnectAll", mock.Anything, session, tc.id)
+			}
+			svcCall.Unset()
+		})
+	}
+}
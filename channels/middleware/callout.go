@@ -160,18 +160,19 @@ func (cm *calloutMiddleware) RemoveChannel(ctx context.Context, session authn.Se
 	return cm.svc.RemoveChannel(ctx, session, id)
 }
 
-func (cm *calloutMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
+func (cm *calloutMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, meta channels.Metadata) error {
 	params := map[string]any{
 		"channel_ids":      chIDs,
 		"client_ids":       thIDs,
 		"connection_types": connTypes,
+		"metadata":         meta,
 	}
 
 	if err := cm.callOut(ctx, session, policies.ChannelType, operations.OpConnectClient, params); err != nil {
 		return err
 	}
 
-	return cm.svc.Connect(ctx, session, chIDs, thIDs, connTypes)
+	return cm.svc.Connect(ctx, session, chIDs, thIDs, connTypes, meta)
 }
 
 func (cm *calloutMiddleware) Disconnect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
@@ -188,6 +189,30 @@ func (cm *calloutMiddleware) Disconnect(ctx context.Context, session authn.Sessi
 	return cm.svc.Disconnect(ctx, session, chIDs, thIDs, connTypes)
 }
 
+func (cm *calloutMiddleware) DisconnectAll(ctx context.Context, session authn.Session, id string) error {
+	params := map[string]any{
+		"entity_id": id,
+	}
+
+	if err := cm.callOut(ctx, session, policies.ChannelType, operations.OpDeleteChannel, params); err != nil {
+		return err
+	}
+
+	return cm.svc.DisconnectAll(ctx, session, id)
+}
+
+func (cm *calloutMiddleware) ListConnections(ctx context.Context, session authn.Session, id string) ([]channels.Connection, error) {
+	params := map[string]any{
+		"entity_id": id,
+	}
+
+	if err := cm.callOut(ctx, session, policies.ChannelType, operations.OpViewChannel, params); err != nil {
+		return nil, err
+	}
+
+	return cm.svc.ListConnections(ctx, session, id)
+}
+
 func (cm *calloutMiddleware) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) error {
 	params := map[string]any{
 		"entity_id":       id,
@@ -218,7 +218,7 @@ func (lm *loggingMiddleware) RemoveChannel(ctx context.Context, session authn.Se
 	return lm.svc.RemoveChannel(ctx, session, id)
 }
 
-func (lm *loggingMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connTypes []connections.ConnType) (err error) {
+func (lm *loggingMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connTypes []connections.ConnType, meta channels.Metadata) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
 			slog.String("duration", time.Since(begin).String()),
@@ -234,7 +234,7 @@ func (lm *loggingMiddleware) Connect(ctx context.Context, session authn.Session,
 		}
 		lm.logger.Info("Connect channels and clients completed successfully", args...)
 	}(time.Now())
-	return lm.svc.Connect(ctx, session, chIDs, clIDs, connTypes)
+	return lm.svc.Connect(ctx, session, chIDs, clIDs, connTypes, meta)
 }
 
 func (lm *loggingMiddleware) Disconnect(ctx context.Context, session authn.Session, chIDs, clIDs []string, connTypes []connections.ConnType) (err error) {
@@ -256,6 +256,43 @@ func (lm *loggingMiddleware) Disconnect(ctx context.Context, session authn.Sessi
 	return lm.svc.Disconnect(ctx, session, chIDs, clIDs, connTypes)
 }
 
+func (lm *loggingMiddleware) DisconnectAll(ctx context.Context, session authn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("channel_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Disconnect all clients from channel failed", args...)
+			return
+		}
+		lm.logger.Info("Disconnect all clients from channel completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DisconnectAll(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListConnections(ctx context.Context, session authn.Session, id string) (conns []channels.Connection, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("channel_id", id),
+			slog.Int("total", len(conns)),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("List channel connections failed", args...)
+			return
+		}
+		lm.logger.Info("List channel connections completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListConnections(ctx, session, id)
+}
+
 func (lm *loggingMiddleware) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -106,12 +106,12 @@ func (ms *metricsMiddleware) RemoveChannel(ctx context.Context, session authn.Se
 	return ms.svc.RemoveChannel(ctx, session, id)
 }
 
-func (ms *metricsMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
+func (ms *metricsMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, meta channels.Metadata) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "connect").Add(1)
 		ms.latency.With("method", "connect").Observe(time.Since(begin).Seconds())
 	}(time.Now())
-	return ms.svc.Connect(ctx, session, chIDs, thIDs, connTypes)
+	return ms.svc.Connect(ctx, session, chIDs, thIDs, connTypes, meta)
 }
 
 func (ms *metricsMiddleware) Disconnect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
@@ -122,6 +122,22 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, session authn.Sessi
 	return ms.svc.Disconnect(ctx, session, chIDs, thIDs, connTypes)
 }
 
+func (ms *metricsMiddleware) DisconnectAll(ctx context.Context, session authn.Session, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disconnect_all").Add(1)
+		ms.latency.With("method", "disconnect_all").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DisconnectAll(ctx, session, id)
+}
+
+func (ms *metricsMiddleware) ListConnections(ctx context.Context, session authn.Session, id string) ([]channels.Connection, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_channel_connections").Add(1)
+		ms.latency.With("method", "list_channel_connections").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListConnections(ctx, session, id)
+}
+
 func (ms *metricsMiddleware) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) (err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "set_parent_group").Add(1)
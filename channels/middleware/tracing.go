@@ -99,13 +99,13 @@ func (tm *tracingMiddleware) RemoveChannel(ctx context.Context, session authn.Se
 	return tm.svc.RemoveChannel(ctx, session, id)
 }
 
-func (tm *tracingMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
+func (tm *tracingMiddleware) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, meta channels.Metadata) error {
 	ctx, span := tracing.StartSpan(ctx, tm.tracer, "connect", trace.WithAttributes(
 		attribute.StringSlice("channel_ids", chIDs),
 		attribute.StringSlice("client_ids", thIDs),
 	))
 	defer span.End()
-	return tm.svc.Connect(ctx, session, chIDs, thIDs, connTypes)
+	return tm.svc.Connect(ctx, session, chIDs, thIDs, connTypes, meta)
 }
 
 func (tm *tracingMiddleware) Disconnect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) error {
@@ -117,6 +117,18 @@ func (tm *tracingMiddleware) Disconnect(ctx context.Context, session authn.Sessi
 	return tm.svc.Disconnect(ctx, session, chIDs, thIDs, connTypes)
 }
 
+func (tm *tracingMiddleware) DisconnectAll(ctx context.Context, session authn.Session, id string) error {
+	ctx, span := tracing.StartSpan(ctx, tm.tracer, "disconnect_all", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+	return tm.svc.DisconnectAll(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) ListConnections(ctx context.Context, session authn.Session, id string) ([]channels.Connection, error) {
+	ctx, span := tracing.StartSpan(ctx, tm.tracer, "svc_list_channel_connections", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+	return tm.svc.ListConnections(ctx, session, id)
+}
+
 func (tm *tracingMiddleware) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) error {
 	ctx, span := tracing.StartSpan(ctx, tm.tracer, "set_parent_group", trace.WithAttributes(
 		attribute.String("parent_group_id", parentGroupID),
@@ -1377,6 +1377,74 @@ func (_c *Repository_RetrieveByRoute_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// RetrieveChannelConnections provides a mock function for the type Repository
+func (_mock *Repository) RetrieveChannelConnections(ctx context.Context, channelID string) ([]channels.Connection, error) {
+	ret := _mock.Called(ctx, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveChannelConnections")
+	}
+
+	var r0 []channels.Connection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]channels.Connection, error)); ok {
+		return returnFunc(ctx, channelID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []channels.Connection); ok {
+		r0 = returnFunc(ctx, channelID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]channels.Connection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, channelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveChannelConnections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveChannelConnections'
+type Repository_RetrieveChannelConnections_Call struct {
+	*mock.Call
+}
+
+// RetrieveChannelConnections is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channelID string
+func (_e *Repository_Expecter) RetrieveChannelConnections(ctx interface{}, channelID interface{}) *Repository_RetrieveChannelConnections_Call {
+	return &Repository_RetrieveChannelConnections_Call{Call: _e.mock.On("RetrieveChannelConnections", ctx, channelID)}
+}
+
+func (_c *Repository_RetrieveChannelConnections_Call) Run(run func(ctx context.Context, channelID string)) *Repository_RetrieveChannelConnections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveChannelConnections_Call) Return(connections []channels.Connection, err error) *Repository_RetrieveChannelConnections_Call {
+	_c.Call.Return(connections, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveChannelConnections_Call) RunAndReturn(run func(ctx context.Context, channelID string) ([]channels.Connection, error)) *Repository_RetrieveChannelConnections_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveEntitiesRolesActionsMembers provides a mock function for the type Repository
 func (_mock *Repository) RetrieveEntitiesRolesActionsMembers(ctx context.Context, entityIDs []string) ([]roles.EntityActionRole, []roles.EntityMemberRole, error) {
 	ret := _mock.Called(ctx, entityIDs)
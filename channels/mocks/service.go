@@ -136,16 +136,16 @@ func (_c *Service_AddRole_Call) RunAndReturn(run func(ctx context.Context, sessi
 }
 
 // Connect provides a mock function for the type Service
-func (_mock *Service) Connect(ctx context.Context, session authn.Session, chIDs []string, clIDs []string, connType []connections.ConnType) error {
-	ret := _mock.Called(ctx, session, chIDs, clIDs, connType)
+func (_mock *Service) Connect(ctx context.Context, session authn.Session, chIDs []string, clIDs []string, connType []connections.ConnType, meta channels.Metadata) error {
+	ret := _mock.Called(ctx, session, chIDs, clIDs, connType, meta)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Connect")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, []string, []string, []connections.ConnType) error); ok {
-		r0 = returnFunc(ctx, session, chIDs, clIDs, connType)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, []string, []string, []connections.ConnType, channels.Metadata) error); ok {
+		r0 = returnFunc(ctx, session, chIDs, clIDs, connType, meta)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -163,11 +163,12 @@ type Service_Connect_Call struct {
 //   - chIDs []string
 //   - clIDs []string
 //   - connType []connections.ConnType
-func (_e *Service_Expecter) Connect(ctx interface{}, session interface{}, chIDs interface{}, clIDs interface{}, connType interface{}) *Service_Connect_Call {
-	return &Service_Connect_Call{Call: _e.mock.On("Connect", ctx, session, chIDs, clIDs, connType)}
+//   - meta channels.Metadata
+func (_e *Service_Expecter) Connect(ctx interface{}, session interface{}, chIDs interface{}, clIDs interface{}, connType interface{}, meta interface{}) *Service_Connect_Call {
+	return &Service_Connect_Call{Call: _e.mock.On("Connect", ctx, session, chIDs, clIDs, connType, meta)}
 }
 
-func (_c *Service_Connect_Call) Run(run func(ctx context.Context, session authn.Session, chIDs []string, clIDs []string, connType []connections.ConnType)) *Service_Connect_Call {
+func (_c *Service_Connect_Call) Run(run func(ctx context.Context, session authn.Session, chIDs []string, clIDs []string, connType []connections.ConnType, meta channels.Metadata)) *Service_Connect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -189,12 +190,17 @@ func (_c *Service_Connect_Call) Run(run func(ctx context.Context, session authn.
 		if args[4] != nil {
 			arg4 = args[4].([]connections.ConnType)
 		}
+		var arg5 channels.Metadata
+		if args[5] != nil {
+			arg5 = args[5].(channels.Metadata)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
 			arg4,
+			arg5,
 		)
 	})
 	return _c
@@ -205,7 +211,7 @@ func (_c *Service_Connect_Call) Return(err error) *Service_Connect_Call {
 	return _c
 }
 
-func (_c *Service_Connect_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, chIDs []string, clIDs []string, connType []connections.ConnType) error) *Service_Connect_Call {
+func (_c *Service_Connect_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, chIDs []string, clIDs []string, connType []connections.ConnType, meta channels.Metadata) error) *Service_Connect_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -448,6 +454,69 @@ func (_c *Service_Disconnect_Call) RunAndReturn(run func(ctx context.Context, se
 	return _c
 }
 
+// DisconnectAll provides a mock function for the type Service
+func (_mock *Service) DisconnectAll(ctx context.Context, session authn.Session, id string) error {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisconnectAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_DisconnectAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisconnectAll'
+type Service_DisconnectAll_Call struct {
+	*mock.Call
+}
+
+// DisconnectAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) DisconnectAll(ctx interface{}, session interface{}, id interface{}) *Service_DisconnectAll_Call {
+	return &Service_DisconnectAll_Call{Call: _e.mock.On("DisconnectAll", ctx, session, id)}
+}
+
+func (_c *Service_DisconnectAll_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_DisconnectAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_DisconnectAll_Call) Return(err error) *Service_DisconnectAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_DisconnectAll_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_DisconnectAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // EnableChannel provides a mock function for the type Service
 func (_mock *Service) EnableChannel(ctx context.Context, session authn.Session, id string) (channels.Channel, error) {
 	ret := _mock.Called(ctx, session, id)
@@ -660,6 +729,80 @@ func (_c *Service_ListChannels_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// ListConnections provides a mock function for the type Service
+func (_mock *Service) ListConnections(ctx context.Context, session authn.Session, id string) ([]channels.Connection, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListConnections")
+	}
+
+	var r0 []channels.Connection
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) ([]channels.Connection, error)); ok {
+		return returnFunc(ctx, session, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) []channels.Connection); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]channels.Connection)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListConnections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListConnections'
+type Service_ListConnections_Call struct {
+	*mock.Call
+}
+
+// ListConnections is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) ListConnections(ctx interface{}, session interface{}, id interface{}) *Service_ListConnections_Call {
+	return &Service_ListConnections_Call{Call: _e.mock.On("ListConnections", ctx, session, id)}
+}
+
+func (_c *Service_ListConnections_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_ListConnections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListConnections_Call) Return(connections []channels.Connection, err error) *Service_ListConnections_Call {
+	_c.Call.Return(connections, err)
+	return _c
+}
+
+func (_c *Service_ListConnections_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) ([]channels.Connection, error)) *Service_ListConnections_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListEntityMembers provides a mock function for the type Service
 func (_mock *Service) ListEntityMembers(ctx context.Context, session authn.Session, entityID string, pq roles.MembersRolePageQuery) (roles.MembersRolePage, error) {
 	ret := _mock.Called(ctx, session, entityID, pq)
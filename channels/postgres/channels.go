@@ -948,9 +948,12 @@ func (cr *channelRepository) RemoveParentGroup(ctx context.Context, ch channels.
 }
 
 func (cr *channelRepository) AddConnections(ctx context.Context, conns []channels.Connection) error {
-	dbConns := toDBConnections(conns)
-	q := `INSERT INTO connections (channel_id, domain_id, client_id, type)
-			VALUES (:channel_id, :domain_id, :client_id, :type );`
+	dbConns, err := toDBConnections(conns)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	q := `INSERT INTO connections (channel_id, domain_id, client_id, type, metadata)
+			VALUES (:channel_id, :domain_id, :client_id, :type, :metadata);`
 
 	if _, err := cr.db.NamedExecContext(ctx, q, dbConns); err != nil {
 		return cr.eh.HandleError(repoerr.ErrCreateEntity, err)
@@ -1030,6 +1033,32 @@ func (cr *channelRepository) ChannelConnectionsCount(ctx context.Context, id str
 	return total, nil
 }
 
+func (cr *channelRepository) RetrieveChannelConnections(ctx context.Context, channelID string) ([]channels.Connection, error) {
+	query := `SELECT channel_id, domain_id, client_id, type, COALESCE(metadata, '{}') AS metadata FROM connections WHERE channel_id = :channel_id`
+
+	rows, err := cr.db.NamedQueryContext(ctx, query, dbConnection{ChannelID: channelID})
+	if err != nil {
+		return nil, cr.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	conns := []channels.Connection{}
+	for rows.Next() {
+		dbConn := dbConnection{}
+		if err := rows.StructScan(&dbConn); err != nil {
+			return nil, cr.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		conn, err := toConnection(dbConn)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+
+	return conns, nil
+}
+
 func (cr *channelRepository) DoesChannelHaveConnections(ctx context.Context, id string) (bool, error) {
 	query := `SELECT 1 FROM connections WHERE channel_id = :channel_id`
 	dbConn := dbConnection{ChannelID: id}
@@ -1296,9 +1325,9 @@ func PageQuery(pm channels.Page) (string, error) {
 	if len(pm.Tags.Elements) > 0 {
 		switch pm.Tags.Operator {
 		case channels.AndOp:
-			query = append(query, "tags @> :tags")
+			query = append(query, "c.tags @> :tags")
 		default: // OR
-			query = append(query, "tags && :tags")
+			query = append(query, "c.tags && :tags")
 		}
 	}
 
@@ -1453,14 +1482,25 @@ type dbConnection struct {
 	DomainID  string               `db:"domain_id"`
 	ClientID  string               `db:"client_id"`
 	Type      connections.ConnType `db:"type"`
+	Metadata  []byte               `db:"metadata"`
 }
 
-func toDBConnections(conns []channels.Connection) []dbConnection {
+func toDBConnections(conns []channels.Connection) ([]dbConnection, error) {
 	var dbconns []dbConnection
 	for _, conn := range conns {
-		dbconns = append(dbconns, toDBConnection(conn))
+		dbconn := toDBConnection(conn)
+		metadata := []byte("{}")
+		if len(conn.Metadata) > 0 {
+			b, err := json.Marshal(conn.Metadata)
+			if err != nil {
+				return nil, errors.Wrap(repoerr.ErrMalformedEntity, err)
+			}
+			metadata = b
+		}
+		dbconn.Metadata = metadata
+		dbconns = append(dbconns, dbconn)
 	}
-	return dbconns
+	return dbconns, nil
 }
 
 func toDBConnection(conn channels.Connection) dbConnection {
@@ -1471,3 +1511,20 @@ func toDBConnection(conn channels.Connection) dbConnection {
 		Type:      conn.Type,
 	}
 }
+
+func toConnection(dbConn dbConnection) (channels.Connection, error) {
+	var metadata channels.Metadata
+	if len(dbConn.Metadata) > 0 {
+		if err := json.Unmarshal(dbConn.Metadata, &metadata); err != nil {
+			return channels.Connection{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return channels.Connection{
+		ClientID:  dbConn.ClientID,
+		ChannelID: dbConn.ChannelID,
+		DomainID:  dbConn.DomainID,
+		Type:      dbConn.Type,
+		Metadata:  metadata,
+	}, nil
+}
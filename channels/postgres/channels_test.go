@@ -1790,6 +1790,58 @@ func TestChannelConnectionsCount(t *testing.T) {
 	}
 }
 
+func TestRetrieveChannelConnections(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM connections")
+		require.Nil(t, err, fmt.Sprintf("clean connections unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM channels")
+		require.Nil(t, err, fmt.Sprintf("clean channels unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	_, err := repo.Save(context.Background(), validChannel)
+	require.Nil(t, err, fmt.Sprintf("save channel unexpected error: %s", err))
+
+	conn := channels.Connection{
+		ClientID:  testsutil.GenerateUUID(t),
+		ChannelID: validChannel.ID,
+		DomainID:  validChannel.Domain,
+		Type:      connections.Publish,
+		Metadata:  channels.Metadata{"alias": "sensor"},
+	}
+	err = repo.AddConnections(context.Background(), []channels.Connection{conn})
+	require.Nil(t, err, fmt.Sprintf("add connection unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		channelID string
+		response  []channels.Connection
+		err       error
+	}{
+		{
+			desc:      "retrieve channel connections successfully",
+			channelID: validChannel.ID,
+			response:  []channels.Connection{conn},
+			err:       nil,
+		},
+		{
+			desc:      "retrieve channel connections with non-existent channel",
+			channelID: testsutil.GenerateUUID(t),
+			response:  []channels.Connection{},
+			err:       nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			conns, err := repo.RetrieveChannelConnections(context.Background(), tc.channelID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, conns, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, conns))
+		})
+	}
+}
+
 func TestDoesChannelHaveConnections(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM connections")
@@ -106,6 +106,15 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 					`DROP INDEX IF EXISTS idx_channels_parent_group_id;`,
 				},
 			},
+			{
+				Id: "channels_07",
+				Up: []string{
+					`ALTER TABLE connections ADD COLUMN IF NOT EXISTS metadata JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE connections DROP COLUMN IF EXISTS metadata`,
+				},
+			},
 		},
 	}
 	channelsMigration.Migrations = append(channelsMigration.Migrations, rolesMigration.Migrations...)
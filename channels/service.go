@@ -282,7 +282,7 @@ func (svc service) RemoveChannel(ctx context.Context, session authn.Session, id
 	return nil
 }
 
-func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType) (retErr error) {
+func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, thIDs []string, connTypes []connections.ConnType, meta Metadata) (retErr error) {
 	for _, chID := range chIDs {
 		c, err := svc.repo.RetrieveByID(ctx, chID)
 		if err != nil {
@@ -319,6 +319,7 @@ func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, th
 					ChannelID: chID,
 					DomainID:  session.DomainID,
 					Type:      connType,
+					Metadata:  meta,
 				})
 				cliConns = append(cliConns, &grpcCommonV1.Connection{
 					ClientId:  thID,
@@ -404,6 +405,35 @@ func (svc service) Disconnect(ctx context.Context, session authn.Session, chIDs,
 	return nil
 }
 
+func (svc service) DisconnectAll(ctx context.Context, session authn.Session, id string) error {
+	c, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	if c.Domain != session.DomainID {
+		return errors.Wrap(svcerr.ErrRemoveEntity, fmt.Errorf("channel id %s has invalid domain id", id))
+	}
+
+	if _, err := svc.clients.RemoveChannelConnections(ctx, &grpcClientsV1.RemoveChannelConnectionsReq{ChannelId: id}); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, errors.Wrap(errRemoveConnectionsClients, err))
+	}
+
+	if err := svc.repo.RemoveChannelConnections(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) ListConnections(ctx context.Context, session authn.Session, id string) ([]Connection, error) {
+	conns, err := svc.repo.RetrieveChannelConnections(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return conns, nil
+}
+
 func (svc service) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) (retErr error) {
 	ch, err := svc.repo.RetrieveByID(ctx, id)
 	if err != nil {
@@ -806,6 +806,7 @@ func TestConnect(t *testing.T) {
 		channelIDs               []string
 		thingIDs                 []string
 		connTypes                []connections.ConnType
+		metadata                 channels.Metadata
 		repoConn                 channels.Connection
 		clientsConn              []*grpcCommonV1.Connection
 		retrieveByIDRes          channels.Channel
@@ -847,6 +848,38 @@ func TestConnect(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc:            "connect successfully with metadata",
+			channelIDs:      []string{validChannel.ID},
+			thingIDs:        []string{validID},
+			connTypes:       []connections.ConnType{connections.Publish},
+			metadata:        channels.Metadata{"qos": float64(1)},
+			retrieveByIDRes: validDomainChannel,
+			retrieveEntityRes: &grpcCommonV1.RetrieveEntityRes{
+				Entity: &grpcCommonV1.EntityBasic{
+					Id:       validID,
+					DomainId: validID,
+					Status:   uint32(channels.EnabledStatus),
+				},
+			},
+			checkConnErr: repoerr.ErrNotFound,
+			repoConn: channels.Connection{
+				ClientID:  validID,
+				ChannelID: validChannel.ID,
+				DomainID:  validID,
+				Type:      connections.Publish,
+				Metadata:  channels.Metadata{"qos": float64(1)},
+			},
+			clientsConn: []*grpcCommonV1.Connection{
+				{
+					ClientId:  validID,
+					ChannelId: validChannel.ID,
+					DomainId:  validID,
+					Type:      uint32(connections.Publish),
+				},
+			},
+			err: nil,
+		},
 		{
 			desc:            "connect with failed to retrieve channel",
 			channelIDs:      []string{validChannel.ID},
@@ -1021,7 +1054,7 @@ func TestConnect(t *testing.T) {
 			repoCall1 := repo.On("CheckConnection", context.Background(), tc.repoConn).Return(tc.checkConnErr)
 			clientsCall1 := clientsSvc.On("AddConnections", context.Background(), &grpcCommonV1.AddConnectionsReq{Connections: tc.clientsConn}).Return(&grpcCommonV1.AddConnectionsRes{}, tc.addClientConnectionsErr)
 			repoCall2 := repo.On("AddConnections", context.Background(), []channels.Connection{tc.repoConn}).Return(tc.addChannelConnectionsErr)
-			err := svc.Connect(context.Background(), validSession, tc.channelIDs, tc.thingIDs, tc.connTypes)
+			err := svc.Connect(context.Background(), validSession, tc.channelIDs, tc.thingIDs, tc.connTypes, tc.metadata)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", tc.err, err))
 			repoCall.Unset()
 			clientsCall.Unset()
@@ -1198,6 +1231,120 @@ func TestDisconnect(t *testing.T) {
 	}
 }
 
+func TestDisconnectAll(t *testing.T) {
+	svc := newService(t)
+
+	validDomainChannel := validChannel
+	validDomainChannel.Domain = validID
+
+	cases := []struct {
+		desc                        string
+		id                          string
+		retrieveByIDRes             channels.Channel
+		retrieveByIDErr             error
+		removeClientConnectionsErr  error
+		removeChannelConnectionsErr error
+		err                         error
+	}{
+		{
+			desc:            "disconnect all successfully",
+			id:              validChannel.ID,
+			retrieveByIDRes: validDomainChannel,
+			err:             nil,
+		},
+		{
+			desc:            "disconnect all with failed to retrieve channel",
+			id:              validChannel.ID,
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:            "disconnect all from channel in another domain",
+			id:              validChannel.ID,
+			retrieveByIDRes: validChannel,
+			err:             svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:                       "disconnect all with failed to remove client connections",
+			id:                         validChannel.ID,
+			retrieveByIDRes:            validDomainChannel,
+			removeClientConnectionsErr: svcerr.ErrRemoveEntity,
+			err:                        svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:                        "disconnect all with failed to remove channel connections",
+			id:                          validChannel.ID,
+			retrieveByIDRes:             validDomainChannel,
+			removeChannelConnectionsErr: repoerr.ErrRemoveEntity,
+			err:                         svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDRes, tc.retrieveByIDErr)
+			clientsCall := clientsSvc.On("RemoveChannelConnections", context.Background(), &grpcClientsV1.RemoveChannelConnectionsReq{ChannelId: tc.id}).Return(&grpcClientsV1.RemoveChannelConnectionsRes{}, tc.removeClientConnectionsErr)
+			repoCall1 := repo.On("RemoveChannelConnections", context.Background(), tc.id).Return(tc.removeChannelConnectionsErr)
+			err := svc.DisconnectAll(context.Background(), validSession, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %v to contain %v", tc.desc, err, tc.err))
+			repoCall.Unset()
+			clientsCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestListConnections(t *testing.T) {
+	svc := newService(t)
+
+	conns := []channels.Connection{
+		{
+			ClientID:  testsutil.GenerateUUID(t),
+			ChannelID: validChannel.ID,
+			DomainID:  validChannel.Domain,
+			Type:      connections.Publish,
+			Metadata:  channels.Metadata{"qos": float64(1)},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		id       string
+		repoResp []channels.Connection
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "list channel connections successfully",
+			id:       validChannel.ID,
+			repoResp: conns,
+		},
+		{
+			desc:     "list connections of channel without connections",
+			id:       validChannel.ID,
+			repoResp: []channels.Connection{},
+		},
+		{
+			desc:    "list channel connections with failed to retrieve",
+			id:      validChannel.ID,
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveChannelConnections", context.Background(), tc.id).Return(tc.repoResp, tc.repoErr)
+			got, err := svc.ListConnections(context.Background(), validSession, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			if err == nil {
+				assert.Equal(t, tc.repoResp, got)
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
 func TestSetParentGroup(t *testing.T) {
 	svc := newService(t)
 
@@ -53,6 +53,9 @@ Examples:
   channels <channel_id> users <domain_id> <user_auth_token>`,
 
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) == 0 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
@@ -53,14 +53,15 @@ type filter struct {
 }
 
 type config struct {
-	Remotes   remotes `toml:"remotes"`
-	Filter    filter  `toml:"filter"`
-	UserToken string  `toml:"user_token"`
-	RawOutput string  `toml:"raw_output"`
+	Remotes      remotes `toml:"remotes"`
+	Filter       filter  `toml:"filter"`
+	UserToken    string  `toml:"user_token"`
+	RefreshToken string  `toml:"refresh_token"`
+	RawOutput    string  `toml:"raw_output"`
 }
 
-// Readable by all user groups but writeable by the user only.
-const filePermission = 0o644
+// Readable and writeable by the user only, since the file stores user tokens.
+const filePermission = 0o600
 
 var (
 	errReadFail            = errors.New("failed to read config file")
@@ -259,6 +260,7 @@ func setConfigValue(key, value string) error {
 		"topic":            &config.Filter.Topic,
 		"raw_output":       &config.RawOutput,
 		"user_token":       &config.UserToken,
+		"refresh_token":    &config.RefreshToken,
 	}
 
 	fieldPtr, ok := configKeyToField[key]
@@ -62,6 +62,9 @@ Examples:
   domains <domain_id> users <user_auth_token>`,
 
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) == 0 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
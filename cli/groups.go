@@ -66,6 +66,9 @@ Examples:
   groups <group_id> disable <domain_id> <user_auth_token>`,
 
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) == 0 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
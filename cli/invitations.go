@@ -17,6 +17,9 @@ var cmdUserInvitations = []cobra.Command{
 			"\tsupermq-cli invitations user get <user_auth_token> - lists all invitations for the user\n" +
 			"\tsupermq-cli invitations user get <user_auth_token> --offset <offset> --limit <limit> - lists all invitations with provided offset and limit\n",
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) != 1 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
@@ -42,6 +45,9 @@ var cmdUserInvitations = []cobra.Command{
 			"Usage:\n" +
 			"\tsupermq-cli invitations user accept 39f97daf-d6b6-40f4-b229-2697be8006ef $USER_TOKEN\n",
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) != 2 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
@@ -62,6 +68,9 @@ var cmdUserInvitations = []cobra.Command{
 			"Usage:\n" +
 			"\tsupermq-cli invitations user reject 39f97daf-d6b6-40f4-b229-2697be8006ef $USER_AUTH_TOKEN\n",
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) != 2 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
@@ -85,6 +94,9 @@ var cmdDomainInvitations = []cobra.Command{
 			"For example:\n" +
 			"\tsupermq-cli invitations domain send 39f97daf-d6b6-40f4-b229-2697be8006ef 4ef09eff-d500-4d56-b04f-d23a512d6f2a ba4c904c-e6d4-4978-9417-1694aac6793e $USER_AUTH_TOKEN\n",
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) != 4 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
@@ -110,6 +122,9 @@ var cmdDomainInvitations = []cobra.Command{
 			"\tsupermq-cli invitations domain get <domain_id> <user_auth_token> - shows invitations for domain\n" +
 			"\tsupermq-cli invitations domain get <domain_id> <user_auth_token> --offset <offset> --limit <limit> - shows invitations with provided offset and limit\n",
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) != 2 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
@@ -136,6 +151,9 @@ var cmdDomainInvitations = []cobra.Command{
 			"Usage:\n" +
 			"\tsupermq-cli invitations domain delete 39f97daf-d6b6-40f4-b229-2697be8006ef 4ef09eff-d500-4d56-b04f-d23a512d6f2a $USER_TOKEN\n",
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) != 3 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
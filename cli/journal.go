@@ -17,6 +17,9 @@ var cmdJournal = cobra.Command{
 		"\tsupermq-cli journal get <entity_type> <entity_id> <domain_id> <user_auth_token> - lists entity journal logs\n" +
 		"\tsupermq-cli journal get <entity_type> <entity_id> <domain_id> <user_auth_token> --offset <offset> --limit <limit> - lists user journal logs with provided offset and limit\n",
 	Run: func(cmd *cobra.Command, args []string) {
+		if !resolveUserToken(cmd, args) {
+			return
+		}
 		if len(args) < 3 || len(args) > 4 {
 			logUsageCmd(*cmd, cmd.Use)
 			return
@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	smqsdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/pelletier/go-toml"
+	"github.com/spf13/cobra"
+)
+
+// expiryLeeway treats tokens that are about to expire as already expired,
+// so they are not rejected by the server in the middle of a command.
+const expiryLeeway = 30 * time.Second
+
+// storedTokenArg can be passed in place of <user_auth_token> to use the
+// token stored in the config file by `cli users token`.
+const storedTokenArg = "-"
+
+var errNoValidToken = errors.New("no valid or refreshable token stored, issue a new one using `cli users token`")
+
+// UserToken returns the access token stored in the config file. If the
+// access token is expired, it is transparently refreshed using the stored
+// refresh token and the new pair is persisted.
+func UserToken(ctx context.Context) (string, error) {
+	if ConfigPath == "" {
+		ConfigPath = defaultConfigPath
+	}
+	c, err := read(ConfigPath)
+	if err != nil {
+		return "", err
+	}
+
+	if c.UserToken != "" && !tokenExpired(c.UserToken) {
+		return c.UserToken, nil
+	}
+	if c.RefreshToken == "" || tokenExpired(c.RefreshToken) {
+		return "", errNoValidToken
+	}
+
+	token, sdkErr := sdk.RefreshToken(ctx, c.RefreshToken)
+	if sdkErr != nil {
+		return "", errors.Wrap(errNoValidToken, sdkErr)
+	}
+	if err := storeToken(token); err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+// resolveUserToken replaces the trailing storedTokenArg argument with the
+// stored user token. It returns false if no valid token is available.
+func resolveUserToken(cmd *cobra.Command, args []string) bool {
+	if len(args) == 0 || args[len(args)-1] != storedTokenArg {
+		return true
+	}
+	token, err := UserToken(cmd.Context())
+	if err != nil {
+		logErrorCmd(*cmd, err)
+		return false
+	}
+	args[len(args)-1] = token
+
+	return true
+}
+
+// storeToken persists the access and refresh token to the config file. It is
+// a no-op when no config file is in use.
+func storeToken(token smqsdk.Token) error {
+	if ConfigPath == "" {
+		return nil
+	}
+	c, err := read(ConfigPath)
+	if err != nil {
+		return err
+	}
+	c.UserToken = token.AccessToken
+	c.RefreshToken = token.RefreshToken
+
+	buf, err := toml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(ConfigPath, buf, filePermission); err != nil {
+		return errors.Wrap(errWritingConfig, err)
+	}
+	// WriteFile keeps the mode of existing files, which may have been
+	// created readable by others.
+	if err := os.Chmod(ConfigPath, filePermission); err != nil {
+		return errors.Wrap(errWritingConfig, err)
+	}
+
+	return nil
+}
+
+// tokenExpired reports whether the JWT expiry claim is in the past. The token
+// signature is not verified since that is done by the server. Tokens which
+// cannot be parsed are considered expired.
+func tokenExpired(token string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return true
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return true
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return true
+	}
+
+	return time.Now().Add(expiryLeeway).After(time.Unix(claims.Exp, 0))
+}
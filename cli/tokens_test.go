@@ -0,0 +1,185 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/cli"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	sdkmocks "github.com/absmach/supermq/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func jwt(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "header." + payload + ".signature"
+}
+
+func TestUserToken(t *testing.T) {
+	valid := jwt(time.Now().Add(time.Hour))
+	expired := jwt(time.Now().Add(-time.Hour))
+	refreshed := mgsdk.Token{
+		AccessToken:  jwt(time.Now().Add(2 * time.Hour)),
+		RefreshToken: jwt(time.Now().Add(24 * time.Hour)),
+	}
+
+	cases := []struct {
+		desc         string
+		accessToken  string
+		refreshToken string
+		refreshCall  bool
+		refreshRes   mgsdk.Token
+		sdkErr       errors.SDKError
+		token        string
+		stored       string
+		err          bool
+	}{
+		{
+			desc:         "get valid access token",
+			accessToken:  valid,
+			refreshToken: valid,
+			token:        valid,
+			stored:       valid,
+		},
+		{
+			desc:         "refresh expired access token",
+			accessToken:  expired,
+			refreshToken: valid,
+			refreshCall:  true,
+			refreshRes:   refreshed,
+			token:        refreshed.AccessToken,
+			stored:       refreshed.AccessToken,
+		},
+		{
+			desc:         "refresh expired access token with failed refresh",
+			accessToken:  expired,
+			refreshToken: valid,
+			refreshCall:  true,
+			sdkErr:       errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			stored:       expired,
+			err:          true,
+		},
+		{
+			desc:         "get token with expired refresh token",
+			accessToken:  expired,
+			refreshToken: expired,
+			stored:       expired,
+			err:          true,
+		},
+		{
+			desc:   "get token without stored tokens",
+			stored: "",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkMock := new(sdkmocks.SDK)
+			cli.SetSDK(sdkMock)
+			sdkCall := sdkMock.On("RefreshToken", mock.Anything, tc.refreshToken).Return(tc.refreshRes, tc.sdkErr)
+
+			cli.ConfigPath = filepath.Join(t.TempDir(), "config.toml")
+			defer func() { cli.ConfigPath = "" }()
+			conf := fmt.Sprintf("user_token = %q\nrefresh_token = %q\n", tc.accessToken, tc.refreshToken)
+			err := os.WriteFile(cli.ConfigPath, []byte(conf), 0o644)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error writing config: %s", tc.desc, err))
+
+			token, err := cli.UserToken(context.Background())
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.token, token, fmt.Sprintf("%s: expected token %s got %s", tc.desc, tc.token, token))
+
+			data, err := os.ReadFile(cli.ConfigPath)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error reading config: %s", tc.desc, err))
+			assert.True(t, strings.Contains(string(data), fmt.Sprintf("user_token = %q", tc.stored)), fmt.Sprintf("%s: expected stored token %s got config %s", tc.desc, tc.stored, data))
+
+			if tc.refreshCall {
+				sdkMock.AssertCalled(t, "RefreshToken", mock.Anything, tc.refreshToken)
+			} else {
+				sdkMock.AssertNotCalled(t, "RefreshToken", mock.Anything, mock.Anything)
+			}
+			if tc.refreshCall && !tc.err {
+				info, err := os.Stat(cli.ConfigPath)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error reading config: %s", tc.desc, err))
+				assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), fmt.Sprintf("%s: expected config mode 0600 got %s", tc.desc, info.Mode().Perm()))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestStoredUserTokenCmd(t *testing.T) {
+	valid := jwt(time.Now().Add(time.Hour))
+	expired := jwt(time.Now().Add(-time.Hour))
+
+	cases := []struct {
+		desc          string
+		args          []string
+		accessToken   string
+		token         string
+		errLogMessage string
+		logType       outputLog
+	}{
+		{
+			desc:        "use stored token",
+			args:        []string{client.ID, delCmd, domainID, "-"},
+			accessToken: valid,
+			token:       valid,
+			logType:     okLog,
+		},
+		{
+			desc:        "use explicit token",
+			args:        []string{client.ID, delCmd, domainID, token},
+			accessToken: valid,
+			token:       token,
+			logType:     okLog,
+		},
+		{
+			desc:          "use stored token without valid token",
+			args:          []string{client.ID, delCmd, domainID, "-"},
+			accessToken:   expired,
+			errLogMessage: "no valid or refreshable token stored",
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkMock := new(sdkmocks.SDK)
+			cli.SetSDK(sdkMock)
+			rootCmd := setFlags(cli.NewClientsCmd())
+
+			cli.ConfigPath = filepath.Join(t.TempDir(), "config.toml")
+			defer func() { cli.ConfigPath = "" }()
+			conf := fmt.Sprintf("user_token = %q\n", tc.accessToken)
+			err := os.WriteFile(cli.ConfigPath, []byte(conf), 0o600)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error writing config: %s", tc.desc, err))
+
+			sdkCall := sdkMock.On("DeleteClient", mock.Anything, client.ID, domainID, tc.token).Return(nil)
+			out := executeCommand(t, rootCmd, tc.args...)
+
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+				sdkMock.AssertCalled(t, "DeleteClient", mock.Anything, client.ID, domainID, tc.token)
+			case errLog:
+				assert.True(t, strings.Contains(out, tc.errLogMessage), fmt.Sprintf("%s unexpected error response: expected %s got %s", tc.desc, tc.errLogMessage, out))
+				sdkMock.AssertNotCalled(t, "DeleteClient", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			sdkCall.Unset()
+		})
+	}
+}
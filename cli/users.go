@@ -88,6 +88,9 @@ Examples:
   users <user_id> delete <user_auth_token>`,
 
 		Run: func(cmd *cobra.Command, args []string) {
+			if !resolveUserToken(cmd, args) {
+				return
+			}
 			if len(args) == 0 {
 				logUsageCmd(*cmd, cmd.Use)
 				return
@@ -476,6 +479,10 @@ func handleUserToken(cmd *cobra.Command, username string, args []string) {
 		logErrorCmd(*cmd, err)
 		return
 	}
+	if err := storeToken(token); err != nil {
+		logErrorCmd(*cmd, err)
+		return
+	}
 
 	logJSONCmd(*cmd, token)
 }
@@ -491,6 +498,10 @@ func handleUserRefreshToken(cmd *cobra.Command, refreshToken string, args []stri
 		logErrorCmd(*cmd, err)
 		return
 	}
+	if err := storeToken(token); err != nil {
+		logErrorCmd(*cmd, err)
+		return
+	}
 
 	logJSONCmd(*cmd, token)
 }
@@ -11,6 +11,7 @@ import (
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/clients"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/policies"
 	roleManagerHttp "github.com/absmach/supermq/pkg/roles/rolemanager/api"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -24,7 +25,7 @@ func clientsHandler(svc clients.Service, authn smqauthn.AuthNMiddleware, r *chi.
 	d := roleManagerHttp.NewDecoder("clientID")
 
 	r.Group(func(r chi.Router) {
-		r.Use(authn.Middleware())
+		r.Use(authn.WithOptions(smqauthn.WithScopeEntityType(policies.ClientType)).Middleware())
 		r.Use(api.RequestIDMiddleware(idp))
 
 		r.Route("/{domainID}/clients", func(r chi.Router) {
@@ -80,6 +81,27 @@ func clientsHandler(svc clients.Service, authn smqauthn.AuthNMiddleware, r *chi.
 					opts...,
 				), "update_client_credentials").ServeHTTP)
 
+				r.Patch("/owner", otelhttp.NewHandler(kithttp.NewServer(
+					updateClientOwnerEndpoint(svc),
+					decodeUpdateClientOwner,
+					api.EncodeResponse,
+					opts...,
+				), "update_client_owner").ServeHTTP)
+
+				r.Post("/certs", otelhttp.NewHandler(kithttp.NewServer(
+					addClientCertEndpoint(svc),
+					decodeAddClientCert,
+					api.EncodeResponse,
+					opts...,
+				), "add_client_cert").ServeHTTP)
+
+				r.Delete("/certs", otelhttp.NewHandler(kithttp.NewServer(
+					removeClientCertsEndpoint(svc),
+					decodeRemoveClientCerts,
+					api.EncodeResponse,
+					opts...,
+				), "remove_client_certs").ServeHTTP)
+
 				r.Post("/enable", otelhttp.NewHandler(kithttp.NewServer(
 					enableClientEndpoint(svc),
 					decodeChangeClientStatus,
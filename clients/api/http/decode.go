@@ -38,6 +38,10 @@ func decodeListClients(_ context.Context, r *http.Request) (any, error) {
 	if err != nil {
 		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	namePrefix, err := apiutil.ReadBoolQuery(r, api.NamePrefixKey, false)
+	if err != nil {
+		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
 
 	tags, err := apiutil.ReadStringQuery(r, api.TagsKey, "")
 	if err != nil {
@@ -62,6 +66,19 @@ func decodeListClients(_ context.Context, r *http.Request) (any, error) {
 		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
+	mf, err := apiutil.ReadStringQuery(r, api.MetadataFilterKey, "")
+	if err != nil {
+		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	var metaFilter *clients.MetadataFilter
+	if mf != "" {
+		filter, err := clients.ToMetadataFilter(mf)
+		if err != nil {
+			return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+		metaFilter = &filter
+	}
+
 	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
 	if err != nil {
 		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
@@ -132,14 +149,32 @@ func decodeListClients(_ context.Context, r *http.Request) (any, error) {
 		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
+	disconnected, err := apiutil.ReadBoolQuery(r, api.DisconnectedKey, false)
+	if err != nil {
+		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	userScoped, err := apiutil.ReadBoolQuery(r, api.UserScopedKey, false)
+	if err != nil {
+		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	id, err := apiutil.ReadStringQuery(r, api.IDOrder, "")
 	if err != nil {
 		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	ids, err := apiutil.ReadStringSliceQuery(r, api.IDsKey, ",")
+	if err != nil {
+		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	ot, err := apiutil.ReadBoolQuery(r, api.OnlyTotal, false)
 	if err != nil {
 		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	approx, err := apiutil.ReadBoolQuery(r, api.ApproxKey, false)
+	if err != nil {
+		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
 
 	cfrom, err := apiutil.ReadStringQuery(r, "created_from", "")
 	if err != nil {
@@ -165,9 +200,11 @@ func decodeListClients(_ context.Context, r *http.Request) (any, error) {
 	req := listClientsReq{
 		Page: clients.Page{
 			Name:           name,
+			NamePrefix:     namePrefix,
 			Tags:           tq,
 			Status:         status,
 			Metadata:       meta,
+			MetadataFilter: metaFilter,
 			RoleName:       roleName,
 			RoleID:         roleID,
 			Actions:        actions,
@@ -179,8 +216,12 @@ func decodeListClients(_ context.Context, r *http.Request) (any, error) {
 			Group:          groupPtr,
 			Channel:        channelID,
 			ConnectionType: connType,
+			Disconnected:   disconnected,
+			UserScoped:     userScoped,
 			ID:             id,
+			IDs:            ids,
 			OnlyTotal:      ot,
+			ApproxCount:    approx,
 			CreatedFrom:    createdFrom,
 			CreatedTo:      createdTo,
 		},
@@ -194,8 +235,14 @@ func decodeUpdateClient(_ context.Context, r *http.Request) (any, error) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
 	}
 
+	unmodifiedSince, err := apiutil.ReadUnmodifiedSince(r)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	req := updateClientReq{
-		id: chi.URLParam(r, clientID),
+		id:              chi.URLParam(r, clientID),
+		unmodifiedSince: unmodifiedSince,
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
@@ -234,6 +281,44 @@ func decodeUpdateClientCredentials(_ context.Context, r *http.Request) (any, err
 	return req, nil
 }
 
+func decodeUpdateClientOwner(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateClientOwnerReq{
+		id: chi.URLParam(r, clientID),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
+func decodeAddClientCert(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := addClientCertReq{
+		id: chi.URLParam(r, clientID),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
+func decodeRemoveClientCerts(_ context.Context, r *http.Request) (any, error) {
+	req := removeClientCertsReq{
+		id: chi.URLParam(r, clientID),
+	}
+
+	return req, nil
+}
+
 func decodeCreateClientReq(_ context.Context, r *http.Request) (any, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -147,6 +147,7 @@ func updateClientEndpoint(svc clients.Service) endpoint.Endpoint {
 			Name:            req.Name,
 			Metadata:        req.Metadata,
 			PrivateMetadata: req.PrivateMetadata,
+			UnmodifiedSince: req.unmodifiedSince,
 		}
 		client, err := svc.Update(ctx, session, cli)
 		if err != nil {
@@ -203,6 +204,26 @@ func updateClientSecretEndpoint(svc clients.Service) endpoint.Endpoint {
 	}
 }
 
+func updateClientOwnerEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(updateClientOwnerReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		if err := svc.UpdateOwner(ctx, session, req.id, req.OwnerID); err != nil {
+			return nil, err
+		}
+
+		return updateClientOwnerRes{}, nil
+	}
+}
+
 func enableClientEndpoint(svc clients.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request any) (any, error) {
 		req := request.(changeClientStatusReq)
@@ -302,3 +323,42 @@ func deleteClientEndpoint(svc clients.Service) endpoint.Endpoint {
 		return deleteClientRes{}, nil
 	}
 }
+
+func addClientCertEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(addClientCertReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+		cert, err := svc.AddCert(ctx, session, req.id, []byte(req.Cert))
+		if err != nil {
+			return nil, err
+		}
+
+		return addClientCertRes{Certificate: cert}, nil
+	}
+}
+
+func removeClientCertsEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(removeClientCertsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+		if err := svc.RemoveCerts(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeClientCertsRes{}, nil
+	}
+}
@@ -25,6 +25,7 @@ import (
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/roles"
 	"github.com/absmach/supermq/pkg/uuid"
@@ -601,6 +602,39 @@ func TestListClients(t *testing.T) {
 			status:   http.StatusBadRequest,
 			err:      apiutil.ErrInvalidQueryParams,
 		},
+		{
+			desc:     "list clients with name prefix",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			pageMeta: clients.Page{
+				Offset:     0,
+				Limit:      10,
+				Order:      api.DefOrder,
+				Dir:        api.DefDir,
+				Actions:    []string{},
+				Name:       "client",
+				NamePrefix: true,
+			},
+			listClientsResponse: clients.ClientsPage{
+				Page: clients.Page{
+					Total: 1,
+				},
+				Clients: []clients.Client{client},
+			},
+			query:  "name=client&name_prefix=true",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list clients with invalid name prefix",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			query:    "name=client&name_prefix=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
 		{
 			desc:     "list clients with status",
 			domainID: domainID,
@@ -867,6 +901,35 @@ func TestListClients(t *testing.T) {
 			status:   http.StatusBadRequest,
 			err:      apiutil.ErrInvalidQueryParams,
 		},
+		{
+			desc:     "list clients with API key scoped to clients",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, Scopes: []string{"clients:read"}},
+			status:   http.StatusOK,
+			pageMeta: clients.Page{
+				Offset:  0,
+				Limit:   10,
+				Order:   api.DefOrder,
+				Dir:     api.DefDir,
+				Actions: []string{},
+			},
+			listClientsResponse: clients.ClientsPage{
+				Page: clients.Page{
+					Total: 1,
+				},
+				Clients: []clients.Client{client},
+			},
+			err: nil,
+		},
+		{
+			desc:     "list clients with API key scoped to channels",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, Scopes: []string{"channels:write"}},
+			status:   http.StatusForbidden,
+			err:      apiutil.ErrScopeNotAllowed,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1038,6 +1101,17 @@ func TestUpdateClient(t *testing.T) {
 			status:      http.StatusUnauthorized,
 			err:         apiutil.ErrBearerToken,
 		},
+		{
+			desc:        "update client with failed precondition",
+			id:          client.ID,
+			data:        fmt.Sprintf(`{"name":"%s","tags":["%s"],"metadata":%s}`, newName, newTag, toJSON(newMetadata)),
+			domainID:    domainID,
+			token:       validToken,
+			authnRes:    smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			contentType: contentType,
+			status:      http.StatusPreconditionFailed,
+			err:         repoerr.ErrPreconditionFailed,
+		},
 		{
 			desc:        "update client with invalid contentype",
 			id:          client.ID,
@@ -1885,3 +1959,309 @@ type respBody struct {
 	Tags        []string       `json:"tags"`
 	Status      clients.Status `json:"status"`
 }
+
+func TestUpdateClientOwnerEndpoint(t *testing.T) {
+	gs, svc, authn := newClientsServer()
+	defer gs.Close()
+
+	ownerID := testsutil.GenerateUUID(t)
+	data := toJSON(map[string]string{"owner_id": ownerID})
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		domainID    string
+		data        string
+		contentType string
+		session     smqauthn.Session
+		svcErr      error
+		status      int
+		authnErr    error
+		err         error
+	}{
+		{
+			desc:        "update client owner successfully",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        data,
+			contentType: contentType,
+			status:      http.StatusNoContent,
+			err:         nil,
+		},
+		{
+			desc:        "update client owner with invalid token",
+			token:       inValidToken,
+			domainID:    validID,
+			id:          validID,
+			data:        data,
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "update client owner with empty owner id",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        `{"owner_id":""}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingUserID,
+		},
+		{
+			desc:        "update client owner with invalid content type",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        data,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "update client owner with malformed request body",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        `{"owner_id":}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMalformedRequestBody,
+		},
+		{
+			desc:        "update client owner with service error",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        data,
+			contentType: contentType,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      gs.Client(),
+				method:      http.MethodPatch,
+				url:         fmt.Sprintf("%s/%s/clients/%s/owner", gs.URL, tc.domainID, tc.id),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.data),
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("UpdateOwner", mock.Anything, tc.session, tc.id, ownerID).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestAddClientCertEndpoint(t *testing.T) {
+	gs, svc, authn := newClientsServer()
+	defer gs.Close()
+
+	certPEM := "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	data := toJSON(map[string]string{"cert": certPEM})
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		domainID    string
+		data        string
+		contentType string
+		session     smqauthn.Session
+		svcRes      clients.Certificate
+		svcErr      error
+		status      int
+		authnErr    error
+		err         error
+	}{
+		{
+			desc:        "add client certificate successfully",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        data,
+			contentType: contentType,
+			svcRes:      clients.Certificate{ClientID: validID, SerialNumber: "1"},
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "add client certificate with invalid token",
+			token:       inValidToken,
+			domainID:    validID,
+			id:          validID,
+			data:        data,
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "add client certificate with empty certificate",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        `{"cert":""}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingCertData,
+		},
+		{
+			desc:        "add client certificate with invalid content type",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        data,
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "add client certificate with malformed request body",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        `{"cert":}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMalformedRequestBody,
+		},
+		{
+			desc:        "add client certificate with service error",
+			token:       validToken,
+			domainID:    validID,
+			id:          validID,
+			data:        data,
+			contentType: contentType,
+			svcErr:      svcerr.ErrCreateEntity,
+			status:      http.StatusUnprocessableEntity,
+			err:         svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      gs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/clients/%s/certs", gs.URL, tc.domainID, tc.id),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.data),
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("AddCert", mock.Anything, tc.session, tc.id, []byte(certPEM)).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRemoveClientCertsEndpoint(t *testing.T) {
+	gs, svc, authn := newClientsServer()
+	defer gs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		domainID string
+		session  smqauthn.Session
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:     "remove client certificates successfully",
+			token:    validToken,
+			id:       validID,
+			domainID: validID,
+			status:   http.StatusNoContent,
+			err:      nil,
+		},
+		{
+			desc:     "remove client certificates with invalid token",
+			token:    inValidToken,
+			id:       validID,
+			domainID: validID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "remove client certificates with empty token",
+			token:  "",
+			id:     validID,
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "remove client certificates with service error",
+			token:    validToken,
+			id:       validID,
+			domainID: validID,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: gs.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/clients/%s/certs", gs.URL, tc.domainID, tc.id),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("RemoveCerts", mock.Anything, tc.session, tc.id).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
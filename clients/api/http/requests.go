@@ -4,6 +4,8 @@
 package http
 
 import (
+	"time"
+
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/clients"
@@ -77,8 +79,8 @@ type listClientsReq struct {
 }
 
 func (req listClientsReq) validate() error {
-	if req.Limit > api.MaxLimitSize || req.Limit < 1 {
-		return apiutil.ErrLimitSize
+	if err := apiutil.ValidatePageMetadata(apiutil.PageMetadata{Offset: req.Offset, Limit: req.Limit}, api.MaxLimitSize); err != nil {
+		return err
 	}
 
 	if len(req.Name) > api.MaxNameSize {
@@ -95,6 +97,21 @@ func (req listClientsReq) validate() error {
 		return apiutil.ErrInvalidDirection
 	}
 
+	for _, id := range req.IDs {
+		if err := api.ValidateUUID(id); err != nil {
+			return err
+		}
+	}
+
+	if req.Disconnected {
+		if req.Channel == "" {
+			return apiutil.ErrMissingChannelID
+		}
+		if err := api.ValidateUUID(req.Channel); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -113,6 +130,7 @@ func (req listMembersReq) validate() error {
 
 type updateClientReq struct {
 	id              string
+	unmodifiedSince time.Time
 	Name            string         `json:"name,omitempty"`
 	Metadata        map[string]any `json:"metadata,omitempty"`
 	PrivateMetadata map[string]any `json:"private_metadata,omitempty"`
@@ -161,6 +179,52 @@ func (req updateClientCredentialsReq) validate() error {
 	return nil
 }
 
+type updateClientOwnerReq struct {
+	id      string
+	OwnerID string `json:"owner_id"`
+}
+
+func (req updateClientOwnerReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.OwnerID == "" {
+		return apiutil.ErrMissingUserID
+	}
+
+	return nil
+}
+
+type addClientCertReq struct {
+	id   string
+	Cert string `json:"cert"`
+}
+
+func (req addClientCertReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Cert == "" {
+		return apiutil.ErrMissingCertData
+	}
+
+	return nil
+}
+
+type removeClientCertsReq struct {
+	id string
+}
+
+func (req removeClientCertsReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type changeClientStatusReq struct {
 	id string
 }
@@ -219,6 +219,58 @@ func TestListClientsReqValidate(t *testing.T) {
 			},
 			err: apiutil.ErrNameSize,
 		},
+		{
+			desc: "valid IDs",
+			req: listClientsReq{
+				Page: clients.Page{
+					Limit: 10,
+					IDs:   []string{validID},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "invalid IDs",
+			req: listClientsReq{
+				Page: clients.Page{
+					Limit: 10,
+					IDs:   []string{validID, "invalid"},
+				},
+			},
+			err: apiutil.ErrInvalidIDFormat,
+		},
+		{
+			desc: "disconnected from valid channel",
+			req: listClientsReq{
+				Page: clients.Page{
+					Limit:        10,
+					Channel:      validID,
+					Disconnected: true,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "disconnected without channel",
+			req: listClientsReq{
+				Page: clients.Page{
+					Limit:        10,
+					Disconnected: true,
+				},
+			},
+			err: apiutil.ErrMissingChannelID,
+		},
+		{
+			desc: "disconnected from channel with invalid ID",
+			req: listClientsReq{
+				Page: clients.Page{
+					Limit:        10,
+					Channel:      "invalid",
+					Disconnected: true,
+				},
+			},
+			err: apiutil.ErrInvalidIDFormat,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -366,6 +418,84 @@ func TestUpdateClientCredentialsReqValidate(t *testing.T) {
 	}
 }
 
+func TestUpdateClientOwnerReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  updateClientOwnerReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: updateClientOwnerReq{
+				id:      validID,
+				OwnerID: validID,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty id",
+			req: updateClientOwnerReq{
+				id:      "",
+				OwnerID: validID,
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty owner id",
+			req: updateClientOwnerReq{
+				id:      validID,
+				OwnerID: "",
+			},
+			err: apiutil.ErrMissingUserID,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.req.validate()
+			assert.Equal(t, tc.err, err, "%s: expected %s got %s\n", tc.desc, tc.err, err)
+		})
+	}
+}
+
+func TestAddClientCertReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  addClientCertReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: addClientCertReq{
+				id:   validID,
+				Cert: valid,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty id",
+			req: addClientCertReq{
+				id:   "",
+				Cert: valid,
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "empty certificate",
+			req: addClientCertReq{
+				id:   validID,
+				Cert: "",
+			},
+			err: apiutil.ErrMissingCertData,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.req.validate()
+			assert.Equal(t, tc.err, err, "%s: expected %s got %s\n", tc.desc, tc.err, err)
+		})
+	}
+}
+
 func TestChangeClientStatusReqValidate(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -118,6 +118,50 @@ func (res clientsPageRes) Empty() bool {
 	return false
 }
 
+type updateClientOwnerRes struct{}
+
+func (res updateClientOwnerRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res updateClientOwnerRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res updateClientOwnerRes) Empty() bool {
+	return true
+}
+
+type addClientCertRes struct {
+	clients.Certificate
+}
+
+func (res addClientCertRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res addClientCertRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res addClientCertRes) Empty() bool {
+	return false
+}
+
+type removeClientCertsRes struct{}
+
+func (res removeClientCertsRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeClientCertsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeClientCertsRes) Empty() bool {
+	return true
+}
+
 type changeClientStatusRes struct {
 	clients.Client
 }
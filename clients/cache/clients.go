@@ -15,22 +15,30 @@ import (
 )
 
 const (
-	keyPrefix = "client_key"
-	idPrefix  = "client_id"
+	keyPrefix        = "client_key"
+	idPrefix         = "client_keys"
+	unknownKeyPrefix = "client_unknown_key"
+	unknownKeysCount = "client_unknown_keys"
 )
 
 var _ clients.Cache = (*clientCache)(nil)
 
 type clientCache struct {
-	client      *redis.Client
-	keyDuration time.Duration
+	client             *redis.Client
+	keyDuration        time.Duration
+	unknownKeyDuration time.Duration
+	unknownKeyLimit    int64
 }
 
-// NewCache returns redis client cache implementation.
-func NewCache(client *redis.Client, duration time.Duration) clients.Cache {
+// NewCache returns redis client cache implementation. Unknown keys are
+// cached for unknownKeyDuration, with at most unknownKeyLimit keys marked as
+// unknown per period. Caching of unknown keys is disabled if either is zero.
+func NewCache(client *redis.Client, keyDuration, unknownKeyDuration time.Duration, unknownKeyLimit int64) clients.Cache {
 	return &clientCache{
-		client:      client,
-		keyDuration: duration,
+		client:             client,
+		keyDuration:        keyDuration,
+		unknownKeyDuration: unknownKeyDuration,
+		unknownKeyLimit:    unknownKeyLimit,
 	}
 }
 
@@ -43,8 +51,13 @@ func (tc *clientCache) Save(ctx context.Context, clientKey, clientID string) err
 		return errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
 
+	// A client can be cached under several keys, e.g. the secret and
+	// certificate fingerprints, so keys are tracked as a set per client.
 	tid := fmt.Sprintf("%s:%s", idPrefix, clientID)
-	if err := tc.client.Set(ctx, tid, clientKey, tc.keyDuration).Err(); err != nil {
+	if err := tc.client.SAdd(ctx, tid, clientKey).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if err := tc.client.Expire(ctx, tid, tc.keyDuration).Err(); err != nil {
 		return errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
 
@@ -57,27 +70,76 @@ func (tc *clientCache) ID(ctx context.Context, clientKey string) (string, error)
 	}
 
 	ckey := fmt.Sprintf("%s:%s", keyPrefix, clientKey)
-	clientID, err := tc.client.Get(ctx, ckey).Result()
+	ukey := fmt.Sprintf("%s:%s", unknownKeyPrefix, clientKey)
+	vals, err := tc.client.MGet(ctx, ckey, ukey).Result()
 	if err != nil {
 		return "", errors.Wrap(repoerr.ErrNotFound, err)
 	}
+	if clientID, ok := vals[0].(string); ok {
+		return clientID, nil
+	}
+	if vals[1] != nil {
+		return "", errors.Wrap(repoerr.ErrNotFound, clients.ErrUnknownKey)
+	}
 
-	return clientID, nil
+	return "", repoerr.ErrNotFound
 }
 
-func (tc *clientCache) Remove(ctx context.Context, clientID string) error {
-	tid := fmt.Sprintf("%s:%s", idPrefix, clientID)
-	key, err := tc.client.Get(ctx, tid).Result()
-	// Redis returns Nil Reply when key does not exist.
-	if err == redis.Nil {
+func (tc *clientCache) SaveUnknown(ctx context.Context, clientKey string) error {
+	if clientKey == "" || tc.unknownKeyDuration == 0 || tc.unknownKeyLimit == 0 {
+		return nil
+	}
+
+	// The number of keys marked as unknown is limited per period, so
+	// scanning random keys can't grow the cache without bounds.
+	var count *redis.IntCmd
+	if _, err := tc.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, unknownKeysCount)
+		pipe.ExpireNX(ctx, unknownKeysCount, tc.unknownKeyDuration)
+		return nil
+	}); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if count.Val() > tc.unknownKeyLimit {
 		return nil
 	}
+
+	ukey := fmt.Sprintf("%s:%s", unknownKeyPrefix, clientKey)
+	if err := tc.client.Set(ctx, ukey, "", tc.unknownKeyDuration).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tc *clientCache) Remove(ctx context.Context, clientID string) error {
+	tid := fmt.Sprintf("%s:%s", idPrefix, clientID)
+	keys, err := tc.client.SMembers(ctx, tid).Result()
 	if err != nil {
 		return errors.Wrap(repoerr.ErrRemoveEntity, err)
 	}
 
-	tkey := fmt.Sprintf("%s:%s", keyPrefix, key)
-	if err := tc.client.Del(ctx, tkey, tid).Err(); err != nil {
+	tkeys := []string{tid}
+	for _, key := range keys {
+		tkeys = append(tkeys, fmt.Sprintf("%s:%s", keyPrefix, key))
+	}
+	if err := tc.client.Del(ctx, tkeys...).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tc *clientCache) RemoveUnknown(ctx context.Context, clientKeys ...string) error {
+	if len(clientKeys) == 0 || tc.unknownKeyDuration == 0 || tc.unknownKeyLimit == 0 {
+		return nil
+	}
+
+	ukeys := make([]string, 0, len(clientKeys))
+	for _, key := range clientKeys {
+		ukeys = append(ukeys, fmt.Sprintf("%s:%s", unknownKeyPrefix, key))
+	}
+	if err := tc.client.Del(ctx, ukeys...).Err(); err != nil {
 		return errors.Wrap(repoerr.ErrRemoveEntity, err)
 	}
 
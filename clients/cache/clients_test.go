@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/absmach/supermq/clients"
 	"github.com/absmach/supermq/clients/cache"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
@@ -25,7 +26,7 @@ const (
 
 func TestSave(t *testing.T) {
 	redisClient.FlushAll(context.Background())
-	tscache := cache.NewCache(redisClient, 1*time.Minute)
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 0, 0)
 	ctx := context.Background()
 
 	cases := []struct {
@@ -96,7 +97,7 @@ func TestSave(t *testing.T) {
 
 func TestID(t *testing.T) {
 	redisClient.FlushAll(context.Background())
-	tscache := cache.NewCache(redisClient, 1*time.Minute)
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 0, 0)
 	ctx := context.Background()
 
 	err := tscache.Save(ctx, testKey, testID)
@@ -137,9 +138,54 @@ func TestID(t *testing.T) {
 	}
 }
 
+func TestSaveUnknown(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	ctx := context.Background()
+
+	cases := []struct {
+		desc    string
+		cache   clients.Cache
+		keys    []string
+		key     string
+		unknown bool
+	}{
+		{
+			desc:    "Get client ID for key saved as unknown",
+			cache:   cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 2),
+			keys:    []string{"unknownKey1"},
+			key:     "unknownKey1",
+			unknown: true,
+		},
+		{
+			desc:    "Get client ID for key saved as unknown over the limit",
+			cache:   cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 2),
+			keys:    []string{"unknownKey2", "unknownKey3"},
+			key:     "unknownKey3",
+			unknown: false,
+		},
+		{
+			desc:    "Get client ID for key saved as unknown with unknown keys caching disabled",
+			cache:   cache.NewCache(redisClient, 1*time.Minute, 0, 0),
+			keys:    []string{"unknownKey4"},
+			key:     "unknownKey4",
+			unknown: false,
+		},
+	}
+
+	for _, tc := range cases {
+		for _, key := range tc.keys {
+			err := tc.cache.SaveUnknown(ctx, key)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while saving unknown key: %s", tc.desc, err))
+		}
+		_, err := tc.cache.ID(ctx, tc.key)
+		assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, repoerr.ErrNotFound, err))
+		assert.Equal(t, tc.unknown, errors.Contains(err, clients.ErrUnknownKey), fmt.Sprintf("%s: expected unknown key %t got %s\n", tc.desc, tc.unknown, err))
+	}
+}
+
 func TestRemove(t *testing.T) {
 	redisClient.FlushAll(context.Background())
-	tscache := cache.NewCache(redisClient, 1*time.Minute)
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 0, 0)
 	ctx := context.Background()
 
 	err := tscache.Save(ctx, testKey, testID)
@@ -177,3 +223,47 @@ func TestRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestRemoveAllKeys(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 0, 0)
+	ctx := context.Background()
+
+	for _, key := range []string{testKey, testKey2} {
+		err := tscache.Save(ctx, key, testID)
+		assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
+	}
+
+	err := tscache.Remove(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to remove: %s", err))
+
+	for _, key := range []string{testKey, testKey2} {
+		_, err := tscache.ID(ctx, key)
+		assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("%s: expected %s got %s\n", key, repoerr.ErrNotFound, err))
+	}
+}
+
+func TestRemoveUnknown(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	tscache := cache.NewCache(redisClient, 1*time.Minute, 1*time.Minute, 10)
+	ctx := context.Background()
+
+	for _, key := range []string{testKey, testKey2} {
+		err := tscache.SaveUnknown(ctx, key)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error while saving unknown key: %s", err))
+	}
+
+	// Removing a client doesn't affect keys marked as unknown.
+	err := tscache.Remove(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error while removing client: %s", err))
+	_, err = tscache.ID(ctx, testKey)
+	assert.True(t, errors.Contains(err, clients.ErrUnknownKey), fmt.Sprintf("expected %s to be marked as unknown got %s\n", testKey, err))
+
+	err = tscache.RemoveUnknown(ctx, testKey)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error while removing unknown key: %s", err))
+
+	_, err = tscache.ID(ctx, testKey)
+	assert.False(t, errors.Contains(err, clients.ErrUnknownKey), fmt.Sprintf("expected %s not to be marked as unknown got %s\n", testKey, err))
+	_, err = tscache.ID(ctx, testKey2)
+	assert.True(t, errors.Contains(err, clients.ErrUnknownKey), fmt.Sprintf("expected %s to remain marked as unknown got %s\n", testKey2, err))
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// Certificate represents an X.509 client certificate bound to a client.
+type Certificate struct {
+	ClientID     string    `json:"client_id"`
+	SerialNumber string    `json:"serial_number"`
+	Subject      string    `json:"subject"`
+	Fingerprint  string    `json:"fingerprint"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CertificateRepository specifies client certificates persistence API.
+// It is used to authenticate clients by the certificate presented during
+// the mTLS handshake instead of the client secret.
+type CertificateRepository interface {
+	// Save binds the certificate to the client.
+	Save(ctx context.Context, cert Certificate) error
+
+	// RetrieveBySerial retrieves the certificate with the given serial number and subject.
+	RetrieveBySerial(ctx context.Context, serial, subject string) (Certificate, error)
+
+	// Remove removes all certificates bound to the client.
+	Remove(ctx context.Context, clientID string) error
+}
+
+// ParseCertificate parses the PEM encoded certificate and returns it with the
+// hex encoded SHA-256 fingerprint of its DER form. Certificates outside of
+// their validity period at the given time are rejected.
+func ParseCertificate(certPEM []byte, now time.Time) (*x509.Certificate, string, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, "", ErrInvalidCertificate
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, "", errors.Wrap(ErrInvalidCertificate, err)
+	}
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, "", ErrInvalidCertificate
+	}
+	sum := sha256.Sum256(cert.Raw)
+
+	return cert, hex.EncodeToString(sum[:]), nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/postgres"
 	"github.com/absmach/supermq/pkg/roles"
 )
@@ -21,6 +22,12 @@ type Connection struct {
 	Type      connections.ConnType
 }
 
+// BatchError describes the failure to save the client at the given index of a batch.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
 type ClientRepository struct {
 	DB postgres.Database
 }
@@ -58,6 +65,22 @@ type Repository interface {
 	// UpdateSecret updates secret for client with given identity.
 	UpdateSecret(ctx context.Context, client Client) (Client, error)
 
+	// RotateSecret replaces the client secret and keeps the current one as
+	// the previous secret, which remains valid until expiresAt.
+	RotateSecret(ctx context.Context, client Client, expiresAt time.Time) (Client, error)
+
+	// RemoveExpiredSecrets purges previous secrets whose grace period has
+	// ended and returns the number of affected clients.
+	RemoveExpiredSecrets(ctx context.Context) (uint64, error)
+
+	// RetrieveSecrets retrieves a page of client IDs and their stored secrets.
+	RetrieveSecrets(ctx context.Context, offset, limit uint64) ([]StoredSecrets, error)
+
+	// ReplaceSecret replaces the stored secrets of the client with the given
+	// ones, only if the stored secrets still match the current ones. The grace
+	// period of the previous secret is kept.
+	ReplaceSecret(ctx context.Context, current, replacement StoredSecrets) error
+
 	// ChangeStatus changes client status to enabled or disabled
 	ChangeStatus(ctx context.Context, client Client) (Client, error)
 
@@ -68,11 +91,23 @@ type Repository interface {
 	// operation failure.
 	Save(ctx context.Context, client ...Client) ([]Client, error)
 
+	// SaveBatch persists the clients one by one within a single transaction.
+	// Clients that fail to save are reported by their index in the batch,
+	// while the rest of the batch is saved. In strict mode the first failure
+	// rolls back the whole batch and is returned as an error.
+	SaveBatch(ctx context.Context, strict bool, clients ...Client) ([]Client, []BatchError, error)
+
 	// RetrieveBySecret retrieves a client based on the secret (key) and domainID.
 	// Domain ID is required because the key is not globally unique,
-	// but unique on the level of Domain.
+	// but unique on the level of Domain. A previous secret matches
+	// only while its rotation grace period has not expired.
 	RetrieveBySecret(ctx context.Context, key, id string, prefix authn.AuthPrefix) (Client, error)
 
+	// RetrieveBySecrets retrieves the clients matching the secret keys in a
+	// single query. Keys without a matching client are omitted. Returned
+	// clients contain only the ID, domain ID and current secret.
+	RetrieveBySecrets(ctx context.Context, keys []SecretKey) (map[SecretKey]Client, error)
+
 	AddConnections(ctx context.Context, conns []Connection) error
 
 	RemoveConnections(ctx context.Context, conns []Connection) error
@@ -123,6 +158,18 @@ type Service interface {
 	// UpdateSecret updates the client's secret
 	UpdateSecret(ctx context.Context, session authn.Session, id, key string) (Client, error)
 
+	// UpdateOwner transfers ownership of the client from its current owners,
+	// the members of its built-in admin role, to the user identified with the
+	// provided owner ID, migrating the role membership and its policies.
+	UpdateOwner(ctx context.Context, session authn.Session, id, ownerID string) error
+
+	// AddCert binds the PEM encoded X.509 certificate to the client, so that
+	// the client can authenticate with it over mTLS instead of its secret.
+	AddCert(ctx context.Context, session authn.Session, id string, certPEM []byte) (Certificate, error)
+
+	// RemoveCerts unbinds all certificates from the client.
+	RemoveCerts(ctx context.Context, session authn.Session, id string) error
+
 	// Enable logically enableds the client identified with the provided ID
 	Enable(ctx context.Context, session authn.Session, id string) (Client, error)
 
@@ -144,11 +191,21 @@ type Cache interface {
 	// Save stores pair client secret, client id.
 	Save(ctx context.Context, clientSecret, clientID string) error
 
-	// ID returns client ID for given client secret.
+	// ID returns client ID for given client secret. If the secret is
+	// marked as unknown, the returned error contains ErrUnknownKey.
 	ID(ctx context.Context, clientSecret string) (string, error)
 
+	// SaveUnknown marks the client secret as unknown for a short period,
+	// so repeated lookups of the same secret don't reach the database.
+	SaveUnknown(ctx context.Context, clientSecret string) error
+
 	// Removes client from cache.
 	Remove(ctx context.Context, clientID string) error
+
+	// RemoveUnknown removes the unknown markers of the given client
+	// secrets, so that newly valid secrets are not rejected until their
+	// markers expire.
+	RemoveUnknown(ctx context.Context, clientSecrets ...string) error
 }
 
 // Client Struct represents a client.
@@ -180,6 +237,9 @@ type Client struct {
 	ConnectionTypes           []connections.ConnType    `json:"connection_types,omitempty"`
 	MemberId                  string                    `json:"member_id,omitempty"`
 	Roles                     []roles.MemberRoleActions `json:"roles,omitempty"`
+	// UnmodifiedSince, if set, makes Update fail unless the entity
+	// was last modified at or before the given time.
+	UnmodifiedSince time.Time `json:"-"`
 }
 
 // ClientsPage contains page related metadata as well as list.
@@ -227,32 +287,127 @@ func ToTagsQuery(s string) TagsQuery {
 	}
 }
 
+// MetadataOperator represents the comparison applied by a metadata filter.
+type MetadataOperator uint8
+
+const (
+	// EqualsMetadataOp matches clients whose metadata value at the path equals the value.
+	EqualsMetadataOp MetadataOperator = iota
+	// ContainsMetadataOp matches clients whose metadata array at the path contains all the values.
+	ContainsMetadataOp
+	// InMetadataOp matches clients whose metadata value at the path equals any of the values.
+	InMetadataOp
+)
+
+// String representation of the metadata operators.
+const (
+	EqualsMetadata   = "eq"
+	ContainsMetadata = "contains"
+	InMetadata       = "in"
+)
+
+// expandSuffix marks the metadata filter path key holding an array whose
+// elements are matched individually.
+const expandSuffix = "[]"
+
+var errInvalidMetadataFilter = errors.New("invalid metadata filter")
+
+// MetadataFilter filters clients by the value found at the given metadata JSON path.
+// If Expand is set, it is the path of a metadata array expanded into rows,
+// and Path is evaluated against each of its elements. The client matches
+// if any of the elements matches.
+type MetadataFilter struct {
+	Expand   []string
+	Path     []string
+	Operator MetadataOperator
+	Values   []string
+}
+
+// ToMetadataFilter parses the metadata filter in the form of
+// "<path>:<operator>:<values>", where path is a dot separated list of
+// metadata keys and values is a comma separated list of values,
+// e.g. "device.serial:in:sn1,sn2". A single path key may end with "[]"
+// to expand the array under it, e.g. "meters[].serial:eq:sn1".
+func ToMetadataFilter(s string) (MetadataFilter, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return MetadataFilter{}, errInvalidMetadataFilter
+	}
+
+	var op MetadataOperator
+	switch parts[1] {
+	case EqualsMetadata:
+		op = EqualsMetadataOp
+	case ContainsMetadata:
+		op = ContainsMetadataOp
+	case InMetadata:
+		op = InMetadataOp
+	default:
+		return MetadataFilter{}, errInvalidMetadataFilter
+	}
+
+	var expand []string
+	path := strings.Split(parts[0], ".")
+	for i := range path {
+		path[i] = strings.TrimSpace(path[i])
+		if key, ok := strings.CutSuffix(path[i], expandSuffix); ok {
+			if expand != nil {
+				return MetadataFilter{}, errInvalidMetadataFilter
+			}
+			path[i] = strings.TrimSpace(key)
+			expand = path[:i+1]
+		}
+		if path[i] == "" {
+			return MetadataFilter{}, errInvalidMetadataFilter
+		}
+	}
+	if expand != nil {
+		path = path[len(expand):]
+	}
+
+	values := []string{parts[2]}
+	if op != EqualsMetadataOp {
+		values = strings.Split(parts[2], ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+	}
+
+	return MetadataFilter{Expand: expand, Path: path, Operator: op, Values: values}, nil
+}
+
 // Page contains the page metadata that helps navigation.
 
 type Page struct {
-	Total          uint64    `json:"total"`
-	Offset         uint64    `json:"offset"`
-	Limit          uint64    `json:"limit"`
-	OnlyTotal      bool      `json:"only_total"`
-	Order          string    `json:"order,omitempty"`
-	Dir            string    `json:"dir,omitempty"`
-	ID             string    `json:"id,omitempty"`
-	Name           string    `json:"name,omitempty"`
-	Metadata       Metadata  `json:"metadata,omitempty"`
-	Domain         string    `json:"domain,omitempty"`
-	Tags           TagsQuery `json:"tags,omitempty"`
-	Status         Status    `json:"status,omitempty"`
-	Identity       string    `json:"identity,omitempty"`
-	Group          *string   `json:"group,omitempty"`
-	Channel        string    `json:"channel,omitempty"`
-	ConnectionType string    `json:"connection_type,omitempty"`
-	RoleName       string    `json:"role_name,omitempty"`
-	RoleID         string    `json:"role_id,omitempty"`
-	Actions        []string  `json:"actions,omitempty"`
-	AccessType     string    `json:"access_type,omitempty"`
-	IDs            []string  `json:"-"`
-	CreatedFrom    time.Time `json:"created_from,omitempty"`
-	CreatedTo      time.Time `json:"created_to,omitempty"`
+	Total            uint64          `json:"total"`
+	Offset           uint64          `json:"offset"`
+	Limit            uint64          `json:"limit"`
+	OnlyTotal        bool            `json:"only_total"`
+	ApproxCount      bool            `json:"approx_count,omitempty"`
+	Order            string          `json:"order,omitempty"`
+	Dir              string          `json:"dir,omitempty"`
+	ID               string          `json:"id,omitempty"`
+	Name             string          `json:"name,omitempty"`
+	NamePrefix       bool            `json:"name_prefix,omitempty"`
+	Metadata         Metadata        `json:"metadata,omitempty"`
+	MetadataFilter   *MetadataFilter `json:"metadata_filter,omitempty"`
+	Domain           string          `json:"domain,omitempty"`
+	Tags             TagsQuery       `json:"tags,omitempty"`
+	Status           Status          `json:"status,omitempty"`
+	Identity         string          `json:"identity,omitempty"`
+	Group            *string         `json:"group,omitempty"`
+	Channel          string          `json:"channel,omitempty"`
+	UserScoped       bool            `json:"user_scoped,omitempty"`
+	ConnectionType   string          `json:"connection_type,omitempty"`
+	Disconnected     bool            `json:"disconnected,omitempty"`
+	RoleName         string          `json:"role_name,omitempty"`
+	RoleID           string          `json:"role_id,omitempty"`
+	Actions          []string        `json:"actions,omitempty"`
+	AccessType       string          `json:"access_type,omitempty"`
+	IDs              []string        `json:"-"`
+	CreatedFrom      time.Time       `json:"created_from,omitempty"`
+	CreatedTo        time.Time       `json:"created_to,omitempty"`
+	PreserveIDsOrder bool            `json:"-"`
 }
 
 // Metadata represents arbitrary JSON.
@@ -261,6 +416,31 @@ type Metadata map[string]any
 // Credentials represent client credentials: its
 // "identity" which can be a username, email, generated name;
 // and "secret" which can be a password or access token.
+// SecretKey identifies a client by its hashed secret, scoped by the client
+// ID or the domain ID as in a packed client key.
+type SecretKey struct {
+	Secret string
+	ID     string
+	Prefix authn.AuthPrefix
+}
+
+// CacheKey returns the key the client is cached under for the secret key.
+// It is built from the hashed secret, so that secrets aren't kept in
+// plain-text in the cache when they are hashed at rest, and so that the keys
+// of a client can be derived from the stored client.
+func (key SecretKey) CacheKey() string {
+	return key.Prefix.String() + ":" + key.ID + ":" + key.Secret
+}
+
+// cacheKeys returns the keys the client with the given hashed secret is
+// cached under.
+func cacheKeys(id, domainID, hash string) []string {
+	return []string{
+		SecretKey{Secret: hash, ID: id, Prefix: authn.BasicAuth}.CacheKey(),
+		SecretKey{Secret: hash, ID: domainID, Prefix: authn.DomainAuth}.CacheKey(),
+	}
+}
+
 type Credentials struct {
 	Identity string `json:"identity,omitempty"` // username or generated login ID
 	Secret   string `json:"secret,omitempty"`   // password or token
@@ -0,0 +1,94 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"testing"
+
+	"github.com/absmach/supermq/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToMetadataFilter(t *testing.T) {
+	cases := []struct {
+		desc     string
+		filter   string
+		expected clients.MetadataFilter
+		err      bool
+	}{
+		{
+			desc:     "equals filter",
+			filter:   "serial:eq:sn1",
+			expected: clients.MetadataFilter{Path: []string{"serial"}, Operator: clients.EqualsMetadataOp, Values: []string{"sn1"}},
+		},
+		{
+			desc:     "equals filter with comma in value",
+			filter:   "device.name:eq:a,b",
+			expected: clients.MetadataFilter{Path: []string{"device", "name"}, Operator: clients.EqualsMetadataOp, Values: []string{"a,b"}},
+		},
+		{
+			desc:     "in filter with nested path",
+			filter:   "device.serial:in:sn1, sn2",
+			expected: clients.MetadataFilter{Path: []string{"device", "serial"}, Operator: clients.InMetadataOp, Values: []string{"sn1", "sn2"}},
+		},
+		{
+			desc:     "contains filter",
+			filter:   "meters:contains:m1,m2",
+			expected: clients.MetadataFilter{Path: []string{"meters"}, Operator: clients.ContainsMetadataOp, Values: []string{"m1", "m2"}},
+		},
+		{
+			desc:     "in filter with expanded array",
+			filter:   "meters[].serial:in:sn1,sn2",
+			expected: clients.MetadataFilter{Expand: []string{"meters"}, Path: []string{"serial"}, Operator: clients.InMetadataOp, Values: []string{"sn1", "sn2"}},
+		},
+		{
+			desc:     "equals filter with nested expanded array",
+			filter:   "site.meters[].device.serial:eq:sn1",
+			expected: clients.MetadataFilter{Expand: []string{"site", "meters"}, Path: []string{"device", "serial"}, Operator: clients.EqualsMetadataOp, Values: []string{"sn1"}},
+		},
+		{
+			desc:     "equals filter on expanded array elements",
+			filter:   "serials[]:eq:sn1",
+			expected: clients.MetadataFilter{Expand: []string{"serials"}, Path: []string{}, Operator: clients.EqualsMetadataOp, Values: []string{"sn1"}},
+		},
+		{
+			desc:   "multiple expanded arrays",
+			filter: "sites[].meters[].serial:eq:sn1",
+			err:    true,
+		},
+		{
+			desc:   "expanded array without key",
+			filter: "[].serial:eq:sn1",
+			err:    true,
+		},
+		{
+			desc:   "invalid operator",
+			filter: "serial:like:sn1",
+			err:    true,
+		},
+		{
+			desc:   "missing values",
+			filter: "serial:eq:",
+			err:    true,
+		},
+		{
+			desc:   "missing operator",
+			filter: "serial",
+			err:    true,
+		},
+		{
+			desc:   "empty path element",
+			filter: "device..serial:eq:sn1",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			filter, err := clients.ToMetadataFilter(tc.filter)
+			assert.Equal(t, tc.err, err != nil, "ToMetadataFilter() error = %v, expected error %v", err, tc.err)
+			assert.Equal(t, tc.expected, filter, "ToMetadataFilter() = %v, expected %v", filter, tc.expected)
+		})
+	}
+}
@@ -11,4 +11,13 @@ var (
 
 	// ErrDisableClient indicates error in disabling client.
 	ErrDisableClient = errors.New("failed to disable client")
+
+	// ErrCertAuthDisabled indicates that client certificate authentication is not enabled.
+	ErrCertAuthDisabled = errors.New("client certificate authentication is not enabled")
+
+	// ErrInvalidCertificate indicates malformed or expired client certificate.
+	ErrInvalidCertificate = errors.New("invalid client certificate")
+
+	// ErrUnknownKey indicates that the client key is cached as unknown.
+	ErrUnknownKey = errors.New("unknown client key")
 )
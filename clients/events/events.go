@@ -18,6 +18,9 @@ const (
 	clientUpdate       = clientPrefix + "update"
 	clientUpdateTags   = clientPrefix + "update_tags"
 	clientUpdateSecret = clientPrefix + "update_secret"
+	clientUpdateOwner  = clientPrefix + "update_owner"
+	clientAddCert      = clientPrefix + "add_cert"
+	clientRemoveCerts  = clientPrefix + "remove_certs"
 	clientEnable       = clientPrefix + "enable"
 	clientDisable      = clientPrefix + "disable"
 	clientRemove       = clientPrefix + "remove"
@@ -38,6 +41,9 @@ var (
 	_ events.Event = (*removeClientEvent)(nil)
 	_ events.Event = (*setParentGroupEvent)(nil)
 	_ events.Event = (*removeParentGroupEvent)(nil)
+	_ events.Event = (*updateClientOwnerEvent)(nil)
+	_ events.Event = (*addClientCertEvent)(nil)
+	_ events.Event = (*removeClientCertsEvent)(nil)
 )
 
 type createClientEvent struct {
@@ -312,6 +318,46 @@ func (dce removeClientEvent) Encode() (map[string]any, error) {
 	}, nil
 }
 
+type addClientCertEvent struct {
+	clients.Certificate
+	authn.Session
+	requestID string
+}
+
+func (ace addClientCertEvent) Encode() (map[string]any, error) {
+	return map[string]any{
+		"operation":     clientAddCert,
+		"id":            ace.ClientID,
+		"serial_number": ace.SerialNumber,
+		"subject":       ace.Subject,
+		"fingerprint":   ace.Fingerprint,
+		"created_at":    ace.CreatedAt,
+		"domain":        ace.DomainID,
+		"user_id":       ace.UserID,
+		"token_type":    ace.Type.String(),
+		"super_admin":   ace.SuperAdmin,
+		"request_id":    ace.requestID,
+	}, nil
+}
+
+type removeClientCertsEvent struct {
+	id string
+	authn.Session
+	requestID string
+}
+
+func (rce removeClientCertsEvent) Encode() (map[string]any, error) {
+	return map[string]any{
+		"operation":   clientRemoveCerts,
+		"id":          rce.id,
+		"domain":      rce.DomainID,
+		"user_id":     rce.UserID,
+		"token_type":  rce.Type.String(),
+		"super_admin": rce.SuperAdmin,
+		"request_id":  rce.requestID,
+	}, nil
+}
+
 type setParentGroupEvent struct {
 	id            string
 	parentGroupID string
@@ -349,3 +395,23 @@ func (rpge removeParentGroupEvent) Encode() (map[string]any, error) {
 		"request_id":  rpge.requestID,
 	}, nil
 }
+
+type updateClientOwnerEvent struct {
+	id      string
+	ownerID string
+	authn.Session
+	requestID string
+}
+
+func (ucoe updateClientOwnerEvent) Encode() (map[string]any, error) {
+	return map[string]any{
+		"operation":   clientUpdateOwner,
+		"id":          ucoe.id,
+		"owner_id":    ucoe.ownerID,
+		"domain":      ucoe.DomainID,
+		"user_id":     ucoe.UserID,
+		"token_type":  ucoe.Type.String(),
+		"super_admin": ucoe.SuperAdmin,
+		"request_id":  ucoe.requestID,
+	}, nil
+}
@@ -5,6 +5,7 @@ package events
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/absmach/supermq/clients"
 	"github.com/absmach/supermq/pkg/authn"
@@ -21,6 +22,9 @@ const (
 	updateStream       = supermqPrefix + clientUpdate
 	updateTagsStream   = supermqPrefix + clientUpdateTags
 	updateSecretStream = supermqPrefix + clientUpdateSecret
+	updateOwnerStream  = supermqPrefix + clientUpdateOwner
+	addCertStream      = supermqPrefix + clientAddCert
+	removeCertsStream  = supermqPrefix + clientRemoveCerts
 	enableStream       = supermqPrefix + clientEnable
 	disableStream      = supermqPrefix + clientDisable
 	removeStream       = supermqPrefix + clientRemove
@@ -40,12 +44,14 @@ type eventStore struct {
 }
 
 // NewEventStoreMiddleware returns wrapper around clients service that sends
-// events to event store.
-func NewEventStoreMiddleware(ctx context.Context, svc clients.Service, url string) (clients.Service, error) {
+// events to event store. Failures to publish events are logged and don't
+// fail the wrapped operations.
+func NewEventStoreMiddleware(ctx context.Context, svc clients.Service, url string, logger *slog.Logger) (clients.Service, error) {
 	publisher, err := store.NewPublisher(ctx, url)
 	if err != nil {
 		return nil, err
 	}
+	publisher = events.NewBestEffortPublisher(publisher, logger)
 	res := rmEvents.NewRoleManagerEventStore("clients", clientPrefix, svc, publisher)
 
 	return &eventStore{
@@ -103,6 +109,62 @@ func (es *eventStore) UpdateSecret(ctx context.Context, session authn.Session, i
 	return es.update(ctx, session, clientUpdateSecret, updateSecretStream, cli)
 }
 
+func (es *eventStore) UpdateOwner(ctx context.Context, session authn.Session, id, ownerID string) error {
+	if err := es.svc.UpdateOwner(ctx, session, id, ownerID); err != nil {
+		return err
+	}
+
+	event := updateClientOwnerEvent{
+		id:        id,
+		ownerID:   ownerID,
+		Session:   session,
+		requestID: middleware.GetReqID(ctx),
+	}
+
+	if err := es.Publish(ctx, updateOwnerStream, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (es *eventStore) AddCert(ctx context.Context, session authn.Session, id string, certPEM []byte) (clients.Certificate, error) {
+	cert, err := es.svc.AddCert(ctx, session, id, certPEM)
+	if err != nil {
+		return cert, err
+	}
+
+	event := addClientCertEvent{
+		Certificate: cert,
+		Session:     session,
+		requestID:   middleware.GetReqID(ctx),
+	}
+
+	if err := es.Publish(ctx, addCertStream, event); err != nil {
+		return cert, err
+	}
+
+	return cert, nil
+}
+
+func (es *eventStore) RemoveCerts(ctx context.Context, session authn.Session, id string) error {
+	if err := es.svc.RemoveCerts(ctx, session, id); err != nil {
+		return err
+	}
+
+	event := removeClientCertsEvent{
+		id:        id,
+		Session:   session,
+		requestID: middleware.GetReqID(ctx),
+	}
+
+	if err := es.Publish(ctx, removeCertsStream, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (es *eventStore) update(ctx context.Context, session authn.Session, operation, stream string, client clients.Client) (clients.Client, error) {
 	event := updateClientEvent{
 		Client:    client,
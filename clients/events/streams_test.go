@@ -14,6 +14,7 @@ import (
 	"github.com/absmach/supermq/clients/events"
 	"github.com/absmach/supermq/clients/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
@@ -44,7 +45,7 @@ var (
 
 func newEventStoreMiddleware(t *testing.T) (*mocks.Service, clients.Service) {
 	svc := new(mocks.Service)
-	nsvc, err := events.NewEventStoreMiddleware(context.Background(), svc, storeURL)
+	nsvc, err := events.NewEventStoreMiddleware(context.Background(), svc, storeURL, smqlog.NewMock())
 	require.Nil(t, err, fmt.Sprintf("create events store middleware failed with unexpected error: %s", err))
 
 	return svc, nsvc
@@ -0,0 +1,87 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+)
+
+const defSecretsBatchSize = uint64(100)
+
+var errHashSecret = errors.New("failed to hash client secret")
+
+// Hasher specifies an API for hashing client secrets before they are stored.
+// Hashing must be deterministic, since clients are looked up by the hash of
+// the presented secret.
+type Hasher interface {
+	// Hash generates the hashed string from plain-text secret.
+	Hash(secret string) (string, error)
+
+	// IsHashed reports whether the stored secret is already hashed.
+	IsHashed(secret string) bool
+}
+
+// StoredSecrets holds the current and the previous secret stored for a client.
+// PreviousSecret is empty if the client has no secret in the grace period.
+type StoredSecrets struct {
+	ClientID       string
+	Secret         string
+	PreviousSecret string
+}
+
+// BackfillSecretHashes hashes all stored client secrets that are still in
+// plain-text, including previous secrets in their rotation grace period, so
+// that they keep authenticating until the grace period ends. It returns the
+// number of clients whose secrets were hashed.
+func BackfillSecretHashes(ctx context.Context, repo Repository, hasher Hasher) (uint64, error) {
+	var hashed uint64
+	for offset := uint64(0); ; offset += defSecretsBatchSize {
+		secrets, err := repo.RetrieveSecrets(ctx, offset, defSecretsBatchSize)
+		if err != nil {
+			return hashed, err
+		}
+		for _, current := range secrets {
+			replacement, err := hashSecrets(hasher, current)
+			if err != nil {
+				return hashed, err
+			}
+			if replacement == current {
+				continue
+			}
+			switch err := repo.ReplaceSecret(ctx, current, replacement); {
+			case errors.Contains(err, repoerr.ErrNotFound):
+				// Secret has been updated in the meantime.
+				continue
+			case err != nil:
+				return hashed, err
+			}
+			hashed++
+		}
+		if uint64(len(secrets)) < defSecretsBatchSize {
+			return hashed, nil
+		}
+	}
+}
+
+func hashSecrets(hasher Hasher, secrets StoredSecrets) (StoredSecrets, error) {
+	if !hasher.IsHashed(secrets.Secret) {
+		hash, err := hasher.Hash(secrets.Secret)
+		if err != nil {
+			return StoredSecrets{}, errors.Wrap(errHashSecret, err)
+		}
+		secrets.Secret = hash
+	}
+	if secrets.PreviousSecret != "" && !hasher.IsHashed(secrets.PreviousSecret) {
+		hash, err := hasher.Hash(secrets.PreviousSecret)
+		if err != nil {
+			return StoredSecrets{}, errors.Wrap(errHashSecret, err)
+		}
+		secrets.PreviousSecret = hash
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hasher contains the domain concept definitions needed to
+// support SuperMQ clients secret hasher sub-service functionality.
+package hasher
@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package hasher
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const hmacPrefix = "hmac-sha256$"
+
+var errEmptyPepper = errors.New("empty secret hashing pepper")
+
+var (
+	_ clients.Hasher = (*hmacHasher)(nil)
+	_ clients.Hasher = (*plaintextHasher)(nil)
+)
+
+type hmacHasher struct {
+	pepper []byte
+}
+
+// New instantiates a HMAC-SHA256 based hasher implementation keyed with the server pepper.
+func New(pepper string) (clients.Hasher, error) {
+	if pepper == "" {
+		return nil, errEmptyPepper
+	}
+
+	return &hmacHasher{pepper: []byte(pepper)}, nil
+}
+
+func (hh *hmacHasher) Hash(secret string) (string, error) {
+	mac := hmac.New(sha256.New, hh.pepper)
+	if _, err := mac.Write([]byte(secret)); err != nil {
+		return "", err
+	}
+
+	return hmacPrefix + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (hh *hmacHasher) IsHashed(secret string) bool {
+	return strings.HasPrefix(secret, hmacPrefix)
+}
+
+type plaintextHasher struct{}
+
+// NewPlaintext instantiates a hasher which keeps secrets in plain-text.
+// It is used for compatibility with deployments which do not hash secrets.
+func NewPlaintext() clients.Hasher {
+	return &plaintextHasher{}
+}
+
+func (ph *plaintextHasher) Hash(secret string) (string, error) {
+	return secret, nil
+}
+
+func (ph *plaintextHasher) IsHashed(secret string) bool {
+	return true
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package hasher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/clients/hasher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACHasher(t *testing.T) {
+	_, err := hasher.New("")
+	assert.NotNil(t, err, "expected error for empty pepper")
+
+	h, err := hasher.New("pepper")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	other, err := hasher.New("other-pepper")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	hash, err := h.Hash("secret")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	hash2, err := h.Hash("secret")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	otherHash, err := other.Hash("secret")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	assert.Equal(t, hash, hash2, "expected deterministic hash")
+	assert.NotEqual(t, "secret", hash, "expected secret to be hashed")
+	assert.NotEqual(t, hash, otherHash, "expected hash to depend on pepper")
+	assert.True(t, h.IsHashed(hash), "expected hashed secret to be recognized")
+	assert.False(t, h.IsHashed("secret"), "expected plain-text secret not to be recognized")
+}
+
+func TestPlaintextHasher(t *testing.T) {
+	h := hasher.NewPlaintext()
+
+	hash, err := h.Hash("secret")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, "secret", hash)
+	assert.True(t, h.IsHashed("secret"), "expected plain-text hasher to treat secrets as final")
+}
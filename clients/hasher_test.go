@@ -0,0 +1,101 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/clients/hasher"
+	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfillSecretHashes(t *testing.T) {
+	hs, err := hasher.New("pepper")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error creating hasher: %s", err))
+	hash := func(secret string) string {
+		h, err := hs.Hash(secret)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error hashing secret: %s", err))
+		return h
+	}
+
+	plain := clients.StoredSecrets{ClientID: "plain", Secret: "secret"}
+	rotated := clients.StoredSecrets{ClientID: "rotated", Secret: "new-secret", PreviousSecret: "old-secret"}
+	rotatedHashed := clients.StoredSecrets{ClientID: "rotated-hashed", Secret: hash("new-secret"), PreviousSecret: "old-secret"}
+	hashed := clients.StoredSecrets{ClientID: "hashed", Secret: hash("secret"), PreviousSecret: hash("old-secret")}
+	changed := clients.StoredSecrets{ClientID: "changed", Secret: "changed"}
+
+	cases := []struct {
+		desc         string
+		secrets      []clients.StoredSecrets
+		retrieveErr  error
+		replacements map[string]clients.StoredSecrets
+		replaceErr   map[string]error
+		hashed       uint64
+		err          error
+	}{
+		{
+			desc:    "backfill plain-text secrets",
+			secrets: []clients.StoredSecrets{plain, rotated, rotatedHashed},
+			replacements: map[string]clients.StoredSecrets{
+				plain.ClientID:         {ClientID: plain.ClientID, Secret: hash("secret")},
+				rotated.ClientID:       {ClientID: rotated.ClientID, Secret: hash("new-secret"), PreviousSecret: hash("old-secret")},
+				rotatedHashed.ClientID: {ClientID: rotatedHashed.ClientID, Secret: hash("new-secret"), PreviousSecret: hash("old-secret")},
+			},
+			hashed: 3,
+		},
+		{
+			desc:    "skip already hashed secrets",
+			secrets: []clients.StoredSecrets{hashed},
+			hashed:  0,
+		},
+		{
+			desc:    "skip secrets updated in the meantime",
+			secrets: []clients.StoredSecrets{changed, plain},
+			replacements: map[string]clients.StoredSecrets{
+				changed.ClientID: {ClientID: changed.ClientID, Secret: hash("changed")},
+				plain.ClientID:   {ClientID: plain.ClientID, Secret: hash("secret")},
+			},
+			replaceErr: map[string]error{changed.ClientID: repoerr.ErrNotFound},
+			hashed:     1,
+		},
+		{
+			desc:    "backfill with failed update",
+			secrets: []clients.StoredSecrets{plain},
+			replacements: map[string]clients.StoredSecrets{
+				plain.ClientID: {ClientID: plain.ClientID, Secret: hash("secret")},
+			},
+			replaceErr: map[string]error{plain.ClientID: repoerr.ErrUpdateEntity},
+			err:        repoerr.ErrUpdateEntity,
+		},
+		{
+			desc:        "backfill with failed retrieve",
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(climocks.Repository)
+			repo.On("RetrieveSecrets", context.Background(), uint64(0), uint64(100)).Return(tc.secrets, tc.retrieveErr)
+			for _, current := range tc.secrets {
+				if replacement, ok := tc.replacements[current.ClientID]; ok {
+					repo.On("ReplaceSecret", context.Background(), current, replacement).Return(tc.replaceErr[current.ClientID])
+				}
+			}
+			count, err := clients.BackfillSecretHashes(context.Background(), repo, hs)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.hashed, count, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.hashed, count))
+			if tc.err == nil {
+				repo.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/absmach/supermq/auth"
+	chOperations "github.com/absmach/supermq/channels/operations"
 	"github.com/absmach/supermq/clients"
 	"github.com/absmach/supermq/clients/operations"
 	dOperations "github.com/absmach/supermq/domains/operations"
@@ -26,6 +27,9 @@ var (
 	errUpdate                  = errors.New("not authorized to update client")
 	errUpdateTags              = errors.New("not authorized to update client tags")
 	errUpdateSecret            = errors.New("not authorized to update client secret")
+	errUpdateOwner             = errors.New("not authorized to update client owner")
+	errAddCert                 = errors.New("not authorized to add client certificate")
+	errRemoveCerts             = errors.New("not authorized to remove client certificates")
 	errEnable                  = errors.New("not authorized to enable client")
 	errDisable                 = errors.New("not authorized to disable client")
 	errDelete                  = errors.New("not authorized to delete client")
@@ -34,6 +38,8 @@ var (
 	errDomainCreateClients     = errors.New("not authorized to create client in domain")
 	errGroupSetChildClients    = errors.New("not authorized to set child client for group")
 	errGroupRemoveChildClients = errors.New("not authorized to remove child client for group")
+	errChannelListClients      = errors.New("not authorized to list clients of channel")
+	errGroupListClients        = errors.New("not authorized to list clients of group")
 )
 
 var _ clients.Service = (*authorizationMiddleware)(nil)
@@ -105,6 +111,31 @@ func (am *authorizationMiddleware) ListClients(ctx context.Context, session auth
 		session.SuperAdmin = true
 	}
 
+	if !session.SuperAdmin && !pm.UserScoped {
+		switch {
+		case pm.Channel != "":
+			if err := am.authorize(ctx, session, policies.ChannelType, chOperations.OpViewChannel, smqauthz.PolicyReq{
+				Domain:      session.DomainID,
+				SubjectType: policies.UserType,
+				Subject:     session.DomainUserID,
+				ObjectType:  policies.ChannelType,
+				Object:      pm.Channel,
+			}); err != nil {
+				return clients.ClientsPage{}, errors.Wrap(err, errChannelListClients)
+			}
+		case pm.Group != nil && *pm.Group != "":
+			if err := am.authorize(ctx, session, policies.GroupType, gOperations.OpViewGroup, smqauthz.PolicyReq{
+				Domain:      session.DomainID,
+				SubjectType: policies.UserType,
+				Subject:     session.DomainUserID,
+				ObjectType:  policies.GroupType,
+				Object:      *pm.Group,
+			}); err != nil {
+				return clients.ClientsPage{}, errors.Wrap(err, errGroupListClients)
+			}
+		}
+	}
+
 	return am.svc.ListClients(ctx, session, pm)
 }
 
@@ -158,6 +189,60 @@ func (am *authorizationMiddleware) UpdateSecret(ctx context.Context, session aut
 	return am.svc.UpdateSecret(ctx, session, id, key)
 }
 
+func (am *authorizationMiddleware) UpdateOwner(ctx context.Context, session authn.Session, id, ownerID string) error {
+	if err := am.authorize(ctx, session, policies.ClientType, operations.OpUpdateClientOwner, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ClientType,
+		Object:      id,
+	}); err != nil {
+		return errors.Wrap(err, errUpdateOwner)
+	}
+	if err := am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Permission:  policies.MembershipPermission,
+		Subject:     policies.EncodeDomainUserID(session.DomainID, ownerID),
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Object:      session.DomainID,
+		ObjectType:  policies.DomainType,
+	}, nil); err != nil {
+		return errors.Wrap(errors.ErrMissingDomainMember, err)
+	}
+
+	return am.svc.UpdateOwner(ctx, session, id, ownerID)
+}
+
+// Certificates are client credentials, so binding them requires the same
+// permission as updating the client secret.
+func (am *authorizationMiddleware) AddCert(ctx context.Context, session authn.Session, id string, certPEM []byte) (clients.Certificate, error) {
+	if err := am.authorize(ctx, session, policies.ClientType, operations.OpUpdateClientSecret, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ClientType,
+		Object:      id,
+	}); err != nil {
+		return clients.Certificate{}, errors.Wrap(err, errAddCert)
+	}
+
+	return am.svc.AddCert(ctx, session, id, certPEM)
+}
+
+func (am *authorizationMiddleware) RemoveCerts(ctx context.Context, session authn.Session, id string) error {
+	if err := am.authorize(ctx, session, policies.ClientType, operations.OpUpdateClientSecret, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ClientType,
+		Object:      id,
+	}); err != nil {
+		return errors.Wrap(err, errRemoveCerts)
+	}
+
+	return am.svc.RemoveCerts(ctx, session, id)
+}
+
 func (am *authorizationMiddleware) Enable(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
 	if err := am.authorize(ctx, session, policies.ClientType, operations.OpEnableClient, smqauthz.PolicyReq{
 		Domain:      session.DomainID,
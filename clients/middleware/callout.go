@@ -132,6 +132,43 @@ func (cm *calloutMiddleware) UpdateSecret(ctx context.Context, session authn.Ses
 	return cm.svc.UpdateSecret(ctx, session, id, key)
 }
 
+func (cm *calloutMiddleware) UpdateOwner(ctx context.Context, session authn.Session, id, ownerID string) error {
+	params := map[string]any{
+		"entity_id": id,
+		"owner_id":  ownerID,
+	}
+
+	if err := cm.callOut(ctx, session, policies.ClientType, operations.OpUpdateClientOwner, params); err != nil {
+		return err
+	}
+
+	return cm.svc.UpdateOwner(ctx, session, id, ownerID)
+}
+
+func (cm *calloutMiddleware) AddCert(ctx context.Context, session authn.Session, id string, certPEM []byte) (clients.Certificate, error) {
+	params := map[string]any{
+		"entity_id": id,
+	}
+
+	if err := cm.callOut(ctx, session, policies.ClientType, operations.OpUpdateClientSecret, params); err != nil {
+		return clients.Certificate{}, err
+	}
+
+	return cm.svc.AddCert(ctx, session, id, certPEM)
+}
+
+func (cm *calloutMiddleware) RemoveCerts(ctx context.Context, session authn.Session, id string) error {
+	params := map[string]any{
+		"entity_id": id,
+	}
+
+	if err := cm.callOut(ctx, session, policies.ClientType, operations.OpUpdateClientSecret, params); err != nil {
+		return err
+	}
+
+	return cm.svc.RemoveCerts(ctx, session, id)
+}
+
 func (cm *calloutMiddleware) Enable(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
 	params := map[string]any{
 		"entity_id": id,
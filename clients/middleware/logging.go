@@ -182,6 +182,65 @@ func (lm *loggingMiddleware) UpdateSecret(ctx context.Context, session authn.Ses
 	return lm.svc.UpdateSecret(ctx, session, oldSecret, newSecret)
 }
 
+func (lm *loggingMiddleware) UpdateOwner(ctx context.Context, session authn.Session, id, ownerID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("client_id", id),
+			slog.String("owner_id", ownerID),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Update client owner failed", args...)
+			return
+		}
+		lm.logger.Info("Update client owner completed successfully", args...)
+	}(time.Now())
+	return lm.svc.UpdateOwner(ctx, session, id, ownerID)
+}
+
+func (lm *loggingMiddleware) AddCert(ctx context.Context, session authn.Session, id string, certPEM []byte) (cert clients.Certificate, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("client_id", id),
+			slog.Group("certificate",
+				slog.String("serial_number", cert.SerialNumber),
+				slog.String("fingerprint", cert.Fingerprint),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Add client certificate failed", args...)
+			return
+		}
+		lm.logger.Info("Add client certificate completed successfully", args...)
+	}(time.Now())
+	return lm.svc.AddCert(ctx, session, id, certPEM)
+}
+
+func (lm *loggingMiddleware) RemoveCerts(ctx context.Context, session authn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("client_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Remove client certificates failed", args...)
+			return
+		}
+		lm.logger.Info("Remove client certificates completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RemoveCerts(ctx, session, id)
+}
+
 func (lm *loggingMiddleware) Enable(ctx context.Context, session authn.Session, id string) (c clients.Client, err error) {
 	defer func(begin time.Time) {
 		args := []any{
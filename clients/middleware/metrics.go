@@ -89,6 +89,30 @@ func (ms *metricsMiddleware) UpdateSecret(ctx context.Context, session authn.Ses
 	return ms.svc.UpdateSecret(ctx, session, oldSecret, newSecret)
 }
 
+func (ms *metricsMiddleware) UpdateOwner(ctx context.Context, session authn.Session, id, ownerID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_client_owner").Add(1)
+		ms.latency.With("method", "update_client_owner").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.UpdateOwner(ctx, session, id, ownerID)
+}
+
+func (ms *metricsMiddleware) AddCert(ctx context.Context, session authn.Session, id string, certPEM []byte) (clients.Certificate, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_client_cert").Add(1)
+		ms.latency.With("method", "add_client_cert").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.AddCert(ctx, session, id, certPEM)
+}
+
+func (ms *metricsMiddleware) RemoveCerts(ctx context.Context, session authn.Session, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_client_certs").Add(1)
+		ms.latency.With("method", "remove_client_certs").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RemoveCerts(ctx, session, id)
+}
+
 func (ms *metricsMiddleware) Enable(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "enable_client").Add(1)
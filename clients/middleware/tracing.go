@@ -87,7 +87,32 @@ func (tm *tracingMiddleware) UpdateSecret(ctx context.Context, session authn.Ses
 	return tm.svc.UpdateSecret(ctx, session, oldSecret, newSecret)
 }
 
+// UpdateOwner traces the "UpdateOwner" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) UpdateOwner(ctx context.Context, session authn.Session, id, ownerID string) error {
+	ctx, span := tracing.StartSpan(ctx, tm.tracer, "svc_update_client_owner", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("owner_id", ownerID),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateOwner(ctx, session, id, ownerID)
+}
+
 // Enable traces the "Enable" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) AddCert(ctx context.Context, session authn.Session, id string, certPEM []byte) (clients.Certificate, error) {
+	ctx, span := tracing.StartSpan(ctx, tm.tracer, "svc_add_client_cert", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.AddCert(ctx, session, id, certPEM)
+}
+
+func (tm *tracingMiddleware) RemoveCerts(ctx context.Context, session authn.Session, id string) error {
+	ctx, span := tracing.StartSpan(ctx, tm.tracer, "svc_remove_client_certs", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.RemoveCerts(ctx, session, id)
+}
+
 func (tm *tracingMiddleware) Enable(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
 	ctx, span := tracing.StartSpan(ctx, tm.tracer, "svc_enable_client", trace.WithAttributes(attribute.String("id", id)))
 	defer span.End()
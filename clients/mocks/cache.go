@@ -164,6 +164,72 @@ func (_c *Cache_Remove_Call) RunAndReturn(run func(ctx context.Context, clientID
 	return _c
 }
 
+// RemoveUnknown provides a mock function for the type Cache
+func (_mock *Cache) RemoveUnknown(ctx context.Context, clientSecrets ...string) error {
+	var tmpRet mock.Arguments
+	if len(clientSecrets) > 0 {
+		tmpRet = _mock.Called(ctx, clientSecrets)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveUnknown")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = returnFunc(ctx, clientSecrets...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Cache_RemoveUnknown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveUnknown'
+type Cache_RemoveUnknown_Call struct {
+	*mock.Call
+}
+
+// RemoveUnknown is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientSecrets ...string
+func (_e *Cache_Expecter) RemoveUnknown(ctx interface{}, clientSecrets ...interface{}) *Cache_RemoveUnknown_Call {
+	return &Cache_RemoveUnknown_Call{Call: _e.mock.On("RemoveUnknown",
+		append([]interface{}{ctx}, clientSecrets...)...)}
+}
+
+func (_c *Cache_RemoveUnknown_Call) Run(run func(ctx context.Context, clientSecrets ...string)) *Cache_RemoveUnknown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *Cache_RemoveUnknown_Call) Return(err error) *Cache_RemoveUnknown_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Cache_RemoveUnknown_Call) RunAndReturn(run func(ctx context.Context, clientSecrets ...string) error) *Cache_RemoveUnknown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function for the type Cache
 func (_mock *Cache) Save(ctx context.Context, clientSecret string, clientID string) error {
 	ret := _mock.Called(ctx, clientSecret, clientID)
@@ -226,3 +292,60 @@ func (_c *Cache_Save_Call) RunAndReturn(run func(ctx context.Context, clientSecr
 	_c.Call.Return(run)
 	return _c
 }
+
+// SaveUnknown provides a mock function for the type Cache
+func (_mock *Cache) SaveUnknown(ctx context.Context, clientSecret string) error {
+	ret := _mock.Called(ctx, clientSecret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUnknown")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, clientSecret)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Cache_SaveUnknown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveUnknown'
+type Cache_SaveUnknown_Call struct {
+	*mock.Call
+}
+
+// SaveUnknown is a helper method to define mock.On call
+//   - ctx context.Context
+//   - clientSecret string
+func (_e *Cache_Expecter) SaveUnknown(ctx interface{}, clientSecret interface{}) *Cache_SaveUnknown_Call {
+	return &Cache_SaveUnknown_Call{Call: _e.mock.On("SaveUnknown", ctx, clientSecret)}
+}
+
+func (_c *Cache_SaveUnknown_Call) Run(run func(ctx context.Context, clientSecret string)) *Cache_SaveUnknown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Cache_SaveUnknown_Call) Return(err error) *Cache_SaveUnknown_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Cache_SaveUnknown_Call) RunAndReturn(run func(ctx context.Context, clientSecret string) error) *Cache_SaveUnknown_Call {
+	_c.Call.Return(run)
+	return _c
+}
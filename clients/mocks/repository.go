@@ -10,6 +10,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/clients"
 	"github.com/absmach/supermq/pkg/authn"
@@ -739,6 +740,66 @@ func (_c *Repository_RemoveEntityMembers_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// RemoveExpiredSecrets provides a mock function for the type Repository
+func (_mock *Repository) RemoveExpiredSecrets(ctx context.Context) (uint64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpiredSecrets")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RemoveExpiredSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveExpiredSecrets'
+type Repository_RemoveExpiredSecrets_Call struct {
+	*mock.Call
+}
+
+// RemoveExpiredSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) RemoveExpiredSecrets(ctx interface{}) *Repository_RemoveExpiredSecrets_Call {
+	return &Repository_RemoveExpiredSecrets_Call{Call: _e.mock.On("RemoveExpiredSecrets", ctx)}
+}
+
+func (_c *Repository_RemoveExpiredSecrets_Call) Run(run func(ctx context.Context)) *Repository_RemoveExpiredSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RemoveExpiredSecrets_Call) Return(v uint64, err error) *Repository_RemoveExpiredSecrets_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *Repository_RemoveExpiredSecrets_Call) RunAndReturn(run func(ctx context.Context) (uint64, error)) *Repository_RemoveExpiredSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveMemberFromAllRoles provides a mock function for the type Repository
 func (_mock *Repository) RemoveMemberFromAllRoles(ctx context.Context, memberID string) error {
 	ret := _mock.Called(ctx, memberID)
@@ -910,6 +971,69 @@ func (_c *Repository_RemoveRoles_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// ReplaceSecret provides a mock function for the type Repository
+func (_mock *Repository) ReplaceSecret(ctx context.Context, current clients.StoredSecrets, replacement clients.StoredSecrets) error {
+	ret := _mock.Called(ctx, current, replacement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceSecret")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, clients.StoredSecrets, clients.StoredSecrets) error); ok {
+		r0 = returnFunc(ctx, current, replacement)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_ReplaceSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSecret'
+type Repository_ReplaceSecret_Call struct {
+	*mock.Call
+}
+
+// ReplaceSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - current clients.StoredSecrets
+//   - replacement clients.StoredSecrets
+func (_e *Repository_Expecter) ReplaceSecret(ctx interface{}, current interface{}, replacement interface{}) *Repository_ReplaceSecret_Call {
+	return &Repository_ReplaceSecret_Call{Call: _e.mock.On("ReplaceSecret", ctx, current, replacement)}
+}
+
+func (_c *Repository_ReplaceSecret_Call) Run(run func(ctx context.Context, current clients.StoredSecrets, replacement clients.StoredSecrets)) *Repository_ReplaceSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 clients.StoredSecrets
+		if args[1] != nil {
+			arg1 = args[1].(clients.StoredSecrets)
+		}
+		var arg2 clients.StoredSecrets
+		if args[2] != nil {
+			arg2 = args[2].(clients.StoredSecrets)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ReplaceSecret_Call) Return(err error) *Repository_ReplaceSecret_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_ReplaceSecret_Call) RunAndReturn(run func(ctx context.Context, current clients.StoredSecrets, replacement clients.StoredSecrets) error) *Repository_ReplaceSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveAll provides a mock function for the type Repository
 func (_mock *Repository) RetrieveAll(ctx context.Context, pm clients.Page) (clients.ClientsPage, error) {
 	ret := _mock.Called(ctx, pm)
@@ -1336,6 +1460,74 @@ func (_c *Repository_RetrieveBySecret_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// RetrieveBySecrets provides a mock function for the type Repository
+func (_mock *Repository) RetrieveBySecrets(ctx context.Context, keys []clients.SecretKey) (map[clients.SecretKey]clients.Client, error) {
+	ret := _mock.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveBySecrets")
+	}
+
+	var r0 map[clients.SecretKey]clients.Client
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []clients.SecretKey) (map[clients.SecretKey]clients.Client, error)); ok {
+		return returnFunc(ctx, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []clients.SecretKey) map[clients.SecretKey]clients.Client); ok {
+		r0 = returnFunc(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[clients.SecretKey]clients.Client)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []clients.SecretKey) error); ok {
+		r1 = returnFunc(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveBySecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveBySecrets'
+type Repository_RetrieveBySecrets_Call struct {
+	*mock.Call
+}
+
+// RetrieveBySecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []clients.SecretKey
+func (_e *Repository_Expecter) RetrieveBySecrets(ctx interface{}, keys interface{}) *Repository_RetrieveBySecrets_Call {
+	return &Repository_RetrieveBySecrets_Call{Call: _e.mock.On("RetrieveBySecrets", ctx, keys)}
+}
+
+func (_c *Repository_RetrieveBySecrets_Call) Run(run func(ctx context.Context, keys []clients.SecretKey)) *Repository_RetrieveBySecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []clients.SecretKey
+		if args[1] != nil {
+			arg1 = args[1].([]clients.SecretKey)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveBySecrets_Call) Return(secretKeyToClient map[clients.SecretKey]clients.Client, err error) *Repository_RetrieveBySecrets_Call {
+	_c.Call.Return(secretKeyToClient, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveBySecrets_Call) RunAndReturn(run func(ctx context.Context, keys []clients.SecretKey) (map[clients.SecretKey]clients.Client, error)) *Repository_RetrieveBySecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveEntitiesRolesActionsMembers provides a mock function for the type Repository
 func (_mock *Repository) RetrieveEntitiesRolesActionsMembers(ctx context.Context, entityIDs []string) ([]roles.EntityActionRole, []roles.EntityMemberRole, error) {
 	ret := _mock.Called(ctx, entityIDs)
@@ -1618,6 +1810,80 @@ func (_c *Repository_RetrieveRole_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// RetrieveSecrets provides a mock function for the type Repository
+func (_mock *Repository) RetrieveSecrets(ctx context.Context, offset uint64, limit uint64) ([]clients.StoredSecrets, error) {
+	ret := _mock.Called(ctx, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveSecrets")
+	}
+
+	var r0 []clients.StoredSecrets
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]clients.StoredSecrets, error)); ok {
+		return returnFunc(ctx, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) []clients.StoredSecrets); ok {
+		r0 = returnFunc(ctx, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.StoredSecrets)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveSecrets'
+type Repository_RetrieveSecrets_Call struct {
+	*mock.Call
+}
+
+// RetrieveSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - offset uint64
+//   - limit uint64
+func (_e *Repository_Expecter) RetrieveSecrets(ctx interface{}, offset interface{}, limit interface{}) *Repository_RetrieveSecrets_Call {
+	return &Repository_RetrieveSecrets_Call{Call: _e.mock.On("RetrieveSecrets", ctx, offset, limit)}
+}
+
+func (_c *Repository_RetrieveSecrets_Call) Run(run func(ctx context.Context, offset uint64, limit uint64)) *Repository_RetrieveSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveSecrets_Call) Return(storedSecretss []clients.StoredSecrets, err error) *Repository_RetrieveSecrets_Call {
+	_c.Call.Return(storedSecretss, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveSecrets_Call) RunAndReturn(run func(ctx context.Context, offset uint64, limit uint64) ([]clients.StoredSecrets, error)) *Repository_RetrieveSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveUserClients provides a mock function for the type Repository
 func (_mock *Repository) RetrieveUserClients(ctx context.Context, domainID string, userID string, pm clients.Page) (clients.ClientsPage, error) {
 	ret := _mock.Called(ctx, domainID, userID, pm)
@@ -2374,6 +2640,78 @@ func (_c *Repository_RoleRemoveMembers_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// RotateSecret provides a mock function for the type Repository
+func (_mock *Repository) RotateSecret(ctx context.Context, client clients.Client, expiresAt time.Time) (clients.Client, error) {
+	ret := _mock.Called(ctx, client, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateSecret")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, clients.Client, time.Time) (clients.Client, error)); ok {
+		return returnFunc(ctx, client, expiresAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, clients.Client, time.Time) clients.Client); ok {
+		r0 = returnFunc(ctx, client, expiresAt)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, clients.Client, time.Time) error); ok {
+		r1 = returnFunc(ctx, client, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RotateSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateSecret'
+type Repository_RotateSecret_Call struct {
+	*mock.Call
+}
+
+// RotateSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - client clients.Client
+//   - expiresAt time.Time
+func (_e *Repository_Expecter) RotateSecret(ctx interface{}, client interface{}, expiresAt interface{}) *Repository_RotateSecret_Call {
+	return &Repository_RotateSecret_Call{Call: _e.mock.On("RotateSecret", ctx, client, expiresAt)}
+}
+
+func (_c *Repository_RotateSecret_Call) Run(run func(ctx context.Context, client clients.Client, expiresAt time.Time)) *Repository_RotateSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 clients.Client
+		if args[1] != nil {
+			arg1 = args[1].(clients.Client)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RotateSecret_Call) Return(client1 clients.Client, err error) *Repository_RotateSecret_Call {
+	_c.Call.Return(client1, err)
+	return _c
+}
+
+func (_c *Repository_RotateSecret_Call) RunAndReturn(run func(ctx context.Context, client clients.Client, expiresAt time.Time) (clients.Client, error)) *Repository_RotateSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function for the type Repository
 func (_mock *Repository) Save(ctx context.Context, client ...clients.Client) ([]clients.Client, error) {
 	var tmpRet mock.Arguments
@@ -2451,6 +2789,97 @@ func (_c *Repository_Save_Call) RunAndReturn(run func(ctx context.Context, clien
 	return _c
 }
 
+// SaveBatch provides a mock function for the type Repository
+func (_mock *Repository) SaveBatch(ctx context.Context, strict bool, clients1 ...clients.Client) ([]clients.Client, []clients.BatchError, error) {
+	var tmpRet mock.Arguments
+	if len(clients1) > 0 {
+		tmpRet = _mock.Called(ctx, strict, clients1)
+	} else {
+		tmpRet = _mock.Called(ctx, strict)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBatch")
+	}
+
+	var r0 []clients.Client
+	var r1 []clients.BatchError
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool, ...clients.Client) ([]clients.Client, []clients.BatchError, error)); ok {
+		return returnFunc(ctx, strict, clients1...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool, ...clients.Client) []clients.Client); ok {
+		r0 = returnFunc(ctx, strict, clients1...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.Client)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bool, ...clients.Client) []clients.BatchError); ok {
+		r1 = returnFunc(ctx, strict, clients1...)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]clients.BatchError)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, bool, ...clients.Client) error); ok {
+		r2 = returnFunc(ctx, strict, clients1...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// Repository_SaveBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveBatch'
+type Repository_SaveBatch_Call struct {
+	*mock.Call
+}
+
+// SaveBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - strict bool
+//   - clients1 ...clients.Client
+func (_e *Repository_Expecter) SaveBatch(ctx interface{}, strict interface{}, clients1 ...interface{}) *Repository_SaveBatch_Call {
+	return &Repository_SaveBatch_Call{Call: _e.mock.On("SaveBatch",
+		append([]interface{}{ctx, strict}, clients1...)...)}
+}
+
+func (_c *Repository_SaveBatch_Call) Run(run func(ctx context.Context, strict bool, clients1 ...clients.Client)) *Repository_SaveBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		var arg2 []clients.Client
+		var variadicArgs []clients.Client
+		if len(args) > 2 {
+			variadicArgs = args[2].([]clients.Client)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_SaveBatch_Call) Return(clients2 []clients.Client, batchErrors []clients.BatchError, err error) *Repository_SaveBatch_Call {
+	_c.Call.Return(clients2, batchErrors, err)
+	return _c
+}
+
+func (_c *Repository_SaveBatch_Call) RunAndReturn(run func(ctx context.Context, strict bool, clients1 ...clients.Client) ([]clients.Client, []clients.BatchError, error)) *Repository_SaveBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchClients provides a mock function for the type Repository
 func (_mock *Repository) SearchClients(ctx context.Context, pm clients.Page) (clients.ClientsPage, error) {
 	ret := _mock.Called(ctx, pm)
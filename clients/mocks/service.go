@@ -44,6 +44,84 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
+// AddCert provides a mock function for the type Service
+func (_mock *Service) AddCert(ctx context.Context, session authn.Session, id string, certPEM []byte) (clients.Certificate, error) {
+	ret := _mock.Called(ctx, session, id, certPEM)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCert")
+	}
+
+	var r0 clients.Certificate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, []byte) (clients.Certificate, error)); ok {
+		return returnFunc(ctx, session, id, certPEM)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, []byte) clients.Certificate); ok {
+		r0 = returnFunc(ctx, session, id, certPEM)
+	} else {
+		r0 = ret.Get(0).(clients.Certificate)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, []byte) error); ok {
+		r1 = returnFunc(ctx, session, id, certPEM)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_AddCert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCert'
+type Service_AddCert_Call struct {
+	*mock.Call
+}
+
+// AddCert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+//   - certPEM []byte
+func (_e *Service_Expecter) AddCert(ctx interface{}, session interface{}, id interface{}, certPEM interface{}) *Service_AddCert_Call {
+	return &Service_AddCert_Call{Call: _e.mock.On("AddCert", ctx, session, id, certPEM)}
+}
+
+func (_c *Service_AddCert_Call) Run(run func(ctx context.Context, session authn.Session, id string, certPEM []byte)) *Service_AddCert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_AddCert_Call) Return(certificate clients.Certificate, err error) *Service_AddCert_Call {
+	_c.Call.Return(certificate, err)
+	return _c
+}
+
+func (_c *Service_AddCert_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string, certPEM []byte) (clients.Certificate, error)) *Service_AddCert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddRole provides a mock function for the type Service
 func (_mock *Service) AddRole(ctx context.Context, session authn.Session, entityID string, roleName string, optionalActions []string, optionalMembers []string) (roles.RoleProvision, error) {
 	ret := _mock.Called(ctx, session, entityID, roleName, optionalActions, optionalMembers)
@@ -728,6 +806,69 @@ func (_c *Service_ListUserClients_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// RemoveCerts provides a mock function for the type Service
+func (_mock *Service) RemoveCerts(ctx context.Context, session authn.Session, id string) error {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCerts")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_RemoveCerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveCerts'
+type Service_RemoveCerts_Call struct {
+	*mock.Call
+}
+
+// RemoveCerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) RemoveCerts(ctx interface{}, session interface{}, id interface{}) *Service_RemoveCerts_Call {
+	return &Service_RemoveCerts_Call{Call: _e.mock.On("RemoveCerts", ctx, session, id)}
+}
+
+func (_c *Service_RemoveCerts_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_RemoveCerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RemoveCerts_Call) Return(err error) *Service_RemoveCerts_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_RemoveCerts_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_RemoveCerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveEntityMembers provides a mock function for the type Service
 func (_mock *Service) RemoveEntityMembers(ctx context.Context, session authn.Session, entityID string, members []string) error {
 	ret := _mock.Called(ctx, session, entityID, members)
@@ -2093,6 +2234,75 @@ func (_c *Service_Update_Call) RunAndReturn(run func(ctx context.Context, sessio
 	return _c
 }
 
+// UpdateOwner provides a mock function for the type Service
+func (_mock *Service) UpdateOwner(ctx context.Context, session authn.Session, id string, ownerID string) error {
+	ret := _mock.Called(ctx, session, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOwner")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) error); ok {
+		r0 = returnFunc(ctx, session, id, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_UpdateOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOwner'
+type Service_UpdateOwner_Call struct {
+	*mock.Call
+}
+
+// UpdateOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+//   - ownerID string
+func (_e *Service_Expecter) UpdateOwner(ctx interface{}, session interface{}, id interface{}, ownerID interface{}) *Service_UpdateOwner_Call {
+	return &Service_UpdateOwner_Call{Call: _e.mock.On("UpdateOwner", ctx, session, id, ownerID)}
+}
+
+func (_c *Service_UpdateOwner_Call) Run(run func(ctx context.Context, session authn.Session, id string, ownerID string)) *Service_UpdateOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_UpdateOwner_Call) Return(err error) *Service_UpdateOwner_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_UpdateOwner_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string, ownerID string) error) *Service_UpdateOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateRoleName provides a mock function for the type Service
 func (_mock *Service) UpdateRoleName(ctx context.Context, session authn.Session, entityID string, roleID string, newRoleName string) (roles.Role, error) {
 	ret := _mock.Called(ctx, session, entityID, roleID, newRoleName)
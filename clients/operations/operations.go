@@ -21,6 +21,7 @@ const (
 	OpConnectToChannel
 	OpDisconnectFromChannel
 	OpListUserClients
+	OpUpdateClientOwner
 )
 
 func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
@@ -73,5 +74,9 @@ func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
 			Name:               "list_user_clients",
 			PermissionRequired: false, // hardcoded to superadmin
 		},
+		OpUpdateClientOwner: {
+			Name:               "update_owner",
+			PermissionRequired: true,
+		},
 	}
 }
@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+var _ clients.CertificateRepository = (*certRepo)(nil)
+
+type certRepo struct {
+	DB postgres.Database
+	eh errors.Handler
+}
+
+// NewCertificateRepository instantiates a PostgreSQL
+// implementation of client certificates repository.
+func NewCertificateRepository(db postgres.Database) clients.CertificateRepository {
+	return &certRepo{
+		DB: db,
+		eh: postgres.NewErrorHandler(),
+	}
+}
+
+func (repo *certRepo) Save(ctx context.Context, cert clients.Certificate) error {
+	q := `INSERT INTO client_certs (client_id, serial_number, subject, fingerprint, created_at)
+	VALUES (:client_id, :serial_number, :subject, :fingerprint, :created_at)`
+
+	if _, err := repo.DB.NamedExecContext(ctx, q, toDBCertificate(cert)); err != nil {
+		return repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *certRepo) RetrieveBySerial(ctx context.Context, serial, subject string) (clients.Certificate, error) {
+	q := fmt.Sprintf(`SELECT cc.client_id, cc.serial_number, cc.subject, cc.fingerprint, cc.created_at
+        FROM client_certs cc JOIN clients c ON c.id = cc.client_id
+        WHERE cc.serial_number = :serial_number AND cc.subject = :subject AND c.status = %d`, clients.EnabledStatus)
+
+	dbcert := dbCertificate{
+		SerialNumber: serial,
+		Subject:      subject,
+	}
+
+	rows, err := repo.DB.NamedQueryContext(ctx, q, dbcert)
+	if err != nil {
+		return clients.Certificate{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dbcert = dbCertificate{}
+	if rows.Next() {
+		if err := rows.StructScan(&dbcert); err != nil {
+			return clients.Certificate{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
+
+		return toCertificate(dbcert), nil
+	}
+
+	return clients.Certificate{}, repoerr.ErrNotFound
+}
+
+func (repo *certRepo) Remove(ctx context.Context, clientID string) error {
+	q := `DELETE FROM client_certs WHERE client_id = :client_id`
+
+	if _, err := repo.DB.NamedExecContext(ctx, q, dbCertificate{ClientID: clientID}); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbCertificate struct {
+	ClientID     string    `db:"client_id"`
+	SerialNumber string    `db:"serial_number"`
+	Subject      string    `db:"subject"`
+	Fingerprint  string    `db:"fingerprint"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+func toDBCertificate(cert clients.Certificate) dbCertificate {
+	return dbCertificate{
+		ClientID:     cert.ClientID,
+		SerialNumber: cert.SerialNumber,
+		Subject:      cert.Subject,
+		Fingerprint:  cert.Fingerprint,
+		CreatedAt:    cert.CreatedAt,
+	}
+}
+
+func toCertificate(dbcert dbCertificate) clients.Certificate {
+	return clients.Certificate{
+		ClientID:     dbcert.ClientID,
+		SerialNumber: dbcert.SerialNumber,
+		Subject:      dbcert.Subject,
+		Fingerprint:  dbcert.Fingerprint,
+		CreatedAt:    dbcert.CreatedAt,
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/clients/postgres"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateRetrieveBySerial(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+	crepo := postgres.NewCertificateRepository(database)
+
+	enabled := clients.Client{
+		ID:          testsutil.GenerateUUID(t),
+		Name:        clientName,
+		Credentials: clients.Credentials{Secret: testsutil.GenerateUUID(t)},
+		Domain:      testsutil.GenerateUUID(t),
+		Status:      clients.EnabledStatus,
+	}
+	disabled := clients.Client{
+		ID:          testsutil.GenerateUUID(t),
+		Name:        clientName,
+		Credentials: clients.Credentials{Secret: testsutil.GenerateUUID(t)},
+		Domain:      testsutil.GenerateUUID(t),
+		Status:      clients.DisabledStatus,
+	}
+	_, err := repo.Save(context.Background(), enabled, disabled)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cert := clients.Certificate{
+		ClientID:     enabled.ID,
+		SerialNumber: "1",
+		Subject:      "CN=enabled",
+		Fingerprint:  "fingerprint",
+		CreatedAt:    time.Now().UTC().Truncate(time.Microsecond),
+	}
+	err = crepo.Save(context.Background(), cert)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = crepo.Save(context.Background(), clients.Certificate{ClientID: disabled.ID, SerialNumber: "2", Subject: "CN=disabled", Fingerprint: "fingerprint"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		serial   string
+		subject  string
+		response clients.Certificate
+		err      error
+	}{
+		{
+			desc:     "retrieve certificate successfully",
+			serial:   cert.SerialNumber,
+			subject:  cert.Subject,
+			response: cert,
+		},
+		{
+			desc:    "retrieve certificate with invalid subject",
+			serial:  cert.SerialNumber,
+			subject: "CN=invalid",
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:    "retrieve certificate of disabled client",
+			serial:  "2",
+			subject: "CN=disabled",
+			err:     repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := crepo.RetrieveBySerial(context.Background(), tc.serial, tc.subject)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response.ClientID, res.ClientID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response.ClientID, res.ClientID))
+			assert.Equal(t, tc.response.Fingerprint, res.Fingerprint, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response.Fingerprint, res.Fingerprint))
+		})
+	}
+
+	err = crepo.Remove(context.Background(), enabled.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = crepo.RetrieveBySerial(context.Background(), cert.SerialNumber, cert.Subject)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("retrieve removed certificate: expected %s got %s\n", repoerr.ErrNotFound, err))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/absmach/supermq/pkg/roles"
 	rolesPostgres "github.com/absmach/supermq/pkg/roles/repo/postgres"
 	"github.com/jackc/pgtype"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 )
 
@@ -91,10 +92,64 @@ func (repo *clientRepo) Save(ctx context.Context, cls ...clients.Client) ([]clie
 	return reClients, nil
 }
 
+func (repo *clientRepo) SaveBatch(ctx context.Context, strict bool, cls ...clients.Client) ([]clients.Client, []clients.BatchError, error) {
+	q := `INSERT INTO clients (id, name, tags, domain_id, parent_group_id, identity, secret, metadata, private_metadata, created_at, updated_at, updated_by, status)
+	VALUES (:id, :name, :tags, :domain_id, :parent_group_id, :identity, :secret, :metadata, :private_metadata, :created_at, :updated_at, :updated_by, :status)`
+
+	var saved []clients.Client
+	var failed []clients.BatchError
+	err := postgres.WithinTx(ctx, repo.DB, func(tx *sqlx.Tx) error {
+		for i, client := range cls {
+			// Each client is saved under its own savepoint, so a failed
+			// insert doesn't abort the rest of the transaction.
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT save_client"); err != nil {
+				return err
+			}
+			c, err := repo.saveInTx(ctx, tx, q, client)
+			if err != nil {
+				failed = append(failed, clients.BatchError{Index: i, Err: err})
+				if strict {
+					return err
+				}
+				if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT save_client"); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT save_client"); err != nil {
+				return err
+			}
+			saved = append(saved, c)
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+		return saved, failed, nil
+	case strict && len(failed) > 0:
+		// The error of the failed client is already mapped.
+		return nil, failed, err
+	default:
+		return nil, nil, repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+	}
+}
+
+func (repo *clientRepo) saveInTx(ctx context.Context, tx *sqlx.Tx, q string, client clients.Client) (clients.Client, error) {
+	dbcli, err := ToDBClient(client)
+	if err != nil {
+		return clients.Client{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := tx.NamedExecContext(ctx, q, dbcli); err != nil {
+		return clients.Client{}, repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return ToClient(dbcli)
+}
+
 func (repo *clientRepo) RetrieveBySecret(ctx context.Context, key, id string, prefix authn.AuthPrefix) (clients.Client, error) {
 	q := fmt.Sprintf(`SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id,  COALESCE(parent_group_id, '') AS parent_group_id, identity, secret, metadata, private_metadata, created_at, updated_at, updated_by, status
         FROM clients
-        WHERE secret = :secret AND status = %d`, clients.EnabledStatus)
+        WHERE (secret = :secret OR (previous_secret = :secret AND previous_secret_expires_at > NOW())) AND status = %d`, clients.EnabledStatus)
 	switch prefix {
 	case authn.DomainAuth:
 		q += " AND domain_id = :domain_id"
@@ -110,7 +165,8 @@ func (repo *clientRepo) RetrieveBySecret(ctx context.Context, key, id string, pr
 		ID:     id,
 	}
 
-	rows, err := repo.DB.NamedQueryContext(ctx, q, dbc)
+	// Secrets must be checked against the latest state, not a lagging replica.
+	rows, err := repo.DB.NamedQueryContext(postgres.WithPrimary(ctx), q, dbc)
 	if err != nil {
 		return clients.Client{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
 	}
@@ -133,6 +189,71 @@ func (repo *clientRepo) RetrieveBySecret(ctx context.Context, key, id string, pr
 	return clients.Client{}, repoerr.ErrNotFound
 }
 
+func (repo *clientRepo) RetrieveBySecrets(ctx context.Context, keys []clients.SecretKey) (map[clients.SecretKey]clients.Client, error) {
+	var secrets, ids pq.StringArray
+	var domainScoped pq.BoolArray
+	for _, key := range keys {
+		if key.Prefix != authn.DomainAuth && key.Prefix != authn.BasicAuth {
+			continue
+		}
+		secrets = append(secrets, key.Secret)
+		ids = append(ids, key.ID)
+		domainScoped = append(domainScoped, key.Prefix == authn.DomainAuth)
+	}
+
+	ret := make(map[clients.SecretKey]clients.Client)
+	if len(secrets) == 0 {
+		return ret, nil
+	}
+
+	// Keys are passed as arrays and matched with a join, so all of them
+	// are resolved with a single query.
+	q := fmt.Sprintf(`SELECT k.secret AS key_secret, k.scope_id, k.domain_scoped, c.id, COALESCE(c.domain_id, '') AS domain_id, c.secret
+        FROM unnest(CAST(:secrets AS TEXT[]), CAST(:ids AS TEXT[]), CAST(:domain_scoped AS BOOLEAN[])) AS k(secret, scope_id, domain_scoped)
+        JOIN clients c ON (c.secret = k.secret OR (c.previous_secret = k.secret AND c.previous_secret_expires_at > NOW()))
+            AND CASE WHEN k.domain_scoped THEN c.domain_id = k.scope_id ELSE c.id = k.scope_id END
+        WHERE c.status = %d`, clients.EnabledStatus)
+	params := map[string]any{
+		"secrets":       secrets,
+		"ids":           ids,
+		"domain_scoped": domainScoped,
+	}
+
+	rows, err := repo.DB.NamedQueryContext(postgres.WithPrimary(ctx), q, params)
+	if err != nil {
+		return nil, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbk struct {
+			KeySecret    string `db:"key_secret"`
+			ScopeID      string `db:"scope_id"`
+			DomainScoped bool   `db:"domain_scoped"`
+			ID           string `db:"id"`
+			Domain       string `db:"domain_id"`
+			Secret       string `db:"secret"`
+		}
+		if err := rows.StructScan(&dbk); err != nil {
+			return nil, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
+		key := clients.SecretKey{Secret: dbk.KeySecret, ID: dbk.ScopeID, Prefix: authn.BasicAuth}
+		if dbk.DomainScoped {
+			key.Prefix = authn.DomainAuth
+		}
+		ret[key] = clients.Client{
+			ID:          dbk.ID,
+			Domain:      dbk.Domain,
+			Credentials: clients.Credentials{Secret: dbk.Secret},
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return ret, nil
+}
+
 func (repo *clientRepo) Update(ctx context.Context, client clients.Client) (clients.Client, error) {
 	var query []string
 	var upq string
@@ -149,12 +270,25 @@ func (repo *clientRepo) Update(ctx context.Context, client clients.Client) (clie
 		upq = strings.Join(query, " ")
 	}
 
+	var precond string
+	if !client.UnmodifiedSince.IsZero() {
+		precond = "AND COALESCE(updated_at, created_at) <= :unmodified_since"
+	}
+
 	q := fmt.Sprintf(`UPDATE clients SET %s updated_at = :updated_at, updated_by = :updated_by
-        WHERE id = :id AND status = :status
+        WHERE id = :id AND status = :status %s
         RETURNING id, name, tags, identity, secret, metadata, private_metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`,
-		upq)
+		upq, precond)
 	client.Status = clients.EnabledStatus
-	return repo.update(ctx, client, q)
+	c, err := repo.update(ctx, client, q)
+	if err != nil && precond != "" && errors.Contains(err, repoerr.ErrNotFound) {
+		// No row matched, so tell a concurrent modification apart from a missing client.
+		if cur, rerr := repo.RetrieveByID(ctx, client.ID); rerr == nil && cur.Status == clients.EnabledStatus {
+			return clients.Client{}, repoerr.ErrPreconditionFailed
+		}
+	}
+
+	return c, err
 }
 
 func (repo *clientRepo) UpdateTags(ctx context.Context, client clients.Client) (clients.Client, error) {
@@ -174,13 +308,90 @@ func (repo *clientRepo) UpdateIdentity(ctx context.Context, client clients.Clien
 }
 
 func (repo *clientRepo) UpdateSecret(ctx context.Context, client clients.Client) (clients.Client, error) {
-	q := `UPDATE clients SET secret = :secret, updated_at = :updated_at, updated_by = :updated_by
+	q := `UPDATE clients SET secret = :secret, previous_secret = NULL, previous_secret_expires_at = NULL, updated_at = :updated_at, updated_by = :updated_by
         WHERE id = :id AND status = :status
         RETURNING id, name, tags, identity, metadata, private_metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`
 	client.Status = clients.EnabledStatus
 	return repo.update(ctx, client, q)
 }
 
+func (repo *clientRepo) RotateSecret(ctx context.Context, client clients.Client, expiresAt time.Time) (clients.Client, error) {
+	q := `UPDATE clients SET previous_secret = secret, previous_secret_expires_at = :previous_secret_expires_at, secret = :secret, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id AND status = :status
+        RETURNING id, name, tags, identity, metadata, private_metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`
+	client.Status = clients.EnabledStatus
+	dbc, err := ToDBClient(client)
+	if err != nil {
+		return clients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	dbc.PreviousSecretExpiresAt = sql.NullTime{Time: expiresAt, Valid: true}
+
+	return repo.updateDBClient(ctx, dbc, q)
+}
+
+func (repo *clientRepo) RemoveExpiredSecrets(ctx context.Context) (uint64, error) {
+	q := `UPDATE clients SET previous_secret = NULL, previous_secret_expires_at = NULL
+        WHERE previous_secret_expires_at IS NOT NULL AND previous_secret_expires_at <= NOW()`
+
+	result, err := repo.DB.ExecContext(ctx, q)
+	if err != nil {
+		return 0, repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return uint64(rows), nil
+}
+
+func (repo *clientRepo) RetrieveSecrets(ctx context.Context, offset, limit uint64) ([]clients.StoredSecrets, error) {
+	q := `SELECT id, secret, COALESCE(previous_secret, '') AS previous_secret FROM clients ORDER BY id LIMIT :limit OFFSET :offset`
+
+	params := map[string]any{
+		"limit":  limit,
+		"offset": offset,
+	}
+	rows, err := repo.DB.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var secrets []clients.StoredSecrets
+	for rows.Next() {
+		var s clients.StoredSecrets
+		if err := rows.Scan(&s.ClientID, &s.Secret, &s.PreviousSecret); err != nil {
+			return nil, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
+		secrets = append(secrets, s)
+	}
+
+	return secrets, nil
+}
+
+func (repo *clientRepo) ReplaceSecret(ctx context.Context, current, replacement clients.StoredSecrets) error {
+	q := `UPDATE clients SET secret = :secret, previous_secret = NULLIF(:previous_secret, '')
+        WHERE id = :id AND secret = :current_secret AND COALESCE(previous_secret, '') = :current_previous_secret`
+
+	params := map[string]any{
+		"id":                      current.ClientID,
+		"current_secret":          current.Secret,
+		"current_previous_secret": current.PreviousSecret,
+		"secret":                  replacement.Secret,
+		"previous_secret":         replacement.PreviousSecret,
+	}
+	result, err := repo.DB.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
 func (repo *clientRepo) ChangeStatus(ctx context.Context, client clients.Client) (clients.Client, error) {
 	q := `UPDATE clients SET status = :status, updated_at = :updated_at, updated_by = :updated_by
 		WHERE id = :id
@@ -425,7 +636,7 @@ func (repo *clientRepo) RetrieveAll(ctx context.Context, pm clients.Page) (clien
 					clients c
 	`
 
-	if pm.Channel != "" {
+	if pm.Channel != "" && !pm.Disconnected {
 		connJoinQuery = `
 			,conn.connection_types
 			FROM
@@ -504,7 +715,13 @@ func (repo *clientRepo) RetrieveAll(ctx context.Context, pm clients.Page) (clien
 			) AS sub_query;
 			`, comQuery)
 
-	total, err := postgres.Total(ctx, repo.DB, cq, dbPage)
+	var total uint64
+	switch {
+	case pm.ApproxCount:
+		total, err = postgres.EstimatedTotal(ctx, repo.DB, comQuery, dbPage)
+	default:
+		total, err = postgres.Total(ctx, repo.DB, cq, dbPage)
+	}
 	if err != nil {
 		return clients.ClientsPage{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
 	}
@@ -540,7 +757,7 @@ func (repo *clientRepo) retrieveClients(ctx context.Context, domainID, userID st
 
 	connCountJoinQuery := connJoinQuery
 
-	if pm.Channel != "" {
+	if pm.Channel != "" && !pm.Disconnected {
 		connCountJoinQuery = `
 			FROM
 					final_clients c
@@ -567,13 +784,7 @@ func (repo *clientRepo) retrieveClients(ctx context.Context, domainID, userID st
 	dbPage.DomainID = domainID
 
 	if pm.OnlyTotal {
-		cq := fmt.Sprintf(`%s
-			SELECT COUNT(*) AS total_count
-			%s
-			%s;
-		`, bq, connCountJoinQuery, pageQuery)
-
-		total, err := postgres.Total(ctx, repo.DB, cq, dbPage)
+		total, err := repo.userClientsTotal(ctx, bq, connCountJoinQuery, pageQuery, pm.ApproxCount, dbPage)
 		if err != nil {
 			return clients.ClientsPage{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
 		}
@@ -587,6 +798,12 @@ func (repo *clientRepo) retrieveClients(ctx context.Context, domainID, userID st
 		}, nil
 	}
 
+	// The estimate replaces the window count, which has to read all the matching rows.
+	totalColumn := "COUNT(*) OVER() AS total_count"
+	if pm.ApproxCount {
+		totalColumn = "0 AS total_count"
+	}
+
 	q := fmt.Sprintf(`
 				%s
 				SELECT
@@ -611,10 +828,10 @@ func (repo *clientRepo) retrieveClients(ctx context.Context, domainID, userID st
 					c.access_provider_role_id,
 					c.access_provider_role_name,
 					c.access_provider_role_actions,
-					COUNT(*) OVER() AS total_count
+					%s
 				%s
 				%s
-	`, bq, connJoinQuery, pageQuery)
+	`, bq, totalColumn, connJoinQuery, pageQuery)
 
 	q = applyOrdering(q, pm)
 
@@ -644,14 +861,8 @@ func (repo *clientRepo) retrieveClients(ctx context.Context, domainID, userID st
 		items = append(items, c)
 	}
 
-	if len(items) == 0 {
-		cq := fmt.Sprintf(`%s
-			SELECT COUNT(*) AS total_count
-			%s
-			%s;
-		`, bq, connCountJoinQuery, pageQuery)
-
-		total, err = postgres.Total(ctx, repo.DB, cq, dbPage)
+	if len(items) == 0 || pm.ApproxCount {
+		total, err = repo.userClientsTotal(ctx, bq, connCountJoinQuery, pageQuery, pm.ApproxCount, dbPage)
 		if err != nil {
 			return clients.ClientsPage{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
 		}
@@ -667,6 +878,27 @@ func (repo *clientRepo) retrieveClients(ctx context.Context, domainID, userID st
 	}, nil
 }
 
+// userClientsTotal returns the number of the clients accessible to the user,
+// or the planner's estimate of it if approx is set.
+func (repo *clientRepo) userClientsTotal(ctx context.Context, bq, joinQuery, pageQuery string, approx bool, dbPage dbClientsPage) (uint64, error) {
+	if approx {
+		q := fmt.Sprintf(`%s
+			SELECT c.id
+			%s
+			%s
+		`, bq, joinQuery, pageQuery)
+
+		return postgres.EstimatedTotal(ctx, repo.DB, q, dbPage)
+	}
+	cq := fmt.Sprintf(`%s
+		SELECT COUNT(*) AS total_count
+		%s
+		%s;
+	`, bq, joinQuery, pageQuery)
+
+	return postgres.Total(ctx, repo.DB, cq, dbPage)
+}
+
 const userClientBaseQuery = `
 	WITH direct_clients AS (
 		SELECT
@@ -1001,6 +1233,10 @@ func (repo *clientRepo) update(ctx context.Context, client clients.Client, query
 		return clients.Client{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
 	}
 
+	return repo.updateDBClient(ctx, dbc, query)
+}
+
+func (repo *clientRepo) updateDBClient(ctx context.Context, dbc DBClient, query string) (clients.Client, error) {
 	row, err := repo.DB.NamedQueryContext(ctx, query, dbc)
 	if err != nil {
 		return clients.Client{}, repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
@@ -1044,6 +1280,7 @@ type DBClient struct {
 	Domain                    string           `db:"domain_id"`
 	ParentGroup               sql.NullString   `db:"parent_group_id,omitempty"`
 	Secret                    string           `db:"secret"`
+	PreviousSecretExpiresAt   sql.NullTime     `db:"previous_secret_expires_at,omitempty"`
 	Metadata                  []byte           `db:"metadata,omitempty"`
 	PrivateMetadata           []byte           `db:"private_metadata,omitempty"`
 	CreatedAt                 time.Time        `db:"created_at,omitempty"`
@@ -1063,6 +1300,7 @@ type DBClient struct {
 	MemberID                  string           `db:"member_id,omitempty"`
 	Roles                     json.RawMessage  `db:"roles,omitempty"`
 	TotalCount                uint64           `db:"total_count"`
+	UnmodifiedSince           time.Time        `db:"unmodified_since,omitempty"`
 }
 
 func ToDBClient(c clients.Client) (DBClient, error) {
@@ -1109,6 +1347,7 @@ func ToDBClient(c clients.Client) (DBClient, error) {
 		UpdatedAt:       updatedAt,
 		UpdatedBy:       updatedBy,
 		Status:          c.Status,
+		UnmodifiedSince: c.UnmodifiedSince,
 	}, nil
 }
 
@@ -1196,57 +1435,81 @@ func ToDBClientsPage(pm clients.Page) (dbClientsPage, error) {
 	if err := tags.Set(pm.Tags.Elements); err != nil {
 		return dbClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
+	var metaExpand, metaPath, metaValues pq.StringArray
+	var metaContains []byte
+	if pm.MetadataFilter != nil {
+		metaExpand = pq.StringArray(pm.MetadataFilter.Expand)
+		metaPath = pq.StringArray(pm.MetadataFilter.Path)
+		metaValues = pq.StringArray(pm.MetadataFilter.Values)
+		if metaContains, err = json.Marshal(pm.MetadataFilter.Values); err != nil {
+			return dbClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
 	return dbClientsPage{
-		Offset:      pm.Offset,
-		Limit:       pm.Limit,
-		Name:        pm.Name,
-		Identity:    pm.Identity,
-		Id:          pm.ID,
-		Metadata:    data,
-		Domain:      pm.Domain,
-		Status:      pm.Status,
-		Tags:        tags,
-		GroupID:     pm.Group,
-		ChannelID:   pm.Channel,
-		RoleName:    pm.RoleName,
-		ConnType:    pm.ConnectionType,
-		RoleID:      pm.RoleID,
-		Actions:     pm.Actions,
-		AccessType:  pm.AccessType,
-		IDs:         pq.StringArray(pm.IDs),
-		CreatedFrom: pm.CreatedFrom,
-		CreatedTo:   pm.CreatedTo,
+		Offset:       pm.Offset,
+		Limit:        pm.Limit,
+		Name:         postgres.EscapeLike(pm.Name),
+		Identity:     postgres.EscapeLike(pm.Identity),
+		Id:           pm.ID,
+		Metadata:     data,
+		MetaExpand:   metaExpand,
+		MetaPath:     metaPath,
+		MetaValues:   metaValues,
+		MetaContains: metaContains,
+		Domain:       pm.Domain,
+		Status:       pm.Status,
+		Tags:         tags,
+		GroupID:      pm.Group,
+		ChannelID:    pm.Channel,
+		RoleName:     pm.RoleName,
+		ConnType:     pm.ConnectionType,
+		RoleID:       pm.RoleID,
+		Actions:      pm.Actions,
+		AccessType:   pm.AccessType,
+		IDs:          pq.StringArray(pm.IDs),
+		CreatedFrom:  pm.CreatedFrom,
+		CreatedTo:    pm.CreatedTo,
 	}, nil
 }
 
 type dbClientsPage struct {
-	Limit       uint64           `db:"limit"`
-	Offset      uint64           `db:"offset"`
-	Name        string           `db:"name"`
-	Id          string           `db:"id"`
-	Domain      string           `db:"domain_id"`
-	Identity    string           `db:"identity"`
-	Metadata    []byte           `db:"metadata"`
-	Tags        pgtype.TextArray `db:"tags"`
-	Status      clients.Status   `db:"status"`
-	GroupID     *string          `db:"group_id"`
-	ChannelID   string           `db:"channel_id"`
-	ConnType    string           `db:"type"`
-	RoleName    string           `db:"role_name"`
-	RoleID      string           `db:"role_id"`
-	Actions     pq.StringArray   `db:"actions"`
-	AccessType  string           `db:"access_type"`
-	CreatedFrom time.Time        `db:"created_from"`
-	CreatedTo   time.Time        `db:"created_to"`
-	IDs         pq.StringArray   `db:"ids"`
-	UserID      string           `db:"user_id"`
-	DomainID    string           `db:"domain_id_param"`
+	Limit        uint64           `db:"limit"`
+	Offset       uint64           `db:"offset"`
+	Name         string           `db:"name"`
+	Id           string           `db:"id"`
+	Domain       string           `db:"domain_id"`
+	Identity     string           `db:"identity"`
+	Metadata     []byte           `db:"metadata"`
+	MetaExpand   pq.StringArray   `db:"metadata_expand"`
+	MetaPath     pq.StringArray   `db:"metadata_path"`
+	MetaValues   pq.StringArray   `db:"metadata_values"`
+	MetaContains []byte           `db:"metadata_contains"`
+	Tags         pgtype.TextArray `db:"tags"`
+	Status       clients.Status   `db:"status"`
+	GroupID      *string          `db:"group_id"`
+	ChannelID    string           `db:"channel_id"`
+	ConnType     string           `db:"type"`
+	RoleName     string           `db:"role_name"`
+	RoleID       string           `db:"role_id"`
+	Actions      pq.StringArray   `db:"actions"`
+	AccessType   string           `db:"access_type"`
+	CreatedFrom  time.Time        `db:"created_from"`
+	CreatedTo    time.Time        `db:"created_to"`
+	IDs          pq.StringArray   `db:"ids"`
+	UserID       string           `db:"user_id"`
+	DomainID     string           `db:"domain_id_param"`
 }
 
 func PageQuery(pm clients.Page) (string, error) {
 	var query []string
+	// Both name matching modes can use a trigram index on the client name:
+	// CREATE INDEX ON clients USING GIN (name gin_trgm_ops).
 	if pm.Name != "" {
-		query = append(query, "c.name ILIKE '%' || :name || '%'")
+		if pm.NamePrefix {
+			query = append(query, "c.name ILIKE :name || '%'")
+		} else {
+			query = append(query, "c.name ILIKE '%' || :name || '%'")
+		}
 	}
 	if pm.Identity != "" {
 		query = append(query, "c.identity ILIKE '%' || :identity || '%'")
@@ -1257,9 +1520,9 @@ func PageQuery(pm clients.Page) (string, error) {
 	if len(pm.Tags.Elements) > 0 {
 		switch pm.Tags.Operator {
 		case clients.AndOp:
-			query = append(query, "tags @> :tags")
+			query = append(query, "c.tags @> :tags")
 		default: // OR
-			query = append(query, "tags && :tags")
+			query = append(query, "c.tags && :tags")
 		}
 	}
 	if len(pm.IDs) != 0 {
@@ -1282,7 +1545,10 @@ func PageQuery(pm clients.Page) (string, error) {
 		}
 	}
 
-	if pm.Channel != "" {
+	switch {
+	case pm.Channel != "" && pm.Disconnected:
+		query = append(query, "c.id NOT IN (SELECT client_id FROM connections WHERE channel_id = :channel_id) ")
+	case pm.Channel != "":
 		query = append(query, "conn.channel_id = :channel_id ")
 		if pm.ConnectionType != "" {
 			query = append(query, "conn.type = :conn_type ")
@@ -1303,6 +1569,9 @@ func PageQuery(pm clients.Page) (string, error) {
 	if len(pm.Metadata) > 0 {
 		query = append(query, "c.metadata @> :metadata")
 	}
+	if pm.MetadataFilter != nil {
+		query = append(query, metadataFilterQuery(*pm.MetadataFilter))
+	}
 
 	if !pm.CreatedFrom.IsZero() {
 		query = append(query, "c.created_at >= :created_from")
@@ -1318,7 +1587,34 @@ func PageQuery(pm clients.Page) (string, error) {
 	return emq, nil
 }
 
+func metadataFilterQuery(mf clients.MetadataFilter) string {
+	meta := "c.metadata"
+	if len(mf.Expand) > 0 {
+		meta = "e"
+	}
+
+	var cond string
+	switch mf.Operator {
+	case clients.ContainsMetadataOp:
+		cond = fmt.Sprintf("%s #> :metadata_path @> :metadata_contains", meta)
+	default:
+		cond = fmt.Sprintf("%s #>> :metadata_path = ANY(:metadata_values)", meta)
+	}
+	if len(mf.Expand) == 0 {
+		return cond
+	}
+
+	// Values other than arrays expand to no rows instead of failing the query.
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM jsonb_array_elements(
+		CASE jsonb_typeof(c.metadata #> :metadata_expand) WHEN 'array' THEN c.metadata #> :metadata_expand ELSE '[]'::jsonb END
+	) AS e WHERE %s)`, cond)
+}
+
 func applyOrdering(emq string, pm clients.Page) string {
+	if pm.PreserveIDsOrder && len(pm.IDs) > 0 {
+		return fmt.Sprintf("%s ORDER BY array_position(:ids, id)", emq)
+	}
+
 	var orderBy string
 	switch pm.Order {
 	case "name":
@@ -1330,13 +1626,14 @@ func applyOrdering(emq string, pm clients.Page) string {
 	case "updated_at":
 		orderBy = "COALESCE(updated_at, created_at)"
 	default:
-		return emq
+		// Client ID keeps the pages stable when no ordering is requested.
+		return fmt.Sprintf("%s ORDER BY id", emq)
 	}
 
 	if pm.Dir == api.AscDir || pm.Dir == api.DescDir {
 		return fmt.Sprintf("%s ORDER BY %s %s, id %s", emq, orderBy, pm.Dir, pm.Dir)
 	}
-	return fmt.Sprintf("%s ORDER BY %s", emq, orderBy)
+	return fmt.Sprintf("%s ORDER BY %s, id", emq, orderBy)
 }
 
 func applyLimitOffset(query string) string {
@@ -1367,14 +1664,15 @@ func (repo *clientRepo) RetrieveByIds(ctx context.Context, ids []string) (client
 		return clients.ClientsPage{}, nil
 	}
 
-	pm := clients.Page{IDs: ids}
+	pm := clients.Page{IDs: ids, PreserveIDsOrder: true}
 	query, err := PageQuery(pm)
 	if err != nil {
 		return clients.ClientsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
 
 	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, c.identity, c.metadata, COALESCE(c.domain_id, '') AS domain_id,  COALESCE(parent_group_id, '') AS parent_group_id, c.status,
-					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s ORDER BY c.created_at`, query)
+					c.created_at, c.updated_at, COALESCE(c.updated_by, '') AS updated_by FROM clients c %s`, query)
+	q = applyOrdering(q, pm)
 
 	dbPage, err := ToDBClientsPage(pm)
 	if err != nil {
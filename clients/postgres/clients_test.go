@@ -24,6 +24,7 @@ import (
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	"github.com/absmach/supermq/pkg/roles"
+	migrate "github.com/rubenv/sql-migrate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -440,6 +441,148 @@ func TestClientsSave(t *testing.T) {
 	}
 }
 
+func TestClientsSaveBatch(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	newClient := func(id string) clients.Client {
+		return clients.Client{
+			ID:     id,
+			Domain: domainID,
+			Name:   namegen.Generate(),
+			Credentials: clients.Credentials{
+				Identity: namegen.Generate() + emailSuffix,
+				Secret:   testsutil.GenerateUUID(t),
+			},
+			Metadata: clients.Metadata{},
+			Status:   clients.EnabledStatus,
+		}
+	}
+
+	existing := newClient(testsutil.GenerateUUID(t))
+	_, err := repo.Save(context.Background(), existing)
+	require.Nil(t, err, fmt.Sprintf("save client unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		strict  bool
+		clients []clients.Client
+		saved   int
+		failed  []int
+		err     error
+	}{
+		{
+			desc:    "save batch successfully",
+			clients: []clients.Client{newClient(testsutil.GenerateUUID(t)), newClient(testsutil.GenerateUUID(t))},
+			saved:   2,
+		},
+		{
+			desc:    "save batch with duplicate client",
+			clients: []clients.Client{newClient(testsutil.GenerateUUID(t)), newClient(existing.ID), newClient(testsutil.GenerateUUID(t))},
+			saved:   2,
+			failed:  []int{1},
+		},
+		{
+			desc:    "save batch with duplicate client in strict mode",
+			strict:  true,
+			clients: []clients.Client{newClient(testsutil.GenerateUUID(t)), newClient(existing.ID), newClient(testsutil.GenerateUUID(t))},
+			saved:   0,
+			failed:  []int{1},
+			err:     repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, failed, err := repo.SaveBatch(context.Background(), tc.strict, tc.clients...)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.saved, len(saved), fmt.Sprintf("%s: expected %d saved clients got %d\n", tc.desc, tc.saved, len(saved)))
+			var indexes []int
+			for _, f := range failed {
+				assert.True(t, errors.Contains(f.Err, repoerr.ErrConflict), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, repoerr.ErrConflict, f.Err))
+				indexes = append(indexes, f.Index)
+			}
+			assert.Equal(t, tc.failed, indexes, fmt.Sprintf("%s: expected failed indexes %v got %v\n", tc.desc, tc.failed, indexes))
+			// In strict mode nothing from the batch must be persisted.
+			if tc.strict {
+				_, err := repo.RetrieveByID(context.Background(), tc.clients[0].ID)
+				assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, repoerr.ErrNotFound, err))
+			}
+		})
+	}
+}
+
+func TestClientsSaveUniqueNames(t *testing.T) {
+	// A dedicated migrations table lets the opt-in migration be applied
+	// and rolled back on its own.
+	ms := migrate.MigrationSet{TableName: "unique_names_migrations"}
+	mig := &migrate.MemoryMigrationSource{Migrations: []*migrate.Migration{postgres.UniqueNamesMigration()}}
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+		_, err = ms.ExecMax(db.DB, "postgres", mig, migrate.Down, 1)
+		require.Nil(t, err, fmt.Sprintf("drop unique names unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	newClient := func(domainID, name string) clients.Client {
+		return clients.Client{
+			ID:          testsutil.GenerateUUID(t),
+			Domain:      domainID,
+			Name:        name,
+			Credentials: clients.Credentials{Secret: testsutil.GenerateUUID(t)},
+			Metadata:    clients.Metadata{},
+			Status:      clients.EnabledStatus,
+		}
+	}
+	_, err := repo.Save(context.Background(), newClient(domainID, clientName), newClient(domainID, clientName))
+	require.Nil(t, err, fmt.Sprintf("save client unexpected error: %s", err))
+	_, err = ms.Exec(db.DB, "postgres", mig, migrate.Up)
+	assert.NotNil(t, err, "set up unique names with duplicate names: expected error got nil")
+	_, err = db.Exec("DELETE FROM clients")
+	require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	_, err = ms.Exec(db.DB, "postgres", mig, migrate.Up)
+	require.Nil(t, err, fmt.Sprintf("set up unique names unexpected error: %s", err))
+
+	_, err = repo.Save(context.Background(), newClient(domainID, clientName))
+	require.Nil(t, err, fmt.Sprintf("save client unexpected error: %s", err))
+	_, err = repo.Save(context.Background(), newClient(domainID, ""))
+	require.Nil(t, err, fmt.Sprintf("save client unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		client clients.Client
+		err    error
+	}{
+		{
+			desc:   "add client with duplicate name in the same domain",
+			client: newClient(domainID, clientName),
+			err:    postgres.ErrNameExists,
+		},
+		{
+			desc:   "add client with duplicate name in another domain",
+			client: newClient(testsutil.GenerateUUID(t), clientName),
+			err:    nil,
+		},
+		{
+			desc:   "add client with duplicate empty name in the same domain",
+			client: newClient(domainID, ""),
+			err:    nil,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := repo.Save(context.Background(), tc.client)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
 func TestClientsRetrieveBySecret(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM clients")
@@ -532,6 +675,86 @@ func TestClientsRetrieveBySecret(t *testing.T) {
 	}
 }
 
+func TestClientsRetrieveBySecrets(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	var saved []clients.Client
+	for range 2 {
+		client := clients.Client{
+			ID:   testsutil.GenerateUUID(t),
+			Name: namegen.Generate(),
+			Credentials: clients.Credentials{
+				Identity: namegen.Generate() + emailSuffix,
+				Secret:   testsutil.GenerateUUID(t),
+			},
+			Domain:          domainID,
+			Metadata:        clients.Metadata{},
+			PrivateMetadata: clients.Metadata{},
+			Status:          clients.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		saved = append(saved, client)
+	}
+
+	basicKey := clients.SecretKey{Secret: saved[0].Credentials.Secret, ID: saved[0].ID, Prefix: authn.BasicAuth}
+	domainKey := clients.SecretKey{Secret: saved[1].Credentials.Secret, ID: domainID, Prefix: authn.DomainAuth}
+	basic := clients.Client{ID: saved[0].ID, Domain: domainID, Credentials: clients.Credentials{Secret: saved[0].Credentials.Secret}}
+	domain := clients.Client{ID: saved[1].ID, Domain: domainID, Credentials: clients.Credentials{Secret: saved[1].Credentials.Secret}}
+
+	cases := []struct {
+		desc     string
+		keys     []clients.SecretKey
+		response map[clients.SecretKey]clients.Client
+		err      error
+	}{
+		{
+			desc:     "retrieve clients by secrets successfully",
+			keys:     []clients.SecretKey{basicKey, domainKey},
+			response: map[clients.SecretKey]clients.Client{basicKey: basic, domainKey: domain},
+			err:      nil,
+		},
+		{
+			desc: "retrieve clients by existing and non-existent secrets",
+			keys: []clients.SecretKey{
+				basicKey,
+				{Secret: "non-existent-secret", ID: domainID, Prefix: authn.DomainAuth},
+			},
+			response: map[clients.SecretKey]clients.Client{basicKey: basic},
+			err:      nil,
+		},
+		{
+			desc: "retrieve clients by secrets with invalid ID types",
+			keys: []clients.SecretKey{
+				{Secret: saved[0].Credentials.Secret, ID: saved[0].ID, Prefix: authn.DomainAuth},
+				{Secret: saved[1].Credentials.Secret, ID: domainID, Prefix: authn.BasicAuth},
+				{Secret: saved[1].Credentials.Secret, ID: domainID},
+			},
+			response: map[clients.SecretKey]clients.Client{},
+			err:      nil,
+		},
+		{
+			desc:     "retrieve clients by empty secrets",
+			keys:     []clients.SecretKey{},
+			response: map[clients.SecretKey]clients.Client{},
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := repo.RetrieveBySecrets(context.Background(), tc.keys)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, res))
+		})
+	}
+}
+
 func TestRetrieveByID(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM clients")
@@ -663,6 +886,42 @@ func TestUpdate(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc:   "update client with valid precondition",
+			update: "name",
+			client: clients.Client{
+				ID:              validClient.ID,
+				Name:            namegen.Generate(),
+				UpdatedAt:       validTimestamp,
+				UpdatedBy:       testsutil.GenerateUUID(t),
+				UnmodifiedSince: validTimestamp,
+			},
+			err: nil,
+		},
+		{
+			desc:   "update client with failed precondition",
+			update: "name",
+			client: clients.Client{
+				ID:              validClient.ID,
+				Name:            namegen.Generate(),
+				UpdatedAt:       validTimestamp,
+				UpdatedBy:       testsutil.GenerateUUID(t),
+				UnmodifiedSince: validTimestamp.Add(-time.Hour),
+			},
+			err: repoerr.ErrPreconditionFailed,
+		},
+		{
+			desc:   "update client with precondition and invalid ID",
+			update: "name",
+			client: clients.Client{
+				ID:              testsutil.GenerateUUID(t),
+				Name:            namegen.Generate(),
+				UpdatedAt:       validTimestamp,
+				UpdatedBy:       testsutil.GenerateUUID(t),
+				UnmodifiedSince: validTimestamp,
+			},
+			err: repoerr.ErrNotFound,
+		},
 		{
 			desc:   "update client with invalid ID",
 			update: "all",
@@ -912,6 +1171,157 @@ func TestUpdateSecret(t *testing.T) {
 	}
 }
 
+func TestRotateSecret(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	client1 := generateClient(t, clients.EnabledStatus, repo)
+	client2 := generateClient(t, clients.EnabledStatus, repo)
+	client3 := generateClient(t, clients.DisabledStatus, repo)
+
+	cases := []struct {
+		desc           string
+		client         clients.Client
+		previousSecret string
+		expiresAt      time.Time
+		previousValid  bool
+		err            error
+	}{
+		{
+			desc: "for enabled client within grace period",
+			client: clients.Client{
+				ID: client1.ID,
+				Credentials: clients.Credentials{
+					Secret: testsutil.GenerateUUID(t),
+				},
+			},
+			previousSecret: client1.Credentials.Secret,
+			expiresAt:      time.Now().UTC().Add(time.Hour),
+			previousValid:  true,
+			err:            nil,
+		},
+		{
+			desc: "for enabled client with expired grace period",
+			client: clients.Client{
+				ID: client2.ID,
+				Credentials: clients.Credentials{
+					Secret: testsutil.GenerateUUID(t),
+				},
+			},
+			previousSecret: client2.Credentials.Secret,
+			expiresAt:      time.Now().UTC().Add(-time.Hour),
+			previousValid:  false,
+			err:            nil,
+		},
+		{
+			desc: "for disabled client",
+			client: clients.Client{
+				ID: client3.ID,
+				Credentials: clients.Credentials{
+					Secret: testsutil.GenerateUUID(t),
+				},
+			},
+			expiresAt: time.Now().UTC().Add(time.Hour),
+			err:       repoerr.ErrNotFound,
+		},
+		{
+			desc: "for invalid client",
+			client: clients.Client{
+				ID: testsutil.GenerateUUID(t),
+				Credentials: clients.Credentials{
+					Secret: testsutil.GenerateUUID(t),
+				},
+			},
+			expiresAt: time.Now().UTC().Add(time.Hour),
+			err:       repoerr.ErrNotFound,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			c.client.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+			c.client.UpdatedBy = testsutil.GenerateUUID(t)
+			_, err := repo.RotateSecret(context.Background(), c.client, c.expiresAt)
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected %s to contain %s\n", err, c.err))
+			if err == nil {
+				rc, err := repo.RetrieveBySecret(context.Background(), c.client.Credentials.Secret, c.client.ID, authn.BasicAuth)
+				require.Nil(t, err, fmt.Sprintf("retrieve client by new secret unexpected error: %s", err))
+				assert.Equal(t, c.client.Credentials.Secret, rc.Credentials.Secret)
+				_, err = repo.RetrieveBySecret(context.Background(), c.previousSecret, c.client.ID, authn.BasicAuth)
+				assert.Equal(t, c.previousValid, err == nil, fmt.Sprintf("%s: expected previous secret valid %t got error %s\n", c.desc, c.previousValid, err))
+			}
+		})
+	}
+
+	removed, err := repo.RemoveExpiredSecrets(context.Background())
+	require.Nil(t, err, fmt.Sprintf("remove expired secrets unexpected error: %s", err))
+	assert.Equal(t, uint64(1), removed)
+}
+func TestReplaceSecret(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	client1 := generateClient(t, clients.EnabledStatus, repo)
+	client2 := generateClient(t, clients.EnabledStatus, repo)
+	previous := client1.Credentials.Secret
+	client1.Credentials.Secret = testsutil.GenerateUUID(t)
+	client1.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	_, err := repo.RotateSecret(context.Background(), client1, time.Now().UTC().Add(time.Hour))
+	require.Nil(t, err, fmt.Sprintf("rotate secret unexpected error: %s", err))
+
+	cases := []struct {
+		desc        string
+		current     clients.StoredSecrets
+		replacement clients.StoredSecrets
+		err         error
+	}{
+		{
+			desc:        "replace secret and previous secret",
+			current:     clients.StoredSecrets{ClientID: client1.ID, Secret: client1.Credentials.Secret, PreviousSecret: previous},
+			replacement: clients.StoredSecrets{ClientID: client1.ID, Secret: "hashed-" + client1.Credentials.Secret, PreviousSecret: "hashed-" + previous},
+			err:         nil,
+		},
+		{
+			desc:        "replace secret without previous secret",
+			current:     clients.StoredSecrets{ClientID: client2.ID, Secret: client2.Credentials.Secret},
+			replacement: clients.StoredSecrets{ClientID: client2.ID, Secret: "hashed-" + client2.Credentials.Secret},
+			err:         nil,
+		},
+		{
+			desc:        "replace changed secret",
+			current:     clients.StoredSecrets{ClientID: client2.ID, Secret: client2.Credentials.Secret},
+			replacement: clients.StoredSecrets{ClientID: client2.ID, Secret: "other"},
+			err:         repoerr.ErrNotFound,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			err := repo.ReplaceSecret(context.Background(), c.current, c.replacement)
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected %s to contain %s\n", err, c.err))
+			if err == nil {
+				_, err := repo.RetrieveBySecret(context.Background(), c.replacement.Secret, c.current.ClientID, authn.BasicAuth)
+				assert.Nil(t, err, fmt.Sprintf("retrieve client by replaced secret unexpected error: %s", err))
+				if c.replacement.PreviousSecret != "" {
+					// The grace period of the previous secret is kept.
+					_, err := repo.RetrieveBySecret(context.Background(), c.replacement.PreviousSecret, c.current.ClientID, authn.BasicAuth)
+					assert.Nil(t, err, fmt.Sprintf("retrieve client by replaced previous secret unexpected error: %s", err))
+				}
+			}
+		})
+	}
+
+	secrets, err := repo.RetrieveSecrets(context.Background(), 0, 10)
+	require.Nil(t, err, fmt.Sprintf("retrieve secrets unexpected error: %s", err))
+	assert.Len(t, secrets, 2)
+}
+
 func TestChangeStatus(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM clients")
@@ -1925,6 +2335,131 @@ func TestRetrieveAll(t *testing.T) {
 	}
 }
 
+func TestRetrieveAllPaginationTiebreaker(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	num := 25
+	domainID := testsutil.GenerateUUID(t)
+	createdAt := time.Now().UTC().Truncate(time.Millisecond)
+	for i := 0; i < num; i++ {
+		client := clients.Client{
+			ID:     testsutil.GenerateUUID(t),
+			Domain: domainID,
+			Name:   namegen.Generate(),
+			Credentials: clients.Credentials{
+				Identity: namegen.Generate() + emailSuffix,
+				Secret:   testsutil.GenerateUUID(t),
+			},
+			Metadata:  clients.Metadata{},
+			Status:    clients.EnabledStatus,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+		_, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("add new client: expected nil got %s\n", err))
+	}
+
+	for _, order := range []string{"", "name", "created_at", "updated_at"} {
+		for _, dir := range []string{"", ascDir, descDir} {
+			desc := fmt.Sprintf("paginate clients ordered by %q %q", order, dir)
+			t.Run(desc, func(t *testing.T) {
+				seen := make(map[string]bool)
+				for offset := uint64(0); offset < uint64(num); offset += 7 {
+					pm := clients.Page{
+						Offset: offset,
+						Limit:  7,
+						Domain: domainID,
+						Status: clients.AllStatus,
+						Order:  order,
+						Dir:    dir,
+					}
+					page, err := repo.RetrieveAll(context.Background(), pm)
+					require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+					for _, c := range page.Clients {
+						assert.False(t, seen[c.ID], fmt.Sprintf("%s: client %s seen twice", desc, c.ID))
+						seen[c.ID] = true
+					}
+				}
+				assert.Len(t, seen, num, fmt.Sprintf("%s: expected %d clients got %d", desc, num, len(seen)))
+			})
+		}
+	}
+}
+
+func TestRetrieveAllPreserveIDsOrder(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	num := 10
+	baseTime := time.Now().UTC().Truncate(time.Millisecond)
+	ids := make([]string, num)
+	for i := range ids {
+		client := clients.Client{
+			ID:     testsutil.GenerateUUID(t),
+			Domain: testsutil.GenerateUUID(t),
+			Name:   namegen.Generate(),
+			Credentials: clients.Credentials{
+				Identity: namegen.Generate() + emailSuffix,
+				Secret:   testsutil.GenerateUUID(t),
+			},
+			Metadata:  clients.Metadata{},
+			Status:    clients.EnabledStatus,
+			CreatedAt: baseTime.Add(time.Duration(i) * time.Millisecond),
+		}
+		_, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("add new client: expected nil got %s\n", err))
+		ids[i] = client.ID
+	}
+	// Request clients in the reverse order of creation.
+	reversed := make([]string, num)
+	for i, id := range ids {
+		reversed[num-1-i] = id
+	}
+
+	cases := []struct {
+		desc string
+		pm   clients.Page
+		resp []string
+	}{
+		{
+			desc: "retrieve clients by IDs in requested order",
+			pm:   clients.Page{Limit: uint64(num), IDs: reversed, PreserveIDsOrder: true},
+			resp: reversed,
+		},
+		{
+			desc: "retrieve page of clients by IDs in requested order",
+			pm:   clients.Page{Offset: 2, Limit: 3, IDs: reversed, PreserveIDsOrder: true},
+			resp: reversed[2:5],
+		},
+		{
+			desc: "retrieve clients by IDs ordered by creation",
+			pm:   clients.Page{Limit: uint64(num), IDs: reversed, Order: defOrder, Dir: ascDir},
+			resp: ids,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			got := make([]string, len(page.Clients))
+			for i, c := range page.Clients {
+				got[i] = c.ID
+			}
+			assert.Equal(t, tc.resp, got, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.resp, got))
+		})
+	}
+}
+
 func TestRetrieveUserClients(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM clients")
@@ -2251,6 +2786,49 @@ func TestRetrieveUserClients(t *testing.T) {
 				Clients: []clients.Client{directClients[0]},
 			},
 		},
+		{
+			desc:     "retrieve clients with name prefix ignoring case",
+			domainID: domain.ID,
+			userID:   userID,
+			pm: clients.Page{
+				Offset:     0,
+				Limit:      nClients,
+				Name:       strings.ToUpper(directClients[0].Name),
+				NamePrefix: true,
+				Status:     clients.AllStatus,
+				Order:      defOrder,
+				Dir:        ascDir,
+			},
+			response: clients.ClientsPage{
+				Page: clients.Page{
+					Total:  1,
+					Offset: 0,
+					Limit:  nClients,
+				},
+				Clients: []clients.Client{directClients[0]},
+			},
+		},
+		{
+			desc:     "retrieve clients with name containing wildcard",
+			domainID: domain.ID,
+			userID:   userID,
+			pm: clients.Page{
+				Offset: 0,
+				Limit:  nClients,
+				Name:   "%",
+				Status: clients.AllStatus,
+				Order:  defOrder,
+				Dir:    ascDir,
+			},
+			response: clients.ClientsPage{
+				Page: clients.Page{
+					Total:  0,
+					Offset: 0,
+					Limit:  nClients,
+				},
+				Clients: []clients.Client(nil),
+			},
+		},
 		{
 			desc:     "retrieve clients with wrong name",
 			domainID: domain.ID,
@@ -3290,6 +3868,17 @@ func TestRetrieveByIDs(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc: "with ids in reverse order",
+			ids:  []string{items[5].ID, items[4].ID, items[3].ID},
+			response: clients.ClientsPage{
+				Page: clients.Page{
+					Total: 3,
+				},
+				Clients: []clients.Client{items[5], items[4], items[3]},
+			},
+			err: nil,
+		},
 		{
 			desc: "with empty ids",
 			ids:  []string{},
@@ -3332,7 +3921,7 @@ func TestRetrieveByIDs(t *testing.T) {
 			assert.Equal(t, c.response.Total, response.Total)
 			expected := stripClientDetails(c.response.Clients)
 			got := stripClientDetails(response.Clients)
-			assert.ElementsMatch(t, expected, got)
+			assert.Equal(t, expected, got, fmt.Sprintf("%s: expected clients in the requested order", c.desc))
 		}
 	}
 }
@@ -4170,3 +4759,135 @@ func verifyClientsOrdering(t *testing.T, clients []clients.Client, order, dir st
 		}
 	}
 }
+
+func TestRetrieveAllDisconnected(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	num := 5
+	domainID := testsutil.GenerateUUID(t)
+	channelID := testsutil.GenerateUUID(t)
+	ids := make([]string, num)
+	for i := range ids {
+		client := clients.Client{
+			ID:     testsutil.GenerateUUID(t),
+			Domain: domainID,
+			Name:   namegen.Generate(),
+			Credentials: clients.Credentials{
+				Identity: namegen.Generate() + emailSuffix,
+				Secret:   testsutil.GenerateUUID(t),
+			},
+			Metadata: clients.Metadata{},
+			Status:   clients.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("add new client: expected nil got %s\n", err))
+		ids[i] = client.ID
+	}
+
+	conns := []clients.Connection{
+		{ClientID: ids[0], ChannelID: channelID, DomainID: domainID, Type: connections.Publish},
+		{ClientID: ids[1], ChannelID: channelID, DomainID: domainID, Type: connections.Publish},
+		{ClientID: ids[2], ChannelID: testsutil.GenerateUUID(t), DomainID: domainID, Type: connections.Publish},
+	}
+	err := repo.AddConnections(context.Background(), conns)
+	require.Nil(t, err, fmt.Sprintf("add connections: expected nil got %s\n", err))
+
+	cases := []struct {
+		desc  string
+		pm    clients.Page
+		total uint64
+	}{
+		{
+			desc:  "count clients connected to channel",
+			pm:    clients.Page{Limit: uint64(num), Channel: channelID, OnlyTotal: true},
+			total: 2,
+		},
+		{
+			desc:  "count clients disconnected from channel",
+			pm:    clients.Page{Limit: uint64(num), Channel: channelID, Disconnected: true, OnlyTotal: true},
+			total: 3,
+		},
+		{
+			desc:  "count clients disconnected from channel without connections",
+			pm:    clients.Page{Limit: uint64(num), Channel: testsutil.GenerateUUID(t), Disconnected: true, OnlyTotal: true},
+			total: uint64(num),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		})
+	}
+}
+
+func TestRetrieveAllApproxCount(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	num := 10
+	domainID := testsutil.GenerateUUID(t)
+	for i := 0; i < num; i++ {
+		client := clients.Client{
+			ID:     testsutil.GenerateUUID(t),
+			Domain: domainID,
+			Name:   namegen.Generate(),
+			Credentials: clients.Credentials{
+				Identity: namegen.Generate() + emailSuffix,
+				Secret:   testsutil.GenerateUUID(t),
+			},
+			Metadata: clients.Metadata{},
+			Status:   clients.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("add new client: expected nil got %s\n", err))
+	}
+	_, err := db.Exec("ANALYZE clients")
+	require.Nil(t, err, fmt.Sprintf("analyze clients unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    clients.Page
+		total uint64
+	}{
+		{
+			desc:  "count clients with approximate count",
+			pm:    clients.Page{Limit: uint64(num), Status: clients.AllStatus, ApproxCount: true},
+			total: uint64(num),
+		},
+		{
+			desc:  "count clients with approximate count and domain filter",
+			pm:    clients.Page{Limit: 1, Status: clients.EnabledStatus, Domain: domainID, ApproxCount: true},
+			total: uint64(num),
+		},
+		{
+			desc:  "count only total clients with approximate count and domain filter",
+			pm:    clients.Page{OnlyTotal: true, Status: clients.EnabledStatus, Domain: domainID, ApproxCount: true},
+			total: uint64(num),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			// The planner's estimate isn't exact, even for freshly analyzed tables.
+			assert.InDelta(t, tc.total, page.Total, 2, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		})
+	}
+
+	page, err := repo.RetrieveUserClients(context.Background(), domainID, testsutil.GenerateUUID(t), clients.Page{Limit: 1, Status: clients.EnabledStatus, ApproxCount: true})
+	assert.Nil(t, err, fmt.Sprintf("retrieve user clients with approximate count: unexpected error %s", err))
+	assert.Empty(t, page.Clients, "retrieve user clients with approximate count: expected no clients")
+}
@@ -5,6 +5,9 @@ package postgres
 
 import "github.com/absmach/supermq/pkg/errors"
 
+// ErrNameExists indicates that a client with the same name already exists in the domain.
+var ErrNameExists = errors.NewConflictError("client name already exists")
+
 var _ errors.Mapper = (*duplicateErrors)(nil)
 
 type duplicateErrors struct{}
@@ -14,6 +17,8 @@ func (d duplicateErrors) GetError(constraint string) (error, bool) {
 	switch constraint {
 	case "clients_domain_id_secret_key":
 		return errors.NewRequestError("client key is not available"), true
+	case uniqueNameIndex:
+		return ErrNameExists, true
 	default:
 		return nil, false
 	}
@@ -12,6 +12,8 @@ import (
 	migrate "github.com/rubenv/sql-migrate"
 )
 
+const uniqueNameIndex = "clients_domain_id_name_key"
+
 func Migration() (*migrate.MemoryMigrationSource, error) {
 	clientsRolesMigration, err := rolesPostgres.Migration(rolesTableNamePrefix, entityTableName, entityIDColumnName)
 	if err != nil {
@@ -112,6 +114,45 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 					`DROP INDEX IF EXISTS idx_connections_client_id;`,
 				},
 			},
+			{
+				Id: "clients_07",
+				Up: []string{
+					`ALTER TABLE clients ADD COLUMN IF NOT EXISTS previous_secret VARCHAR(4096)`,
+					`ALTER TABLE clients ADD COLUMN IF NOT EXISTS previous_secret_expires_at TIMESTAMPTZ`,
+					`CREATE INDEX IF NOT EXISTS idx_clients_previous_secret ON clients(previous_secret) WHERE previous_secret IS NOT NULL;`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_clients_previous_secret;`,
+					`ALTER TABLE clients DROP COLUMN IF EXISTS previous_secret_expires_at`,
+					`ALTER TABLE clients DROP COLUMN IF EXISTS previous_secret`,
+				},
+			},
+			{
+				Id: "clients_08",
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS idx_clients_tags ON clients USING GIN (tags);`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_clients_tags;`,
+				},
+			},
+			{
+				Id: "clients_10",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS client_certs (
+						client_id     VARCHAR(36) NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+						serial_number VARCHAR(128) NOT NULL,
+						subject       VARCHAR(1024) NOT NULL,
+						fingerprint   VARCHAR(64) NOT NULL,
+						created_at    TIMESTAMPTZ,
+						PRIMARY KEY   (serial_number, subject)
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_client_certs_client_id ON client_certs(client_id);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS client_certs`,
+				},
+			},
 		},
 	}
 
@@ -126,3 +167,30 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 
 	return clientsMigration, nil
 }
+
+// UniqueNamesMigration returns the opt-in migration that makes client names
+// unique within a domain. Clients with an empty name are not subject to the
+// constraint. The migration fails with a descriptive error if duplicate names
+// already exist, and builds the index concurrently so that client writes are
+// not blocked meanwhile. Once applied, it must be migrated down before it is
+// left out of the migration source again.
+func UniqueNamesMigration() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "clients_09",
+		Up: []string{
+			`DO $$
+			BEGIN
+				IF EXISTS (SELECT 1 FROM clients WHERE name <> '' GROUP BY domain_id, name HAVING COUNT(*) > 1) THEN
+					RAISE EXCEPTION 'clients with duplicate names within a domain exist, rename them before enabling unique client names';
+				END IF;
+			END $$`,
+			`CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS ` + uniqueNameIndex + ` ON clients (domain_id, name) WHERE name <> ''`,
+		},
+		Down: []string{
+			`DROP INDEX CONCURRENTLY IF EXISTS ` + uniqueNameIndex,
+		},
+		// Indexes can't be built concurrently inside a transaction.
+		DisableTransactionUp:   true,
+		DisableTransactionDown: true,
+	}
+}
@@ -165,6 +165,140 @@ func (_c *Service_Authenticate_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// AuthenticateMany provides a mock function for the type Service
+func (_mock *Service) AuthenticateMany(ctx context.Context, keys []string) (map[string]string, error) {
+	ret := _mock.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateMany")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string]string, error)); ok {
+		return returnFunc(ctx, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string]string); ok {
+		r0 = returnFunc(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_AuthenticateMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticateMany'
+type Service_AuthenticateMany_Call struct {
+	*mock.Call
+}
+
+// AuthenticateMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+func (_e *Service_Expecter) AuthenticateMany(ctx interface{}, keys interface{}) *Service_AuthenticateMany_Call {
+	return &Service_AuthenticateMany_Call{Call: _e.mock.On("AuthenticateMany", ctx, keys)}
+}
+
+func (_c *Service_AuthenticateMany_Call) Run(run func(ctx context.Context, keys []string)) *Service_AuthenticateMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_AuthenticateMany_Call) Return(stringToString map[string]string, err error) *Service_AuthenticateMany_Call {
+	_c.Call.Return(stringToString, err)
+	return _c
+}
+
+func (_c *Service_AuthenticateMany_Call) RunAndReturn(run func(ctx context.Context, keys []string) (map[string]string, error)) *Service_AuthenticateMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IdentifyCert provides a mock function for the type Service
+func (_mock *Service) IdentifyCert(ctx context.Context, certPEM []byte) (string, error) {
+	ret := _mock.Called(ctx, certPEM)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IdentifyCert")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte) (string, error)); ok {
+		return returnFunc(ctx, certPEM)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte) string); ok {
+		r0 = returnFunc(ctx, certPEM)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = returnFunc(ctx, certPEM)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_IdentifyCert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IdentifyCert'
+type Service_IdentifyCert_Call struct {
+	*mock.Call
+}
+
+// IdentifyCert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - certPEM []byte
+func (_e *Service_Expecter) IdentifyCert(ctx interface{}, certPEM interface{}) *Service_IdentifyCert_Call {
+	return &Service_IdentifyCert_Call{Call: _e.mock.On("IdentifyCert", ctx, certPEM)}
+}
+
+func (_c *Service_IdentifyCert_Call) Run(run func(ctx context.Context, certPEM []byte)) *Service_IdentifyCert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_IdentifyCert_Call) Return(s string, err error) *Service_IdentifyCert_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *Service_IdentifyCert_Call) RunAndReturn(run func(ctx context.Context, certPEM []byte) (string, error)) *Service_IdentifyCert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveChannelConnections provides a mock function for the type Service
 func (_mock *Service) RemoveChannelConnections(ctx context.Context, channelID string) error {
 	ret := _mock.Called(ctx, channelID)
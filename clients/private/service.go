@@ -5,18 +5,30 @@ package private
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/absmach/supermq/clients"
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/policies"
 )
 
 type Service interface {
-	// Authenticate returns client ID for given client key.
+	// Authenticate returns client ID for given client key. Tokens packed
+	// with authn.CertPack are authenticated by the client certificate.
 	Authenticate(ctx context.Context, key string) (string, error)
 
+	// AuthenticateMany returns client IDs for given client keys. Keys not
+	// matching any client are omitted.
+	AuthenticateMany(ctx context.Context, keys []string) (map[string]string, error)
+
+	// IdentifyCert returns client ID for given PEM encoded client certificate.
+	IdentifyCert(ctx context.Context, certPEM []byte) (string, error)
+
 	RetrieveById(ctx context.Context, id string) (clients.Client, error)
 
 	RetrieveByIds(ctx context.Context, ids []string) (clients.ClientsPage, error)
@@ -32,42 +44,152 @@ type Service interface {
 
 var _ Service = (*service)(nil)
 
-func New(repo clients.Repository, cache clients.Cache, evaluator policies.Evaluator, policy policies.Service) Service {
+const certKeyPrefix = "cert"
+
+// New instantiates the private clients service. Client certificate
+// authentication is disabled if certs is nil.
+func New(repo clients.Repository, certs clients.CertificateRepository, cache clients.Cache, evaluator policies.Evaluator, policy policies.Service, hasher clients.Hasher) Service {
 	return service{
 		repo:      repo,
+		certs:     certs,
 		cache:     cache,
 		evaluator: evaluator,
 		policy:    policy,
+		hasher:    hasher,
 	}
 }
 
 type service struct {
 	repo      clients.Repository
+	certs     clients.CertificateRepository
 	cache     clients.Cache
 	evaluator policies.Evaluator
 	policy    policies.Service
+	hasher    clients.Hasher
 }
 
 func (svc service) Authenticate(ctx context.Context, token string) (string, error) {
-	id, err := svc.cache.ID(ctx, token)
-	if err == nil {
-		return id, nil
+	if certPEM, ok := authn.CertUnpack(token); ok {
+		return svc.IdentifyCert(ctx, certPEM)
 	}
 	prefix, id, key, err := authn.AuthUnpack(token)
 	if err != nil && err != authn.ErrNotEncoded {
 		return "", err
 	}
-	client, err := svc.repo.RetrieveBySecret(ctx, key, id, prefix)
+	hash, err := svc.hasher.Hash(key)
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	cacheKey := clients.SecretKey{Secret: hash, ID: id, Prefix: prefix}.CacheKey()
+	cid, err := svc.cache.ID(ctx, cacheKey)
+	switch {
+	case err == nil:
+		return cid, nil
+	case errors.Contains(err, clients.ErrUnknownKey):
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	client, err := svc.repo.RetrieveBySecret(ctx, hash, id, prefix)
 	if err != nil {
+		// Unknown keys are cached to absorb key scans. Failing to cache
+		// them doesn't change the outcome, so the error is ignored.
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			_ = svc.cache.SaveUnknown(ctx, cacheKey)
+		}
 		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
-	if err := svc.cache.Save(ctx, token, client.ID); err != nil {
+	// Secrets valid only within the rotation grace period are not cached,
+	// so they stop authenticating as soon as the grace period ends.
+	if client.Credentials.Secret != hash {
+		return client.ID, nil
+	}
+	if err := svc.cache.Save(ctx, cacheKey, client.ID); err != nil {
 		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 
 	return client.ID, nil
 }
 
+func (svc service) AuthenticateMany(ctx context.Context, tokens []string) (map[string]string, error) {
+	ids := make(map[string]string, len(tokens))
+	misses := make(map[clients.SecretKey]string)
+	cacheKeys := make(map[string]string, len(tokens))
+	for _, token := range tokens {
+		prefix, id, key, err := authn.AuthUnpack(token)
+		if err != nil {
+			continue
+		}
+		hash, err := svc.hasher.Hash(key)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		sk := clients.SecretKey{Secret: hash, ID: id, Prefix: prefix}
+		cacheKeys[token] = sk.CacheKey()
+		cid, err := svc.cache.ID(ctx, cacheKeys[token])
+		switch {
+		case err == nil:
+			ids[token] = cid
+			continue
+		case errors.Contains(err, clients.ErrUnknownKey):
+			continue
+		}
+		misses[sk] = token
+	}
+	if len(misses) == 0 {
+		return ids, nil
+	}
+
+	found, err := svc.repo.RetrieveBySecrets(ctx, slices.Collect(maps.Keys(misses)))
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	for key, token := range misses {
+		client, ok := found[key]
+		if !ok {
+			_ = svc.cache.SaveUnknown(ctx, cacheKeys[token])
+			continue
+		}
+		ids[token] = client.ID
+		// As in Authenticate, grace period secrets are not cached.
+		if client.Credentials.Secret != key.Secret {
+			continue
+		}
+		if err := svc.cache.Save(ctx, cacheKeys[token], client.ID); err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+	}
+
+	return ids, nil
+}
+
+func (svc service) IdentifyCert(ctx context.Context, certPEM []byte) (string, error) {
+	if svc.certs == nil {
+		return "", errors.Wrap(svcerr.ErrAuthentication, clients.ErrCertAuthDisabled)
+	}
+	cert, fingerprint, err := clients.ParseCertificate(certPEM, time.Now())
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	key := certKeyPrefix + ":" + fingerprint
+	if id, err := svc.cache.ID(ctx, key); err == nil {
+		return id, nil
+	}
+
+	c, err := svc.certs.RetrieveBySerial(ctx, cert.SerialNumber.String(), cert.Subject.String())
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	// Serial number and subject are not enough on their own, since anyone
+	// can issue a certificate with the same values.
+	if c.Fingerprint != fingerprint {
+		return "", svcerr.ErrAuthentication
+	}
+	if err := svc.cache.Save(ctx, key, c.ClientID); err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	return c.ClientID, nil
+}
+
 func (svc service) RetrieveById(ctx context.Context, ids string) (clients.Client, error) {
 	return svc.repo.RetrieveByID(ctx, ids)
 }
@@ -0,0 +1,250 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package private_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/clients/hasher"
+	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/clients/private"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	policymocks "github.com/absmach/supermq/pkg/policies/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	clientID = "6e5e10b3-d4df-4758-b426-4929d55ad740"
+	secret   = "strongsecret"
+)
+
+func TestAuthenticate(t *testing.T) {
+	hs, err := hasher.New("pepper")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error creating hasher: %s", err))
+	hash := func(secret string) string {
+		h, err := hs.Hash(secret)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error hashing secret: %s", err))
+		return h
+	}
+
+	token := authn.AuthPack(authn.BasicAuth, clientID, secret)
+	secretHash := hash(secret)
+	cacheKey := clients.SecretKey{Secret: secretHash, ID: clientID, Prefix: authn.BasicAuth}.CacheKey()
+
+	cases := []struct {
+		desc        string
+		cacheID     string
+		cacheErr    error
+		client      clients.Client
+		retrieveErr error
+		save        bool
+		saveUnknown bool
+		id          string
+		err         error
+	}{
+		{
+			desc:    "authenticate from cache",
+			cacheID: clientID,
+			id:      clientID,
+		},
+		{
+			desc:     "authenticate with key marked as unknown",
+			cacheErr: clients.ErrUnknownKey,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "authenticate with current secret",
+			cacheErr: repoerr.ErrNotFound,
+			client:   clients.Client{ID: clientID, Credentials: clients.Credentials{Secret: secretHash}},
+			save:     true,
+			id:       clientID,
+		},
+		{
+			desc:     "authenticate with secret in grace period",
+			cacheErr: repoerr.ErrNotFound,
+			client:   clients.Client{ID: clientID, Credentials: clients.Credentials{Secret: hash("newsecret")}},
+			id:       clientID,
+		},
+		{
+			desc:        "authenticate with unknown secret",
+			cacheErr:    repoerr.ErrNotFound,
+			retrieveErr: repoerr.ErrNotFound,
+			saveUnknown: true,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(climocks.Repository)
+			cache := new(climocks.Cache)
+			svc := private.New(repo, nil, cache, new(policymocks.Evaluator), new(policymocks.Service), hs)
+
+			// The cache is never accessed with the plain-text secret.
+			cacheCall := cache.On("ID", context.Background(), cacheKey).Return(tc.cacheID, tc.cacheErr)
+			repoCall := repo.On("RetrieveBySecret", context.Background(), secretHash, clientID, authn.BasicAuth).Return(tc.client, tc.retrieveErr)
+			cacheCall1 := cache.On("Save", context.Background(), cacheKey, clientID).Return(nil)
+			cacheCall2 := cache.On("SaveUnknown", context.Background(), cacheKey).Return(nil)
+			id, err := svc.Authenticate(context.Background(), token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+			if tc.save {
+				cache.AssertCalled(t, "Save", context.Background(), cacheKey, clientID)
+			} else {
+				cache.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tc.saveUnknown {
+				cache.AssertCalled(t, "SaveUnknown", context.Background(), cacheKey)
+			} else {
+				cache.AssertNotCalled(t, "SaveUnknown", mock.Anything, mock.Anything)
+			}
+			cacheCall.Unset()
+			repoCall.Unset()
+			cacheCall1.Unset()
+			cacheCall2.Unset()
+		})
+	}
+}
+
+func newCertificate(t *testing.T, notAfter time.Time) (*x509.Certificate, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error generating key: %s", err))
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: clientID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error creating certificate: %s", err))
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error parsing certificate: %s", err))
+
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestIdentifyCert(t *testing.T) {
+	cert, certPEM := newCertificate(t, time.Now().Add(time.Hour))
+	_, expiredPEM := newCertificate(t, time.Now().Add(-time.Minute))
+	_, otherPEM := newCertificate(t, time.Now().Add(time.Hour))
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := hex.EncodeToString(sum[:])
+	cacheKey := "cert:" + fingerprint
+	stored := clients.Certificate{
+		ClientID:     clientID,
+		SerialNumber: cert.SerialNumber.String(),
+		Subject:      cert.Subject.String(),
+		Fingerprint:  fingerprint,
+	}
+
+	cases := []struct {
+		desc        string
+		certs       bool
+		certPEM     []byte
+		cacheID     string
+		cacheErr    error
+		stored      clients.Certificate
+		retrieveErr error
+		save        bool
+		id          string
+		err         error
+	}{
+		{
+			desc:    "identify with certificate authentication disabled",
+			certPEM: certPEM,
+			err:     clients.ErrCertAuthDisabled,
+		},
+		{
+			desc:    "identify with malformed certificate",
+			certs:   true,
+			certPEM: []byte("certificate"),
+			err:     clients.ErrInvalidCertificate,
+		},
+		{
+			desc:    "identify with expired certificate",
+			certs:   true,
+			certPEM: expiredPEM,
+			err:     clients.ErrInvalidCertificate,
+		},
+		{
+			desc:    "identify from cache",
+			certs:   true,
+			certPEM: certPEM,
+			cacheID: clientID,
+			id:      clientID,
+		},
+		{
+			desc:     "identify with bound certificate",
+			certs:    true,
+			certPEM:  certPEM,
+			cacheErr: repoerr.ErrNotFound,
+			stored:   stored,
+			save:     true,
+			id:       clientID,
+		},
+		{
+			desc:        "identify with unbound certificate",
+			certs:       true,
+			certPEM:     certPEM,
+			cacheErr:    repoerr.ErrNotFound,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "identify with certificate not matching the bound fingerprint",
+			certs:    true,
+			certPEM:  otherPEM,
+			cacheErr: repoerr.ErrNotFound,
+			stored:   stored,
+			err:      svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cache := new(climocks.Cache)
+			certs := new(climocks.CertificateRepository)
+			var repo clients.CertificateRepository
+			if tc.certs {
+				repo = certs
+			}
+			svc := private.New(new(climocks.Repository), repo, cache, new(policymocks.Evaluator), new(policymocks.Service), hasher.NewPlaintext())
+
+			cache.On("ID", context.Background(), mock.Anything).Return(tc.cacheID, tc.cacheErr)
+			certs.On("RetrieveBySerial", context.Background(), mock.Anything, mock.Anything).Return(tc.stored, tc.retrieveErr)
+			cache.On("Save", context.Background(), cacheKey, clientID).Return(nil)
+			id, err := svc.IdentifyCert(context.Background(), tc.certPEM)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+			// Adapters terminating mTLS authenticate by certificate through Authenticate.
+			if tc.err == nil {
+				id, err = svc.Authenticate(context.Background(), authn.CertPack(tc.certPEM))
+				assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+				assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+			}
+			if tc.save {
+				cache.AssertCalled(t, "Save", context.Background(), cacheKey, clientID)
+			} else {
+				cache.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
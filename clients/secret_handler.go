@@ -0,0 +1,53 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// The secretsSweepHandler is a cron job that runs periodically to purge the previous
+// client secrets whose rotation grace period has ended.
+// The handler runs in a separate goroutine until the given context is canceled.
+
+package clients
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type secretsSweepHandler struct {
+	clients       Repository
+	checkInterval time.Duration
+	logger        *slog.Logger
+}
+
+func NewSecretsSweepHandler(ctx context.Context, clients Repository, checkInterval time.Duration, logger *slog.Logger) {
+	handler := &secretsSweepHandler{
+		clients:       clients,
+		checkInterval: checkInterval,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				handler.handle(ctx)
+			}
+		}
+	}()
+}
+
+func (h *secretsSweepHandler) handle(ctx context.Context) {
+	removed, err := h.clients.RemoveExpiredSecrets(ctx)
+	if err != nil {
+		h.logger.Error("failed to remove expired client secrets", slog.Any("error", err))
+		return
+	}
+	if removed > 0 {
+		h.logger.Info("expired client secrets removed", slog.Uint64("count", removed))
+	}
+}
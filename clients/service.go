@@ -4,6 +4,8 @@ package clients
 
 import (
 	"context"
+	"log/slog"
+	"slices"
 	"time"
 
 	smq "github.com/absmach/supermq"
@@ -24,38 +26,62 @@ var (
 	errSetSameParentGroup  = errors.NewRequestError("client already assigned to the parent group")
 	errParentGroupDomainID = errors.NewRequestError("parent group has invalid domain id")
 	errParentGroupDisabled = errors.NewRequestError("parent group is not enabled")
+	errSameOwner           = errors.NewRequestError("client is already owned by the given user")
+	errAdminRoleNotFound   = errors.New("client built-in admin role not found")
 )
+
+const defRolesLimit = 100
+
 var _ Service = (*service)(nil)
 
 type service struct {
 	repo       Repository
+	certs      CertificateRepository
 	policy     policies.Service
 	channels   grpcChannelsV1.ChannelsServiceClient
 	groups     grpcGroupsV1.GroupsServiceClient
 	cache      Cache
 	idProvider smq.IDProvider
+	hasher     Hasher
+	// secretGracePeriod is the duration for which the previous
+	// client secret remains valid after secret update.
+	secretGracePeriod time.Duration
+	clock             smq.Clock
+	logger            *slog.Logger
 	roles.ProvisionManageService
 }
 
-// NewService returns a new Clients service implementation.
-func NewService(repo Repository, policy policies.Service, cache Cache, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, idProvider smq.IDProvider, sIDProvider smq.IDProvider, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action) (Service, error) {
+// NewService returns a new Clients service implementation. If clock is nil,
+// the system time is used.
+// NewService returns a new clients service implementation. Binding client
+// certificates is disabled if certs is nil.
+func NewService(repo Repository, certs CertificateRepository, policy policies.Service, cache Cache, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, idProvider smq.IDProvider, sIDProvider smq.IDProvider, hasher Hasher, availableActions []roles.Action, builtInRoles map[roles.BuiltInRoleName][]roles.Action, secretGracePeriod time.Duration, clock smq.Clock, logger *slog.Logger) (Service, error) {
+	if clock == nil {
+		clock = smq.NewClock()
+	}
 	rpms, err := roles.NewProvisionManageService(policies.ClientType, repo, policy, sIDProvider, availableActions, builtInRoles)
 	if err != nil {
 		return service{}, err
 	}
 	return service{
 		repo:                   repo,
+		certs:                  certs,
 		policy:                 policy,
 		channels:               channels,
 		groups:                 groups,
 		cache:                  cache,
 		idProvider:             idProvider,
+		hasher:                 hasher,
+		secretGracePeriod:      secretGracePeriod,
+		clock:                  clock,
+		logger:                 logger,
 		ProvisionManageService: rpms,
 	}, nil
 }
 
 func (svc service) CreateClients(ctx context.Context, session authn.Session, cls ...Client) (retClients []Client, retRps []roles.RoleProvision, retErr error) {
 	var clients []Client
+	secrets := make(map[string]string, len(cls))
 	for _, c := range cls {
 		if c.ID == "" {
 			clientID, err := svc.idProvider.ID()
@@ -71,11 +97,17 @@ func (svc service) CreateClients(ctx context.Context, session authn.Session, cls
 			}
 			c.Credentials.Secret = key
 		}
+		secrets[c.ID] = c.Credentials.Secret
+		hash, err := svc.hasher.Hash(c.Credentials.Secret)
+		if err != nil {
+			return []Client{}, []roles.RoleProvision{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+		c.Credentials.Secret = hash
 		if c.Status != DisabledStatus && c.Status != EnabledStatus {
 			return []Client{}, []roles.RoleProvision{}, svcerr.ErrInvalidStatus
 		}
 		c.Domain = session.DomainID
-		c.CreatedAt = time.Now().UTC()
+		c.CreatedAt = svc.clock.Now().UTC()
 		clients = append(clients, c)
 	}
 
@@ -84,8 +116,12 @@ func (svc service) CreateClients(ctx context.Context, session authn.Session, cls
 		return []Client{}, []roles.RoleProvision{}, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
 	newClientIDs := []string{}
-	for _, newClient := range newClients {
+	for i, newClient := range newClients {
 		newClientIDs = append(newClientIDs, newClient.ID)
+		// Secret is returned in plain-text only on creation.
+		if secret, ok := secrets[newClient.ID]; ok {
+			newClients[i].Credentials.Secret = secret
+		}
 	}
 
 	defer func() {
@@ -96,6 +132,14 @@ func (svc service) CreateClients(ctx context.Context, session authn.Session, cls
 		}
 	}()
 
+	// Secrets of new clients might have been presented before and marked
+	// as unknown.
+	var keys []string
+	for _, c := range clients {
+		keys = append(keys, cacheKeys(c.ID, c.Domain, c.Credentials.Secret)...)
+	}
+	svc.removeUnknown(ctx, keys...)
+
 	newBuiltInRoleMembers := map[roles.BuiltInRoleName][]roles.Member{
 		BuiltInRoleAdmin: {roles.Member(session.UserID)},
 	}
@@ -139,8 +183,13 @@ func (svc service) View(ctx context.Context, session authn.Session, id string, w
 }
 
 func (svc service) ListClients(ctx context.Context, session authn.Session, pm Page) (ClientsPage, error) {
-	switch session.SuperAdmin {
-	case true:
+	switch {
+	// Listing clients of a channel or a group is authorized against the
+	// channel or the group itself, so all of its clients are returned
+	// unless the caller explicitly asks for user scoped listing. Clients
+	// disconnected from a channel aren't covered by the channel, so they
+	// are always listed in the user scope.
+	case session.SuperAdmin, !pm.UserScoped && pm.Channel != "" && !pm.Disconnected, !pm.UserScoped && pm.Group != nil && *pm.Group != "":
 		pm.Domain = session.DomainID
 		cp, err := svc.repo.RetrieveAll(ctx, pm)
 		if err != nil {
@@ -170,8 +219,9 @@ func (svc service) Update(ctx context.Context, session authn.Session, cli Client
 		Name:            cli.Name,
 		Metadata:        cli.Metadata,
 		PrivateMetadata: cli.PrivateMetadata,
-		UpdatedAt:       time.Now().UTC(),
+		UpdatedAt:       svc.clock.Now().UTC(),
 		UpdatedBy:       session.UserID,
+		UnmodifiedSince: cli.UnmodifiedSince,
 	}
 	client, err := svc.repo.Update(ctx, client)
 	if err != nil {
@@ -184,7 +234,7 @@ func (svc service) UpdateTags(ctx context.Context, session authn.Session, cli Cl
 	client := Client{
 		ID:        cli.ID,
 		Tags:      cli.Tags,
-		UpdatedAt: time.Now().UTC(),
+		UpdatedAt: svc.clock.Now().UTC(),
 		UpdatedBy: session.UserID,
 	}
 	client, err := svc.repo.UpdateTags(ctx, client)
@@ -195,33 +245,141 @@ func (svc service) UpdateTags(ctx context.Context, session authn.Session, cli Cl
 }
 
 func (svc service) UpdateSecret(ctx context.Context, session authn.Session, id, key string) (Client, error) {
+	hash, err := svc.hasher.Hash(key)
+	if err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
 	client := Client{
 		ID: id,
 		Credentials: Credentials{
-			Secret: key,
+			Secret: hash,
 		},
-		UpdatedAt: time.Now().UTC(),
+		UpdatedAt: svc.clock.Now().UTC(),
 		UpdatedBy: session.UserID,
 		Status:    EnabledStatus,
 	}
-	client, err := svc.repo.UpdateSecret(ctx, client)
+	switch {
+	case svc.secretGracePeriod > 0:
+		client, err = svc.repo.RotateSecret(ctx, client, client.UpdatedAt.Add(svc.secretGracePeriod))
+	default:
+		client, err = svc.repo.UpdateSecret(ctx, client)
+	}
 	if err != nil {
 		return Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	// The new secret is already committed, so a stale cache entry must not
+	// turn the update into a failure; it expires with the key duration.
+	if err := svc.cache.Remove(ctx, id); err != nil {
+		svc.logger.Warn("failed to remove client from cache after secret update", slog.String("id", id), slog.Any("error", err))
+	}
+	svc.removeUnknown(ctx, cacheKeys(client.ID, client.Domain, hash)...)
+
 	return client, nil
 }
 
+func (svc service) UpdateOwner(ctx context.Context, session authn.Session, id, ownerID string) (retErr error) {
+	adminRole, err := svc.retrieveBuiltInAdminRole(ctx, session, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	// The caller may be a domain admin rather than the owner, so the
+	// previous owners are the current members of the admin role.
+	owners, err := svc.retrieveRoleMembers(ctx, session, id, adminRole.ID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	previous := slices.DeleteFunc(slices.Clone(owners), func(owner string) bool { return owner == ownerID })
+	isOwner := len(previous) < len(owners)
+	if isOwner && len(previous) == 0 {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errSameOwner)
+	}
+
+	// Both role membership changes write the owner policy first and the
+	// role members table second, so a failed policy migration never
+	// leaves the database pointing to the new owner.
+	if !isOwner {
+		if _, err := svc.RoleAddMembers(ctx, session, id, adminRole.ID, []string{ownerID}); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		defer func() {
+			if retErr != nil {
+				if errRollback := svc.RoleRemoveMembers(ctx, session, id, adminRole.ID, []string{ownerID}); errRollback != nil {
+					retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollback))
+				}
+			}
+		}()
+	}
+
+	if len(previous) > 0 {
+		if err := svc.RoleRemoveMembers(ctx, session, id, adminRole.ID, previous); err != nil {
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (svc service) AddCert(ctx context.Context, session authn.Session, id string, certPEM []byte) (Certificate, error) {
+	if svc.certs == nil {
+		return Certificate{}, errors.Wrap(svcerr.ErrCreateEntity, ErrCertAuthDisabled)
+	}
+	now := svc.clock.Now().UTC()
+	x509Cert, fingerprint, err := ParseCertificate(certPEM, now)
+	if err != nil {
+		return Certificate{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	cert := Certificate{
+		ClientID:     id,
+		SerialNumber: x509Cert.SerialNumber.String(),
+		Subject:      x509Cert.Subject.String(),
+		Fingerprint:  fingerprint,
+		CreatedAt:    now,
+	}
+	if err := svc.certs.Save(ctx, cert); err != nil {
+		return Certificate{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return cert, nil
+}
+
+func (svc service) RemoveCerts(ctx context.Context, session authn.Session, id string) error {
+	if svc.certs == nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, ErrCertAuthDisabled)
+	}
+	if err := svc.certs.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	// Authenticated certificates are cached under the client, so the
+	// client is evicted for them to stop authenticating.
+	if err := svc.cache.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
 func (svc service) Enable(ctx context.Context, session authn.Session, id string) (Client, error) {
 	client := Client{
 		ID:        id,
 		Status:    EnabledStatus,
-		UpdatedAt: time.Now().UTC(),
+		UpdatedAt: svc.clock.Now().UTC(),
 	}
 	client, err := svc.changeClientStatus(ctx, session, client)
 	if err != nil {
 		return Client{}, errors.Wrap(ErrEnableClient, err)
 	}
 
+	// The secret of the disabled client is marked as unknown if it has
+	// been presented in the meantime. The status change doesn't return the
+	// secret, so the client is read back for it.
+	c, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		svc.logger.Warn("failed to retrieve enabled client to remove its unknown keys from cache", slog.String("id", id), slog.Any("error", err))
+		return client, nil
+	}
+	svc.removeUnknown(ctx, cacheKeys(c.ID, c.Domain, c.Credentials.Secret)...)
+
 	return client, nil
 }
 
@@ -229,7 +387,7 @@ func (svc service) Disable(ctx context.Context, session authn.Session, id string
 	client := Client{
 		ID:        id,
 		Status:    DisabledStatus,
-		UpdatedAt: time.Now().UTC(),
+		UpdatedAt: svc.clock.Now().UTC(),
 	}
 	client, err := svc.changeClientStatus(ctx, session, client)
 	if err != nil {
@@ -289,7 +447,7 @@ func (svc service) SetParentGroup(ctx context.Context, session authn.Session, pa
 			}
 		}
 	}()
-	cli = Client{ID: id, ParentGroup: parentGroupID, UpdatedBy: session.UserID, UpdatedAt: time.Now().UTC()}
+	cli = Client{ID: id, ParentGroup: parentGroupID, UpdatedBy: session.UserID, UpdatedAt: svc.clock.Now().UTC()}
 
 	if err := svc.repo.SetParentGroup(ctx, cli); err != nil {
 		return errors.Wrap(svcerr.ErrUpdateEntity, err)
@@ -325,7 +483,7 @@ func (svc service) RemoveParentGroup(ctx context.Context, session authn.Session,
 			}
 		}()
 
-		cli := Client{ID: id, UpdatedBy: session.UserID, UpdatedAt: time.Now().UTC()}
+		cli := Client{ID: id, UpdatedBy: session.UserID, UpdatedAt: svc.clock.Now().UTC()}
 
 		if err := svc.repo.RemoveParentGroup(ctx, cli); err != nil {
 			return errors.Wrap(svcerr.ErrUpdateEntity, err)
@@ -353,16 +511,6 @@ func (svc service) Delete(ctx context.Context, session authn.Session, id string)
 		return errors.Wrap(svcerr.ErrRemoveEntity, err)
 	}
 
-	filterDeletePolicies := []policies.Policy{
-		{
-			SubjectType: policies.ClientType,
-			Subject:     id,
-		},
-		{
-			ObjectType: policies.ClientType,
-			Object:     id,
-		},
-	}
 	deletePolicies := []policies.Policy{
 		{
 			SubjectType: policies.DomainType,
@@ -373,7 +521,14 @@ func (svc service) Delete(ctx context.Context, session authn.Session, id string)
 		},
 	}
 
-	if err := svc.RemoveEntitiesRoles(ctx, session.DomainID, session.DomainUserID, []string{id}, filterDeletePolicies, deletePolicies); err != nil {
+	if err := svc.RemoveEntitiesRoles(ctx, session.DomainID, session.DomainUserID, []string{id}, nil, deletePolicies); err != nil {
+		return errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+
+	// Remove any relationship left referencing the client, such as connections
+	// and group memberships, before removing the client so that the delete can
+	// be retried on failure.
+	if err := svc.policy.RemoveObjectPolicies(ctx, policies.ClientType, id); err != nil {
 		return errors.Wrap(svcerr.ErrDeletePolicies, err)
 	}
 
@@ -401,3 +556,47 @@ func (svc service) changeClientStatus(ctx context.Context, session authn.Session
 	}
 	return client, nil
 }
+
+// removeUnknown removes the unknown markers of the given client keys. The
+// markers expire shortly on their own, so failing to remove them is logged
+// instead of failing the operation.
+func (svc service) removeUnknown(ctx context.Context, keys ...string) {
+	if err := svc.cache.RemoveUnknown(ctx, keys...); err != nil {
+		svc.logger.Warn("failed to remove unknown client keys from cache", slog.Any("error", err))
+	}
+}
+
+func (svc service) retrieveBuiltInAdminRole(ctx context.Context, session authn.Session, id string) (roles.Role, error) {
+	var offset uint64
+	for {
+		rp, err := svc.RetrieveAllRoles(ctx, session, id, defRolesLimit, offset)
+		if err != nil {
+			return roles.Role{}, err
+		}
+		for _, ro := range rp.Roles {
+			if ro.Name == BuiltInRoleAdmin.String() {
+				return ro, nil
+			}
+		}
+		offset += uint64(len(rp.Roles))
+		if len(rp.Roles) == 0 || offset >= rp.Total {
+			return roles.Role{}, errors.Wrap(svcerr.ErrNotFound, errAdminRoleNotFound)
+		}
+	}
+}
+
+func (svc service) retrieveRoleMembers(ctx context.Context, session authn.Session, id, roleID string) ([]string, error) {
+	var members []string
+	var offset uint64
+	for {
+		mp, err := svc.RoleListMembers(ctx, session, id, roleID, defRolesLimit, offset)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, mp.Members...)
+		offset += uint64(len(mp.Members))
+		if len(mp.Members) == 0 || offset >= mp.Total {
+			return members, nil
+		}
+	}
+}
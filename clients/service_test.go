@@ -5,17 +5,30 @@ package clients_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"slices"
 	"testing"
+	"time"
 
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/clients/hasher"
 	climocks "github.com/absmach/supermq/clients/mocks"
 	gpmocks "github.com/absmach/supermq/groups/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
@@ -26,6 +39,7 @@ import (
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -65,23 +79,29 @@ var (
 	pService     *policymocks.Service
 	cache        *climocks.Cache
 	repo         *climocks.Repository
+	certs        *climocks.CertificateRepository
 	chgRPCClient *chmocks.ChannelsServiceClient
 	gpgRPCClient *gpmocks.GroupsServiceClient
 )
 
 func newService() clients.Service {
+	return newServiceWithSecretGracePeriod(0)
+}
+
+func newServiceWithSecretGracePeriod(secretGracePeriod time.Duration) clients.Service {
 	pService = new(policymocks.Service)
 	cache = new(climocks.Cache)
 	idProvider := uuid.NewMock()
 	sidProvider := uuid.NewMock()
 	repo = new(climocks.Repository)
+	certs = new(climocks.CertificateRepository)
 	chgRPCClient = new(chmocks.ChannelsServiceClient)
 	gpgRPCClient = new(gpmocks.GroupsServiceClient)
 	availableActions := []roles.Action{}
 	builtInRoles := map[roles.BuiltInRoleName][]roles.Action{
 		clients.BuiltInRoleAdmin: availableActions,
 	}
-	tsv, _ := clients.NewService(repo, pService, cache, chgRPCClient, gpgRPCClient, idProvider, sidProvider, availableActions, builtInRoles)
+	tsv, _ := clients.NewService(repo, certs, pService, cache, chgRPCClient, gpgRPCClient, idProvider, sidProvider, hasher.NewPlaintext(), availableActions, builtInRoles, secretGracePeriod, nil, smqlog.NewMock())
 	return tsv
 }
 
@@ -89,15 +109,16 @@ func TestCreateClients(t *testing.T) {
 	svc := newService()
 
 	cases := []struct {
-		desc            string
-		client          clients.Client
-		token           string
-		addPolicyErr    error
-		deletePolicyErr error
-		saveErr         error
-		addRoleErr      error
-		deleteErr       error
-		err             error
+		desc             string
+		client           clients.Client
+		token            string
+		addPolicyErr     error
+		deletePolicyErr  error
+		saveErr          error
+		addRoleErr       error
+		deleteErr        error
+		removeUnknownErr error
+		err              error
 	}{
 		{
 			desc:   "create a new client successfully",
@@ -307,6 +328,18 @@ func TestCreateClients(t *testing.T) {
 			saveErr:         repoerr.ErrConflict,
 			deletePolicyErr: svcerr.ErrInvalidPolicy,
 			err:             repoerr.ErrConflict,
+		}, {
+			desc: "create a new client with failed to remove unknown keys",
+			client: clients.Client{
+				Credentials: clients.Credentials{
+					Identity: "newclientwithfailedcache@example.com",
+					Secret:   secret,
+				},
+				Status: clients.EnabledStatus,
+			},
+			token:            validToken,
+			removeUnknownErr: repoerr.ErrRemoveEntity,
+			err:              nil,
 		},
 	}
 
@@ -317,9 +350,17 @@ func TestCreateClients(t *testing.T) {
 			policyCall1 := pService.On("DeletePolicies", context.Background(), mock.Anything).Return(tc.deletePolicyErr)
 			repoCall1 := repo.On("AddRoles", context.Background(), mock.Anything).Return([]roles.RoleProvision{}, tc.addRoleErr)
 			repoCall2 := repo.On("Delete", context.Background(), mock.Anything).Return(tc.deleteErr)
+			cacheCall := cache.On("RemoveUnknown", context.Background(), mock.Anything).Return(tc.removeUnknownErr)
 			expected, _, err := svc.CreateClients(context.Background(), smqauthn.Session{}, tc.client)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			if err == nil {
+				// Only the markers of the new client secret are removed.
+				secret := tc.client.Credentials.Secret
+				unknownKey := clients.SecretKey{Secret: secret, Prefix: smqauthn.DomainAuth}.CacheKey()
+				ok := cache.AssertCalled(t, "RemoveUnknown", context.Background(), mock.MatchedBy(func(keys []string) bool {
+					return len(keys) == 2 && (secret == "" || slices.Contains(keys, unknownKey))
+				}))
+				assert.True(t, ok, fmt.Sprintf("RemoveUnknown was not called with the new client keys on %s", tc.desc))
 				tc.client.ID = expected[0].ID
 				tc.client.CreatedAt = expected[0].CreatedAt
 				tc.client.UpdatedAt = expected[0].UpdatedAt
@@ -333,6 +374,7 @@ func TestCreateClients(t *testing.T) {
 			policyCall1.Unset()
 			repoCall1.Unset()
 			repoCall2.Unset()
+			cacheCall.Unset()
 		})
 	}
 }
@@ -602,6 +644,75 @@ func TestListClients(t *testing.T) {
 			retrieveAllCall.Unset()
 		})
 	}
+
+	channelID := testsutil.GenerateUUID(t)
+	groupID := testsutil.GenerateUUID(t)
+	sharedClients := clients.ClientsPage{
+		Page:    clients.Page{Total: 2, Offset: 0, Limit: 100},
+		Clients: []clients.Client{client, clientWithRoles},
+	}
+	userClients := clients.ClientsPage{
+		Page:    clients.Page{Total: 1, Offset: 0, Limit: 100},
+		Clients: []clients.Client{client},
+	}
+
+	cases3 := []struct {
+		desc     string
+		session  smqauthn.Session
+		page     clients.Page
+		response clients.ClientsPage
+	}{
+		{
+			desc:     "list channel clients as non admin returns all channel clients",
+			session:  smqauthn.Session{UserID: nonAdminID, DomainID: domainID},
+			page:     clients.Page{Offset: 0, Limit: 100, Channel: channelID},
+			response: sharedClients,
+		},
+		{
+			desc:     "list group clients as non admin returns all group clients",
+			session:  smqauthn.Session{UserID: nonAdminID, DomainID: domainID},
+			page:     clients.Page{Offset: 0, Limit: 100, Group: &groupID},
+			response: sharedClients,
+		},
+		{
+			desc:     "list channel clients as non admin with user scope",
+			session:  smqauthn.Session{UserID: nonAdminID, DomainID: domainID},
+			page:     clients.Page{Offset: 0, Limit: 100, Channel: channelID, UserScoped: true},
+			response: userClients,
+		},
+		{
+			desc:     "list group clients as non admin with user scope",
+			session:  smqauthn.Session{UserID: nonAdminID, DomainID: domainID},
+			page:     clients.Page{Offset: 0, Limit: 100, Group: &groupID, UserScoped: true},
+			response: userClients,
+		},
+		{
+			desc:     "list clients disconnected from channel as non admin returns user clients",
+			session:  smqauthn.Session{UserID: nonAdminID, DomainID: domainID},
+			page:     clients.Page{Offset: 0, Limit: 100, Channel: channelID, Disconnected: true},
+			response: userClients,
+		},
+		{
+			desc:     "list clients disconnected from channel as super admin returns all clients",
+			session:  smqauthn.Session{UserID: adminID, DomainID: domainID, SuperAdmin: true},
+			page:     clients.Page{Offset: 0, Limit: 100, Channel: channelID, Disconnected: true},
+			response: sharedClients,
+		},
+	}
+
+	for _, tc := range cases3 {
+		t.Run(tc.desc, func(t *testing.T) {
+			domainPage := tc.page
+			domainPage.Domain = tc.session.DomainID
+			retrieveAllCall := repo.On("RetrieveAll", context.Background(), domainPage).Return(sharedClients, nil)
+			retrieveUserClientsCall := repo.On("RetrieveUserClients", context.Background(), tc.session.DomainID, tc.session.UserID, tc.page).Return(userClients, nil)
+			page, err := svc.ListClients(context.Background(), tc.session, tc.page)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s\n", tc.desc, err))
+			assert.Equal(t, tc.response, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, page))
+			retrieveAllCall.Unset()
+			retrieveUserClientsCall.Unset()
+		})
+	}
 }
 
 func TestUpdateClient(t *testing.T) {
@@ -698,15 +809,17 @@ func TestUpdateTags(t *testing.T) {
 }
 
 func TestUpdateSecret(t *testing.T) {
-	svc := newService()
+	gracePeriod := time.Hour
 
 	cases := []struct {
 		desc                 string
 		client               clients.Client
 		newSecret            string
+		secretGracePeriod    time.Duration
 		updateSecretResponse clients.Client
 		session              smqauthn.Session
 		updateErr            error
+		removeCacheErr       error
 		err                  error
 	}{
 		{
@@ -732,19 +845,343 @@ func TestUpdateSecret(t *testing.T) {
 			updateErr:            repoerr.ErrMalformedEntity,
 			err:                  svcerr.ErrUpdateEntity,
 		},
+		{
+			desc:              "update client secret with grace period successfully",
+			client:            client,
+			newSecret:         "newSecret",
+			secretGracePeriod: gracePeriod,
+			session:           smqauthn.Session{UserID: validID},
+			updateSecretResponse: clients.Client{
+				ID: client.ID,
+				Credentials: clients.Credentials{
+					Identity: client.Credentials.Identity,
+					Secret:   "newSecret",
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:                 "update client secret with grace period with failed to rotate secret",
+			client:               client,
+			newSecret:            "newSecret",
+			secretGracePeriod:    gracePeriod,
+			session:              smqauthn.Session{UserID: validID},
+			updateSecretResponse: clients.Client{},
+			updateErr:            repoerr.ErrMalformedEntity,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:      "update client secret with failed to remove cache",
+			client:    client,
+			newSecret: "newSecret",
+			session:   smqauthn.Session{UserID: validID},
+			updateSecretResponse: clients.Client{
+				ID: client.ID,
+				Credentials: clients.Credentials{
+					Identity: client.Credentials.Identity,
+					Secret:   "newSecret",
+				},
+			},
+			removeCacheErr: svcerr.ErrRemoveEntity,
+			err:            nil,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			repoCall := repo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateErr)
+			svc := newServiceWithSecretGracePeriod(tc.secretGracePeriod)
+			var repoCall *mock.Call
+			switch {
+			case tc.secretGracePeriod > 0:
+				repoCall = repo.On("RotateSecret", context.Background(), mock.Anything, mock.Anything).Return(tc.updateSecretResponse, tc.updateErr)
+			default:
+				repoCall = repo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateErr)
+			}
+			cacheCall := cache.On("Remove", context.Background(), tc.client.ID).Return(tc.removeCacheErr)
+			cacheCall1 := cache.On("RemoveUnknown", context.Background(), mock.Anything).Return(nil)
 			updatedClient, err := svc.UpdateSecret(context.Background(), tc.session, tc.client.ID, tc.newSecret)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			assert.Equal(t, tc.updateSecretResponse, updatedClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.updateSecretResponse, updatedClient))
+			if tc.secretGracePeriod > 0 && tc.updateErr == nil {
+				ok := repo.AssertCalled(t, "RotateSecret", context.Background(), mock.Anything, mock.MatchedBy(func(expiresAt time.Time) bool {
+					return time.Until(expiresAt) > 0 && time.Until(expiresAt) <= tc.secretGracePeriod
+				}))
+				assert.True(t, ok, fmt.Sprintf("RotateSecret was not called with the expected expiration on %s", tc.desc))
+			}
+			if err == nil {
+				keys := []string{
+					clients.SecretKey{Secret: tc.newSecret, ID: tc.client.ID, Prefix: smqauthn.BasicAuth}.CacheKey(),
+					clients.SecretKey{Secret: tc.newSecret, Prefix: smqauthn.DomainAuth}.CacheKey(),
+				}
+				cache.AssertCalled(t, "RemoveUnknown", context.Background(), keys)
+			}
+			repoCall.Unset()
+			cacheCall.Unset()
+			cacheCall1.Unset()
+		})
+	}
+}
+
+func TestUpdateOwner(t *testing.T) {
+	svc := newService()
+
+	oldOwner := validID
+	newOwner := testsutil.GenerateUUID(t)
+	admin := testsutil.GenerateUUID(t)
+	session := smqauthn.Session{UserID: oldOwner, DomainID: validID, DomainUserID: validID + "_" + oldOwner}
+	adminSession := smqauthn.Session{UserID: admin, DomainID: validID, DomainUserID: validID + "_" + admin, SuperAdmin: true}
+	adminRole := roles.Role{ID: testsutil.GenerateUUID(t), Name: clients.BuiltInRoleAdmin.String(), EntityID: client.ID}
+	newOwnerPolicies := []policysvc.Policy{
+		{
+			SubjectType: policysvc.UserType,
+			Subject:     policysvc.EncodeDomainUserID(session.DomainID, newOwner),
+			Relation:    policysvc.MemberRelation,
+			Object:      adminRole.ID,
+			ObjectType:  policysvc.RoleType,
+		},
+	}
+	oldOwnerPolicies := []policysvc.Policy{
+		{
+			SubjectType: policysvc.UserType,
+			Subject:     policysvc.EncodeDomainUserID(session.DomainID, oldOwner),
+			Relation:    policysvc.MemberRelation,
+			Object:      adminRole.ID,
+			ObjectType:  policysvc.RoleType,
+		},
+	}
+
+	cases := []struct {
+		desc                   string
+		session                smqauthn.Session
+		id                     string
+		ownerID                string
+		rolesPage              roles.RolePage
+		retrieveRolesErr       error
+		membersPage            roles.MembersPage
+		listMembersErr         error
+		addPoliciesErr         error
+		addMembersErr          error
+		deleteOldPoliciesErr   error
+		removeMembersErr       error
+		deleteNewPoliciesErr   error
+		newOwnerAdded          bool
+		oldOwnerPolicyRemoved  bool
+		newOwnerPolicyRollback bool
+		err                    error
+	}{
+		{
+			desc:                  "update client owner successfully",
+			session:               session,
+			id:                    client.ID,
+			ownerID:               newOwner,
+			rolesPage:             roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}},
+			membersPage:           roles.MembersPage{Total: 1, Members: []string{oldOwner}},
+			newOwnerAdded:         true,
+			oldOwnerPolicyRemoved: true,
+		},
+		{
+			desc:                  "update client owner by domain admin",
+			session:               adminSession,
+			id:                    client.ID,
+			ownerID:               newOwner,
+			rolesPage:             roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}},
+			membersPage:           roles.MembersPage{Total: 1, Members: []string{oldOwner}},
+			newOwnerAdded:         true,
+			oldOwnerPolicyRemoved: true,
+		},
+		{
+			desc:                  "update client owner to one of the current owners",
+			session:               session,
+			id:                    client.ID,
+			ownerID:               newOwner,
+			rolesPage:             roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}},
+			membersPage:           roles.MembersPage{Total: 2, Members: []string{oldOwner, newOwner}},
+			oldOwnerPolicyRemoved: true,
+		},
+		{
+			desc:        "update client owner to the current owner",
+			session:     session,
+			id:          client.ID,
+			ownerID:     oldOwner,
+			rolesPage:   roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}},
+			membersPage: roles.MembersPage{Total: 1, Members: []string{oldOwner}},
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:             "update client owner with failed to retrieve roles",
+			session:          session,
+			id:               client.ID,
+			ownerID:          newOwner,
+			retrieveRolesErr: repoerr.ErrNotFound,
+			err:              svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:      "update client owner without built-in admin role",
+			session:   session,
+			id:        client.ID,
+			ownerID:   newOwner,
+			rolesPage: roles.RolePage{Total: 1, Roles: []roles.Role{{ID: testsutil.GenerateUUID(t), Name: "viewer"}}},
+			err:       svcerr.ErrNotFound,
+		},
+		{
+			desc:           "update client owner with failed to list current owners",
+			session:        session,
+			id:             client.ID,
+			ownerID:        newOwner,
+			rolesPage:      roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}},
+			listMembersErr: repoerr.ErrViewEntity,
+			err:            svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:           "update client owner with failed to add new owner policy",
+			session:        session,
+			id:             client.ID,
+			ownerID:        newOwner,
+			rolesPage:      roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}},
+			membersPage:    roles.MembersPage{Total: 1, Members: []string{oldOwner}},
+			addPoliciesErr: svcerr.ErrAuthorization,
+			err:            svcerr.ErrAddPolicies,
+		},
+		{
+			desc:          "update client owner with failed to add new owner to admin role",
+			session:       session,
+			id:            client.ID,
+			ownerID:       newOwner,
+			rolesPage:     roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}},
+			membersPage:   roles.MembersPage{Total: 1, Members: []string{oldOwner}},
+			addMembersErr: repoerr.ErrCreateEntity,
+			err:           svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                   "update client owner with failed to remove old owner policy",
+			session:                session,
+			id:                     client.ID,
+			ownerID:                newOwner,
+			rolesPage:              roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}},
+			membersPage:            roles.MembersPage{Total: 1, Members: []string{oldOwner}},
+			deleteOldPoliciesErr:   svcerr.ErrAuthorization,
+			newOwnerPolicyRollback: true,
+			err:                    svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:                   "update client owner with failed to remove old owner and failed to rollback",
+			session:                session,
+			id:                     client.ID,
+			ownerID:                newOwner,
+			rolesPage:              roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}},
+			membersPage:            roles.MembersPage{Total: 1, Members: []string{oldOwner}},
+			deleteOldPoliciesErr:   svcerr.ErrAuthorization,
+			deleteNewPoliciesErr:   svcerr.ErrAuthorization,
+			newOwnerPolicyRollback: true,
+			err:                    apiutil.ErrRollbackTx,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveAllRoles", context.Background(), tc.id, uint64(100), uint64(0)).Return(tc.rolesPage, tc.retrieveRolesErr)
+			repoCall1 := repo.On("RetrieveEntityRole", context.Background(), tc.id, adminRole.ID).Return(adminRole, nil)
+			repoCall4 := repo.On("RoleListMembers", context.Background(), adminRole.ID, uint64(100), uint64(0)).Return(tc.membersPage, tc.listMembersErr)
+			policyCall := pService.On("AddPolicies", context.Background(), newOwnerPolicies).Return(tc.addPoliciesErr)
+			repoCall2 := repo.On("RoleAddMembers", context.Background(), mock.Anything, []string{tc.ownerID}).Return([]string{tc.ownerID}, tc.addMembersErr)
+			policyCall1 := pService.On("DeletePolicies", context.Background(), oldOwnerPolicies).Return(tc.deleteOldPoliciesErr)
+			policyCall2 := pService.On("DeletePolicies", context.Background(), newOwnerPolicies).Return(tc.deleteNewPoliciesErr)
+			repoCall3 := repo.On("RoleRemoveMembers", context.Background(), mock.Anything, mock.Anything).Return(tc.removeMembersErr)
+			err := svc.UpdateOwner(context.Background(), tc.session, tc.id, tc.ownerID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.newOwnerAdded {
+				ok := pService.AssertCalled(t, "AddPolicies", context.Background(), newOwnerPolicies)
+				assert.True(t, ok, fmt.Sprintf("%s: new owner policy was not added", tc.desc))
+			} else if tc.err == nil {
+				ok := pService.AssertNotCalled(t, "AddPolicies", context.Background(), newOwnerPolicies)
+				assert.True(t, ok, fmt.Sprintf("%s: existing owner policy was added again", tc.desc))
+			}
+			if tc.oldOwnerPolicyRemoved {
+				ok := pService.AssertCalled(t, "DeletePolicies", context.Background(), oldOwnerPolicies)
+				assert.True(t, ok, fmt.Sprintf("%s: previous owner policy was not removed", tc.desc))
+			}
+			if tc.newOwnerPolicyRollback {
+				ok := pService.AssertCalled(t, "DeletePolicies", context.Background(), newOwnerPolicies)
+				assert.True(t, ok, fmt.Sprintf("%s: new owner policy was not rolled back", tc.desc))
+			}
 			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			repoCall4.Unset()
+			policyCall.Unset()
+			policyCall1.Unset()
+			policyCall2.Unset()
+			pService.Calls = nil
 		})
 	}
 }
 
+func TestUpdateOwnerTransfersAccess(t *testing.T) {
+	svc := newService()
+
+	oldOwner := validID
+	newOwner := testsutil.GenerateUUID(t)
+	session := smqauthn.Session{UserID: oldOwner, DomainID: validID, DomainUserID: validID + "_" + oldOwner}
+	adminRole := roles.Role{ID: testsutil.GenerateUUID(t), Name: clients.BuiltInRoleAdmin.String(), EntityID: client.ID}
+
+	// The policies and role members are kept in memory, so the test checks
+	// the resulting access instead of the calls made to migrate it.
+	rolePolicies := map[string]bool{policysvc.EncodeDomainUserID(session.DomainID, oldOwner): true}
+	members := map[string]bool{oldOwner: true}
+	// Client deletion is granted through the built-in admin role membership.
+	canDelete := func(userID string) bool {
+		return rolePolicies[policysvc.EncodeDomainUserID(session.DomainID, userID)] && members[userID]
+	}
+
+	repoCall := repo.On("RetrieveAllRoles", context.Background(), client.ID, uint64(100), uint64(0)).Return(roles.RolePage{Total: 1, Roles: []roles.Role{adminRole}}, nil)
+	repoCall1 := repo.On("RetrieveEntityRole", context.Background(), client.ID, adminRole.ID).Return(adminRole, nil)
+	repoCall2 := repo.On("RoleListMembers", context.Background(), adminRole.ID, uint64(100), uint64(0)).Return(roles.MembersPage{Total: 1, Members: []string{oldOwner}}, nil)
+	repoCall3 := repo.On("RoleAddMembers", context.Background(), mock.Anything, mock.Anything).Return([]string{newOwner}, nil).Run(func(args mock.Arguments) {
+		for _, m := range args.Get(2).([]string) {
+			members[m] = true
+		}
+	})
+	repoCall4 := repo.On("RoleRemoveMembers", context.Background(), mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		for _, m := range args.Get(2).([]string) {
+			delete(members, m)
+		}
+	})
+	policyCall := pService.On("AddPolicies", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		for _, pr := range args.Get(1).([]policysvc.Policy) {
+			if pr.Object == adminRole.ID && pr.Relation == policysvc.MemberRelation {
+				rolePolicies[pr.Subject] = true
+			}
+		}
+	})
+	policyCall1 := pService.On("DeletePolicies", context.Background(), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		for _, pr := range args.Get(1).([]policysvc.Policy) {
+			if pr.Object == adminRole.ID && pr.Relation == policysvc.MemberRelation {
+				delete(rolePolicies, pr.Subject)
+			}
+		}
+	})
+	defer func() {
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		policyCall.Unset()
+		policyCall1.Unset()
+		pService.Calls = nil
+	}()
+
+	require.True(t, canDelete(oldOwner), "previous owner should be able to delete the client before the transfer")
+	require.False(t, canDelete(newOwner), "new owner shouldn't be able to delete the client before the transfer")
+
+	err := svc.UpdateOwner(context.Background(), session, client.ID, newOwner)
+	assert.Nil(t, err, fmt.Sprintf("update client owner: expected nil got %s\n", err))
+	assert.False(t, canDelete(oldOwner), "previous owner shouldn't be able to delete the client after the transfer")
+	assert.True(t, canDelete(newOwner), "new owner should be able to delete the client after the transfer")
+	assert.Equal(t, map[string]bool{newOwner: true}, members, "new owner should be the only admin role member")
+}
+
 func TestEnable(t *testing.T) {
 	svc := newService()
 
@@ -762,6 +1199,7 @@ func TestEnable(t *testing.T) {
 		retrieveByIDResponse clients.Client
 		changeStatusErr      error
 		retrieveIDErr        error
+		removeUnknownErr     error
 		err                  error
 	}{
 		{
@@ -783,6 +1221,16 @@ func TestEnable(t *testing.T) {
 			changeStatusErr:      repoerr.ErrMalformedEntity,
 			err:                  svcerr.ErrUpdateEntity,
 		},
+		{
+			desc:                 "enable disabled client with failed to remove unknown keys",
+			id:                   disabledClient1.ID,
+			session:              smqauthn.Session{UserID: validID},
+			client:               disabledClient1,
+			changeStatusResponse: endisabledClient1,
+			retrieveByIDResponse: disabledClient1,
+			removeUnknownErr:     repoerr.ErrRemoveEntity,
+			err:                  nil,
+		},
 		{
 			desc:                 "enable enabled client",
 			id:                   enabledClient1.ID,
@@ -809,10 +1257,19 @@ func TestEnable(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			repoCall := repo.On("RetrieveByID", context.Background(), mock.Anything).Return(tc.retrieveByIDResponse, tc.retrieveIDErr)
 			repoCall1 := repo.On("ChangeStatus", context.Background(), mock.Anything).Return(tc.changeStatusResponse, tc.changeStatusErr)
+			cacheCall := cache.On("RemoveUnknown", context.Background(), mock.Anything).Return(tc.removeUnknownErr)
 			_, err := svc.Enable(context.Background(), tc.session, tc.id)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				keys := []string{
+					clients.SecretKey{Secret: tc.retrieveByIDResponse.Credentials.Secret, ID: tc.retrieveByIDResponse.ID, Prefix: smqauthn.BasicAuth}.CacheKey(),
+					clients.SecretKey{Secret: tc.retrieveByIDResponse.Credentials.Secret, Prefix: smqauthn.DomainAuth}.CacheKey(),
+				}
+				cache.AssertCalled(t, "RemoveUnknown", context.Background(), keys)
+			}
 			repoCall.Unset()
 			repoCall1.Unset()
+			cacheCall.Unset()
 		})
 	}
 }
@@ -917,6 +1374,7 @@ func TestDelete(t *testing.T) {
 		removeConnectionsErr error
 		changeStatusErr      error
 		deletePoliciesErr    error
+		removePoliciesErr    error
 		removeErr            error
 		deleteErr            error
 		err                  error
@@ -969,6 +1427,19 @@ func TestDelete(t *testing.T) {
 			deleteErr: svcerr.ErrNotFound,
 			err:       svcerr.ErrRemoveEntity,
 		},
+		{
+			desc:              "Delete client with failed to remove remaining policies",
+			clientID:          client.ID,
+			removePoliciesErr: svcerr.ErrNotFound,
+			err:               svcerr.ErrDeletePolicies,
+		},
+		{
+			desc:              "Delete client with failed to remove remaining policies keeps the client",
+			clientID:          client.ID,
+			removePoliciesErr: svcerr.ErrNotFound,
+			deleteErr:         svcerr.ErrNotFound,
+			err:               svcerr.ErrDeletePolicies,
+		},
 	}
 
 	for _, tc := range cases {
@@ -979,7 +1450,7 @@ func TestDelete(t *testing.T) {
 			repoCall2 := repo.On("ChangeStatus", context.Background(), clients.Client{ID: tc.clientID, Status: clients.DeletedStatus}).Return(client, tc.changeStatusErr)
 			repoCall3 := repo.On("RetrieveEntitiesRolesActionsMembers", context.Background(), []string{tc.clientID}).Return([]roles.EntityActionRole{}, []roles.EntityMemberRole{}, nil)
 			policyCall1 := pService.On("DeletePolicies", context.Background(), mock.Anything).Return(tc.deletePoliciesErr)
-			policyCall2 := pService.On("DeletePolicyFilter", context.Background(), mock.Anything).Return(tc.deletePoliciesErr)
+			policyCall2 := pService.On("RemoveObjectPolicies", context.Background(), policysvc.ClientType, tc.clientID).Return(tc.removePoliciesErr)
 			repoCall4 := repo.On("Delete", context.Background(), []string{tc.clientID}).Return(tc.deleteErr)
 			err := svc.Delete(context.Background(), smqauthn.Session{}, tc.clientID)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
@@ -1260,3 +1731,114 @@ func TestRemoveParentGroup(t *testing.T) {
 		})
 	}
 }
+
+func newCertificate(t *testing.T, notAfter time.Time) (*x509.Certificate, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error generating key: %s", err))
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: client.ID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error creating certificate: %s", err))
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error parsing certificate: %s", err))
+
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestAddCert(t *testing.T) {
+	cert, certPEM := newCertificate(t, time.Now().Add(time.Hour))
+	_, expiredPEM := newCertificate(t, time.Now().Add(-time.Minute))
+	sum := sha256.Sum256(cert.Raw)
+
+	cases := []struct {
+		desc    string
+		certPEM []byte
+		saveErr error
+		err     error
+	}{
+		{
+			desc:    "add client certificate successfully",
+			certPEM: certPEM,
+			err:     nil,
+		},
+		{
+			desc:    "add malformed client certificate",
+			certPEM: []byte("certificate"),
+			err:     clients.ErrInvalidCertificate,
+		},
+		{
+			desc:    "add expired client certificate",
+			certPEM: expiredPEM,
+			err:     clients.ErrInvalidCertificate,
+		},
+		{
+			desc:    "add client certificate bound to another client",
+			certPEM: certPEM,
+			saveErr: repoerr.ErrConflict,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := newService()
+			repoCall := certs.On("Save", context.Background(), mock.Anything).Return(tc.saveErr)
+			added, err := svc.AddCert(context.Background(), smqauthn.Session{}, client.ID, tc.certPEM)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, client.ID, added.ClientID, fmt.Sprintf("%s: expected client ID %s got %s\n", tc.desc, client.ID, added.ClientID))
+				assert.Equal(t, cert.SerialNumber.String(), added.SerialNumber, fmt.Sprintf("%s: expected serial number %s got %s\n", tc.desc, cert.SerialNumber, added.SerialNumber))
+				assert.Equal(t, cert.Subject.String(), added.Subject, fmt.Sprintf("%s: expected subject %s got %s\n", tc.desc, cert.Subject, added.Subject))
+				assert.Equal(t, hex.EncodeToString(sum[:]), added.Fingerprint, fmt.Sprintf("%s: expected fingerprint %x got %s\n", tc.desc, sum, added.Fingerprint))
+				certs.AssertCalled(t, "Save", context.Background(), added)
+			}
+			if errors.Contains(tc.err, clients.ErrInvalidCertificate) {
+				certs.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRemoveCerts(t *testing.T) {
+	cases := []struct {
+		desc           string
+		removeErr      error
+		removeCacheErr error
+		err            error
+	}{
+		{
+			desc: "remove client certificates successfully",
+			err:  nil,
+		},
+		{
+			desc:      "remove client certificates with failed to remove from repo",
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:           "remove client certificates with failed to remove cache",
+			removeCacheErr: repoerr.ErrRemoveEntity,
+			err:            svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := newService()
+			repoCall := certs.On("Remove", context.Background(), client.ID).Return(tc.removeErr)
+			cacheCall := cache.On("Remove", context.Background(), client.ID).Return(tc.removeCacheErr)
+			err := svc.RemoveCerts(context.Background(), smqauthn.Session{}, client.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.removeErr != nil {
+				cache.AssertNotCalled(t, "Remove", mock.Anything, mock.Anything)
+			}
+			repoCall.Unset()
+			cacheCall.Unset()
+		})
+	}
+}
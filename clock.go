@@ -0,0 +1,25 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package supermq
+
+import "time"
+
+// Clock specifies an API for retrieving the current time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+var _ Clock = (*systemClock)(nil)
+
+type systemClock struct{}
+
+// NewClock returns a Clock backed by the system time.
+func NewClock() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net/netip"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
@@ -17,6 +19,7 @@ import (
 	grpcAuthV1 "github.com/absmach/supermq/api/grpc/auth/v1"
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	"github.com/absmach/supermq/auth"
+	grpcapi "github.com/absmach/supermq/auth/api/grpc"
 	authgrpcapi "github.com/absmach/supermq/auth/api/grpc/auth"
 	tokengrpcapi "github.com/absmach/supermq/auth/api/grpc/token"
 	httpapi "github.com/absmach/supermq/auth/api/http"
@@ -29,6 +32,7 @@ import (
 	redisclient "github.com/absmach/supermq/internal/clients/redis"
 	smqlog "github.com/absmach/supermq/logger"
 	"github.com/absmach/supermq/pkg/jaeger"
+	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/pkg/policies/spicedb"
 	pgclient "github.com/absmach/supermq/pkg/postgres"
 	"github.com/absmach/supermq/pkg/prometheus"
@@ -36,7 +40,6 @@ import (
 	grpcserver "github.com/absmach/supermq/pkg/server/grpc"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
 	"github.com/absmach/supermq/pkg/uuid"
-	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/authzed/authzed-go/v1"
 	"github.com/authzed/grpcutil"
 	"github.com/caarlos0/env/v11"
@@ -75,12 +78,21 @@ type config struct {
 	SpicedbPort                   string        `env:"SMQ_SPICEDB_PORT"                           envDefault:"50051"`
 	SpicedbSchemaFile             string        `env:"SMQ_SPICEDB_SCHEMA_FILE"                    envDefault:"./docker/spicedb/schema.zed"`
 	SpicedbPreSharedKey           string        `env:"SMQ_SPICEDB_PRE_SHARED_KEY"                 envDefault:"12345678"`
+	SpicedbSchemaForce            bool          `env:"SMQ_SPICEDB_SCHEMA_FORCE"                   envDefault:"false"`
 	TraceRatio                    float64       `env:"SMQ_JAEGER_TRACE_RATIO"                     envDefault:"1.0"`
 	ESURL                         string        `env:"SMQ_ES_URL"                                 envDefault:"nats://localhost:4222"`
 	CacheURL                      string        `env:"SMQ_AUTH_CACHE_URL"                         envDefault:"redis://localhost:6379/0"`
 	CacheKeyDuration              time.Duration `env:"SMQ_AUTH_CACHE_KEY_DURATION"                envDefault:"10m"`
 	JWKSCacheMaxAge               int           `env:"SMQ_AUTH_JWKS_CACHE_MAX_AGE"                envDefault:"900"`
 	JWKSCacheStaleWhileRevalidate int           `env:"SMQ_AUTH_JWKS_CACHE_STALE_WHILE_REVALIDATE" envDefault:"60"`
+	IdentityCacheSize             int           `env:"SMQ_AUTH_IDENTITY_CACHE_SIZE"               envDefault:"0"`
+	ExpiredKeysSweepInterval      time.Duration `env:"SMQ_AUTH_EXPIRED_KEYS_SWEEP_INTERVAL"       envDefault:"1h"`
+	AuditLog                      bool          `env:"SMQ_AUTH_AUDIT_LOG"                         envDefault:"false"`
+	AuditLogBufferSize            int           `env:"SMQ_AUTH_AUDIT_LOG_BUFFER_SIZE"             envDefault:"1024"`
+	AuditLogFlushInterval         time.Duration `env:"SMQ_AUTH_AUDIT_LOG_FLUSH_INTERVAL"          envDefault:"1s"`
+	AuditLogRetention             time.Duration `env:"SMQ_AUTH_AUDIT_LOG_RETENTION"               envDefault:"2160h"`
+	AuditLogSweepInterval         time.Duration `env:"SMQ_AUTH_AUDIT_LOG_SWEEP_INTERVAL"          envDefault:"1h"`
+	TrustedProxies                []string      `env:"SMQ_AUTH_TRUSTED_PROXIES"                   envDefault:""`
 }
 
 func main() {
@@ -130,6 +142,24 @@ func main() {
 	}
 	defer db.Close()
 
+	if err := pgclient.RegisterStats(db, dbConfig); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+
+	replicas, err := pgclient.ConnectReplicas(dbConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer func() {
+		for _, replica := range replicas {
+			replica.Close()
+		}
+	}()
+
 	tp, err := jaeger.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
@@ -143,7 +173,8 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
-	spicedbclient, err := initSpiceDB(ctx, cfg)
+	schemaVersions := apostgres.NewSchemaVersionRepo(pgclient.NewDatabase(db, dbConfig, tracer))
+	spicedbclient, err := initSpiceDB(ctx, cfg, schemaVersions, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init spicedb grpc client : %s\n", err.Error()))
 		exitCode = 1
@@ -183,7 +214,9 @@ func main() {
 		}
 	}
 
-	svc, err := newService(db, tracer, cfg, dbConfig, logger, spicedbclient, cacheclient, cfg.CacheKeyDuration, tokenizer, idProvider)
+	pService := spicedb.NewPolicyService(spicedbclient, logger)
+
+	svc, err := newService(ctx, db, replicas, tracer, cfg, dbConfig, logger, spicedbclient, pService, cacheclient, cfg.CacheKeyDuration, tokenizer, idProvider)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create service : %s\n", err.Error()))
 		exitCode = 1
@@ -202,7 +235,13 @@ func main() {
 		grpcAuthV1.RegisterAuthServiceServer(srv, authgrpcapi.NewAuthServer(svc))
 	}
 
-	gs := grpcserver.NewServer(ctx, cancel, svcName, grpcServerConfig, registerAuthServiceServer, logger)
+	interceptors := grpc.ChainUnaryInterceptor(
+		grpcapi.RecoveryInterceptor(logger),
+		grpcapi.LoggingInterceptor(logger),
+		grpcapi.TokenInterceptor,
+	)
+
+	gs := grpcserver.NewServer(ctx, cancel, svcName, grpcServerConfig, registerAuthServiceServer, logger, interceptors)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
@@ -219,7 +258,13 @@ func main() {
 		exitCode = 1
 		return
 	}
-	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, logger, cfg.InstanceID, cfg.JWKSCacheMaxAge, cfg.JWKSCacheStaleWhileRevalidate), logger)
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse trusted proxies : %s", err))
+		exitCode = 1
+		return
+	}
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, logger, cfg.InstanceID, cfg.JWKSCacheMaxAge, cfg.JWKSCacheStaleWhileRevalidate, trustedProxies, pService.Health), logger)
 
 	g.Go(func() error {
 		return hs.Start()
@@ -234,7 +279,7 @@ func main() {
 	}
 }
 
-func initSpiceDB(ctx context.Context, cfg config) (*authzed.ClientWithExperimental, error) {
+func initSpiceDB(ctx context.Context, cfg config, versions spicedb.SchemaVersionRepository, logger *slog.Logger) (*authzed.ClientWithExperimental, error) {
 	client, err := authzed.NewClientWithExperimentalAPIs(
 		fmt.Sprintf("%s:%s", cfg.SpicedbHost, cfg.SpicedbPort),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -244,26 +289,56 @@ func initSpiceDB(ctx context.Context, cfg config) (*authzed.ClientWithExperiment
 		return client, err
 	}
 
-	if err := initSchema(ctx, client, cfg.SpicedbSchemaFile); err != nil {
+	if err := initSchema(ctx, client, versions, cfg.SpicedbSchemaFile, cfg.SpicedbSchemaForce, logger); err != nil {
 		return client, err
 	}
 
 	return client, nil
 }
 
-func initSchema(ctx context.Context, client *authzed.ClientWithExperimental, schemaFilePath string) error {
+func initSchema(ctx context.Context, client *authzed.ClientWithExperimental, versions spicedb.SchemaVersionRepository, schemaFilePath string, force bool, logger *slog.Logger) error {
 	schemaContent, err := os.ReadFile(schemaFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to read spice db schema file : %w", err)
 	}
 
-	if _, err = client.SchemaServiceClient.WriteSchema(ctx, &v1.WriteSchemaRequest{Schema: string(schemaContent)}); err != nil {
+	version, err := spicedb.ApplySchema(ctx, client.SchemaServiceClient, versions, string(schemaContent), force)
+	if err != nil {
 		return fmt.Errorf("failed to create schema in spicedb : %w", err)
 	}
+	logger.Info("SpiceDB schema applied", slog.String("version", version.Hash), slog.Bool("updated", version.Token != ""))
 
 	return nil
 }
 
+// parseTrustedProxies parses the trusted proxy addresses, given either as
+// single IP addresses or as CIDR ranges.
+func parseTrustedProxies(proxies []string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if strings.Contains(proxy, "/") {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return nil, err
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return prefixes, nil
+}
+
 func validateKeyConfig(isSymmetric bool, cfg config, l *slog.Logger) error {
 	if isSymmetric {
 		if cfg.SecretKey == "secret" {
@@ -291,7 +366,7 @@ func validateKeyConfig(isSymmetric bool, cfg config, l *slog.Logger) error {
 	return nil
 }
 
-func newService(db *sqlx.DB, tracer trace.Tracer, cfg config, dbConfig pgclient.Config, logger *slog.Logger, spicedbClient *authzed.ClientWithExperimental, cacheClient *redis.Client, keyDuration time.Duration, tokenizer auth.Tokenizer, idProvider supermq.IDProvider) (auth.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, replicas []*sqlx.DB, tracer trace.Tracer, cfg config, dbConfig pgclient.Config, logger *slog.Logger, spicedbClient *authzed.ClientWithExperimental, pService policies.Service, cacheClient *redis.Client, keyDuration time.Duration, tokenizer auth.Tokenizer, idProvider supermq.IDProvider) (auth.Service, error) {
 	patsCache := cache.NewPatsCache(cacheClient, keyDuration)
 	tokensCache, err := cache.NewUserActiveTokensCache(cacheClient, keyDuration)
 	if err != nil {
@@ -299,18 +374,41 @@ func newService(db *sqlx.DB, tracer trace.Tracer, cfg config, dbConfig pgclient.
 	}
 
 	database := pgclient.NewDatabase(db, dbConfig, tracer)
+	if len(replicas) > 0 {
+		database = pgclient.NewReplicatedDatabase(db, replicas, dbConfig, tracer)
+	}
+	if dbConfig.LogQueries {
+		database = pgclient.NewLoggingDatabase(database, logger)
+	}
 	keysRepo := apostgres.New(database)
 	patsRepo := apostgres.NewPatRepo(database, patsCache)
 	hasher := hasher.New()
 
 	pEvaluator := spicedb.NewPolicyEvaluator(spicedbClient, logger)
-	pService := spicedb.NewPolicyService(spicedbClient, logger)
 
-	svc := auth.New(keysRepo, patsRepo, nil, tokensCache, hasher, idProvider, tokenizer, pEvaluator, pService, cfg.AccessDuration, cfg.RefreshDuration, cfg.InvitationDuration)
+	// The identity cache is per instance and is not invalidated across
+	// replicas, so revoked keys may be accepted elsewhere for keyDuration.
+	var identityCache auth.IdentityCache
+	if cfg.IdentityCacheSize > 0 {
+		identityCache = cache.NewIdentityCache(cfg.IdentityCacheSize, keyDuration)
+	}
+
+	svc := auth.New(keysRepo, patsRepo, nil, tokensCache, identityCache, hasher, idProvider, tokenizer, pEvaluator, pService, cfg.AccessDuration, cfg.RefreshDuration, cfg.InvitationDuration, supermq.NewClock())
+	if cfg.AuditLog {
+		auditRepo := apostgres.NewAuditRepo(database)
+		svc = middleware.NewAudit(svc, auth.NewBufferedAuditLogger(ctx, auditRepo, cfg.AuditLogBufferSize, cfg.AuditLogFlushInterval, logger), logger)
+		if cfg.AuditLogRetention > 0 {
+			auth.NewAuditRetentionHandler(ctx, auditRepo, cfg.AuditLogSweepInterval, cfg.AuditLogRetention, logger)
+		}
+	}
 	svc = middleware.NewLogging(svc, logger)
 	counter, latency := prometheus.MakeMetrics("auth", "api")
 	svc = middleware.NewMetrics(svc, counter, latency)
 	svc = middleware.NewTracing(svc, tracer)
 
+	if cfg.ExpiredKeysSweepInterval > 0 {
+		auth.NewExpiredKeysHandler(ctx, keysRepo, cfg.ExpiredKeysSweepInterval, logger)
+	}
+
 	return svc, nil
 }
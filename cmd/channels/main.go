@@ -154,6 +154,12 @@ func main() {
 	}
 	defer db.Close()
 
+	if err := pgclient.RegisterStats(db, dbConfig); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+
 	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
@@ -304,6 +310,9 @@ func main() {
 	}
 
 	ddatabase := pg.NewDatabase(db, dbConfig, tracer)
+	if dbConfig.LogQueries {
+		ddatabase = pg.NewLoggingDatabase(ddatabase, logger)
+	}
 	drepo := dpostgres.NewRepository(ddatabase)
 
 	if err := dconsumer.DomainsEventsSubscribe(ctx, drepo, cfg.ESURL, cfg.ESConsumerName, logger); err != nil {
@@ -313,6 +322,9 @@ func main() {
 	}
 
 	gdatabase := pg.NewDatabase(db, dbConfig, tracer)
+	if dbConfig.LogQueries {
+		gdatabase = pg.NewLoggingDatabase(gdatabase, logger)
+	}
 	grepo := gpostgres.New(gdatabase)
 
 	if err := gconsumer.GroupsEventsSubscribe(ctx, grepo, cfg.ESURL, cfg.ESConsumerName, logger); err != nil {
@@ -373,6 +385,9 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, cach
 	permConfig *permissions.PermissionConfig,
 ) (channels.Service, pChannels.Service, error) {
 	database := pg.NewDatabase(db, dbConfig, tracer)
+	if dbConfig.LogQueries {
+		database = pg.NewLoggingDatabase(database, logger)
+	}
 	repo := postgres.NewRepository(database)
 
 	idp := uuid.New()
@@ -19,11 +19,13 @@ import (
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	grpcGroupsV1 "github.com/absmach/supermq/api/grpc/groups/v1"
 	"github.com/absmach/supermq/auth"
+	choperations "github.com/absmach/supermq/channels/operations"
 	"github.com/absmach/supermq/clients"
 	grpcapi "github.com/absmach/supermq/clients/api/grpc"
 	httpapi "github.com/absmach/supermq/clients/api/http"
 	"github.com/absmach/supermq/clients/cache"
 	"github.com/absmach/supermq/clients/events"
+	"github.com/absmach/supermq/clients/hasher"
 	"github.com/absmach/supermq/clients/middleware"
 	clientsOps "github.com/absmach/supermq/clients/operations"
 	"github.com/absmach/supermq/clients/postgres"
@@ -84,27 +86,38 @@ const (
 	defDB                  = "clients"
 	defSvcHTTPPort         = "9000"
 	defSvcAuthGRPCPort     = "7000"
+	plaintextHashing       = "plaintext"
+	hmacHashing            = "hmac"
 )
 
 type config struct {
-	InstanceID          string        `env:"SMQ_CLIENTS_INSTANCE_ID"        envDefault:""`
-	LogLevel            string        `env:"SMQ_CLIENTS_LOG_LEVEL"          envDefault:"info"`
-	StandaloneID        string        `env:"SMQ_CLIENTS_STANDALONE_ID"      envDefault:""`
-	StandaloneToken     string        `env:"SMQ_CLIENTS_STANDALONE_TOKEN"   envDefault:""`
-	CacheURL            string        `env:"SMQ_CLIENTS_CACHE_URL"          envDefault:"redis://localhost:6379/0"`
-	CacheKeyDuration    time.Duration `env:"SMQ_CLIENTS_CACHE_KEY_DURATION" envDefault:"10m"`
-	JaegerURL           url.URL       `env:"SMQ_JAEGER_URL"                 envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry       bool          `env:"SMQ_SEND_TELEMETRY"             envDefault:"true"`
-	ESURL               string        `env:"SMQ_ES_URL"                     envDefault:"nats://localhost:4222"`
-	ESConsumerName      string        `env:"SMQ_CLIENTS_EVENT_CONSUMER"     envDefault:"clients"`
-	TraceRatio          float64       `env:"SMQ_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
-	SpicedbHost         string        `env:"SMQ_SPICEDB_HOST"               envDefault:"localhost"`
-	SpicedbPort         string        `env:"SMQ_SPICEDB_PORT"               envDefault:"50051"`
-	SpicedbPreSharedKey string        `env:"SMQ_SPICEDB_PRE_SHARED_KEY"     envDefault:"12345678"`
-	SpicedbSchemaFile   string        `env:"SMQ_SPICEDB_SCHEMA_FILE"        envDefault:"schema.zed"`
-	AuthKeyAlgorithm    string        `env:"SMQ_AUTH_KEYS_ALGORITHM"        envDefault:"RS256"`
-	JWKSURL             string        `env:"SMQ_AUTH_JWKS_URL"              envDefault:"http://auth:9001/keys/.well-known/jwks.json"`
-	PermissionsFile     string        `env:"SMQ_PERMISSIONS_FILE"           envDefault:"permission.yaml"`
+	InstanceID              string        `env:"SMQ_CLIENTS_INSTANCE_ID"                envDefault:""`
+	LogLevel                string        `env:"SMQ_CLIENTS_LOG_LEVEL"                  envDefault:"info"`
+	StandaloneID            string        `env:"SMQ_CLIENTS_STANDALONE_ID"              envDefault:""`
+	StandaloneToken         string        `env:"SMQ_CLIENTS_STANDALONE_TOKEN"           envDefault:""`
+	CacheURL                string        `env:"SMQ_CLIENTS_CACHE_URL"                  envDefault:"redis://localhost:6379/0"`
+	CacheKeyDuration        time.Duration `env:"SMQ_CLIENTS_CACHE_KEY_DURATION"         envDefault:"10m"`
+	CacheUnknownKeyDuration time.Duration `env:"SMQ_CLIENTS_CACHE_UNKNOWN_KEY_DURATION" envDefault:"10s"`
+	CacheUnknownKeyLimit    int64         `env:"SMQ_CLIENTS_CACHE_UNKNOWN_KEY_LIMIT"    envDefault:"10000"`
+	SecretGracePeriod       time.Duration `env:"SMQ_CLIENTS_SECRET_GRACE_PERIOD"        envDefault:"0s"`
+	SecretSweepInterval     time.Duration `env:"SMQ_CLIENTS_SECRET_SWEEP_INTERVAL"      envDefault:"1h"`
+	SecretHashing           string        `env:"SMQ_CLIENTS_SECRET_HASHING"             envDefault:"plaintext"`
+	SecretHashPepper        string        `env:"SMQ_CLIENTS_SECRET_HASH_PEPPER"         envDefault:""`
+	SecretHashBackfill      bool          `env:"SMQ_CLIENTS_SECRET_HASH_BACKFILL"       envDefault:"false"`
+	CertAuth                bool          `env:"SMQ_CLIENTS_CERT_AUTH"                  envDefault:"false"`
+	UniqueNames             bool          `env:"SMQ_CLIENTS_UNIQUE_NAMES"               envDefault:"false"`
+	JaegerURL               url.URL       `env:"SMQ_JAEGER_URL"                         envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry           bool          `env:"SMQ_SEND_TELEMETRY"                     envDefault:"true"`
+	ESURL                   string        `env:"SMQ_ES_URL"                             envDefault:"nats://localhost:4222"`
+	ESConsumerName          string        `env:"SMQ_CLIENTS_EVENT_CONSUMER"             envDefault:"clients"`
+	TraceRatio              float64       `env:"SMQ_JAEGER_TRACE_RATIO"                 envDefault:"1.0"`
+	SpicedbHost             string        `env:"SMQ_SPICEDB_HOST"                       envDefault:"localhost"`
+	SpicedbPort             string        `env:"SMQ_SPICEDB_PORT"                       envDefault:"50051"`
+	SpicedbPreSharedKey     string        `env:"SMQ_SPICEDB_PRE_SHARED_KEY"             envDefault:"12345678"`
+	SpicedbSchemaFile       string        `env:"SMQ_SPICEDB_SCHEMA_FILE"                envDefault:"schema.zed"`
+	AuthKeyAlgorithm        string        `env:"SMQ_AUTH_KEYS_ALGORITHM"                envDefault:"RS256"`
+	JWKSURL                 string        `env:"SMQ_AUTH_JWKS_URL"                      envDefault:"http://auth:9001/keys/.well-known/jwks.json"`
+	PermissionsFile         string        `env:"SMQ_PERMISSIONS_FILE"                   envDefault:"permission.yaml"`
 }
 
 func main() {
@@ -147,6 +160,9 @@ func main() {
 		exitCode = 1
 		return
 	}
+	if cfg.UniqueNames {
+		tm.Migrations = append(tm.Migrations, postgres.UniqueNamesMigration())
+	}
 	db, err := pgclient.Setup(dbConfig, *tm)
 	if err != nil {
 		logger.Error(err.Error())
@@ -155,6 +171,24 @@ func main() {
 	}
 	defer db.Close()
 
+	if err := pgclient.RegisterStats(db, dbConfig); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+
+	replicas, err := pgclient.ConnectReplicas(dbConfig)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer func() {
+		for _, replica := range replicas {
+			replica.Close()
+		}
+	}()
+
 	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
@@ -296,7 +330,7 @@ func main() {
 		return
 	}
 
-	svc, psvc, err := newService(ctx, db, dbConfig, authz, policyEvaluator, policyService, cacheclient,
+	svc, psvc, err := newService(ctx, db, replicas, dbConfig, authz, policyEvaluator, policyService, cacheclient,
 		cfg, channelsgRPC, groupsClient, tracer, logger, callout, permConfig)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create services: %s", err))
@@ -305,6 +339,9 @@ func main() {
 	}
 
 	ddatabase := pg.NewDatabase(db, dbConfig, tracer)
+	if dbConfig.LogQueries {
+		ddatabase = pg.NewLoggingDatabase(ddatabase, logger)
+	}
 	drepo := dpostgres.NewRepository(ddatabase)
 
 	if err := dconsumer.DomainsEventsSubscribe(ctx, drepo, cfg.ESURL, cfg.ESConsumerName, logger); err != nil {
@@ -314,6 +351,9 @@ func main() {
 	}
 
 	gdatabase := pg.NewDatabase(db, dbConfig, tracer)
+	if dbConfig.LogQueries {
+		gdatabase = pg.NewLoggingDatabase(gdatabase, logger)
+	}
 	grepo := gpostgres.New(gdatabase)
 
 	if err := gconsumer.GroupsEventsSubscribe(ctx, grepo, cfg.ESURL, cfg.ESConsumerName, logger); err != nil {
@@ -368,8 +408,14 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization, pe policies.Evaluator, ps policies.Service, cacheClient *redis.Client, cfg config, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, tracer trace.Tracer, logger *slog.Logger, callout callout.Callout, permConfig *permissions.PermissionConfig) (clients.Service, pClients.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, replicas []*sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization, pe policies.Evaluator, ps policies.Service, cacheClient *redis.Client, cfg config, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, tracer trace.Tracer, logger *slog.Logger, callout callout.Callout, permConfig *permissions.PermissionConfig) (clients.Service, pClients.Service, error) {
 	database := pg.NewDatabase(db, dbConfig, tracer)
+	if len(replicas) > 0 {
+		database = pg.NewReplicatedDatabase(db, replicas, dbConfig, tracer)
+	}
+	if dbConfig.LogQueries {
+		database = pg.NewLoggingDatabase(database, logger)
+	}
 	repo := postgres.NewRepository(database)
 
 	idp := uuid.New()
@@ -379,19 +425,39 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 	}
 
 	// Clients service
-	cache := cache.NewCache(cacheClient, cfg.CacheKeyDuration)
+	cache := cache.NewCache(cacheClient, cfg.CacheKeyDuration, cfg.CacheUnknownKeyDuration, cfg.CacheUnknownKeyLimit)
 
 	availableActions, builtInRoles, err := availableActionsAndBuiltInRoles(cfg.SpicedbSchemaFile)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	csvc, err := clients.NewService(repo, ps, cache, channels, groups, idp, sidp, availableActions, builtInRoles)
+	hsr, err := newHasher(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.SecretHashing != plaintextHashing && cfg.SecretHashBackfill {
+		hashed, err := clients.BackfillSecretHashes(ctx, repo, hsr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to hash plain-text client secrets: %w", err)
+		}
+		logger.Info(fmt.Sprintf("hashed %d plain-text client secrets", hashed))
+	}
+
+	var certs clients.CertificateRepository
+	if cfg.CertAuth {
+		certs = postgres.NewCertificateRepository(database)
+	}
+	csvc, err := clients.NewService(repo, certs, ps, cache, channels, groups, idp, sidp, hsr, availableActions, builtInRoles, cfg.SecretGracePeriod, supermq.NewClock(), logger)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	csvc, err = events.NewEventStoreMiddleware(ctx, csvc, cfg.ESURL)
+	if cfg.SecretGracePeriod > 0 {
+		clients.NewSecretsSweepHandler(ctx, repo, cfg.SecretSweepInterval, logger)
+	}
+
+	csvc, err = events.NewEventStoreMiddleware(ctx, csvc, cfg.ESURL, logger)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -416,16 +482,23 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 		return nil, nil, fmt.Errorf("failed to get group permissions: %w", err)
 	}
 
+	channelOps, _, err := permConfig.GetEntityPermissions("channels")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get channel permissions: %w", err)
+	}
+
 	entitiesOps, err := permissions.NewEntitiesOperations(
 		permissions.EntitiesPermission{
-			policies.ClientType: clientOps,
-			policies.DomainType: domainOps,
-			policies.GroupType:  groupOps,
+			policies.ClientType:  clientOps,
+			policies.DomainType:  domainOps,
+			policies.GroupType:   groupOps,
+			policies.ChannelType: channelOps,
 		},
 		permissions.EntitiesOperationDetails[permissions.Operation]{
-			policies.ClientType: clientsOps.OperationDetails(),
-			policies.DomainType: doperations.OperationDetails(),
-			policies.GroupType:  goperations.OperationDetails(),
+			policies.ClientType:  clientsOps.OperationDetails(),
+			policies.DomainType:  doperations.OperationDetails(),
+			policies.GroupType:   goperations.OperationDetails(),
+			policies.ChannelType: choperations.OperationDetails(),
 		},
 	)
 	if err != nil {
@@ -449,7 +522,7 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 
 	csvc = middleware.NewLogging(csvc, logger)
 
-	isvc := pClients.New(repo, cache, pe, ps)
+	isvc := pClients.New(repo, certs, cache, pe, ps, hsr)
 
 	return csvc, isvc, err
 }
@@ -481,3 +554,14 @@ func availableActionsAndBuiltInRoles(spicedbSchemaFile string) ([]roles.Action,
 
 	return availableActions, builtInRoles, err
 }
+
+func newHasher(cfg config) (clients.Hasher, error) {
+	switch cfg.SecretHashing {
+	case plaintextHashing:
+		return hasher.NewPlaintext(), nil
+	case hmacHashing:
+		return hasher.New(cfg.SecretHashPepper)
+	default:
+		return nil, fmt.Errorf("unsupported client secret hashing %q", cfg.SecretHashing)
+	}
+}
@@ -136,6 +136,12 @@ func main() {
 	}
 	defer db.Close()
 
+	if err := pgclient.RegisterStats(db, dbConfig); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+
 	tp, err := jaeger.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
@@ -189,6 +195,9 @@ func main() {
 	authnMiddleware := smqauthn.NewAuthNMiddleware(authn)
 
 	database := postgres.NewDatabase(db, dbConfig, tracer)
+	if dbConfig.LogQueries {
+		database = postgres.NewLoggingDatabase(database, logger)
+	}
 	domainsRepo := dpostgres.NewRepository(database)
 
 	cacheclient, err := redisclient.Connect(cfg.CacheURL)
@@ -142,6 +142,12 @@ func main() {
 	}
 	defer db.Close()
 
+	if err := pgclient.RegisterStats(db, dbConfig); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+
 	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
@@ -282,6 +288,9 @@ func main() {
 	}
 
 	ddatabase := pg.NewDatabase(db, dbConfig, tracer)
+	if dbConfig.LogQueries {
+		ddatabase = pg.NewLoggingDatabase(ddatabase, logger)
+	}
 	drepo := dpostgres.NewRepository(ddatabase)
 
 	if err := dconsumer.DomainsEventsSubscribe(ctx, drepo, cfg.ESURL, cfg.ESConsumerName, logger); err != nil {
@@ -341,6 +350,9 @@ func newService(ctx context.Context, authz smqauthz.Authorization, policy polici
 	clients grpcClientsV1.ClientsServiceClient, tracer trace.Tracer, logger *slog.Logger, c config, callout callout.Callout, permConfig *permissions.PermissionConfig,
 ) (groups.Service, pgroups.Service, error) {
 	database := pg.NewDatabase(db, dbConfig, tracer)
+	if dbConfig.LogQueries {
+		database = pg.NewLoggingDatabase(database, logger)
+	}
 	idp := uuid.New()
 	sid, err := sid.New()
 	if err != nil {
@@ -358,7 +370,7 @@ func newService(ctx context.Context, authz smqauthz.Authorization, policy polici
 	if err != nil {
 		return nil, nil, err
 	}
-	svc, err = events.New(ctx, svc, c.ESURL)
+	svc, err = events.New(ctx, svc, c.ESURL, logger)
 	if err != nil {
 		return nil, nil, err
 	}
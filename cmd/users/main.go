@@ -20,6 +20,7 @@ import (
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	grpcUsersV1 "github.com/absmach/supermq/api/grpc/users/v1"
 	"github.com/absmach/supermq/auth"
+	redisclient "github.com/absmach/supermq/internal/clients/redis"
 	"github.com/absmach/supermq/internal/email"
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
@@ -31,6 +32,8 @@ import (
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
 	"github.com/absmach/supermq/pkg/oauth2"
+	oauth2cache "github.com/absmach/supermq/pkg/oauth2/cache"
+	githuboauth "github.com/absmach/supermq/pkg/oauth2/github"
 	googleoauth "github.com/absmach/supermq/pkg/oauth2/google"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/pkg/policies/spicedb"
@@ -69,6 +72,7 @@ const (
 	envPrefixAuth    = "SMQ_AUTH_GRPC_"
 	envPrefixDomains = "SMQ_DOMAINS_GRPC_"
 	envPrefixGoogle  = "SMQ_GOOGLE_"
+	envPrefixGitHub  = "SMQ_GITHUB_"
 	defDB            = "users"
 	defSvcHTTPPort   = "9002"
 	defSvcGRPCPort   = "7002"
@@ -90,6 +94,11 @@ type config struct {
 	SelfRegister               bool          `env:"SMQ_USERS_ALLOW_SELF_REGISTER"         envDefault:"false"`
 	OAuthUIRedirectURL         string        `env:"SMQ_OAUTH_UI_REDIRECT_URL"             envDefault:"http://localhost:9095/domains"`
 	OAuthUIErrorURL            string        `env:"SMQ_OAUTH_UI_ERROR_URL"                envDefault:"http://localhost:9095/error"`
+	OAuthAllowedEmailDomains   []string      `env:"SMQ_OAUTH_ALLOWED_EMAIL_DOMAINS"       envDefault:"" envSeparator:","`
+	OAuthDeniedEmailDomains    []string      `env:"SMQ_OAUTH_DENIED_EMAIL_DOMAINS"        envDefault:"" envSeparator:","`
+	OAuthStateDuration         time.Duration `env:"SMQ_OAUTH_STATE_DURATION"              envDefault:"10m"`
+	OAuthMaxBodySize           int64         `env:"SMQ_OAUTH_MAX_BODY_SIZE"               envDefault:"65536"`
+	CacheURL                   string        `env:"SMQ_USERS_CACHE_URL"                   envDefault:"redis://localhost:6379/0"`
 	DeleteInterval             time.Duration `env:"SMQ_USERS_DELETE_INTERVAL"             envDefault:"24h"`
 	DeleteAfter                time.Duration `env:"SMQ_USERS_DELETE_AFTER"                envDefault:"720h"`
 	SpicedbHost                string        `env:"SMQ_SPICEDB_HOST"                      envDefault:"localhost"`
@@ -166,6 +175,12 @@ func main() {
 	}
 	defer db.Close()
 
+	if err := pgclient.RegisterStats(db, dbConfig); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+
 	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
@@ -180,6 +195,9 @@ func main() {
 	tracer := tp.Tracer(svcName)
 
 	database := pg.NewDatabase(db, dbConfig, tracer)
+	if dbConfig.LogQueries {
+		database = pg.NewLoggingDatabase(database, logger)
+	}
 	repo := postgres.NewRepository(database)
 
 	authClientConfig := grpcclient.Config{}
@@ -295,9 +313,27 @@ func main() {
 	}
 	oauthProvider := googleoauth.NewProvider(oauthConfig, cfg.OAuthUIRedirectURL, cfg.OAuthUIErrorURL)
 
+	githubConfig := oauth2.Config{}
+	if err := env.ParseWithOptions(&githubConfig, env.Options{Prefix: envPrefixGitHub}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s GitHub configuration : %s", svcName, err.Error()))
+		exitCode = 1
+		return
+	}
+	githubProvider := githuboauth.NewProvider(githubConfig, cfg.OAuthUIRedirectURL, cfg.OAuthUIErrorURL)
+
+	cacheclient, err := redisclient.Connect(cfg.CacheURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to %s cache : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	defer cacheclient.Close()
+	states := oauth2cache.NewStateStore(cacheclient, cfg.OAuthStateDuration)
+
+	oauthCounter, oauthLatency := prometheus.MakeLabeledMetrics(svcName, "oauth", "provider", "result")
 	mux := chi.NewRouter()
 	idp := uuid.New()
-	httpSrv := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(csvc, authnMiddleware, tokenClient, cfg.SelfRegister, mux, logger, cfg.InstanceID, cfg.PassRegex, idp, oauthProvider), logger)
+	httpSrv := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(csvc, authnMiddleware, tokenClient, cfg.SelfRegister, mux, logger, cfg.InstanceID, cfg.PassRegex, idp, cfg.OAuthMaxBodySize, states, oauthCounter, oauthLatency, oauthProvider, githubProvider), logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
@@ -337,7 +373,7 @@ func newService(ctx context.Context, authz smqauthz.Authorization, token grpcTok
 		return nil, err
 	}
 
-	svc := users.NewService(token, repo, policyService, emailerClient, hsr, idp)
+	svc := users.NewService(token, repo, policyService, emailerClient, hsr, idp, users.EmailDomains{Allowed: c.OAuthAllowedEmailDomains, Denied: c.OAuthDeniedEmailDomains})
 
 	svc, err = events.NewEventStoreMiddleware(ctx, svc, c.ESURL)
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/domains"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/policies"
 	roleManagerHttp "github.com/absmach/supermq/pkg/roles/rolemanager/api"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -30,7 +31,7 @@ func MakeHandler(svc domains.Service, authn smqauthn.AuthNMiddleware, mux *chi.M
 		r.Use(api.RequestIDMiddleware(idp))
 
 		r.Group(func(r chi.Router) {
-			r.Use(authn.WithOptions(smqauthn.WithDomainCheck(false)).Middleware())
+			r.Use(authn.WithOptions(smqauthn.WithDomainCheck(false), smqauthn.WithScopeEntityType(policies.DomainType)).Middleware())
 			r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
 				createDomainEndpoint(svc),
 				decodeCreateDomainRequest,
@@ -49,7 +50,7 @@ func MakeHandler(svc domains.Service, authn smqauthn.AuthNMiddleware, mux *chi.M
 		})
 
 		r.Route("/{domainID}", func(r chi.Router) {
-			r.Use(authn.Middleware())
+			r.Use(authn.WithOptions(smqauthn.WithScopeEntityType(policies.DomainType)).Middleware())
 			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
 				retrieveDomainEndpoint(svc),
 				decodeRetrieveDomainRequest,
@@ -88,7 +89,7 @@ func MakeHandler(svc domains.Service, authn smqauthn.AuthNMiddleware, mux *chi.M
 		})
 
 		r.Route("/{domainID}/invitations", func(r chi.Router) {
-			r.Use(authn.Middleware())
+			r.Use(authn.WithOptions(smqauthn.WithScopeEntityType(policies.DomainType)).Middleware())
 			r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
 				sendInvitationEndpoint(svc),
 				decodeSendInvitationReq,
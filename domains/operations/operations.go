@@ -31,6 +31,7 @@ const (
 	OpListDomainChannels
 	OpCreateDomainGroups
 	OpListDomainGroups
+	OpDeleteDomainGroups
 )
 
 func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
@@ -133,6 +134,11 @@ func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
 			Name:               "list_groups",
 			PermissionRequired: true,
 		},
+
+		OpDeleteDomainGroups: {
+			Name:               "delete_groups",
+			PermissionRequired: true,
+		},
 	}
 	return ops
 }
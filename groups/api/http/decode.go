@@ -60,8 +60,13 @@ func DecodeGroupUpdate(_ context.Context, r *http.Request) (any, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
 	}
+	unmodifiedSince, err := apiutil.ReadUnmodifiedSince(r)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	req := updateGroupReq{
-		id: chi.URLParam(r, "groupID"),
+		id:              chi.URLParam(r, "groupID"),
+		unmodifiedSince: unmodifiedSince,
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
@@ -207,14 +212,14 @@ func decodeHierarchyPageMeta(r *http.Request) (groups.HierarchyPageMeta, error)
 	if err != nil {
 		return groups.HierarchyPageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
-	hierarchyDir, err := apiutil.ReadNumQuery[int64](r, api.DirKey, -1)
+	hierarchyDir, err := apiutil.ReadNumQuery[int64](r, api.DirKey, int64(groups.Descendants))
 	if err != nil {
 		return groups.HierarchyPageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
 	return groups.HierarchyPageMeta{
 		Level:     level,
-		Direction: hierarchyDir,
+		Direction: groups.Direction(hierarchyDir),
 		Tree:      tree,
 	}, nil
 }
@@ -240,10 +245,18 @@ func decodePageMeta(r *http.Request) (groups.PageMeta, error) {
 	if err != nil {
 		return groups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	namePrefix, err := apiutil.ReadBoolQuery(r, api.NamePrefixKey, false)
+	if err != nil {
+		return groups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	id, err := apiutil.ReadStringQuery(r, api.IDOrder, "")
 	if err != nil {
 		return groups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	ids, err := apiutil.ReadStringSliceQuery(r, api.IDsKey, ",")
+	if err != nil {
+		return groups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
 	meta, err := apiutil.ReadMetadataQuery(r, api.MetadataKey, nil)
 	if err != nil {
 		return groups.PageMeta{}, errors.Wrap(apiutil.ErrValidation, err)
@@ -327,7 +340,9 @@ func decodePageMeta(r *http.Request) (groups.PageMeta, error) {
 		Offset:      offset,
 		Limit:       limit,
 		Name:        name,
+		NamePrefix:  namePrefix,
 		ID:          id,
+		IDs:         ids,
 		Metadata:    meta,
 		Status:      st,
 		RoleName:    roleName,
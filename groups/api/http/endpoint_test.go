@@ -972,6 +972,37 @@ func TestListGroups(t *testing.T) {
 			status:   http.StatusBadRequest,
 			err:      apiutil.ErrInvalidQueryParams,
 		},
+		{
+			desc:     "list groups with name prefix",
+			domainID: validID,
+			token:    validToken,
+			pageMeta: groups.PageMeta{
+				Offset:     0,
+				Limit:      10,
+				Order:      api.DefOrder,
+				Dir:        api.DefDir,
+				Actions:    []string{},
+				Name:       "client",
+				NamePrefix: true,
+			},
+			listGroupsResponse: groups.Page{
+				PageMeta: groups.PageMeta{
+					Total: 1,
+				},
+				Groups: []groups.Group{validGroupResp},
+			},
+			query:  "name=client&name_prefix=true",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list groups with invalid name prefix",
+			domainID: validID,
+			token:    validToken,
+			query:    "name=client&name_prefix=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
 		{
 			desc:     "list groups with status",
 			domainID: validID,
@@ -1320,6 +1351,181 @@ func TestDeleteGroupEndpoint(t *testing.T) {
 	}
 }
 
+func TestDeleteGroupTreeEndpoint(t *testing.T) {
+	gs, svc, authn := newGroupsServer()
+	defer gs.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		domainID string
+		session  smqauthn.Session
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:     "delete group tree successfully",
+			token:    validToken,
+			domainID: validID,
+			id:       validID,
+			svcErr:   nil,
+			status:   http.StatusNoContent,
+			err:      nil,
+		},
+		{
+			desc:     "delete group tree with invalid token",
+			token:    invalidToken,
+			session:  smqauthn.Session{},
+			domainID: validID,
+			id:       validID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "delete group tree with empty token",
+			token:    "",
+			session:  smqauthn.Session{},
+			domainID: validID,
+			id:       validID,
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "delete group tree with service error",
+			token:    validToken,
+			id:       validID,
+			domainID: validID,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: gs.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/groups/%s/hierarchy", gs.URL, tc.domainID, tc.id),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("DeleteGroupTree", mock.Anything, tc.session, tc.id).Return([]string{tc.id}, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestPreviewDeleteGroupEndpoint(t *testing.T) {
+	gs, svc, authn := newGroupsServer()
+	defer gs.Close()
+
+	impact := groups.DeletionImpact{
+		ChildrenGroups:   1,
+		DescendantGroups: 2,
+		Roles:            1,
+		RoleMembers:      3,
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		domainID string
+		session  smqauthn.Session
+		svcResp  groups.DeletionImpact
+		svcErr   error
+		resp     groups.DeletionImpact
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:     "preview delete group successfully",
+			token:    validToken,
+			domainID: validID,
+			id:       validID,
+			svcResp:  impact,
+			resp:     impact,
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "preview delete group with invalid token",
+			token:    invalidToken,
+			session:  smqauthn.Session{},
+			domainID: validID,
+			id:       validID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "preview delete group with empty token",
+			token:    "",
+			session:  smqauthn.Session{},
+			domainID: validID,
+			id:       validID,
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:   "preview delete group with empty domainID",
+			token:  validToken,
+			id:     validID,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrMissingDomainID,
+		},
+		{
+			desc:     "preview delete group with service error",
+			token:    validToken,
+			id:       validID,
+			domainID: validID,
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: gs.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/groups/%s/deletion-impact", gs.URL, tc.domainID, tc.id),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("PreviewDeleteGroup", mock.Anything, tc.session, tc.id).Return(tc.svcResp, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var resp groups.DeletionImpact
+				err = json.NewDecoder(res.Body).Decode(&resp)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %+v got %+v", tc.desc, tc.resp, resp))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func TestRetrieveGroupHierarchyEndpoint(t *testing.T) {
 	gs, svc, authn := newGroupsServer()
 	defer gs.Close()
@@ -1448,6 +1654,15 @@ func TestRetrieveGroupHierarchyEndpoint(t *testing.T) {
 			status:   http.StatusBadRequest,
 			err:      apiutil.ErrInvalidQueryParams,
 		},
+		{
+			desc:     "retrieve group hierarchy with unknown direction",
+			token:    validToken,
+			groupID:  validID,
+			domainID: validID,
+			query:    "level=1&dir=2&tree=false",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidDirection,
+		},
 		{
 			desc:     "retrieve group hierarchy with invalid tree",
 			token:    validToken,
@@ -69,10 +69,11 @@ func UpdateGroupEndpoint(svc groups.Service) endpoint.Endpoint {
 		}
 
 		group := groups.Group{
-			ID:          req.id,
-			Name:        req.Name,
-			Description: req.Description,
-			Metadata:    req.Metadata,
+			ID:              req.id,
+			Name:            req.Name,
+			Description:     req.Description,
+			Metadata:        req.Metadata,
+			UnmodifiedSince: req.unmodifiedSince,
 		}
 
 		group, err := svc.UpdateGroup(ctx, session, group)
@@ -208,6 +209,43 @@ func DeleteGroupEndpoint(svc groups.Service) endpoint.Endpoint {
 	}
 }
 
+func deleteGroupTreeEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(groupReq)
+		if err := req.validate(); err != nil {
+			return deleteGroupRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return deleteGroupRes{}, svcerr.ErrAuthentication
+		}
+		if _, err := svc.DeleteGroupTree(ctx, session, req.id); err != nil {
+			return deleteGroupRes{}, err
+		}
+		return deleteGroupRes{deleted: true}, nil
+	}
+}
+
+func previewDeleteGroupEndpoint(svc groups.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(groupReq)
+		if err := req.validate(); err != nil {
+			return previewDeleteGroupRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return previewDeleteGroupRes{}, svcerr.ErrAuthentication
+		}
+		impact, err := svc.PreviewDeleteGroup(ctx, session, req.id)
+		if err != nil {
+			return previewDeleteGroupRes{}, err
+		}
+		return previewDeleteGroupRes{DeletionImpact: impact}, nil
+	}
+}
+
 func retrieveGroupHierarchyEndpoint(svc groups.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request any) (any, error) {
 		req := request.(retrieveGroupHierarchyReq)
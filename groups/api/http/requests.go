@@ -4,6 +4,8 @@
 package api
 
 import (
+	"time"
+
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/groups"
@@ -23,10 +25,11 @@ func (req createGroupReq) validate() error {
 }
 
 type updateGroupReq struct {
-	id          string
-	Name        string                 `json:"name,omitempty"`
-	Description nullable.Value[string] `json:"description,omitempty"`
-	Metadata    map[string]any         `json:"metadata,omitempty"`
+	id              string
+	unmodifiedSince time.Time
+	Name            string                 `json:"name,omitempty"`
+	Description     nullable.Value[string] `json:"description,omitempty"`
+	Metadata        map[string]any         `json:"metadata,omitempty"`
 }
 
 func (req updateGroupReq) validate() error {
@@ -59,8 +62,8 @@ type listGroupsReq struct {
 }
 
 func (req listGroupsReq) validate() error {
-	if req.Limit > api.MaxLimitSize || req.Limit < 1 {
-		return apiutil.ErrLimitSize
+	if err := apiutil.ValidatePageMetadata(apiutil.PageMetadata{Offset: req.Offset, Limit: req.Limit}, api.MaxLimitSize); err != nil {
+		return err
 	}
 
 	if req.userID != "" && req.groupID != "" {
@@ -77,6 +80,12 @@ func (req listGroupsReq) validate() error {
 		return apiutil.ErrInvalidDirection
 	}
 
+	for _, id := range req.IDs {
+		if err := api.ValidateUUID(id); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -113,6 +122,11 @@ func (req retrieveGroupHierarchyReq) validate() error {
 	if req.Level > groups.MaxLevel {
 		return apiutil.ErrLevel
 	}
+	switch req.Direction {
+	case groups.Ancestors, groups.Descendants:
+	default:
+		return apiutil.ErrInvalidDirection
+	}
 	if req.id == "" {
 		return apiutil.ErrMissingID
 	}
@@ -214,8 +228,5 @@ func (req listChildrenGroupsReq) validate() error {
 	if req.id == "" {
 		return apiutil.ErrMissingID
 	}
-	if req.Limit > api.MaxLimitSize || req.Limit < 1 {
-		return apiutil.ErrLimitSize
-	}
-	return nil
+	return apiutil.ValidatePageMetadata(apiutil.PageMetadata{Offset: req.Offset, Limit: req.Limit}, api.MaxLimitSize)
 }
@@ -126,6 +126,26 @@ func TestListGroupReqValidation(t *testing.T) {
 			},
 			err: apiutil.ErrLimitSize,
 		},
+		{
+			desc: "valid IDs",
+			req: listGroupsReq{
+				PageMeta: groups.PageMeta{
+					Limit: 10,
+					IDs:   []string{testsutil.GenerateUUID(t)},
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "invalid IDs",
+			req: listGroupsReq{
+				PageMeta: groups.PageMeta{
+					Limit: 10,
+					IDs:   []string{testsutil.GenerateUUID(t), "invalid"},
+				},
+			},
+			err: apiutil.ErrInvalidIDFormat,
+		},
 	}
 
 	for _, tc := range cases {
@@ -216,6 +236,18 @@ func TestRetrieveGroupHierarchyReqValidation(t *testing.T) {
 			},
 			err: apiutil.ErrLevel,
 		},
+		{
+			desc: "invalid direction",
+			req: retrieveGroupHierarchyReq{
+				HierarchyPageMeta: groups.HierarchyPageMeta{
+					Tree:      true,
+					Level:     1,
+					Direction: 0,
+				},
+				id: valid,
+			},
+			err: apiutil.ErrInvalidDirection,
+		},
 		{
 			desc: "empty id",
 			req: retrieveGroupHierarchyReq{
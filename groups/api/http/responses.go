@@ -17,6 +17,7 @@ var (
 	_ supermq.Response = (*changeStatusRes)(nil)
 	_ supermq.Response = (*viewGroupRes)(nil)
 	_ supermq.Response = (*updateGroupRes)(nil)
+	_ supermq.Response = (*previewDeleteGroupRes)(nil)
 	_ supermq.Response = (*retrieveGroupHierarchyRes)(nil)
 	_ supermq.Response = (*addParentGroupRes)(nil)
 	_ supermq.Response = (*removeParentGroupRes)(nil)
@@ -144,10 +145,26 @@ func (res deleteGroupRes) Empty() bool {
 	return true
 }
 
+type previewDeleteGroupRes struct {
+	groups.DeletionImpact `json:",inline"`
+}
+
+func (res previewDeleteGroupRes) Code() int {
+	return http.StatusOK
+}
+
+func (res previewDeleteGroupRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res previewDeleteGroupRes) Empty() bool {
+	return false
+}
+
 type retrieveGroupHierarchyRes struct {
-	Level     uint64         `json:"level"`
-	Direction int64          `json:"direction"`
-	Groups    []viewGroupRes `json:"groups"`
+	Level     uint64           `json:"level"`
+	Direction groups.Direction `json:"direction"`
+	Groups    []viewGroupRes   `json:"groups"`
 }
 
 func (res retrieveGroupHierarchyRes) Code() int {
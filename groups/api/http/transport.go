@@ -11,6 +11,7 @@ import (
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/groups"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/policies"
 	roleManagerHttp "github.com/absmach/supermq/pkg/roles/rolemanager/api"
 	"github.com/go-chi/chi/v5"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -26,7 +27,7 @@ func MakeHandler(svc groups.Service, authn smqauthn.AuthNMiddleware, mux *chi.Mu
 	d := roleManagerHttp.NewDecoder("groupID")
 
 	mux.Route("/{domainID}/groups", func(r chi.Router) {
-		r.Use(authn.Middleware())
+		r.Use(authn.WithOptions(smqauthn.WithScopeEntityType(policies.GroupType)).Middleware())
 		r.Use(api.RequestIDMiddleware(idp))
 
 		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
@@ -73,6 +74,13 @@ func MakeHandler(svc groups.Service, authn smqauthn.AuthNMiddleware, mux *chi.Mu
 				opts...,
 			), "delete_group").ServeHTTP)
 
+			r.Get("/deletion-impact", otelhttp.NewHandler(kithttp.NewServer(
+				previewDeleteGroupEndpoint(svc),
+				DecodeGroupRequest,
+				api.EncodeResponse,
+				opts...,
+			), "preview_delete_group").ServeHTTP)
+
 			r.Post("/enable", otelhttp.NewHandler(kithttp.NewServer(
 				EnableGroupEndpoint(svc),
 				DecodeChangeGroupStatusRequest,
@@ -96,6 +104,13 @@ func MakeHandler(svc groups.Service, authn smqauthn.AuthNMiddleware, mux *chi.Mu
 				opts...,
 			), "retrieve_group_hierarchy").ServeHTTP)
 
+			r.Delete("/hierarchy", otelhttp.NewHandler(kithttp.NewServer(
+				deleteGroupTreeEndpoint(svc),
+				DecodeGroupRequest,
+				api.EncodeResponse,
+				opts...,
+			), "delete_group_tree").ServeHTTP)
+
 			r.Route("/parent", func(r chi.Router) {
 				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
 					addParentGroupEndpoint(svc),
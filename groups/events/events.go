@@ -23,6 +23,7 @@ const (
 	groupList                    = groupPrefix + "list"
 	groupListUserGroups          = groupPrefix + "list_user_groups"
 	groupRemove                  = groupPrefix + "remove"
+	groupPreviewRemove           = groupPrefix + "preview_remove"
 	groupRetrieveGroupHierarchy  = groupPrefix + "retrieve_group_hierarchy"
 	groupAddParentGroup          = groupPrefix + "add_parent_group"
 	groupRemoveParentGroup       = groupPrefix + "remove_parent_group"
@@ -38,6 +39,7 @@ var (
 	_ events.Event = (*changeGroupStatusEvent)(nil)
 	_ events.Event = (*viewGroupEvent)(nil)
 	_ events.Event = (*deleteGroupEvent)(nil)
+	_ events.Event = (*previewDeleteGroupEvent)(nil)
 	_ events.Event = (*viewGroupEvent)(nil)
 	_ events.Event = (*listGroupEvent)(nil)
 	_ events.Event = (*addParentGroupEvent)(nil)
@@ -298,6 +300,29 @@ func (rge deleteGroupEvent) Encode() (map[string]any, error) {
 	}, nil
 }
 
+type previewDeleteGroupEvent struct {
+	id string
+	groups.DeletionImpact
+	authn.Session
+	requestID string
+}
+
+func (pdge previewDeleteGroupEvent) Encode() (map[string]any, error) {
+	return map[string]any{
+		"operation":         groupPreviewRemove,
+		"id":                pdge.id,
+		"children_groups":   pdge.ChildrenGroups,
+		"descendant_groups": pdge.DescendantGroups,
+		"roles":             pdge.Roles,
+		"role_members":      pdge.RoleMembers,
+		"domain":            pdge.DomainID,
+		"user_id":           pdge.UserID,
+		"token_type":        pdge.Type.String(),
+		"super_admin":       pdge.SuperAdmin,
+		"request_id":        pdge.requestID,
+	}, nil
+}
+
 type retrieveGroupHierarchyEvent struct {
 	id string
 	groups.HierarchyPageMeta
@@ -5,6 +5,7 @@ package events
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/absmach/supermq/groups"
 	"github.com/absmach/supermq/pkg/authn"
@@ -26,6 +27,7 @@ const (
 	listStream              = supermqPrefix + groupList
 	listUserGroupsStream    = supermqPrefix + groupListUserGroups
 	removeStream            = supermqPrefix + groupRemove
+	previewRemoveStream     = supermqPrefix + groupPreviewRemove
 	retrieveHierarchyStream = supermqPrefix + groupRetrieveGroupHierarchy
 	addParentStream         = supermqPrefix + groupAddParentGroup
 	removeParentStream      = supermqPrefix + groupRemoveParentGroup
@@ -43,13 +45,15 @@ type eventStore struct {
 	rmEvents.RoleManagerEventStore
 }
 
-// NewEventStoreMiddleware returns wrapper around clients service that sends
-// events to event store.
-func New(ctx context.Context, svc groups.Service, url string) (groups.Service, error) {
+// New returns wrapper around groups service that sends events to event
+// store. Failures to publish events are logged and don't fail the wrapped
+// operations.
+func New(ctx context.Context, svc groups.Service, url string, logger *slog.Logger) (groups.Service, error) {
 	publisher, err := store.NewPublisher(ctx, url)
 	if err != nil {
 		return nil, err
 	}
+	publisher = events.NewBestEffortPublisher(publisher, logger)
 	rmes := rmEvents.NewRoleManagerEventStore("groups", groupPrefix, svc, publisher)
 
 	return &eventStore{
@@ -228,6 +232,33 @@ func (es eventStore) DeleteGroup(ctx context.Context, session authn.Session, id
 	return nil
 }
 
+func (es eventStore) DeleteGroupTree(ctx context.Context, session authn.Session, id string) ([]string, error) {
+	deleted, err := es.svc.DeleteGroupTree(ctx, session, id)
+	// Groups deleted before a failure are gone, so their events are published regardless.
+	for _, groupID := range deleted {
+		if perr := es.Publish(ctx, removeStream, deleteGroupEvent{
+			id:        groupID,
+			Session:   session,
+			requestID: middleware.GetReqID(ctx),
+		}); perr != nil && err == nil {
+			err = perr
+		}
+	}
+
+	return deleted, err
+}
+
+func (es eventStore) PreviewDeleteGroup(ctx context.Context, session authn.Session, id string) (groups.DeletionImpact, error) {
+	impact, err := es.svc.PreviewDeleteGroup(ctx, session, id)
+	if err != nil {
+		return impact, err
+	}
+	if err := es.Publish(ctx, previewRemoveStream, previewDeleteGroupEvent{id: id, DeletionImpact: impact, Session: session, requestID: middleware.GetReqID(ctx)}); err != nil {
+		return impact, err
+	}
+	return impact, nil
+}
+
 func (es eventStore) RetrieveGroupHierarchy(ctx context.Context, session authn.Session, id string, hm groups.HierarchyPageMeta) (groups.HierarchyPage, error) {
 	g, err := es.svc.RetrieveGroupHierarchy(ctx, session, id, hm)
 	if err != nil {
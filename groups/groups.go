@@ -12,9 +12,11 @@ import (
 	"github.com/absmach/supermq/pkg/roles"
 )
 
-// MaxLevel represents the maximum group hierarchy level.
 const (
-	MaxLevel      = uint64(20)
+	// MaxLevel represents the maximum group hierarchy level.
+	MaxLevel = uint64(20)
+	// MaxPathLength is the maximum nesting depth of the groups hierarchy,
+	// counting the root group. Hierarchy queries never traverse deeper.
 	MaxPathLength = 20
 )
 
@@ -50,6 +52,9 @@ type Group struct {
 	AccessProviderRoleActions []string                  `json:"access_provider_role_actions,omitempty"`
 	MemberId                  string                    `json:"member_id,omitempty"`
 	Roles                     []roles.MemberRoleActions `json:"roles,omitempty"`
+	// UnmodifiedSince, if set, makes Update fail unless the entity
+	// was last modified at or before the given time.
+	UnmodifiedSince time.Time `json:"-"`
 }
 
 type Member struct {
@@ -73,9 +78,31 @@ type Page struct {
 	Groups []Group
 }
 
+// Direction is the direction in which the groups hierarchy is traversed.
+type Direction int64
+
+const (
+	// Descendants traverses the hierarchy down from the group.
+	Descendants Direction = -1
+	// Ancestors traverses the hierarchy up from the group.
+	Ancestors Direction = 1
+)
+
+// String representation of the hierarchy directions.
+func (d Direction) String() string {
+	switch d {
+	case Ancestors:
+		return "ancestors"
+	case Descendants:
+		return "descendants"
+	default:
+		return "unknown"
+	}
+}
+
 type HierarchyPageMeta struct {
-	Level     uint64 `json:"level"`
-	Direction int64  `json:"direction"` // ancestors (+1) or descendants (-1)
+	Level     uint64    `json:"level"`
+	Direction Direction `json:"direction"` // ancestors (+1) or descendants (-1)
 	// - `true`  - result is JSON tree representing groups hierarchy,
 	// - `false` - result is JSON array of groups.
 	Tree bool `json:"tree"`
@@ -85,6 +112,20 @@ type HierarchyPage struct {
 	Groups []Group
 }
 
+// DeletionImpact contains the number of entities
+// that are affected by the group deletion.
+type DeletionImpact struct {
+	// ChildrenGroups is the number of groups that become root groups.
+	ChildrenGroups uint64 `json:"children_groups"`
+	// DescendantGroups is the number of groups detached from the hierarchy,
+	// including the children groups.
+	DescendantGroups uint64 `json:"descendant_groups"`
+	// Roles is the number of the group roles that are removed.
+	Roles uint64 `json:"roles"`
+	// RoleMembers is the number of members removed from the group roles.
+	RoleMembers uint64 `json:"role_members"`
+}
+
 // Repository specifies a group persistence API.
 type Repository interface {
 	// Save group.
@@ -135,6 +176,16 @@ type Repository interface {
 	// Delete a group
 	Delete(ctx context.Context, groupID string) error
 
+	// RetrieveDeletionImpact counts the entities affected by the group deletion.
+	RetrieveDeletionImpact(ctx context.Context, groupID string) (DeletionImpact, error)
+
+	// MarkTreeDeleted sets the deleted status on the group and all its
+	// descendant groups at once and returns their IDs, ordered from the
+	// deepest level to the group itself. Groups with the deleted status
+	// can't be moved or given children, so the tree doesn't change while
+	// it's being deleted.
+	MarkTreeDeleted(ctx context.Context, groupID string) ([]string, error)
+
 	roles.Repository
 }
 
@@ -166,6 +217,14 @@ type Service interface {
 	// DeleteGroup delete the given group id
 	DeleteGroup(ctx context.Context, session authn.Session, id string) error
 
+	// PreviewDeleteGroup returns the entities that would be affected by deleting
+	// the given group id, without deleting it.
+	PreviewDeleteGroup(ctx context.Context, session authn.Session, id string) (DeletionImpact, error)
+
+	// DeleteGroupTree deletes the given group id together with all its
+	// descendant groups, and returns the IDs of the deleted groups.
+	DeleteGroupTree(ctx context.Context, session authn.Session, id string) ([]string, error)
+
 	RetrieveGroupHierarchy(ctx context.Context, session authn.Session, id string, hm HierarchyPageMeta) (HierarchyPage, error)
 
 	AddParentGroup(ctx context.Context, session authn.Session, id, parentID string) error
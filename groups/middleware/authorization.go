@@ -28,6 +28,7 @@ var (
 	errEnable                      = errors.New("not authorized to enable group")
 	errDisable                     = errors.New("not authorized to disable group")
 	errDelete                      = errors.New("not authorized to delete group")
+	errDeleteTree                  = errors.New("not authorized to delete descendant groups of group")
 	errViewHierarchy               = errors.New("not authorized to view group parent/children hierarchy")
 	errListChildrenGroups          = errors.New("not authorized to view chidden groups of group")
 	errSetParentGroup              = errors.New("not authorized to set parent group to group")
@@ -229,6 +230,57 @@ func (am *authorizationMiddleware) DeleteGroup(ctx context.Context, session auth
 	return am.svc.DeleteGroup(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) DeleteGroupTree(ctx context.Context, session authn.Session, id string) ([]string, error) {
+	if err := am.authorize(ctx, session, policies.GroupType, operations.OpDeleteGroup, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      id,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		return []string{}, errors.Wrap(errDelete, err)
+	}
+
+	// Delete permission on the group doesn't grant delete permission on its
+	// descendants, while subgroup delete permission does for every group in
+	// the tree, including groups added to it later. Domain-wide group delete
+	// permission covers all the groups in the domain.
+	if err := am.authorize(ctx, session, policies.GroupType, operations.OpDeleteGroupTree, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      id,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		if derr := am.authorize(ctx, session, policies.DomainType, dOperations.OpDeleteDomainGroups, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			SubjectKind: policies.UsersKind,
+			Subject:     session.DomainUserID,
+			Object:      session.DomainID,
+			ObjectType:  policies.DomainType,
+		}); derr != nil {
+			return []string{}, errors.Wrap(errDeleteTree, err)
+		}
+	}
+
+	return am.svc.DeleteGroupTree(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) PreviewDeleteGroup(ctx context.Context, session authn.Session, id string) (groups.DeletionImpact, error) {
+	if err := am.authorize(ctx, session, policies.GroupType, operations.OpDeleteGroup, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		Object:      id,
+		ObjectType:  policies.GroupType,
+	}); err != nil {
+		return groups.DeletionImpact{}, errors.Wrap(errDelete, err)
+	}
+
+	return am.svc.PreviewDeleteGroup(ctx, session, id)
+}
+
 func (am *authorizationMiddleware) RetrieveGroupHierarchy(ctx context.Context, session authn.Session, id string, hm groups.HierarchyPageMeta) (groups.HierarchyPage, error) {
 	if err := am.authorize(ctx, session, policies.GroupType, operations.OpRetrieveGroupHierarchy, smqauthz.PolicyReq{
 		Domain:      session.DomainID,
@@ -395,7 +447,7 @@ func (am *authorizationMiddleware) authorize(ctx context.Context, session authn.
 	if session.PatID != "" {
 		entityID := pr.Object
 		opName := am.entitiesOps.OperationName(entityType, op)
-		if op == dOperations.OpListDomainGroups || op == dOperations.OpCreateDomainGroups {
+		if op == dOperations.OpListDomainGroups || op == dOperations.OpCreateDomainGroups || op == dOperations.OpDeleteDomainGroups {
 			entityID = auth.AnyIDs
 		}
 		pat = &smqauthz.PATReq{
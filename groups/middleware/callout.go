@@ -161,6 +161,32 @@ func (cm *calloutMiddleware) DeleteGroup(ctx context.Context, session authn.Sess
 	return cm.svc.DeleteGroup(ctx, session, id)
 }
 
+func (cm *calloutMiddleware) DeleteGroupTree(ctx context.Context, session authn.Session, id string) ([]string, error) {
+	params := map[string]any{
+		"entity_id": id,
+		"recursive": true,
+	}
+
+	if err := cm.callOut(ctx, session, policies.GroupType, operations.OpDeleteGroup, params); err != nil {
+		return []string{}, err
+	}
+
+	return cm.svc.DeleteGroupTree(ctx, session, id)
+}
+
+func (cm *calloutMiddleware) PreviewDeleteGroup(ctx context.Context, session authn.Session, id string) (groups.DeletionImpact, error) {
+	params := map[string]any{
+		"entity_id": id,
+		"dry_run":   true,
+	}
+
+	if err := cm.callOut(ctx, session, policies.GroupType, operations.OpDeleteGroup, params); err != nil {
+		return groups.DeletionImpact{}, err
+	}
+
+	return cm.svc.PreviewDeleteGroup(ctx, session, id)
+}
+
 func (cm *calloutMiddleware) RetrieveGroupHierarchy(ctx context.Context, session authn.Session, id string, hm groups.HierarchyPageMeta) (groups.HierarchyPage, error) {
 	params := map[string]any{
 		"entity_id":          id,
@@ -232,6 +232,49 @@ func (lm *loggingMiddleware) DeleteGroup(ctx context.Context, session authn.Sess
 	return lm.svc.DeleteGroup(ctx, session, id)
 }
 
+func (lm *loggingMiddleware) DeleteGroupTree(ctx context.Context, session authn.Session, id string) (deleted []string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("group_id", id),
+			slog.Int("deleted_groups", len(deleted)),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Delete group tree failed", args...)
+			return
+		}
+		lm.logger.Info("Delete group tree completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DeleteGroupTree(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) PreviewDeleteGroup(ctx context.Context, session authn.Session, id string) (impact groups.DeletionImpact, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("group_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Preview delete group failed", args...)
+			return
+		}
+		args = append(args, slog.Group("impact",
+			slog.Uint64("children_groups", impact.ChildrenGroups),
+			slog.Uint64("descendant_groups", impact.DescendantGroups),
+			slog.Uint64("roles", impact.Roles),
+			slog.Uint64("role_members", impact.RoleMembers),
+		))
+		lm.logger.Info("Preview delete group completed successfully", args...)
+	}(time.Now())
+	return lm.svc.PreviewDeleteGroup(ctx, session, id)
+}
+
 func (lm *loggingMiddleware) RetrieveGroupHierarchy(ctx context.Context, session authn.Session, id string, hm groups.HierarchyPageMeta) (gp groups.HierarchyPage, err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -241,7 +284,7 @@ func (lm *loggingMiddleware) RetrieveGroupHierarchy(ctx context.Context, session
 			slog.String("domain_id", session.DomainID),
 			slog.Group("page",
 				slog.Uint64("level", hm.Level),
-				slog.Int64("direction", hm.Direction),
+				slog.String("direction", hm.Direction.String()),
 				slog.Bool("tree", hm.Tree),
 			),
 		}
@@ -113,6 +113,22 @@ func (ms *metricsMiddleware) DeleteGroup(ctx context.Context, session authn.Sess
 	return ms.svc.DeleteGroup(ctx, session, id)
 }
 
+func (ms *metricsMiddleware) DeleteGroupTree(ctx context.Context, session authn.Session, id string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "delete_group_tree").Add(1)
+		ms.latency.With("method", "delete_group_tree").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DeleteGroupTree(ctx, session, id)
+}
+
+func (ms *metricsMiddleware) PreviewDeleteGroup(ctx context.Context, session authn.Session, id string) (groups.DeletionImpact, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "preview_delete_group").Add(1)
+		ms.latency.With("method", "preview_delete_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.PreviewDeleteGroup(ctx, session, id)
+}
+
 func (ms *metricsMiddleware) RetrieveGroupHierarchy(ctx context.Context, session authn.Session, id string, hm groups.HierarchyPageMeta) (groups.HierarchyPage, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_parent_groups").Add(1)
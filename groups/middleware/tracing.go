@@ -116,12 +116,26 @@ func (tm *tracingMiddleware) DisableGroup(ctx context.Context, session authn.Ses
 	return tm.svc.DisableGroup(ctx, session, id)
 }
 
+func (tm *tracingMiddleware) DeleteGroupTree(ctx context.Context, session authn.Session, id string) ([]string, error) {
+	ctx, span := tracing.StartSpan(ctx, tm.tracer, "svc_delete_group_tree", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.DeleteGroupTree(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) PreviewDeleteGroup(ctx context.Context, session authn.Session, id string) (groups.DeletionImpact, error) {
+	ctx, span := tracing.StartSpan(ctx, tm.tracer, "svc_preview_delete_group", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.PreviewDeleteGroup(ctx, session, id)
+}
+
 func (tm *tracingMiddleware) RetrieveGroupHierarchy(ctx context.Context, session authn.Session, id string, hm groups.HierarchyPageMeta) (groups.HierarchyPage, error) {
 	ctx, span := tracing.StartSpan(ctx, tm.tracer, "svc_list_group_hierarchy",
 		trace.WithAttributes(
 			attribute.String("id", id),
 			attribute.Int64("level", int64(hm.Level)),
-			attribute.Int64("direction", hm.Direction),
+			attribute.String("direction", hm.Direction.String()),
 			attribute.Bool("tree", hm.Tree),
 		))
 	defer span.End()
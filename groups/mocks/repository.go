@@ -378,6 +378,74 @@ func (_c *Repository_ListEntityMembers_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// MarkTreeDeleted provides a mock function for the type Repository
+func (_mock *Repository) MarkTreeDeleted(ctx context.Context, groupID string) ([]string, error) {
+	ret := _mock.Called(ctx, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkTreeDeleted")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, groupID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_MarkTreeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkTreeDeleted'
+type Repository_MarkTreeDeleted_Call struct {
+	*mock.Call
+}
+
+// MarkTreeDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *Repository_Expecter) MarkTreeDeleted(ctx interface{}, groupID interface{}) *Repository_MarkTreeDeleted_Call {
+	return &Repository_MarkTreeDeleted_Call{Call: _e.mock.On("MarkTreeDeleted", ctx, groupID)}
+}
+
+func (_c *Repository_MarkTreeDeleted_Call) Run(run func(ctx context.Context, groupID string)) *Repository_MarkTreeDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_MarkTreeDeleted_Call) Return(strings []string, err error) *Repository_MarkTreeDeleted_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *Repository_MarkTreeDeleted_Call) RunAndReturn(run func(ctx context.Context, groupID string) ([]string, error)) *Repository_MarkTreeDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveEntityMembers provides a mock function for the type Repository
 func (_mock *Repository) RemoveEntityMembers(ctx context.Context, entityID string, members []string) error {
 	ret := _mock.Called(ctx, entityID, members)
@@ -1176,6 +1244,72 @@ func (_c *Repository_RetrieveChildrenGroups_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// RetrieveDeletionImpact provides a mock function for the type Repository
+func (_mock *Repository) RetrieveDeletionImpact(ctx context.Context, groupID string) (groups.DeletionImpact, error) {
+	ret := _mock.Called(ctx, groupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveDeletionImpact")
+	}
+
+	var r0 groups.DeletionImpact
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (groups.DeletionImpact, error)); ok {
+		return returnFunc(ctx, groupID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) groups.DeletionImpact); ok {
+		r0 = returnFunc(ctx, groupID)
+	} else {
+		r0 = ret.Get(0).(groups.DeletionImpact)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RetrieveDeletionImpact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveDeletionImpact'
+type Repository_RetrieveDeletionImpact_Call struct {
+	*mock.Call
+}
+
+// RetrieveDeletionImpact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - groupID string
+func (_e *Repository_Expecter) RetrieveDeletionImpact(ctx interface{}, groupID interface{}) *Repository_RetrieveDeletionImpact_Call {
+	return &Repository_RetrieveDeletionImpact_Call{Call: _e.mock.On("RetrieveDeletionImpact", ctx, groupID)}
+}
+
+func (_c *Repository_RetrieveDeletionImpact_Call) Run(run func(ctx context.Context, groupID string)) *Repository_RetrieveDeletionImpact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RetrieveDeletionImpact_Call) Return(deletionImpact groups.DeletionImpact, err error) *Repository_RetrieveDeletionImpact_Call {
+	_c.Call.Return(deletionImpact, err)
+	return _c
+}
+
+func (_c *Repository_RetrieveDeletionImpact_Call) RunAndReturn(run func(ctx context.Context, groupID string) (groups.DeletionImpact, error)) *Repository_RetrieveDeletionImpact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveEntitiesRolesActionsMembers provides a mock function for the type Repository
 func (_mock *Repository) RetrieveEntitiesRolesActionsMembers(ctx context.Context, entityIDs []string) ([]roles.EntityActionRole, []roles.EntityMemberRole, error) {
 	ret := _mock.Called(ctx, entityIDs)
@@ -415,6 +415,80 @@ func (_c *Service_DeleteGroup_Call) RunAndReturn(run func(ctx context.Context, s
 	return _c
 }
 
+// DeleteGroupTree provides a mock function for the type Service
+func (_mock *Service) DeleteGroupTree(ctx context.Context, session authn.Session, id string) ([]string, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroupTree")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) ([]string, error)); ok {
+		return returnFunc(ctx, session, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) []string); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_DeleteGroupTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteGroupTree'
+type Service_DeleteGroupTree_Call struct {
+	*mock.Call
+}
+
+// DeleteGroupTree is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) DeleteGroupTree(ctx interface{}, session interface{}, id interface{}) *Service_DeleteGroupTree_Call {
+	return &Service_DeleteGroupTree_Call{Call: _e.mock.On("DeleteGroupTree", ctx, session, id)}
+}
+
+func (_c *Service_DeleteGroupTree_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_DeleteGroupTree_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_DeleteGroupTree_Call) Return(strings []string, err error) *Service_DeleteGroupTree_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *Service_DeleteGroupTree_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) ([]string, error)) *Service_DeleteGroupTree_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DisableGroup provides a mock function for the type Service
 func (_mock *Service) DisableGroup(ctx context.Context, session authn.Session, id string) (groups.Group, error) {
 	ret := _mock.Called(ctx, session, id)
@@ -945,6 +1019,78 @@ func (_c *Service_ListUserGroups_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// PreviewDeleteGroup provides a mock function for the type Service
+func (_mock *Service) PreviewDeleteGroup(ctx context.Context, session authn.Session, id string) (groups.DeletionImpact, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewDeleteGroup")
+	}
+
+	var r0 groups.DeletionImpact
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) (groups.DeletionImpact, error)); ok {
+		return returnFunc(ctx, session, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) groups.DeletionImpact); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(groups.DeletionImpact)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_PreviewDeleteGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewDeleteGroup'
+type Service_PreviewDeleteGroup_Call struct {
+	*mock.Call
+}
+
+// PreviewDeleteGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) PreviewDeleteGroup(ctx interface{}, session interface{}, id interface{}) *Service_PreviewDeleteGroup_Call {
+	return &Service_PreviewDeleteGroup_Call{Call: _e.mock.On("PreviewDeleteGroup", ctx, session, id)}
+}
+
+func (_c *Service_PreviewDeleteGroup_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_PreviewDeleteGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_PreviewDeleteGroup_Call) Return(deletionImpact groups.DeletionImpact, err error) *Service_PreviewDeleteGroup_Call {
+	_c.Call.Return(deletionImpact, err)
+	return _c
+}
+
+func (_c *Service_PreviewDeleteGroup_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) (groups.DeletionImpact, error)) *Service_PreviewDeleteGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveAllChildrenGroups provides a mock function for the type Service
 func (_mock *Service) RemoveAllChildrenGroups(ctx context.Context, session authn.Session, id string) error {
 	ret := _mock.Called(ctx, session, id)
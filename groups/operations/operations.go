@@ -25,6 +25,7 @@ const (
 	OpGroupSetChildChannel
 	OpGroupRemoveChildChannel
 	OpListUserGroups
+	OpDeleteGroupTree
 )
 
 func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
@@ -101,5 +102,9 @@ func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
 			Name:               "list_user_groups",
 			PermissionRequired: false, // hardcoded to superadmin
 		},
+		OpDeleteGroupTree: {
+			Name:               "delete_tree",
+			PermissionRequired: true,
+		},
 	}
 }
@@ -7,7 +7,10 @@ import "github.com/absmach/supermq/pkg/errors"
 
 var _ errors.Mapper = (*duplicateErrors)(nil)
 
-var errCyclicParentGroup = errors.NewRequestError("cyclic parent, group is parent of requested group")
+var (
+	errCyclicParentGroup = errors.NewRequestError("cyclic parent, group is parent of requested group")
+	errMaxNestedDepth    = errors.NewRequestError("reached max nested depth")
+)
 
 type duplicateErrors struct{}
 
@@ -31,12 +31,18 @@ const (
 	rolesTableNamePrefix = "groups"
 	entityTableName      = "groups"
 	entityIDColumnName   = "id"
+
+	// hierarchyQueryTimeout limits the hierarchy queries, which may be
+	// slow on deep or wide group trees.
+	hierarchyQueryTimeout = 10 * time.Second
 )
 
 var (
 	errParentGroupID   = errors.New("parent group id is empty")
 	errParentGroupPath = errors.New("parent group path is empty")
 	errParentSuffix    = errors.New("parent group path doesn't have parent id suffix")
+	errParentNotFound  = errors.New("parent group not found")
+	errNoRowReturned   = errors.New("no row returned")
 )
 
 type groupRepository struct {
@@ -78,7 +84,12 @@ func (repo groupRepository) Save(ctx context.Context, g groups.Group) (groups.Gr
 	}
 
 	defer row.Close()
-	row.Next()
+	if !row.Next() {
+		if err := row.Err(); err != nil {
+			return groups.Group{}, repo.eh.HandleError(repoerr.ErrCreateEntity, err)
+		}
+		return groups.Group{}, errors.Wrap(repoerr.ErrCreateEntity, errNoRowReturned)
+	}
 	dbg = dbGroup{}
 	if err := row.StructScan(&dbg); err != nil {
 		return groups.Group{}, repo.eh.HandleError(repoerr.ErrCreateEntity, err)
@@ -102,10 +113,14 @@ func (repo groupRepository) Update(ctx context.Context, g groups.Group) (groups.
 	if len(query) > 0 {
 		upq = strings.Join(query, " ")
 	}
+	var precond string
+	if !g.UnmodifiedSince.IsZero() {
+		precond = "AND COALESCE(updated_at, created_at) <= :unmodified_since"
+	}
 	g.Status = groups.EnabledStatus
 	q := fmt.Sprintf(`UPDATE groups SET %s updated_at = :updated_at, updated_by = :updated_by
-		WHERE id = :id AND status = :status
-		RETURNING id, name, tags, description, domain_id, COALESCE(parent_id, '') AS parent_id, metadata, created_at, updated_at, updated_by, status`, upq)
+		WHERE id = :id AND status = :status %s
+		RETURNING id, name, tags, description, domain_id, COALESCE(parent_id, '') AS parent_id, metadata, created_at, updated_at, updated_by, status`, upq, precond)
 
 	dbu, err := toDBGroup(g)
 	if err != nil {
@@ -119,6 +134,12 @@ func (repo groupRepository) Update(ctx context.Context, g groups.Group) (groups.
 
 	defer row.Close()
 	if ok := row.Next(); !ok {
+		if row.Err() == nil && precond != "" {
+			// No row matched, so tell a concurrent modification apart from a missing group.
+			if cur, err := repo.RetrieveByID(ctx, g.ID); err == nil && cur.Status == groups.EnabledStatus {
+				return groups.Group{}, repoerr.ErrPreconditionFailed
+			}
+		}
 		return groups.Group{}, errors.Wrap(repoerr.ErrNotFound, row.Err())
 	}
 	dbu = dbGroup{}
@@ -196,7 +217,10 @@ func (repo groupRepository) RetrieveByID(ctx context.Context, id string) (groups
 	defer row.Close()
 
 	dbg = dbGroup{}
-	if ok := row.Next(); !ok {
+	if !row.Next() {
+		if err := row.Err(); err != nil {
+			return groups.Group{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
 		return groups.Group{}, repoerr.ErrNotFound
 	}
 	if err := row.StructScan(&dbg); err != nil {
@@ -354,6 +378,9 @@ func (repo groupRepository) RetrieveByIDWithRoles(ctx context.Context, id, membe
 
 	dbg := dbGroup{}
 	if !row.Next() {
+		if err := row.Err(); err != nil {
+			return groups.Group{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
 		return groups.Group{}, repoerr.ErrNotFound
 	}
 
@@ -406,7 +433,10 @@ func (repo groupRepository) RetrieveByIDAndUser(ctx context.Context, domainID, u
 	defer row.Close()
 
 	dbg = dbGroup{}
-	if ok := row.Next(); !ok {
+	if !row.Next() {
+		if err := row.Err(); err != nil {
+			return groups.Group{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
 		return groups.Group{}, repoerr.ErrNotFound
 	}
 	if err := row.StructScan(&dbg); err != nil {
@@ -422,7 +452,9 @@ func (repo groupRepository) RetrieveAll(ctx context.Context, pm groups.PageMeta)
 		query += " AND nlevel(g.path) = 1 "
 	}
 
-	orderClause := ""
+	// Group ID breaks ties, so pages stay stable when ordered by columns
+	// with duplicate values.
+	orderClause := "ORDER BY g.id"
 	var orderBy string
 	switch pm.Order {
 	case "name":
@@ -502,9 +534,16 @@ func (repo groupRepository) RetrieveByIDs(ctx context.Context, pm groups.PageMet
 	}
 	query := buildQuery(pm, ids...)
 
-	q := fmt.Sprintf(`SELECT DISTINCT g.id, g.domain_id, tags, COALESCE(g.parent_id, '') AS parent_id, g.name, g.tags, g.description,
+	// Group IDs are unique, so DISTINCT can be dropped when ordering by an
+	// expression which is not in the select list.
+	distinct, orderBy := "DISTINCT ", "g.created_at, g.id"
+	if pm.PreserveIDsOrder && len(ids) > 0 {
+		distinct, orderBy = "", "array_position(:ids, g.id)"
+	}
+
+	q := fmt.Sprintf(`SELECT %sg.id, g.domain_id, tags, COALESCE(g.parent_id, '') AS parent_id, g.name, g.tags, g.description,
 		g.metadata, g.created_at, g.updated_at, g.updated_by, g.status,
-		COUNT(*) OVER() AS total_count FROM groups g %s ORDER BY g.created_at LIMIT :limit OFFSET :offset;`, query)
+		COUNT(*) OVER() AS total_count FROM groups g %s ORDER BY %s LIMIT :limit OFFSET :offset;`, distinct, query, orderBy)
 
 	dbPageMeta, err := toDBGroupPageMeta(pm)
 	if err != nil {
@@ -550,11 +589,17 @@ func (repo groupRepository) RetrieveByIDs(ctx context.Context, pm groups.PageMet
 
 func (repo groupRepository) RetrieveHierarchy(ctx context.Context, domainID, userID, groupID string, hm groups.HierarchyPageMeta) (groups.HierarchyPage, error) {
 	var dirQuery string
-	switch {
-	case hm.Direction >= 0:
-		dirQuery = "g.path @> (SELECT path FROM groups WHERE id = :id)"
-	default:
+	switch hm.Direction {
+	case groups.Descendants:
 		dirQuery = "g.path <@ (SELECT path FROM groups WHERE id = :id)"
+		if hm.Level > 0 {
+			dirQuery += " AND nlevel(g.path) <= (SELECT nlevel(path) FROM groups WHERE id = :id) + :level"
+		}
+	default:
+		dirQuery = "g.path @> (SELECT path FROM groups WHERE id = :id)"
+		if hm.Level > 0 {
+			dirQuery += " AND nlevel(g.path) >= (SELECT nlevel(path) FROM groups WHERE id = :id) - :level"
+		}
 	}
 
 	baseQuery := userGroupsBaseQuery
@@ -601,13 +646,18 @@ func (repo groupRepository) RetrieveHierarchy(ctx context.Context, domainID, use
 		"domain_id_param": domainID,
 	}
 
-	rows, err := repo.db.NamedQueryContext(ctx, query, parameters)
-	if err != nil {
-		return groups.HierarchyPage{}, repo.eh.HandleError(repoerr.ErrFailedToRetrieveAllGroups, err)
-	}
-	defer rows.Close()
+	var items []groups.Group
+	ctx = postgres.WithStatementTimeout(ctx, hierarchyQueryTimeout)
+	err := postgres.WithinTx(ctx, repo.db, func(tx *sqlx.Tx) error {
+		rows, err := sqlx.NamedQueryContext(ctx, tx, query, parameters)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
 
-	items, err := repo.processRows(rows)
+		items, err = repo.processRows(rows)
+		return err
+	})
 	if err != nil {
 		return groups.HierarchyPage{}, repo.eh.HandleError(repoerr.ErrFailedToRetrieveAllGroups, err)
 	}
@@ -615,177 +665,157 @@ func (repo groupRepository) RetrieveHierarchy(ctx context.Context, domainID, use
 	return groups.HierarchyPage{HierarchyPageMeta: hm, Groups: items}, nil
 }
 
-func (repo groupRepository) AssignParentGroup(ctx context.Context, parentGroupID string, groupIDs ...string) (err error) {
+func (repo groupRepository) AssignParentGroup(ctx context.Context, parentGroupID string, groupIDs ...string) error {
 	if len(groupIDs) == 0 {
 		return nil
 	}
 
-	tx, err := repo.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
-	}
-	defer func() {
+	err := postgres.WithinTx(ctx, repo.db, func(tx *sqlx.Tx) error {
+		pGroup, err := repo.retrieveParentInTx(tx, parentGroupID)
 		if err != nil {
-			if errRollback := tx.Rollback(); errRollback != nil {
-				err = errors.Wrap(err, errRollback)
-			}
+			return err
 		}
-	}()
-
-	pq := `SELECT id, path FROM groups WHERE id = $1 LIMIT 1;`
-	rows, err := tx.Queryx(pq, parentGroupID)
-	if err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
-	}
-	defer rows.Close()
-
-	pGroups, err := repo.processRows(rows)
-	if err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
-	}
-	if len(pGroups) == 0 {
-		return repoerr.ErrUpdateEntity
-	}
-	pGroup := pGroups[0]
-
-	if pGroup.ID == "" {
-		return errors.Wrap(repoerr.ErrViewEntity, errParentGroupID)
-	}
-	if pGroup.Path == "" {
-		return errors.Wrap(repoerr.ErrViewEntity, errParentGroupPath)
-	}
-	if !strings.HasSuffix(pGroup.Path, pGroup.ID) {
-		return errors.Wrap(repoerr.ErrViewEntity, errParentSuffix)
-	}
-	sPaths := strings.Split(pGroup.Path, ".") // 021b9f24-5337-469b-abfa-586f5813dd41.bd4a1fea-6303-4dca-9628-301cd1165a8c.c7e8f389-11e9-4849-a474-e186012ddf38
-	for _, sPath := range sPaths {
-		for _, cgid := range groupIDs {
-			if sPath == cgid {
-				return errors.Wrap(repoerr.ErrUpdateEntity, errCyclicParentGroup)
+		if !strings.HasSuffix(pGroup.Path, pGroup.ID) {
+			return errors.Wrap(repoerr.ErrViewEntity, errParentSuffix)
+		}
+		sPaths := strings.Split(pGroup.Path, ".") // 021b9f24-5337-469b-abfa-586f5813dd41.bd4a1fea-6303-4dca-9628-301cd1165a8c.c7e8f389-11e9-4849-a474-e186012ddf38
+		for _, sPath := range sPaths {
+			for _, cgid := range groupIDs {
+				if sPath == cgid {
+					return errCyclicParentGroup
+				}
 			}
 		}
-	}
 
-	query := `	UPDATE groups
+		query := `	UPDATE groups
 			SET parent_id = :parent_id
-			WHERE id = ANY(:children_group_ids)
+			WHERE id = ANY(:children_group_ids) AND status <> :deleted_status
 			RETURNING id, path;`
 
-	params := map[string]any{
-		"parent_id":          pGroup.ID,
-		"children_group_ids": groupIDs,
-	}
-
-	crows, err := tx.NamedQuery(query, params)
-	if err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
-	}
-	defer crows.Close()
-	cgroups, err := repo.processRows(crows)
-	if err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
-	}
-
-	childrenPaths := []string{}
-	for _, cg := range cgroups {
-		spath := strings.Split(cg.Path, ".")
-		if len(spath) > 0 {
-			childrenPaths = append(childrenPaths, cg.Path)
+		params := map[string]any{
+			"parent_id":          pGroup.ID,
+			"children_group_ids": groupIDs,
+			"deleted_status":     groups.DeletedStatus,
+		}
+		childrenPaths, err := repo.updateChildrenInTx(tx, query, params)
+		if err != nil {
+			return err
 		}
-	}
 
-	query = `UPDATE groups
+		query = `UPDATE groups
 				SET path = text2ltree(COALESCE($1, '') || '.' || ltree2text(path))
 				WHERE path <@ ANY($2::ltree[]);`
 
-	if _, err := tx.Exec(query, pGroup.Path, childrenPaths); err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
-	}
+		if _, err := tx.Exec(query, pGroup.Path, childrenPaths); err != nil {
+			return err
+		}
+
+		// Moving a subtree under the parent must not nest it deeper than allowed.
+		var depth int
+		if err := tx.Get(&depth, `SELECT COALESCE(MAX(nlevel(path)), 0) FROM groups WHERE path <@ $1::ltree;`, pGroup.Path); err != nil {
+			return err
+		}
+		if depth > groups.MaxPathLength {
+			return errMaxNestedDepth
+		}
 
-	if err := tx.Commit(); err != nil {
+		return nil
+	})
+	if err != nil {
 		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
 	}
+
 	return nil
 }
 
-func (repo groupRepository) UnassignParentGroup(ctx context.Context, parentGroupID string, groupIDs ...string) (err error) {
+func (repo groupRepository) UnassignParentGroup(ctx context.Context, parentGroupID string, groupIDs ...string) error {
 	if len(groupIDs) == 0 {
 		return nil
 	}
 
-	tx, err := repo.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
-	}
-	defer func() {
+	err := postgres.WithinTx(ctx, repo.db, func(tx *sqlx.Tx) error {
+		pGroup, err := repo.retrieveParentInTx(tx, parentGroupID)
 		if err != nil {
-			if errRollback := tx.Rollback(); errRollback != nil {
-				err = errors.Wrap(err, errRollback)
-			}
+			return err
 		}
-	}()
-	pq := `SELECT id, path FROM groups WHERE id = $1 LIMIT 1;`
-	rows, err := tx.Queryx(pq, parentGroupID)
+
+		query := `UPDATE groups
+			  SET parent_id = NULL
+			  WHERE id = ANY(:children_group_ids) AND parent_id = :parent_id AND status <> :deleted_status
+			  RETURNING id, path;`
+
+		params := map[string]any{
+			"parent_id":          pGroup.ID,
+			"children_group_ids": groupIDs,
+			"deleted_status":     groups.DeletedStatus,
+		}
+		childrenPaths, err := repo.updateChildrenInTx(tx, query, params)
+		if err != nil {
+			return err
+		}
+
+		query = `UPDATE groups
+				SET path = text2ltree(replace(ltree2text(path), $1 || '.', ''))
+				WHERE path <@ ANY($2::ltree[]);`
+
+		_, err = tx.Exec(query, pGroup.Path, childrenPaths)
+		return err
+	})
 	if err != nil {
 		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
 	}
+
+	return nil
+}
+
+// retrieveParentInTx retrieves the ID and path of the parent group within the transaction.
+func (repo groupRepository) retrieveParentInTx(tx *sqlx.Tx, parentGroupID string) (groups.Group, error) {
+	// Lock the parent so it can't be marked deleted while its children change.
+	rows, err := tx.Queryx(`SELECT id, path FROM groups WHERE id = $1 AND status <> $2 LIMIT 1 FOR SHARE;`, parentGroupID, groups.DeletedStatus)
+	if err != nil {
+		return groups.Group{}, err
+	}
 	defer rows.Close()
 
 	pGroups, err := repo.processRows(rows)
 	if err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
+		return groups.Group{}, err
 	}
 	if len(pGroups) == 0 {
-		return repoerr.ErrUpdateEntity
+		return groups.Group{}, errParentNotFound
 	}
 	pGroup := pGroups[0]
 
 	if pGroup.ID == "" {
-		return errors.Wrap(repoerr.ErrViewEntity, errParentGroupID)
+		return groups.Group{}, errors.Wrap(repoerr.ErrViewEntity, errParentGroupID)
 	}
 	if pGroup.Path == "" {
-		return errors.Wrap(repoerr.ErrViewEntity, errParentGroupPath)
+		return groups.Group{}, errors.Wrap(repoerr.ErrViewEntity, errParentGroupPath)
 	}
 
-	query := `UPDATE groups
-			  SET parent_id = NULL
-			  WHERE id = ANY(:children_group_ids) AND parent_id = :parent_id
-			  RETURNING id, path;`
+	return pGroup, nil
+}
 
-	parameters := map[string]any{
-		"parent_id":          pGroup.ID,
-		"children_group_ids": groupIDs,
-	}
-	crows, err := tx.NamedQuery(query, parameters)
+// updateChildrenInTx updates the children groups within the transaction
+// and returns the paths of the updated groups.
+func (repo groupRepository) updateChildrenInTx(tx *sqlx.Tx, query string, params map[string]any) ([]string, error) {
+	rows, err := tx.NamedQuery(query, params)
 	if err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
+		return nil, err
 	}
-	defer crows.Close()
-	cgroups, err := repo.processRows(crows)
+	defer rows.Close()
+
+	cgroups, err := repo.processRows(rows)
 	if err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
+		return nil, err
 	}
 
 	childrenPaths := []string{}
 	for _, cg := range cgroups {
-		spath := strings.Split(cg.Path, ".")
-		if len(spath) > 0 {
-			childrenPaths = append(childrenPaths, cg.Path)
-		}
+		childrenPaths = append(childrenPaths, cg.Path)
 	}
 
-	query = `UPDATE groups
-				SET path = text2ltree(replace(ltree2text(path), $1 || '.', ''))
-				WHERE path <@ ANY($2::ltree[]);`
-
-	if _, err := tx.Exec(query, pGroup.Path, childrenPaths); err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
-	}
-
-	if err := tx.Commit(); err != nil {
-		return repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
-	}
-	return nil
+	return childrenPaths, nil
 }
 
 func (repo groupRepository) UnassignAllChildrenGroups(ctx context.Context, id string) error {
@@ -819,6 +849,76 @@ func (repo groupRepository) Delete(ctx context.Context, groupID string) error {
 	return nil
 }
 
+func (repo groupRepository) RetrieveDeletionImpact(ctx context.Context, groupID string) (groups.DeletionImpact, error) {
+	q := fmt.Sprintf(`SELECT
+			(SELECT COUNT(*) FROM groups c WHERE c.parent_id = g.id) AS children_groups,
+			(SELECT COUNT(*) FROM groups d WHERE d.path <@ g.path AND d.id <> g.id) AS descendant_groups,
+			(SELECT COUNT(*) FROM %[1]s_roles r WHERE r.entity_id = g.id) AS roles,
+			(SELECT COUNT(*) FROM %[1]s_role_members rm WHERE rm.entity_id = g.id) AS role_members
+		FROM groups g
+		WHERE g.id = :id`, rolesTableNamePrefix)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, map[string]any{"id": groupID})
+	if err != nil {
+		return groups.DeletionImpact{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return groups.DeletionImpact{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+		}
+		return groups.DeletionImpact{}, repoerr.ErrNotFound
+	}
+	var impact dbDeletionImpact
+	if err := rows.StructScan(&impact); err != nil {
+		return groups.DeletionImpact{}, repo.eh.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return groups.DeletionImpact{
+		ChildrenGroups:   impact.ChildrenGroups,
+		DescendantGroups: impact.DescendantGroups,
+		Roles:            impact.Roles,
+		RoleMembers:      impact.RoleMembers,
+	}, nil
+}
+
+func (repo groupRepository) MarkTreeDeleted(ctx context.Context, groupID string) ([]string, error) {
+	q := `WITH tree AS (
+			UPDATE groups SET status = :status
+			WHERE path <@ (SELECT path FROM groups WHERE id = :id)
+			RETURNING id, nlevel(path) AS level
+		)
+		SELECT id FROM tree ORDER BY level DESC;`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, dbGroup{ID: groupID, Status: groups.DeletedStatus})
+	if err != nil {
+		return []string{}, repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return []string{}, repo.eh.HandleError(repoerr.ErrUpdateEntity, err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return []string{}, repoerr.ErrNotFound
+	}
+
+	return ids, nil
+}
+
+type dbDeletionImpact struct {
+	ChildrenGroups   uint64 `db:"children_groups"`
+	DescendantGroups uint64 `db:"descendant_groups"`
+	Roles            uint64 `db:"roles"`
+	RoleMembers      uint64 `db:"role_members"`
+}
+
 func (repo groupRepository) RetrieveAllParentGroups(ctx context.Context, domainID, userID, groupID string, pm groups.PageMeta) (groups.Page, error) {
 	cGroup, err := repo.RetrieveByID(ctx, groupID)
 	if err != nil {
@@ -1214,11 +1314,17 @@ final_groups AS (
 func buildQuery(gm groups.PageMeta, ids ...string) string {
 	queries := []string{}
 
-	if len(ids) > 0 {
-		queries = append(queries, "id = ANY(:ids)")
+	if len(ids) > 0 || len(gm.IDs) > 0 {
+		queries = append(queries, "g.id = ANY(:ids)")
 	}
+	// Both name matching modes can use a trigram index on the group name:
+	// CREATE INDEX ON groups USING GIN (name gin_trgm_ops).
 	if gm.Name != "" {
-		queries = append(queries, "g.name ILIKE '%' || :name || '%'")
+		if gm.NamePrefix {
+			queries = append(queries, "g.name ILIKE :name || '%'")
+		} else {
+			queries = append(queries, "g.name ILIKE '%' || :name || '%'")
+		}
 	}
 	if gm.ID != "" {
 		queries = append(queries, "g.id = :id")
@@ -1229,9 +1335,9 @@ func buildQuery(gm groups.PageMeta, ids ...string) string {
 	if len(gm.Tags.Elements) > 0 {
 		switch gm.Tags.Operator {
 		case groups.AndOp:
-			queries = append(queries, "tags @> :tags")
+			queries = append(queries, "g.tags @> :tags")
 		default: // OR
-			queries = append(queries, "tags && :tags")
+			queries = append(queries, "g.tags && :tags")
 		}
 	}
 	if gm.DomainID != "" {
@@ -1291,6 +1397,7 @@ type dbGroup struct {
 	Roles                     json.RawMessage  `db:"roles,omitempty"`
 	TotalCount                uint64           `db:"total_count"`
 	UserID                    string           `db:"user_id,omitempty"`
+	UnmodifiedSince           time.Time        `db:"unmodified_since,omitempty"`
 	DomainIDParam             string           `db:"domain_id_param,omitempty"`
 }
 
@@ -1320,18 +1427,19 @@ func toDBGroup(g groups.Group) (dbGroup, error) {
 		updatedBy = &g.UpdatedBy
 	}
 	return dbGroup{
-		ID:          g.ID,
-		Name:        g.Name,
-		ParentID:    parentID,
-		DomainID:    g.Domain,
-		Description: sql.NullString{String: g.Description.Value, Valid: g.Description.Valid},
-		Tags:        tags,
-		Metadata:    data,
-		Path:        g.Path,
-		CreatedAt:   g.CreatedAt,
-		UpdatedAt:   updatedAt,
-		UpdatedBy:   updatedBy,
-		Status:      g.Status,
+		ID:              g.ID,
+		Name:            g.Name,
+		ParentID:        parentID,
+		DomainID:        g.Domain,
+		Description:     sql.NullString{String: g.Description.Value, Valid: g.Description.Valid},
+		Tags:            tags,
+		Metadata:        data,
+		Path:            g.Path,
+		CreatedAt:       g.CreatedAt,
+		UpdatedAt:       updatedAt,
+		UpdatedBy:       updatedBy,
+		Status:          g.Status,
+		UnmodifiedSince: g.UnmodifiedSince,
 	}, nil
 }
 
@@ -1407,7 +1515,8 @@ func toDBGroupPageMeta(pm groups.PageMeta) (dbGroupPageMeta, error) {
 	}
 	return dbGroupPageMeta{
 		ID:          pm.ID,
-		Name:        pm.Name,
+		IDs:         pm.IDs,
+		Name:        postgres.EscapeLike(pm.Name),
 		Metadata:    data,
 		Tags:        tags,
 		Total:       pm.Total,
@@ -1473,9 +1582,12 @@ func (repo groupRepository) getInsertQuery(c context.Context, g groups.Group) (s
 		if err != nil {
 			return "", "", err
 		}
+		if parent.Status == groups.DeletedStatus {
+			return "", "", errParentNotFound
+		}
 		path := parent.Path + "." + g.ID
 		if len(strings.Split(path, ".")) > groups.MaxPathLength {
-			return "", "", fmt.Errorf("reached max nested depth")
+			return "", "", errMaxNestedDepth
 		}
 		return `INSERT INTO groups (name, description, tags, id, domain_id, parent_id, metadata, created_at, status, path)
 		VALUES (:name, :description, :tags, :id, :domain_id, :parent_id, :metadata, :created_at, :status, CAST(:path AS ltree))
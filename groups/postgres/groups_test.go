@@ -17,7 +17,9 @@ import (
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	pgclient "github.com/absmach/supermq/pkg/postgres"
 	"github.com/absmach/supermq/pkg/roles"
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -247,6 +249,29 @@ func TestSave(t *testing.T) {
 	}
 }
 
+func TestSaveWithoutReturnedRow(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DROP TRIGGER IF EXISTS skip_group_insert ON groups")
+		require.Nil(t, err, fmt.Sprintf("drop trigger unexpected error: %s", err))
+		_, err = db.Exec("DROP FUNCTION IF EXISTS skip_group_insert")
+		require.Nil(t, err, fmt.Sprintf("drop function unexpected error: %s", err))
+	})
+
+	// A BEFORE INSERT trigger returning NULL silently skips the insert,
+	// so INSERT ... RETURNING yields no row and no error.
+	_, err := db.Exec(`CREATE FUNCTION skip_group_insert() RETURNS trigger AS $$ BEGIN RETURN NULL; END; $$ LANGUAGE plpgsql`)
+	require.Nil(t, err, fmt.Sprintf("create function unexpected error: %s", err))
+	_, err = db.Exec(`CREATE TRIGGER skip_group_insert BEFORE INSERT ON groups FOR EACH ROW EXECUTE FUNCTION skip_group_insert()`)
+	require.Nil(t, err, fmt.Sprintf("create trigger unexpected error: %s", err))
+
+	repo := postgres.New(database)
+
+	group := validGroup
+	group.ID = testsutil.GenerateUUID(t)
+	_, err = repo.Save(context.Background(), group)
+	assert.True(t, errors.Contains(err, repoerr.ErrCreateEntity), fmt.Sprintf("expected %s got %s\n", repoerr.ErrCreateEntity, err))
+}
+
 func TestUpdate(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM groups")
@@ -310,6 +335,42 @@ func TestUpdate(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc:   "update group with valid precondition",
+			update: "name",
+			group: groups.Group{
+				ID:              group.ID,
+				Name:            namegen.Generate(),
+				UpdatedAt:       validTimestamp,
+				UpdatedBy:       testsutil.GenerateUUID(t),
+				UnmodifiedSince: validTimestamp,
+			},
+			err: nil,
+		},
+		{
+			desc:   "update group with failed precondition",
+			update: "name",
+			group: groups.Group{
+				ID:              group.ID,
+				Name:            namegen.Generate(),
+				UpdatedAt:       validTimestamp,
+				UpdatedBy:       testsutil.GenerateUUID(t),
+				UnmodifiedSince: validTimestamp.Add(-time.Hour),
+			},
+			err: repoerr.ErrPreconditionFailed,
+		},
+		{
+			desc:   "update group with precondition and invalid ID",
+			update: "name",
+			group: groups.Group{
+				ID:              testsutil.GenerateUUID(t),
+				Name:            namegen.Generate(),
+				UpdatedAt:       validTimestamp,
+				UpdatedBy:       testsutil.GenerateUUID(t),
+				UnmodifiedSince: validTimestamp,
+			},
+			err: repoerr.ErrNotFound,
+		},
 		{
 			desc:   "update group with invalid ID",
 			update: "all",
@@ -524,12 +585,23 @@ func TestRetrieveByID(t *testing.T) {
 			group: groups.Group{},
 			err:   repoerr.ErrNotFound,
 		},
+		{
+			desc:  "retrieve group by id with non-existing ID",
+			id:    testsutil.GenerateUUID(t),
+			group: groups.Group{},
+			err:   repoerr.ErrNotFound,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			group, err := repo.RetrieveByID(context.Background(), tc.id)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == repoerr.ErrNotFound {
+				_, ok := err.(*errors.NotFoundError)
+				assert.True(t, ok, fmt.Sprintf("%s: expected not found error got %T\n", tc.desc, err))
+				assert.Equal(t, groups.Group{}, group, fmt.Sprintf("%s: expected empty group got %v\n", tc.desc, group))
+			}
 			if err == nil {
 				assert.Nil(t, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 				assert.Equal(t, tc.resp, group, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.group, group))
@@ -886,6 +958,45 @@ func TestRetrieveAll(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc: "retrieve groups with name prefix ignoring case",
+			page: groups.Page{
+				PageMeta: groups.PageMeta{
+					Offset:     0,
+					Limit:      10,
+					Name:       strings.ToUpper(items[0].Name),
+					NamePrefix: true,
+				},
+			},
+			response: groups.Page{
+				PageMeta: groups.PageMeta{
+					Total:  1,
+					Offset: 0,
+					Limit:  10,
+				},
+				Groups: []groups.Group{items[0]},
+			},
+			err: nil,
+		},
+		{
+			desc: "retrieve groups with name containing wildcard",
+			page: groups.Page{
+				PageMeta: groups.PageMeta{
+					Offset: 0,
+					Limit:  10,
+					Name:   "%",
+				},
+			},
+			response: groups.Page{
+				PageMeta: groups.PageMeta{
+					Total:  0,
+					Offset: 0,
+					Limit:  10,
+				},
+				Groups: []groups.Group(nil),
+			},
+			err: nil,
+		},
 		{
 			desc: "retrieve groups with domain",
 			page: groups.Page{
@@ -1307,6 +1418,57 @@ func TestRetrieveAll(t *testing.T) {
 	}
 }
 
+func TestRetrieveAllPaginationTiebreaker(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+	num := 25
+	domainID := testsutil.GenerateUUID(t)
+	createdAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	for i := 0; i < num; i++ {
+		group := groups.Group{
+			ID:        testsutil.GenerateUUID(t),
+			Domain:    domainID,
+			Name:      namegen.Generate(),
+			Metadata:  map[string]any{},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+			Status:    groups.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), group)
+		require.Nil(t, err, fmt.Sprintf("create group unexpected error: %s", err))
+	}
+
+	for _, order := range []string{"", "name", "created_at", "updated_at"} {
+		for _, dir := range []string{ascDir, descDir} {
+			desc := fmt.Sprintf("paginate groups ordered by %q %s", order, dir)
+			t.Run(desc, func(t *testing.T) {
+				seen := make(map[string]bool)
+				for offset := uint64(0); offset < uint64(num); offset += 7 {
+					pm := groups.PageMeta{
+						Offset:   offset,
+						Limit:    7,
+						DomainID: domainID,
+						Order:    order,
+						Dir:      dir,
+					}
+					page, err := repo.RetrieveAll(context.Background(), pm)
+					require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", desc, err))
+					for _, g := range page.Groups {
+						assert.False(t, seen[g.ID], fmt.Sprintf("%s: group %s seen twice", desc, g.ID))
+						seen[g.ID] = true
+					}
+				}
+				assert.Len(t, seen, num, fmt.Sprintf("%s: expected %d groups got %d", desc, num, len(seen)))
+			})
+		}
+	}
+}
+
 func TestRetrieveByIDs(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM groups")
@@ -1578,6 +1740,77 @@ func TestRetrieveByIDs(t *testing.T) {
 	}
 }
 
+func TestRetrieveByIDsPreserveOrder(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+	num := 10
+
+	var ids []string
+	for i := 0; i < num; i++ {
+		name := namegen.Generate()
+		group := groups.Group{
+			ID:          testsutil.GenerateUUID(t),
+			Domain:      testsutil.GenerateUUID(t),
+			Name:        name,
+			Description: desc,
+			Metadata:    map[string]any{"name": name},
+			CreatedAt:   time.Now().UTC().Add(time.Duration(i) * time.Second).Truncate(time.Microsecond),
+			Status:      groups.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), group)
+		require.Nil(t, err, fmt.Sprintf("create group unexpected error: %s", err))
+		ids = append(ids, group.ID)
+	}
+	// Request groups in the reverse order of creation.
+	reversed := make([]string, num)
+	for i, id := range ids {
+		reversed[num-1-i] = id
+	}
+
+	cases := []struct {
+		desc string
+		pm   groups.PageMeta
+		ids  []string
+		resp []string
+	}{
+		{
+			desc: "retrieve groups by IDs ordered by creation",
+			pm:   groups.PageMeta{Limit: uint64(num)},
+			ids:  reversed,
+			resp: ids,
+		},
+		{
+			desc: "retrieve groups by IDs in requested order",
+			pm:   groups.PageMeta{Limit: uint64(num), PreserveIDsOrder: true},
+			ids:  reversed,
+			resp: reversed,
+		},
+		{
+			desc: "retrieve page of groups by IDs in requested order",
+			pm:   groups.PageMeta{Offset: 2, Limit: 3, PreserveIDsOrder: true},
+			ids:  reversed,
+			resp: reversed[2:5],
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveByIDs(context.Background(), tc.pm, tc.ids...)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, uint64(num), page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, num, page.Total))
+			got := make([]string, len(page.Groups))
+			for i, g := range page.Groups {
+				got[i] = g.ID
+			}
+			assert.Equal(t, tc.resp, got, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.resp, got))
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM groups")
@@ -1619,6 +1852,184 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestRetrieveDeletionImpact(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	var items []groups.Group
+	parentID := ""
+	for i := 0; i < 3; i++ {
+		name := namegen.Generate()
+		group := groups.Group{
+			ID:          testsutil.GenerateUUID(t),
+			Domain:      domainID,
+			Parent:      parentID,
+			Name:        name,
+			Description: desc,
+			Metadata:    map[string]any{"name": name},
+			CreatedAt:   validTimestamp,
+			Status:      groups.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), group)
+		require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+		items = append(items, group)
+		parentID = group.ID
+	}
+	_, err := repo.AddRoles(context.Background(), []roles.RoleProvision{
+		{
+			Role: roles.Role{
+				ID:        testsutil.GenerateUUID(t) + "_" + items[0].ID,
+				Name:      "admin",
+				EntityID:  items[0].ID,
+				CreatedAt: validTimestamp,
+				CreatedBy: userID,
+			},
+			OptionalActions: availableActions,
+			OptionalMembers: []string{userID},
+		},
+	})
+	require.Nil(t, err, fmt.Sprintf("add roles unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		impact groups.DeletionImpact
+		err    error
+	}{
+		{
+			desc: "retrieve deletion impact of root group",
+			id:   items[0].ID,
+			impact: groups.DeletionImpact{
+				ChildrenGroups:   1,
+				DescendantGroups: 2,
+				Roles:            1,
+				RoleMembers:      1,
+			},
+			err: nil,
+		},
+		{
+			desc:   "retrieve deletion impact of leaf group",
+			id:     items[2].ID,
+			impact: groups.DeletionImpact{},
+			err:    nil,
+		},
+		{
+			desc: "retrieve deletion impact with invalid ID",
+			id:   invalidID,
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "retrieve deletion impact with empty ID",
+			id:   "",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			impact, err := repo.RetrieveDeletionImpact(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.impact, impact, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, tc.impact, impact))
+		})
+	}
+}
+
+func TestMarkTreeDeleted(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	var items []groups.Group
+	parentID := ""
+	for i := 0; i < 4; i++ {
+		name := namegen.Generate()
+		group := groups.Group{
+			ID:          testsutil.GenerateUUID(t),
+			Domain:      domainID,
+			Parent:      parentID,
+			Name:        name,
+			Description: desc,
+			Metadata:    map[string]any{"name": name},
+			CreatedAt:   validTimestamp,
+			Status:      groups.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), group)
+		require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+		items = append(items, group)
+		parentID = group.ID
+	}
+
+	cases := []struct {
+		desc string
+		id   string
+		ids  []string
+		err  error
+	}{
+		{
+			desc: "mark tree of leaf group deleted",
+			id:   items[3].ID,
+			ids:  []string{items[3].ID},
+			err:  nil,
+		},
+		{
+			desc: "mark tree of group deleted",
+			id:   items[1].ID,
+			ids:  []string{items[3].ID, items[2].ID, items[1].ID},
+			err:  nil,
+		},
+		{
+			desc: "mark tree with invalid ID deleted",
+			id:   invalidID,
+			ids:  []string{},
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ids, err := repo.MarkTreeDeleted(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ids, ids))
+			for _, id := range ids {
+				group, err := repo.RetrieveByID(context.Background(), id)
+				require.Nil(t, err, fmt.Sprintf("%s: retrieve group unexpected error: %s", tc.desc, err))
+				assert.Equal(t, groups.DeletedStatus, group.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, groups.DeletedStatus, group.Status))
+			}
+		})
+	}
+
+	group, err := repo.RetrieveByID(context.Background(), items[0].ID)
+	require.Nil(t, err, fmt.Sprintf("retrieve group unexpected error: %s", err))
+	assert.Equal(t, groups.EnabledStatus, group.Status, fmt.Sprintf("expected status of group outside the tree %s got %s\n", groups.EnabledStatus, group.Status))
+
+	// The deleted tree can't be given new children.
+	child := groups.Group{
+		ID:        testsutil.GenerateUUID(t),
+		Domain:    domainID,
+		Parent:    items[1].ID,
+		Name:      namegen.Generate(),
+		CreatedAt: validTimestamp,
+		Status:    groups.EnabledStatus,
+	}
+	_, err = repo.Save(context.Background(), child)
+	assert.NotNil(t, err, "expected error saving a child of a deleted group")
+	child.Parent = ""
+	_, err = repo.Save(context.Background(), child)
+	require.Nil(t, err, fmt.Sprintf("save group unexpected error: %s", err))
+	err = repo.AssignParentGroup(context.Background(), items[1].ID, child.ID)
+	assert.NotNil(t, err, "expected error assigning a deleted parent group")
+}
+
 func TestAssignParentGroup(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM groups")
@@ -1844,9 +2255,7 @@ func TestRetrieveHierarchy(t *testing.T) {
 	domainID := testsutil.GenerateUUID(t)
 	num := 10
 
-	var items []groups.Group
-	parentID := ""
-	for i := 0; i < num; i++ {
+	saveGroup := func(parentID string) groups.Group {
 		name := namegen.Generate()
 		group := groups.Group{
 			ID:          testsutil.GenerateUUID(t),
@@ -1875,11 +2284,20 @@ func TestRetrieveHierarchy(t *testing.T) {
 		}
 		_, err = repo.AddRoles(context.Background(), newRolesProvision)
 		require.Nil(t, err, fmt.Sprintf("add roles unexpected error: %s", err))
+
+		return group
+	}
+
+	var items []groups.Group
+	parentID := ""
+	for i := 0; i < num; i++ {
+		group := saveGroup(parentID)
 		items = append(items, group)
 		if i == 0 {
 			parentID = group.ID
 		}
 	}
+	grandchild := saveGroup(items[1].ID)
 
 	cases := []struct {
 		desc     string
@@ -1930,6 +2348,66 @@ func TestRetrieveHierarchy(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc:     "retrieve descendants with level 2 successfully",
+			groupID:  items[0].ID,
+			userID:   userID,
+			domainID: domainID,
+			hm: groups.HierarchyPageMeta{
+				Level:     2,
+				Direction: -1,
+				Tree:      false,
+			},
+			resp: groups.HierarchyPage{
+				Groups: append(append([]groups.Group{}, items...), grandchild),
+				HierarchyPageMeta: groups.HierarchyPageMeta{
+					Level:     2,
+					Direction: -1,
+					Tree:      false,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:     "retrieve descendants without level limit successfully",
+			groupID:  items[0].ID,
+			userID:   userID,
+			domainID: domainID,
+			hm: groups.HierarchyPageMeta{
+				Level:     0,
+				Direction: -1,
+				Tree:      false,
+			},
+			resp: groups.HierarchyPage{
+				Groups: append(append([]groups.Group{}, items...), grandchild),
+				HierarchyPageMeta: groups.HierarchyPageMeta{
+					Level:     0,
+					Direction: -1,
+					Tree:      false,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc:     "retrieve ancestors with level 1 successfully",
+			groupID:  grandchild.ID,
+			userID:   userID,
+			domainID: domainID,
+			hm: groups.HierarchyPageMeta{
+				Level:     1,
+				Direction: +1,
+				Tree:      false,
+			},
+			resp: groups.HierarchyPage{
+				Groups: []groups.Group{items[1], grandchild},
+				HierarchyPageMeta: groups.HierarchyPageMeta{
+					Level:     1,
+					Direction: +1,
+					Tree:      false,
+				},
+			},
+			err: nil,
+		},
 		{
 			desc:     "retrieve hierarchy with invalid ID",
 			groupID:  testsutil.GenerateUUID(t),
@@ -1999,6 +2477,156 @@ func TestRetrieveHierarchy(t *testing.T) {
 	}
 }
 
+func TestRetrieveHierarchyDepth(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	chain := saveGroupsChain(t, repo, domainID, userID, "", 10)
+
+	cases := []struct {
+		desc    string
+		groupID string
+		hm      groups.HierarchyPageMeta
+		groups  []groups.Group
+		err     error
+	}{
+		{
+			desc:    "retrieve 3 levels of descendants",
+			groupID: chain[0].ID,
+			hm:      groups.HierarchyPageMeta{Level: 3, Direction: groups.Descendants},
+			groups:  chain[:4],
+		},
+		{
+			desc:    "retrieve 3 levels of ancestors",
+			groupID: chain[9].ID,
+			hm:      groups.HierarchyPageMeta{Level: 3, Direction: groups.Ancestors},
+			groups:  chain[6:],
+		},
+		{
+			desc:    "retrieve all descendants",
+			groupID: chain[0].ID,
+			hm:      groups.HierarchyPageMeta{Direction: groups.Descendants},
+			groups:  chain,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			gpPage, err := repo.RetrieveHierarchy(context.Background(), domainID, userID, tc.groupID, tc.hm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			got := stripGroupDetails(gpPage.Groups)
+			expected := stripGroupDetails(append([]groups.Group{}, tc.groups...))
+			assert.ElementsMatch(t, expected, got, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expected, got))
+		})
+	}
+}
+
+func TestStatementTimeout(t *testing.T) {
+	cases := []struct {
+		desc    string
+		ctx     context.Context
+		timeout string
+		query   string
+		err     error
+	}{
+		{
+			desc:    "with configured statement timeout",
+			ctx:     context.Background(),
+			timeout: "0",
+			query:   "SELECT pg_sleep(0.1)",
+			err:     nil,
+		},
+		{
+			desc:    "with per-call statement timeout",
+			ctx:     pgclient.WithStatementTimeout(context.Background(), time.Second),
+			timeout: "1s",
+			query:   "SELECT pg_sleep(0.1)",
+			err:     nil,
+		},
+		{
+			desc:    "with exceeded per-call statement timeout",
+			ctx:     pgclient.WithStatementTimeout(context.Background(), 10*time.Millisecond),
+			timeout: "10ms",
+			query:   "SELECT pg_sleep(1)",
+			err:     repoerr.ErrDeadlineExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := pgclient.WithinTx(tc.ctx, database, func(tx *sqlx.Tx) error {
+				var timeout string
+				if err := tx.GetContext(tc.ctx, &timeout, "SHOW statement_timeout"); err != nil {
+					return err
+				}
+				assert.Equal(t, tc.timeout, timeout, fmt.Sprintf("%s: expected timeout %s got %s\n", tc.desc, tc.timeout, timeout))
+				_, err := tx.ExecContext(tc.ctx, tc.query)
+				return err
+			})
+			err = pgclient.NewErrorHandler().HandleError(repoerr.ErrViewEntity, err)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+
+	var timeout string
+	err := db.Get(&timeout, "SHOW statement_timeout")
+	assert.Nil(t, err, fmt.Sprintf("show statement timeout unexpected error: %s", err))
+	assert.Equal(t, "0", timeout, "statement timeout must be reset once the transaction ends")
+}
+
+func TestAssignParentGroupMaxDepth(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	userID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	chain := saveGroupsChain(t, repo, domainID, userID, "", 10)
+	short := saveGroupsChain(t, repo, domainID, userID, "", 10)
+	long := saveGroupsChain(t, repo, domainID, userID, "", 11)
+
+	cases := []struct {
+		desc     string
+		parentID string
+		childID  string
+		err      error
+	}{
+		{
+			desc:     "assign subtree within max depth",
+			parentID: chain[9].ID,
+			childID:  short[0].ID,
+			err:      nil,
+		},
+		{
+			desc:     "assign subtree exceeding max depth",
+			parentID: chain[9].ID,
+			childID:  long[0].ID,
+			err:      repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.AssignParentGroup(context.Background(), tc.parentID, tc.childID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				child, err := repo.RetrieveByID(context.Background(), tc.childID)
+				require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Empty(t, child.Parent, fmt.Sprintf("%s: expected group to keep no parent, got %s", tc.desc, child.Parent))
+			}
+		})
+	}
+}
+
 func TestRetrieveAllParentGroups(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM groups")
@@ -2434,6 +3062,45 @@ func getIDs(groups []groups.Group) []string {
 	return ids
 }
 
+// saveGroupsChain saves num groups nested one under the other,
+// each one accessible to the given user through the admin role.
+func saveGroupsChain(t *testing.T, repo groups.Repository, domainID, userID, parentID string, num int) []groups.Group {
+	var chain []groups.Group
+	for i := 0; i < num; i++ {
+		name := namegen.Generate()
+		group := groups.Group{
+			ID:          testsutil.GenerateUUID(t),
+			Domain:      domainID,
+			Parent:      parentID,
+			Name:        name,
+			Description: desc,
+			Metadata:    map[string]any{"name": name},
+			CreatedAt:   time.Now().UTC().Truncate(time.Microsecond),
+			Status:      groups.EnabledStatus,
+		}
+		_, err := repo.Save(context.Background(), group)
+		require.Nil(t, err, fmt.Sprintf("create group unexpected error: %s", err))
+		_, err = repo.AddRoles(context.Background(), []roles.RoleProvision{
+			{
+				Role: roles.Role{
+					ID:        testsutil.GenerateUUID(t) + "_" + group.ID,
+					Name:      "admin",
+					EntityID:  group.ID,
+					CreatedAt: validTimestamp,
+					CreatedBy: userID,
+				},
+				OptionalActions: availableActions,
+				OptionalMembers: []string{userID},
+			},
+		})
+		require.Nil(t, err, fmt.Sprintf("add roles unexpected error: %s", err))
+		chain = append(chain, group)
+		parentID = group.ID
+	}
+
+	return chain
+}
+
 func stripGroupDetails(groups []groups.Group) []groups.Group {
 	for i := range groups {
 		groups[i].Level = 0
@@ -2475,3 +3142,92 @@ func verifyGroupsOrdering(t *testing.T, groups []groups.Group, order, dir string
 		}
 	}
 }
+
+func TestRoleListMembers(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM groups")
+		require.Nil(t, err, fmt.Sprintf("clean groups unexpected error: %s", err))
+	})
+
+	repo := postgres.New(database)
+
+	group, err := repo.Save(context.Background(), validGroup)
+	require.Nil(t, err, fmt.Sprintf("create group unexpected error: %s", err))
+
+	num := 10
+	members := make([]string, num)
+	for i := range members {
+		members[i] = testsutil.GenerateUUID(t)
+	}
+	rp := roles.RoleProvision{
+		Role: roles.Role{
+			ID:        testsutil.GenerateUUID(t) + "_" + group.ID,
+			Name:      "admin",
+			EntityID:  group.ID,
+			CreatedAt: validTimestamp,
+			CreatedBy: members[0],
+		},
+		OptionalActions: availableActions,
+		OptionalMembers: members,
+	}
+	_, err = repo.AddRoles(context.Background(), []roles.RoleProvision{rp})
+	require.Nil(t, err, fmt.Sprintf("add roles unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		roleID string
+		limit  uint64
+		offset uint64
+		size   int
+		total  uint64
+	}{
+		{
+			desc:   "list all role members",
+			roleID: rp.ID,
+			limit:  uint64(num),
+			size:   num,
+			total:  uint64(num),
+		},
+		{
+			desc:   "list first page of role members",
+			roleID: rp.ID,
+			limit:  3,
+			size:   3,
+			total:  uint64(num),
+		},
+		{
+			desc:   "list last partial page of role members",
+			roleID: rp.ID,
+			limit:  3,
+			offset: 9,
+			size:   1,
+			total:  uint64(num),
+		},
+		{
+			desc:   "list role members with offset out of range",
+			roleID: rp.ID,
+			limit:  3,
+			offset: uint64(num),
+			size:   0,
+			total:  uint64(num),
+		},
+		{
+			desc:   "list members of non-existing role",
+			roleID: testsutil.GenerateUUID(t),
+			limit:  3,
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RoleListMembers(context.Background(), tc.roleID, tc.limit, tc.offset)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.size, len(page.Members), fmt.Sprintf("%s: expected %d members got %d", tc.desc, tc.size, len(page.Members)))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+			assert.Equal(t, tc.limit, page.Limit, fmt.Sprintf("%s: expected limit %d got %d", tc.desc, tc.limit, page.Limit))
+			assert.Equal(t, tc.offset, page.Offset, fmt.Sprintf("%s: expected offset %d got %d", tc.desc, tc.offset, page.Offset))
+		})
+	}
+}
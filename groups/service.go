@@ -167,7 +167,7 @@ func (svc service) UpdateGroupTags(ctx context.Context, session smqauthn.Session
 	group := Group{
 		ID:        g.ID,
 		Tags:      g.Tags,
-		UpdatedAt: time.Now(),
+		UpdatedAt: time.Now().UTC(),
 		UpdatedBy: session.UserID,
 	}
 	group, err := svc.repo.UpdateTags(ctx, group)
@@ -285,7 +285,7 @@ func (svc service) RemoveParentGroup(ctx context.Context, session smqauthn.Sessi
 }
 
 func (svc service) AddChildrenGroups(ctx context.Context, session smqauthn.Session, parentGroupID string, childrenGroupIDs []string) (retErr error) {
-	childrenGroupsPage, err := svc.repo.RetrieveByIDs(ctx, PageMeta{Limit: 1<<63 - 1}, childrenGroupIDs...)
+	childrenGroupsPage, err := svc.repo.RetrieveByIDs(ctx, PageMeta{Limit: 1<<63 - 1, PreserveIDsOrder: true}, childrenGroupIDs...)
 	if err != nil {
 		return errors.Wrap(svcerr.ErrViewEntity, err)
 	}
@@ -329,7 +329,7 @@ func (svc service) AddChildrenGroups(ctx context.Context, session smqauthn.Sessi
 }
 
 func (svc service) RemoveChildrenGroups(ctx context.Context, session smqauthn.Session, parentGroupID string, childrenGroupIDs []string) (retErr error) {
-	childrenGroupsPage, err := svc.repo.RetrieveByIDs(ctx, PageMeta{Limit: 1<<63 - 1}, childrenGroupIDs...)
+	childrenGroupsPage, err := svc.repo.RetrieveByIDs(ctx, PageMeta{Limit: 1<<63 - 1, PreserveIDsOrder: true}, childrenGroupIDs...)
 	if err != nil {
 		return errors.Wrap(svcerr.ErrViewEntity, err)
 	}
@@ -411,16 +411,6 @@ func (svc service) DeleteGroup(ctx context.Context, session smqauthn.Session, id
 		return errors.Wrap(svcerr.ErrRemoveEntity, err)
 	}
 
-	filterDeletePolicies := []policies.Policy{
-		{
-			SubjectType: policies.GroupType,
-			Subject:     id,
-		},
-		{
-			ObjectType: policies.GroupType,
-			Object:     id,
-		},
-	}
 	deletePolicies := []policies.Policy{
 		{
 			SubjectType: policies.DomainType,
@@ -440,7 +430,14 @@ func (svc service) DeleteGroup(ctx context.Context, session smqauthn.Session, id
 			Object:      id,
 		})
 	}
-	if err := svc.RemoveEntitiesRoles(ctx, session.DomainID, session.DomainUserID, []string{id}, filterDeletePolicies, deletePolicies); err != nil {
+	if err := svc.RemoveEntitiesRoles(ctx, session.DomainID, session.DomainUserID, []string{id}, nil, deletePolicies); err != nil {
+		return errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+
+	// Remove any relationship left referencing the group, such as child groups,
+	// channels and clients, before removing the group so that the delete can
+	// be retried on failure.
+	if err := svc.policy.RemoveObjectPolicies(ctx, policies.GroupType, id); err != nil {
 		return errors.Wrap(svcerr.ErrDeletePolicies, err)
 	}
 
@@ -451,6 +448,37 @@ func (svc service) DeleteGroup(ctx context.Context, session smqauthn.Session, id
 	return nil
 }
 
+func (svc service) PreviewDeleteGroup(ctx context.Context, session smqauthn.Session, id string) (DeletionImpact, error) {
+	impact, err := svc.repo.RetrieveDeletionImpact(ctx, id)
+	if err != nil {
+		return DeletionImpact{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return impact, nil
+}
+
+func (svc service) DeleteGroupTree(ctx context.Context, session smqauthn.Session, id string) ([]string, error) {
+	// The whole tree is marked deleted first, so it can't change while its
+	// groups are deleted one by one.
+	ids, err := svc.repo.MarkTreeDeleted(ctx, id)
+	if err != nil {
+		return []string{}, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	// Groups are deleted starting from the deepest level, so if the deletion
+	// fails part way, the remaining groups still form a valid hierarchy and
+	// the deletion can be retried.
+	deleted := []string{}
+	for _, groupID := range ids {
+		if err := svc.DeleteGroup(ctx, session, groupID); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, groupID)
+	}
+
+	return deleted, nil
+}
+
 func (svc service) changeGroupStatus(ctx context.Context, session smqauthn.Session, group Group) (Group, error) {
 	dbGroup, err := svc.repo.RetrieveByID(ctx, group.ID)
 	if err != nil {
@@ -994,7 +994,7 @@ func TestAddChildrenGroups(t *testing.T) {
 				ObjectType:  policysvc.GroupType,
 				Object:      validGroup.ID,
 			}
-			repoCall := repo.On("RetrieveByIDs", context.Background(), groups.PageMeta{Limit: 1<<63 - 1}, tc.childrenIDs).Return(tc.retrieveResp, tc.retrieveErr)
+			repoCall := repo.On("RetrieveByIDs", context.Background(), groups.PageMeta{Limit: 1<<63 - 1, PreserveIDsOrder: true}, tc.childrenIDs).Return(tc.retrieveResp, tc.retrieveErr)
 			policyCall := policies.On("AddPolicies", context.Background(), []policysvc.Policy{pol}).Return(tc.addPoliciesErr)
 			policyCall1 := policies.On("DeletePolicies", context.Background(), []policysvc.Policy{pol}).Return(tc.deletePoliciesErr)
 			repoCall1 := repo.On("AssignParentGroup", context.Background(), tc.parentID, tc.childrenIDs).Return(tc.assignParentErr)
@@ -1112,7 +1112,7 @@ func TestRemoveChildrenGroups(t *testing.T) {
 				ObjectType:  policysvc.GroupType,
 				Object:      childGroupID,
 			}
-			repoCall := repo.On("RetrieveByIDs", context.Background(), groups.PageMeta{Limit: 1<<63 - 1}, tc.childrenIDs).Return(tc.retrieveResp, tc.retrieveErr)
+			repoCall := repo.On("RetrieveByIDs", context.Background(), groups.PageMeta{Limit: 1<<63 - 1, PreserveIDsOrder: true}, tc.childrenIDs).Return(tc.retrieveResp, tc.retrieveErr)
 			policyCall := policies.On("DeletePolicies", context.Background(), []policysvc.Policy{pol}).Return(tc.deletePoliciesErr)
 			policyCall1 := policies.On("AddPolicies", context.Background(), []policysvc.Policy{pol}).Return(tc.addPoliciesErr)
 			repoCall1 := repo.On("UnassignParentGroup", context.Background(), tc.parentID, tc.childrenIDs).Return(tc.unassignParentErr)
@@ -1299,6 +1299,14 @@ func TestDeleteGroup(t *testing.T) {
 			deletePoliciesErr: svcerr.ErrAuthorization,
 			err:               svcerr.ErrDeletePolicies,
 		},
+		{
+			desc:              "delete group with failed to delete policies keeps the group",
+			id:                validGroup.ID,
+			changeStatusRes:   validGroup,
+			deleteErr:         repoerr.ErrNotFound,
+			deletePoliciesErr: svcerr.ErrAuthorization,
+			err:               svcerr.ErrDeletePolicies,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1308,7 +1316,7 @@ func TestDeleteGroup(t *testing.T) {
 			svcCall := channels.On("UnsetParentGroupFromChannels", context.Background(), &grpcChannelsV1.UnsetParentGroupFromChannelsReq{ParentGroupId: tc.id}).Return(&grpcChannelsV1.UnsetParentGroupFromChannelsRes{}, tc.unsetFromChannels)
 			svcCall1 := clients.On("UnsetParentGroupFromClient", context.Background(), &grpcClientsV1.UnsetParentGroupFromClientReq{ParentGroupId: tc.id}).Return(&grpcClientsV1.UnsetParentGroupFromClientRes{}, tc.unsetFromClients)
 			repoCall2 := repo.On("RetrieveEntitiesRolesActionsMembers", context.Background(), []string{tc.id}).Return([]roles.EntityActionRole{}, []roles.EntityMemberRole{}, nil)
-			policyCall := policies.On("DeletePolicyFilter", context.Background(), mock.Anything).Return(tc.deletePoliciesErr)
+			policyCall := policies.On("RemoveObjectPolicies", context.Background(), policysvc.GroupType, tc.id).Return(tc.deletePoliciesErr)
 			policyCall1 := policies.On("DeletePolicies", context.Background(), mock.Anything).Return(nil)
 			err := svc.DeleteGroup(context.Background(), validSession, tc.id)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
@@ -1322,3 +1330,105 @@ func TestDeleteGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteGroupTree(t *testing.T) {
+	svc := newService(t)
+
+	cases := []struct {
+		desc              string
+		id                string
+		descendantIDs     []string
+		descendantIDsErr  error
+		unsetFromChannels error
+		deleted           []string
+		err               error
+	}{
+		{
+			desc:          "delete group tree successfully",
+			id:            validGroup.ID,
+			descendantIDs: []string{childGroupID, validGroup.ID},
+			deleted:       []string{childGroupID, validGroup.ID},
+			err:           nil,
+		},
+		{
+			desc:             "delete group tree with failed to retrieve descendants",
+			id:               validGroup.ID,
+			descendantIDsErr: repoerr.ErrNotFound,
+			deleted:          []string{},
+			err:              svcerr.ErrRemoveEntity,
+		},
+		{
+			desc:              "delete group tree with failed to delete group",
+			id:                validGroup.ID,
+			descendantIDs:     []string{childGroupID, validGroup.ID},
+			unsetFromChannels: svcerr.ErrRemoveEntity,
+			deleted:           []string{},
+			err:               svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("MarkTreeDeleted", context.Background(), tc.id).Return(tc.descendantIDs, tc.descendantIDsErr)
+			repoCall1 := repo.On("ChangeStatus", context.Background(), mock.Anything).Return(validGroup, nil)
+			repoCall2 := repo.On("Delete", context.Background(), mock.Anything).Return(nil)
+			repoCall3 := repo.On("RetrieveEntitiesRolesActionsMembers", context.Background(), mock.Anything).Return([]roles.EntityActionRole{}, []roles.EntityMemberRole{}, nil)
+			svcCall := channels.On("UnsetParentGroupFromChannels", context.Background(), mock.Anything).Return(&grpcChannelsV1.UnsetParentGroupFromChannelsRes{}, tc.unsetFromChannels)
+			svcCall1 := clients.On("UnsetParentGroupFromClient", context.Background(), mock.Anything).Return(&grpcClientsV1.UnsetParentGroupFromClientRes{}, nil)
+			policyCall := policies.On("RemoveObjectPolicies", context.Background(), policysvc.GroupType, mock.Anything).Return(nil)
+			policyCall1 := policies.On("DeletePolicies", context.Background(), mock.Anything).Return(nil)
+			deleted, err := svc.DeleteGroupTree(context.Background(), validSession, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			assert.Equal(t, tc.deleted, deleted, fmt.Sprintf("expected deleted groups %v got %v", tc.deleted, deleted))
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			svcCall.Unset()
+			svcCall1.Unset()
+			policyCall.Unset()
+			policyCall1.Unset()
+		})
+	}
+}
+
+func TestPreviewDeleteGroup(t *testing.T) {
+	svc := newService(t)
+
+	cases := []struct {
+		desc     string
+		id       string
+		repoResp groups.DeletionImpact
+		repoErr  error
+		err      error
+	}{
+		{
+			desc: "preview delete group successfully",
+			id:   validGroup.ID,
+			repoResp: groups.DeletionImpact{
+				ChildrenGroups:   1,
+				DescendantGroups: 2,
+				Roles:            1,
+				RoleMembers:      3,
+			},
+		},
+		{
+			desc:    "preview delete group with failed to retrieve",
+			id:      testsutil.GenerateUUID(t),
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveDeletionImpact", context.Background(), tc.id).Return(tc.repoResp, tc.repoErr)
+			got, err := svc.PreviewDeleteGroup(context.Background(), validSession, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected error %v to contain %v", err, tc.err))
+			if err == nil {
+				assert.Equal(t, tc.repoResp, got)
+			}
+			repoCall.Unset()
+		})
+	}
+}
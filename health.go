@@ -4,15 +4,21 @@
 package supermq
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 )
 
 const (
 	contentType     = "Content-Type"
 	contentTypeJSON = "application/health+json"
 	svcStatus       = "pass"
+	svcFailStatus   = "fail"
 	description     = " service"
+	// readinessTimeout bounds the time spent on readiness checks so that a
+	// hanging dependency makes the service unready instead of blocking probes.
+	readinessTimeout = 5 * time.Second
 )
 
 var (
@@ -76,3 +82,46 @@ func Health(service, instanceID string) http.HandlerFunc {
 		}
 	})
 }
+
+// ReadinessCheck verifies that a dependency required to serve requests
+// is available.
+type ReadinessCheck func(ctx context.Context) error
+
+// Readiness exposes an HTTP handler reporting whether the service is ready
+// to serve requests. It responds with 503 Service Unavailable if any of the
+// checks fails.
+func Readiness(service, instanceID string, checks ...ReadinessCheck) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(contentType, contentTypeJSON)
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		res := HealthInfo{
+			Status:      svcStatus,
+			Version:     Version,
+			Commit:      Commit,
+			Description: service + description,
+			BuildTime:   BuildTime,
+			InstanceID:  instanceID,
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		code := http.StatusOK
+		for _, check := range checks {
+			if err := check(ctx); err != nil {
+				res.Status = svcFailStatus
+				code = http.StatusServiceUnavailable
+				break
+			}
+		}
+
+		w.WriteHeader(code)
+
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
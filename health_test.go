@@ -0,0 +1,87 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package supermq_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/absmach/supermq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadiness(t *testing.T) {
+	var checked []string
+	check := func(name string, err error) supermq.ReadinessCheck {
+		return func(ctx context.Context) error {
+			checked = append(checked, name)
+			if _, ok := ctx.Deadline(); !ok {
+				return errors.New("missing deadline")
+			}
+			return err
+		}
+	}
+	errUnavailable := errors.New("unavailable")
+
+	cases := []struct {
+		desc    string
+		method  string
+		checks  []supermq.ReadinessCheck
+		code    int
+		status  string
+		checked []string
+	}{
+		{
+			desc:   "ready without checks",
+			method: http.MethodGet,
+			code:   http.StatusOK,
+			status: "pass",
+		},
+		{
+			desc:    "ready with passing checks",
+			method:  http.MethodGet,
+			checks:  []supermq.ReadinessCheck{check("first", nil), check("second", nil)},
+			code:    http.StatusOK,
+			status:  "pass",
+			checked: []string{"first", "second"},
+		},
+		{
+			desc:    "not ready with failing check",
+			method:  http.MethodGet,
+			checks:  []supermq.ReadinessCheck{check("first", errUnavailable), check("second", nil)},
+			code:    http.StatusServiceUnavailable,
+			status:  "fail",
+			checked: []string{"first"},
+		},
+		{
+			desc:   "invalid method",
+			method: http.MethodPost,
+			checks: []supermq.ReadinessCheck{check("first", nil)},
+			code:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			checked = nil
+			req := httptest.NewRequest(tc.method, "/readiness", nil)
+			rec := httptest.NewRecorder()
+			supermq.Readiness("test", "instance", tc.checks...).ServeHTTP(rec, req)
+			assert.Equal(t, tc.code, rec.Code, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.code, rec.Code))
+			assert.Equal(t, tc.checked, checked, fmt.Sprintf("%s: expected checks %v got %v", tc.desc, tc.checked, checked))
+			if tc.status == "" {
+				return
+			}
+			var res supermq.HealthInfo
+			err := json.NewDecoder(rec.Body).Decode(&res)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding response: %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, res.Status))
+		})
+	}
+}
@@ -159,6 +159,9 @@ func (svc *adapterService) authenticate(ctx context.Context, authType, token str
 		if err != nil {
 			return "", err
 		}
+		if authnSession.Type != smqauthn.AccessToken && authnSession.Type != smqauthn.PersonalAccessToken {
+			return "", svcerr.ErrAuthentication
+		}
 		return authnSession.UserID, nil
 	case policies.ClientType:
 		authnRes, err := svc.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{Token: token})
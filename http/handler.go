@@ -264,6 +264,9 @@ func (h *handler) authenticate(ctx context.Context, authType, token, domainID st
 		if err != nil {
 			return "", "", err
 		}
+		if authnSession.Type != smqauthn.AccessToken && authnSession.Type != smqauthn.PersonalAccessToken {
+			return "", "", svcerr.ErrAuthentication
+		}
 		if authnSession.Role == smqauthn.SuperAdminRole {
 			return authnSession.UserID, authnSession.UserID, nil
 		}
@@ -5,6 +5,7 @@ package mqtt
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -86,8 +87,14 @@ func (h *handler) AuthConnect(ctx context.Context) error {
 	}
 
 	pwd := string(s.Password)
+	token := authn.AuthPack(authn.BasicAuth, s.Username, pwd)
+	// Clients connecting over mTLS without a password are authenticated
+	// by the certificate they presented.
+	if pwd == "" && len(s.Cert.Raw) > 0 {
+		token = authn.CertPack(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Cert.Raw}))
+	}
 
-	res, err := h.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{Token: authn.AuthPack(authn.BasicAuth, s.Username, pwd)})
+	res, err := h.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{Token: token})
 	if err != nil {
 		return errors.Wrap(svcerr.ErrAuthentication, err)
 	}
@@ -98,6 +105,10 @@ func (h *handler) AuthConnect(ctx context.Context) error {
 	if s.Username != "" && res.GetId() != s.Username {
 		return errInvalidUserId
 	}
+	// Publish and subscribe authorize the client by the session username.
+	if s.Username == "" {
+		s.Username = res.GetId()
+	}
 
 	return nil
 }
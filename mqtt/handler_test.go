@@ -6,6 +6,8 @@ package mqtt_test
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"log"
 	"testing"
@@ -144,20 +146,36 @@ func TestAuthConnect(t *testing.T) {
 				Id:            clientID,
 			},
 		},
+		{
+			desc: "connect with client certificate",
+			session: &session.Session{
+				ID:   clientID,
+				Cert: x509.Certificate{Raw: []byte("certificate")},
+			},
+			authNRes: &grpcClientsV1.AuthnRes{
+				Authenticated: true,
+				Id:            clientID,
+			},
+			err: nil,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			ctx := context.TODO()
-			password := ""
-			username := ""
+			token := authn.AuthPack(authn.BasicAuth, "", "")
 			if tc.session != nil {
 				ctx = session.NewContext(ctx, tc.session)
-				password = string(tc.session.Password)
-				username = tc.session.Username
+				token = authn.AuthPack(authn.BasicAuth, tc.session.Username, string(tc.session.Password))
+				if len(tc.session.Password) == 0 && len(tc.session.Cert.Raw) > 0 {
+					token = authn.CertPack(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tc.session.Cert.Raw}))
+				}
 			}
-			clientsCall := clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{Token: authn.AuthPack(authn.BasicAuth, username, password)}).Return(tc.authNRes, tc.authNErr)
+			clientsCall := clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{Token: token}).Return(tc.authNRes, tc.authNErr)
 			err := handler.AuthConnect(ctx)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.authNRes.GetId(), tc.session.Username, fmt.Sprintf("%s: expected session username %s got %s\n", tc.desc, tc.authNRes.GetId(), tc.session.Username))
+			}
 			clientsCall.Unset()
 		})
 	}
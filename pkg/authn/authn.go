@@ -17,6 +17,12 @@ const (
 	AccessToken TokenType = iota
 	// PersonalAccessToken represents token generated by user for automation.
 	PersonalAccessToken
+	// RefreshToken represents token used to obtain a new access token.
+	RefreshToken
+	// RecoveryToken represents token used to reset the user password.
+	RecoveryToken
+	// InvitationToken represents token used to invite new users.
+	InvitationToken
 )
 
 const PatPrefix = "pat_"
@@ -27,6 +33,12 @@ func (t TokenType) String() string {
 		return "access token"
 	case PersonalAccessToken:
 		return "pat"
+	case RefreshToken:
+		return "refresh token"
+	case RecoveryToken:
+		return "recovery token"
+	case InvitationToken:
+		return "invitation token"
 	default:
 		return "unknown"
 	}
@@ -48,6 +60,9 @@ type Session struct {
 	SuperAdmin   bool
 	Verified     bool
 	Role         Role
+	// Scopes limit the session to the listed entity kinds and access levels.
+	// Sessions without scopes have the full authority of the user.
+	Scopes []string
 }
 
 // Authn is supermq authentication library.
@@ -107,3 +122,26 @@ func AuthUnpack(token string) (AuthPrefix, string, string, error) {
 func AuthPack(prefix AuthPrefix, id, key string) string {
 	return prefix.String() + base64.StdEncoding.EncodeToString([]byte(id+":"+key))
 }
+
+// certPrefix marks tokens carrying a PEM encoded client certificate in place
+// of the client key, so that adapters terminating mTLS can authenticate
+// clients by the certificate presented during the handshake.
+const certPrefix = "Cert"
+
+// CertPack packs the PEM encoded client certificate into a token.
+func CertPack(certPEM []byte) string {
+	return certPrefix + base64.StdEncoding.EncodeToString(certPEM)
+}
+
+// CertUnpack returns the PEM encoded client certificate packed into the token.
+// The second value reports whether the token carries a certificate.
+func CertUnpack(token string) ([]byte, bool) {
+	if !strings.HasPrefix(token, certPrefix) {
+		return nil, false
+	}
+	certPEM, err := base64.StdEncoding.DecodeString(token[len(certPrefix):])
+	if err != nil || !strings.HasPrefix(string(certPEM), "-----BEGIN ") {
+		return nil, false
+	}
+	return certPEM, true
+}
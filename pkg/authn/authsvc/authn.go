@@ -7,6 +7,8 @@ import (
 	"context"
 
 	grpcAuthV1 "github.com/absmach/supermq/api/grpc/auth/v1"
+	smqauth "github.com/absmach/supermq/auth"
+	grpcapi "github.com/absmach/supermq/auth/api/grpc"
 	"github.com/absmach/supermq/auth/api/grpc/auth"
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
@@ -33,7 +35,7 @@ func NewAuthentication(ctx context.Context, cfg grpcclient.Config) (authn.Authen
 	if err != nil || resp.GetStatus() != grpchealth.HealthCheckResponse_SERVING {
 		return nil, nil, grpcclient.ErrSvcNotServing
 	}
-	authSvcClient := auth.NewAuthClient(client.Connection(), cfg.Timeout)
+	authSvcClient := auth.NewAuthClient(client.Connection(), cfg.Timeout, grpcapi.WithMethodTimeouts(cfg.MethodTimeouts))
 	return authentication{authSvcClient}, client, nil
 }
 
@@ -47,5 +49,20 @@ func (a authentication) Authenticate(ctx context.Context, token string) (authn.S
 		return authn.Session{Type: authn.PersonalAccessToken, PatID: res.GetId(), UserID: res.GetUserId(), Role: authn.Role(res.GetUserRole())}, nil
 	}
 
-	return authn.Session{Type: authn.AccessToken, UserID: res.GetUserId(), Role: authn.Role(res.GetUserRole()), Verified: res.GetVerified()}, nil
+	return authn.Session{Type: tokenType(smqauth.KeyType(res.GetKeyType())), UserID: res.GetUserId(), Role: authn.Role(res.GetUserRole()), Verified: res.GetVerified(), Scopes: res.GetScopes()}, nil
+}
+
+// tokenType returns the session token type of the given key type. API keys
+// act on behalf of the user, so they are treated as access tokens.
+func tokenType(kt smqauth.KeyType) authn.TokenType {
+	switch kt {
+	case smqauth.RefreshKey:
+		return authn.RefreshToken
+	case smqauth.RecoveryKey:
+		return authn.RecoveryToken
+	case smqauth.InvitationKey:
+		return authn.InvitationToken
+	default:
+		return authn.AccessToken
+	}
 }
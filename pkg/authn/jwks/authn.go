@@ -14,6 +14,7 @@ import (
 
 	grpcAuthV1 "github.com/absmach/supermq/api/grpc/auth/v1"
 	smqauth "github.com/absmach/supermq/auth"
+	grpcapi "github.com/absmach/supermq/auth/api/grpc"
 	"github.com/absmach/supermq/auth/api/grpc/auth"
 	smqjwt "github.com/absmach/supermq/auth/tokenizer/util"
 	"github.com/absmach/supermq/pkg/authn"
@@ -75,7 +76,7 @@ func NewAuthentication(ctx context.Context, jwksURL string, cfg grpcclient.Confi
 	if err != nil || resp.GetStatus() != grpchealth.HealthCheckResponse_SERVING {
 		return nil, nil, grpcclient.ErrSvcNotServing
 	}
-	authSvcClient := auth.NewAuthClient(client.Connection(), cfg.Timeout)
+	authSvcClient := auth.NewAuthClient(client.Connection(), cfg.Timeout, grpcapi.WithMethodTimeouts(cfg.MethodTimeouts))
 
 	httpClient := &http.Client{}
 
@@ -122,13 +123,27 @@ func (a authentication) Authenticate(ctx context.Context, token string) (authn.S
 	}
 
 	return authn.Session{
-		Type:     authn.AccessToken,
+		Type:     tokenType(key.Type),
 		UserID:   key.Subject,
 		Role:     authn.Role(key.Role),
 		Verified: key.Verified,
+		Scopes:   key.Scopes,
 	}, nil
 }
 
+func tokenType(kt smqauth.KeyType) authn.TokenType {
+	switch kt {
+	case smqauth.RefreshKey:
+		return authn.RefreshToken
+	case smqauth.RecoveryKey:
+		return authn.RecoveryToken
+	case smqauth.InvitationKey:
+		return authn.InvitationToken
+	default:
+		return authn.AccessToken
+	}
+}
+
 func isSignatureError(err error) bool {
 	return !errors.Contains(err, errJWTExpiryKey) &&
 		!errors.Contains(err, errInvalidIssuer) &&
@@ -8,9 +8,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/authz"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/go-chi/chi/v5"
@@ -29,6 +31,8 @@ const (
 type middlewareOptions struct {
 	domainCheck         bool
 	allowUnverifiedUser bool
+	allowedTokenTypes   []TokenType
+	scopeEntityType     string
 }
 
 // defaultMiddlewareOptions returns the default middleware configuration.
@@ -36,6 +40,7 @@ func defaultMiddlewareOptions() *middlewareOptions {
 	return &middlewareOptions{
 		domainCheck:         true,
 		allowUnverifiedUser: false,
+		allowedTokenTypes:   []TokenType{AccessToken, PersonalAccessToken},
 	}
 }
 
@@ -56,12 +61,32 @@ func WithAllowUnverifiedUser(allowed bool) MiddlewareOption {
 	}
 }
 
+// WithAllowedTokenTypes sets the token types accepted by the middleware.
+// By default, only access tokens and personal access tokens are accepted.
+func WithAllowedTokenTypes(types ...TokenType) MiddlewareOption {
+	return func(opts *middlewareOptions) {
+		opts.allowedTokenTypes = types
+	}
+}
+
+// WithScopeEntityType sets the entity type whose API key scopes grant access
+// to the routes. Safe methods need read access and all other methods need
+// write access. Sessions of scoped API keys are rejected on routes without
+// a scope entity type.
+func WithScopeEntityType(entityType string) MiddlewareOption {
+	return func(opts *middlewareOptions) {
+		opts.scopeEntityType = entityType
+	}
+}
+
 // WithDefaultMiddlewareOptions resets options to default values.
 func WithDefaultMiddlewareOptions() MiddlewareOption {
 	return func(opts *middlewareOptions) {
 		defaults := defaultMiddlewareOptions()
 		opts.domainCheck = defaults.domainCheck
 		opts.allowUnverifiedUser = defaults.allowUnverifiedUser
+		opts.allowedTokenTypes = defaults.allowedTokenTypes
+		opts.scopeEntityType = defaults.scopeEntityType
 	}
 }
 
@@ -138,11 +163,24 @@ func (a *authnMiddleware) Middleware() func(http.Handler) http.Handler {
 				return
 			}
 
+			if !slices.Contains(opts.allowedTokenTypes, resp.Type) {
+				encodeError(w, apiutil.ErrTokenTypeNotAllowed, http.StatusUnauthorized)
+				return
+			}
+
 			if resp.Type == AccessToken && !opts.allowUnverifiedUser && resp.Role != SuperAdminRole && !resp.Verified {
 				encodeError(w, apiutil.ErrEmailNotVerified, http.StatusUnauthorized)
 				return
 			}
 
+			// Scopes are checked here as well as on authorization, because
+			// listings are filtered by the user's policies and never call
+			// Authorize for the listed entities.
+			if len(resp.Scopes) > 0 && !authz.ScopesAllowAccess(resp.Scopes, opts.scopeEntityType, scopeAccess(r.Method)) {
+				encodeError(w, apiutil.ErrScopeNotAllowed, http.StatusForbidden)
+				return
+			}
+
 			if opts.domainCheck {
 				domain := chi.URLParam(r, "domainID")
 				if domain == "" {
@@ -164,6 +202,15 @@ func (a *authnMiddleware) Middleware() func(http.Handler) http.Handler {
 	}
 }
 
+func scopeAccess(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return authz.ReadScope
+	default:
+		return authz.WriteScope
+	}
+}
+
 func encodeError(w http.ResponseWriter, err error, statusCode int) {
 	if errorVal, ok := err.(errors.Error); ok {
 		w.Header().Set("Content-Type", jsonContentType)
@@ -7,8 +7,10 @@ import (
 	"context"
 
 	grpcAuthV1 "github.com/absmach/supermq/api/grpc/auth/v1"
+	grpcapi "github.com/absmach/supermq/auth/api/grpc"
 	"github.com/absmach/supermq/auth/api/grpc/auth"
 	"github.com/absmach/supermq/domains"
+	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/authz"
 	pkgDomians "github.com/absmach/supermq/pkg/domains"
 	"github.com/absmach/supermq/pkg/errors"
@@ -18,6 +20,8 @@ import (
 	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+var errScopeNotAllowed = errors.New("operation not allowed by API key scopes")
+
 type authorization struct {
 	authSvcClient grpcAuthV1.AuthServiceClient
 	domains       pkgDomians.Authorization
@@ -39,7 +43,7 @@ func NewAuthorization(ctx context.Context, cfg grpcclient.Config, domainsAuthz p
 		return nil, nil, grpcclient.ErrSvcNotServing
 	}
 
-	authSvcClient := auth.NewAuthClient(client.Connection(), cfg.Timeout)
+	authSvcClient := auth.NewAuthClient(client.Connection(), cfg.Timeout, grpcapi.WithMethodTimeouts(cfg.MethodTimeouts))
 	return authorization{
 		authSvcClient: authSvcClient,
 		domains:       domainsAuthz,
@@ -47,6 +51,14 @@ func NewAuthorization(ctx context.Context, cfg grpcclient.Config, domainsAuthz p
 }
 
 func (a authorization) Authorize(ctx context.Context, pr authz.PolicyReq, pat *authz.PATReq) error {
+	// Requests made with a scoped API key are limited to the key's scopes,
+	// even if the user who issued the key is allowed to do more.
+	if session, ok := ctx.Value(authn.SessionKey).(authn.Session); ok && len(session.Scopes) > 0 {
+		if !authz.ScopesAllow(session.Scopes, pr) {
+			return errors.Wrap(errors.ErrAuthorization, errScopeNotAllowed)
+		}
+	}
+
 	if pr.SubjectType == policies.UserType && (pr.ObjectType == policies.GroupType || pr.ObjectType == policies.ClientType || pr.ObjectType == policies.DomainType) {
 		domainID := pr.Domain
 		if domainID == "" {
@@ -0,0 +1,107 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/policies"
+)
+
+const (
+	// ReadScope grants viewing entities of the scope's kind.
+	ReadScope = "read"
+	// WriteScope grants every operation on entities of the scope's kind.
+	WriteScope = "write"
+
+	scopeSeparator = ":"
+)
+
+// ErrInvalidScope indicates a scope which is not in the "<entities>:<access>" format.
+var ErrInvalidScope = errors.New("invalid scope")
+
+// scopeEntities maps policy object types to the entity names used in scopes.
+var scopeEntities = map[string]string{
+	policies.ClientType:  "clients",
+	policies.ChannelType: "channels",
+	policies.GroupType:   "groups",
+	policies.DomainType:  "domains",
+}
+
+// ValidateScopes checks that each of the scopes names a known entity kind and
+// access level, for example "clients:read" or "channels:write".
+func ValidateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		entity, access, ok := strings.Cut(scope, scopeSeparator)
+		if !ok || (access != ReadScope && access != WriteScope) || !knownEntity(entity) {
+			return errors.Wrap(ErrInvalidScope, errors.New(scope))
+		}
+	}
+
+	return nil
+}
+
+// ScopesAllow reports whether the scopes grant the permission requested by
+// the policy request. Write access implies read access.
+func ScopesAllow(scopes []string, pr PolicyReq) bool {
+	entity, access := requestScope(pr)
+
+	return scopesAllow(scopes, entity, access)
+}
+
+// ScopesAllowAccess reports whether the scopes grant the access level, read
+// or write, on the entities of the given policy object type.
+func ScopesAllowAccess(scopes []string, entityType, access string) bool {
+	return scopesAllow(scopes, scopeEntities[entityType], access)
+}
+
+func scopesAllow(scopes []string, entity, access string) bool {
+	if entity == "" {
+		return false
+	}
+	for _, scope := range scopes {
+		e, a, _ := strings.Cut(scope, scopeSeparator)
+		if e == entity && (a == access || a == WriteScope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requestScope returns the entity kind and the access level needed for the
+// policy request. Permissions checked on a parent entity, such as
+// "client_create_permission" on a domain, are scoped to the child kind.
+func requestScope(pr PolicyReq) (string, string) {
+	perm := strings.TrimPrefix(pr.Permission, "subgroup_")
+
+	entity := scopeEntities[pr.ObjectType]
+	for objectType, e := range scopeEntities {
+		if strings.HasPrefix(perm, objectType+"_") {
+			entity = e
+			break
+		}
+	}
+
+	access := WriteScope
+	for _, read := range []string{"read", "view", "membership", "subscribe"} {
+		if strings.Contains(perm, read) {
+			access = ReadScope
+			break
+		}
+	}
+
+	return entity, access
+}
+
+func knownEntity(entity string) bool {
+	for _, e := range scopeEntities {
+		if e == entity {
+			return true
+		}
+	}
+
+	return false
+}
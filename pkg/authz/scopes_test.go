@@ -0,0 +1,179 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authz_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScopes(t *testing.T) {
+	cases := []struct {
+		desc   string
+		scopes []string
+		err    error
+	}{
+		{
+			desc:   "validate empty scopes",
+			scopes: nil,
+			err:    nil,
+		},
+		{
+			desc:   "validate valid scopes",
+			scopes: []string{"clients:read", "channels:write", "groups:read", "domains:write"},
+			err:    nil,
+		},
+		{
+			desc:   "validate scope with unknown entity",
+			scopes: []string{"things:read"},
+			err:    authz.ErrInvalidScope,
+		},
+		{
+			desc:   "validate scope with unknown access",
+			scopes: []string{"clients:delete"},
+			err:    authz.ErrInvalidScope,
+		},
+		{
+			desc:   "validate scope without access",
+			scopes: []string{"clients"},
+			err:    authz.ErrInvalidScope,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := authz.ValidateScopes(tc.scopes)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestScopesAllow(t *testing.T) {
+	cases := []struct {
+		desc    string
+		scopes  []string
+		pr      authz.PolicyReq
+		allowed bool
+	}{
+		{
+			desc:    "view client with read scope",
+			scopes:  []string{"clients:read"},
+			pr:      authz.PolicyReq{ObjectType: policies.ClientType, Permission: "read_permission"},
+			allowed: true,
+		},
+		{
+			desc:    "update client with read scope",
+			scopes:  []string{"clients:read"},
+			pr:      authz.PolicyReq{ObjectType: policies.ClientType, Permission: "update_permission"},
+			allowed: false,
+		},
+		{
+			desc:    "update client with write scope",
+			scopes:  []string{"clients:write"},
+			pr:      authz.PolicyReq{ObjectType: policies.ClientType, Permission: "update_permission"},
+			allowed: true,
+		},
+		{
+			desc:    "view client with write scope",
+			scopes:  []string{"clients:write"},
+			pr:      authz.PolicyReq{ObjectType: policies.ClientType, Permission: "read_permission"},
+			allowed: true,
+		},
+		{
+			desc:    "update channel with client write scope",
+			scopes:  []string{"clients:write"},
+			pr:      authz.PolicyReq{ObjectType: policies.ChannelType, Permission: "update_permission"},
+			allowed: false,
+		},
+		{
+			desc:    "create client in domain with client write scope",
+			scopes:  []string{"clients:write"},
+			pr:      authz.PolicyReq{ObjectType: policies.DomainType, Permission: "client_create_permission"},
+			allowed: true,
+		},
+		{
+			desc:    "create client in domain with domain write scope",
+			scopes:  []string{"domains:write"},
+			pr:      authz.PolicyReq{ObjectType: policies.DomainType, Permission: "client_create_permission"},
+			allowed: false,
+		},
+		{
+			desc:    "create channel in subgroup with channel write scope",
+			scopes:  []string{"channels:write"},
+			pr:      authz.PolicyReq{ObjectType: policies.GroupType, Permission: "subgroup_channel_create_permission"},
+			allowed: true,
+		},
+		{
+			desc:    "manage platform with all scopes",
+			scopes:  []string{"clients:write", "channels:write", "groups:write", "domains:write"},
+			pr:      authz.PolicyReq{ObjectType: policies.PlatformType, Permission: policies.AdminPermission},
+			allowed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			allowed := authz.ScopesAllow(tc.scopes, tc.pr)
+			assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.allowed, allowed))
+		})
+	}
+}
+
+func TestScopesAllowAccess(t *testing.T) {
+	cases := []struct {
+		desc       string
+		scopes     []string
+		entityType string
+		access     string
+		allowed    bool
+	}{
+		{
+			desc:       "read clients with read scope",
+			scopes:     []string{"clients:read"},
+			entityType: policies.ClientType,
+			access:     authz.ReadScope,
+			allowed:    true,
+		},
+		{
+			desc:       "write clients with read scope",
+			scopes:     []string{"clients:read"},
+			entityType: policies.ClientType,
+			access:     authz.WriteScope,
+			allowed:    false,
+		},
+		{
+			desc:       "read clients with write scope",
+			scopes:     []string{"clients:write"},
+			entityType: policies.ClientType,
+			access:     authz.ReadScope,
+			allowed:    true,
+		},
+		{
+			desc:       "read clients with channel scope",
+			scopes:     []string{"channels:write"},
+			entityType: policies.ClientType,
+			access:     authz.ReadScope,
+			allowed:    false,
+		},
+		{
+			desc:       "read without entity type",
+			scopes:     []string{"clients:write", "channels:write", "groups:write", "domains:write"},
+			entityType: "",
+			access:     authz.ReadScope,
+			allowed:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			allowed := authz.ScopesAllowAccess(tc.scopes, tc.entityType, tc.access)
+			assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.allowed, allowed))
+		})
+	}
+}
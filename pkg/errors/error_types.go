@@ -18,8 +18,9 @@ func (e *customError) Embed(err error) error {
 	}
 
 	return &customError{
-		msg: e.msg,
-		err: Wrap(err, e.err),
+		msg:   e.msg,
+		err:   wrap(err, e.err),
+		stack: e.stack,
 	}
 }
 
@@ -216,3 +217,111 @@ func (e *NotFoundError) Embed(err error) error {
 }
 
 func (*NotFoundError) isNestable() {}
+
+type PreconditionError struct {
+	customError
+}
+
+var _ nestableError = (*PreconditionError)(nil)
+
+func NewPreconditionError(message string) NestError {
+	return &PreconditionError{
+		customError: newCustomError(message),
+	}
+}
+
+func NewPreconditionErrorWithErr(message string, err error) NestError {
+	return &PreconditionError{
+		customError: newCustomErrorWithError(message, err),
+	}
+}
+
+func (e *PreconditionError) Embed(err error) error {
+	embedded := e.customError.Embed(err)
+	return &PreconditionError{
+		customError: *embedded.(*customError),
+	}
+}
+
+func (*PreconditionError) isNestable() {}
+
+type ConflictError struct {
+	customError
+}
+
+var _ nestableError = (*ConflictError)(nil)
+
+func NewConflictError(message string) NestError {
+	return &ConflictError{
+		customError: newCustomError(message),
+	}
+}
+
+func NewConflictErrorWithErr(message string, err error) NestError {
+	return &ConflictError{
+		customError: newCustomErrorWithError(message, err),
+	}
+}
+
+func (e *ConflictError) Embed(err error) error {
+	embedded := e.customError.Embed(err)
+	return &ConflictError{
+		customError: *embedded.(*customError),
+	}
+}
+
+func (*ConflictError) isNestable() {}
+
+type PayloadTooLargeError struct {
+	customError
+}
+
+var _ nestableError = (*PayloadTooLargeError)(nil)
+
+func NewPayloadTooLargeError(message string) NestError {
+	return &PayloadTooLargeError{
+		customError: newCustomError(message),
+	}
+}
+
+func NewPayloadTooLargeErrorWithErr(message string, err error) NestError {
+	return &PayloadTooLargeError{
+		customError: newCustomErrorWithError(message, err),
+	}
+}
+
+func (e *PayloadTooLargeError) Embed(err error) error {
+	embedded := e.customError.Embed(err)
+	return &PayloadTooLargeError{
+		customError: *embedded.(*customError),
+	}
+}
+
+func (*PayloadTooLargeError) isNestable() {}
+
+type TimeoutError struct {
+	customError
+}
+
+var _ nestableError = (*TimeoutError)(nil)
+
+func NewTimeoutError(message string) NestError {
+	return &TimeoutError{
+		customError: newCustomError(message),
+	}
+}
+
+func NewTimeoutErrorWithErr(message string, err error) NestError {
+	return &TimeoutError{
+		customError: newCustomErrorWithError(message, err),
+	}
+}
+
+func (e *TimeoutError) Embed(err error) error {
+	embedded := e.customError.Embed(err)
+	return &TimeoutError{
+		customError: *embedded.(*customError),
+	}
+}
+
+func (*TimeoutError) isNestable() {}
@@ -6,8 +6,38 @@ package errors
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
 )
 
+// stackDepth is the maximum number of stack frames captured by an error.
+const stackDepth = 32
+
+// debugExpose controls if wrapped errors are serialized to JSON.
+var debugExpose atomic.Bool
+
+// SetDebugExpose enables or disables serialization of the wrapped errors in
+// MarshalJSON. It is disabled by default.
+//
+// UNSAFE: wrapped errors carry internal details such as database error codes
+// and queries. It is meant for debugging only and must never be enabled in production.
+func SetDebugExpose(expose bool) {
+	debugExpose.Store(expose)
+}
+
+// captureStack controls if errors record the stack they were created at.
+var captureStack atomic.Bool
+
+// CaptureStack enables or disables capturing of the call stack when errors are
+// created with Wrap or Newf. It is disabled by default, in which case creating
+// an error has no additional overhead. The stack is never serialized to JSON and
+// is meant to be logged only.
+func CaptureStack(capture bool) {
+	captureStack.Store(capture)
+}
+
 // Error specifies an API that must be fullfiled by error type.
 type Error interface {
 	// Error implements the error interface.
@@ -27,8 +57,9 @@ var _ Error = (*customError)(nil)
 
 // customError represents a SuperMQ error.
 type customError struct {
-	msg string
-	err error
+	msg   string
+	err   error
+	stack []uintptr
 }
 
 func newCustomError(msg string) customError {
@@ -53,6 +84,35 @@ func New(text string) Error {
 	}
 }
 
+// Newf returns an Error with the message formatted according to the format
+// specifier. If the format contains the %w verb, the corresponding error
+// is wrapped, so it can be found using Contains and errors.Is.
+func Newf(format string, args ...any) Error {
+	err := fmt.Errorf(format, args...)
+
+	return &formattedError{
+		customError: customError{
+			msg:   err.Error(),
+			err:   errors.Unwrap(err),
+			stack: callers(),
+		},
+	}
+}
+
+// formattedError is an Error whose message already
+// contains the text of the wrapped error.
+type formattedError struct {
+	customError
+}
+
+func (fe *formattedError) Error() string {
+	return fe.msg
+}
+
+func (fe *formattedError) Unwrap() error {
+	return fe.err
+}
+
 func (ce *customError) Error() string {
 	if ce == nil {
 		return ""
@@ -71,11 +131,28 @@ func (ce *customError) Err() error {
 	return ce.err
 }
 
+// Stack returns the program counters of the stack the error was created at,
+// or nil if the stack was not captured.
+func (ce *customError) Stack() []uintptr {
+	return ce.stack
+}
+
+func (ce *customError) setStack(stack []uintptr) {
+	ce.stack = stack
+}
+
 func (ce *customError) MarshalJSON() ([]byte, error) {
+	var debug string
+	if debugExpose.Load() && ce.err != nil {
+		debug = ce.err.Error()
+	}
+
 	return json.Marshal(&struct {
-		Msg string `json:"message"`
+		Msg   string `json:"message"`
+		Debug string `json:"unsafe_debug_error,omitempty"`
 	}{
-		Msg: ce.Msg(),
+		Msg:   ce.Msg(),
+		Debug: debug,
 	})
 }
 
@@ -97,6 +174,21 @@ func Contains(e1, e2 error) bool {
 
 // Wrap returns an Error that wrap err with wrapper.
 func Wrap(wrapper, err error) error {
+	if wrapper == nil || err == nil {
+		return wrapper
+	}
+	wrapped := wrap(wrapper, err)
+	if captureStack.Load() {
+		// Keep the stack of the nested error if it was embedded into the result.
+		if se, ok := wrapped.(stackError); ok && se.Stack() == nil {
+			se.setStack(callers())
+		}
+	}
+
+	return wrapped
+}
+
+func wrap(wrapper, err error) error {
 	if wrapper == nil || err == nil {
 		return wrapper
 	}
@@ -127,6 +219,62 @@ func Unwrap(err error) (error, error) {
 	return nil, err
 }
 
+// Stack returns the stack captured by the innermost error in the chain, which
+// is the closest to the place where the error originated. It returns nil if
+// none of the errors in the chain has a captured stack.
+func Stack(err error) []uintptr {
+	var stack []uintptr
+	for err != nil {
+		if se, ok := err.(stackError); ok && se.Stack() != nil {
+			stack = se.Stack()
+		}
+		ce, ok := err.(Error)
+		if !ok {
+			break
+		}
+		err = ce.Err()
+	}
+
+	return stack
+}
+
+// FormatStack returns a human readable representation of the stack,
+// with the function name and its file and line in each frame.
+func FormatStack(stack []uintptr) string {
+	if len(stack) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+
+	return sb.String()
+}
+
+type stackError interface {
+	Stack() []uintptr
+	setStack(stack []uintptr)
+}
+
+// callers returns the stack of the caller of the function that called it,
+// or nil if capturing of the stack is disabled.
+func callers() []uintptr {
+	if !captureStack.Load() {
+		return nil
+	}
+	pcs := make([]uintptr, stackDepth)
+	// Skip runtime.Callers, callers and the error constructor.
+	n := runtime.Callers(3, pcs)
+
+	return pcs[:n]
+}
+
 func cast(err error) Error {
 	if err == nil {
 		return nil
@@ -7,6 +7,7 @@ import (
 	nerrors "errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/absmach/supermq/pkg/errors"
@@ -79,6 +80,152 @@ func TestError(t *testing.T) {
 	}
 }
 
+func TestNewf(t *testing.T) {
+	cases := []struct {
+		desc    string
+		format  string
+		args    []any
+		msg     string
+		wrapped error
+	}{
+		{
+			desc:   "format message",
+			format: "client %s not found",
+			args:   []any{"123"},
+			msg:    "client 123 not found",
+		},
+		{
+			desc:    "format message with wrapped native error",
+			format:  "failed to save client %s: %w",
+			args:    []any{"123", nat},
+			msg:     "failed to save client 123: native error",
+			wrapped: nat,
+		},
+		{
+			desc:    "format message with wrapped error",
+			format:  "failed to save client %s: %w",
+			args:    []any{"123", err0},
+			msg:     "failed to save client 123: 0",
+			wrapped: err0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			err := errors.Newf(c.format, c.args...)
+			assert.Equal(t, c.msg, err.Error())
+			assert.Equal(t, c.msg, err.Msg())
+			assert.Equal(t, c.wrapped, err.Err())
+			if c.wrapped != nil {
+				assert.True(t, errors.Contains(err, c.wrapped))
+				assert.True(t, nerrors.Is(err, c.wrapped))
+			}
+		})
+	}
+}
+
+func TestSetDebugExpose(t *testing.T) {
+	pgErr := errors.New("ERROR: duplicate key value violates unique constraint (SQLSTATE 23505)")
+	err := errors.Wrap(err0, pgErr).(errors.Error)
+
+	cases := []struct {
+		desc   string
+		expose bool
+		bytes  []byte
+	}{
+		{
+			desc:   "marshal error with debug expose disabled",
+			expose: false,
+			bytes:  []byte(`{"message":"0"}`),
+		},
+		{
+			desc:   "marshal error with debug expose enabled",
+			expose: true,
+			bytes:  []byte(`{"message":"0","unsafe_debug_error":"ERROR: duplicate key value violates unique constraint (SQLSTATE 23505)"}`),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			errors.SetDebugExpose(c.expose)
+			defer errors.SetDebugExpose(false)
+			data, derr := err.MarshalJSON()
+			assert.Nil(t, derr)
+			assert.Equal(t, string(c.bytes), string(data))
+		})
+	}
+}
+
+func TestCaptureStack(t *testing.T) {
+	cases := []struct {
+		desc    string
+		capture bool
+		err     func() error
+		stack   bool
+	}{
+		{
+			desc:    "wrap error with stack capture disabled",
+			capture: false,
+			err:     func() error { return errors.Wrap(err1, err0) },
+			stack:   false,
+		},
+		{
+			desc:    "wrap error with stack capture enabled",
+			capture: true,
+			err:     func() error { return errors.Wrap(err1, err0) },
+			stack:   true,
+		},
+		{
+			desc:    "wrap nestable error with stack capture enabled",
+			capture: true,
+			err:     func() error { return errors.Wrap(err1, errors.Wrap(errors.ErrMalformedEntity, nat)) },
+			stack:   true,
+		},
+		{
+			desc:    "format error with stack capture enabled",
+			capture: true,
+			err:     func() error { return errors.Newf("failed to save %s: %w", "client", err0) },
+			stack:   true,
+		},
+		{
+			desc:    "new error with stack capture enabled",
+			capture: true,
+			err:     func() error { return errors.New("0") },
+			stack:   false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			errors.CaptureStack(c.capture)
+			defer errors.CaptureStack(false)
+			err := c.err()
+			stack := errors.Stack(err)
+			assert.Equal(t, c.stack, stack != nil, fmt.Sprintf("%s: expected stack captured %t got %t", c.desc, c.stack, stack != nil))
+			if c.stack {
+				trace := errors.FormatStack(stack)
+				assert.True(t, strings.HasPrefix(trace, "github.com/absmach/supermq/pkg/errors_test.TestCaptureStack"), fmt.Sprintf("%s: expected stack to start at the test, got %s", c.desc, trace))
+			}
+			data, derr := err.(errors.Error).MarshalJSON()
+			assert.Nil(t, derr)
+			assert.NotContains(t, string(data), "errors_test", fmt.Sprintf("%s: expected stack not to be serialized", c.desc))
+		})
+	}
+}
+
+func BenchmarkWrap(b *testing.B) {
+	for _, capture := range []bool{false, true} {
+		b.Run(fmt.Sprintf("capture_stack=%t", capture), func(b *testing.B) {
+			errors.CaptureStack(capture)
+			defer errors.CaptureStack(false)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = errors.Wrap(err1, err0)
+			}
+		})
+	}
+}
+
 func TestContains(t *testing.T) {
 	internalErr1 := errors.New("some internal error 1")
 	internalErr2 := errors.New("some internal error 2")
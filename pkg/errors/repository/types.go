@@ -16,6 +16,12 @@ var (
 	// ErrConflict indicates that entity already exists.
 	ErrConflict = errors.New("entity already exists")
 
+	// ErrPreconditionFailed indicates that entity was modified after the expected time.
+	ErrPreconditionFailed = errors.NewPreconditionError("entity was modified")
+
+	// ErrDeadlineExceeded indicates that the query was canceled because it took too long.
+	ErrDeadlineExceeded = errors.NewTimeoutError("database query deadline exceeded")
+
 	// ErrCreateEntity indicates error in creating entity or entities.
 	ErrCreateEntity = errors.New("failed to create entity in the db")
 
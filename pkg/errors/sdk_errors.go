@@ -11,8 +11,9 @@ import (
 )
 
 type errorRes struct {
-	Err string `json:"error"`
-	Msg string `json:"message"`
+	Err   string `json:"error"`
+	Msg   string `json:"message"`
+	Debug string `json:"unsafe_debug_error"`
 }
 
 // Failed to read response body.
@@ -115,6 +116,10 @@ func CheckError(resp *http.Response, expectedStatusCodes ...int) SDKError {
 	if err := json.Unmarshal(body, &content); err != nil {
 		return NewSDKErrorWithStatus(err, resp.StatusCode)
 	}
+	// The wrapped error is returned only by services with debug expose enabled.
+	if content.Err == "" {
+		content.Err = content.Debug
+	}
 	if content.Err == "" {
 		return NewSDKErrorWithStatus(New(content.Msg), resp.StatusCode)
 	}
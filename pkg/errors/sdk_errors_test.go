@@ -197,6 +197,15 @@ func TestCheckError(t *testing.T) {
 			codes: []int{http.StatusOK},
 			err:   errors.NewSDKErrorWithStatus(errors.New(""), http.StatusNotFound),
 		},
+		{
+			desc: "error with exposed debug error",
+			resp: &http.Response{
+				StatusCode: http.StatusConflict,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"message":"entity already exists","unsafe_debug_error":"duplicate key value"}`))),
+			},
+			codes: []int{http.StatusOK},
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(errors.New("entity already exists"), errors.New("duplicate key value")), http.StatusConflict),
+		},
 	}
 
 	for _, c := range cases {
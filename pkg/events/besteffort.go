@@ -0,0 +1,45 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"log/slog"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+var publishFailures = kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+	Namespace: "supermq",
+	Subsystem: "events",
+	Name:      "publish_failures_total",
+	Help:      "Number of events that failed to be published.",
+}, []string{"stream"})
+
+var _ Publisher = (*bestEffortPublisher)(nil)
+
+type bestEffortPublisher struct {
+	Publisher
+	logger *slog.Logger
+}
+
+// NewBestEffortPublisher returns a publisher that never fails, so publishing
+// events can't fail the operation that emitted them. Failed events are logged
+// and counted in the supermq_events_publish_failures_total metric instead.
+func NewBestEffortPublisher(pub Publisher, logger *slog.Logger) Publisher {
+	return &bestEffortPublisher{
+		Publisher: pub,
+		logger:    logger,
+	}
+}
+
+func (bp *bestEffortPublisher) Publish(ctx context.Context, stream string, event Event) error {
+	if err := bp.Publisher.Publish(ctx, stream, event); err != nil {
+		publishFailures.With("stream", stream).Add(1)
+		bp.logger.Warn("failed to publish event", slog.String("stream", stream), slog.Any("error", err))
+	}
+
+	return nil
+}
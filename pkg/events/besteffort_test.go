@@ -0,0 +1,51 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testEvent struct{}
+
+func (testEvent) Encode() (map[string]any, error) {
+	return map[string]any{"operation": "test"}, nil
+}
+
+func TestBestEffortPublish(t *testing.T) {
+	cases := []struct {
+		desc   string
+		pubErr error
+	}{
+		{
+			desc:   "publish event successfully",
+			pubErr: nil,
+		},
+		{
+			desc:   "publish event with failed publish",
+			pubErr: errors.New("failed to publish"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pub := new(mocks.Publisher)
+			pubCall := pub.On("Publish", mock.Anything, "stream", testEvent{}).Return(tc.pubErr)
+			bp := events.NewBestEffortPublisher(pub, smqlog.NewMock())
+			err := bp.Publish(context.Background(), "stream", testEvent{})
+			assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s", tc.desc, err))
+			pub.AssertCalled(t, "Publish", mock.Anything, "stream", testEvent{})
+			pubCall.Unset()
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	grpcGroupsV1 "github.com/absmach/supermq/api/grpc/groups/v1"
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	grpcUsersV1 "github.com/absmach/supermq/api/grpc/users/v1"
+	grpcapi "github.com/absmach/supermq/auth/api/grpc"
 	tokengrpc "github.com/absmach/supermq/auth/api/grpc/token"
 	channelsgrpc "github.com/absmach/supermq/channels/api/grpc"
 	clientsauth "github.com/absmach/supermq/clients/api/grpc"
@@ -41,7 +42,7 @@ func SetupTokenClient(ctx context.Context, cfg Config) (grpcTokenV1.TokenService
 		return nil, nil, ErrSvcNotServing
 	}
 
-	return tokengrpc.NewTokenClient(client.Connection(), cfg.Timeout), client, nil
+	return tokengrpc.NewTokenClient(client.Connection(), cfg.Timeout, grpcapi.WithMethodTimeouts(cfg.MethodTimeouts)), client, nil
 }
 
 // SetupDomiansClient loads domains gRPC configuration and creates a new domains gRPC client.
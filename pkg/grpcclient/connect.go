@@ -4,16 +4,21 @@
 package grpcclient
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"time"
 
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/server"
+	grpcserver "github.com/absmach/supermq/pkg/server/grpc"
+	"github.com/go-chi/chi/v5/middleware"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 )
 
 type security int
@@ -32,11 +37,14 @@ var (
 )
 
 type Config struct {
-	URL               string        `env:"URL"              envDefault:""`
-	Timeout           time.Duration `env:"TIMEOUT"          envDefault:"1s"`
-	ClientCert        string        `env:"CLIENT_CERT"      envDefault:""`
-	ClientKey         string        `env:"CLIENT_KEY"       envDefault:""`
-	ServerCAFile      string        `env:"SERVER_CA_CERTS"  envDefault:""`
+	URL               string                   `env:"URL"               envDefault:""`
+	Timeout           time.Duration            `env:"TIMEOUT"           envDefault:"1s"`
+	MethodTimeouts    map[string]time.Duration `env:"METHOD_TIMEOUTS"   envDefault:""`
+	ClientCert        string                   `env:"CLIENT_CERT"       envDefault:""`
+	ClientKey         string                   `env:"CLIENT_KEY"        envDefault:""`
+	ServerCAFile      string                   `env:"SERVER_CA_CERTS"   envDefault:""`
+	KeepaliveTime     time.Duration            `env:"KEEPALIVE_TIME"    envDefault:"0s"`
+	KeepaliveTimeout  time.Duration            `env:"KEEPALIVE_TIMEOUT" envDefault:"20s"`
 	BypassHealthCheck bool
 }
 
@@ -103,6 +111,7 @@ func (c *client) Secure() string {
 func connect(cfg Config) (*grpc.ClientConn, security, error) {
 	opts := []grpc.DialOption{
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithUnaryInterceptor(requestIDInterceptor),
 	}
 	secure := withoutTLS
 	tc := insecure.NewCredentials()
@@ -140,6 +149,14 @@ func connect(cfg Config) (*grpc.ClientConn, security, error) {
 		grpc.WithWriteBufferSize(buffSize),
 	)
 
+	// Keepalive pings are disabled unless the ping interval is set.
+	if cfg.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    cfg.KeepaliveTime,
+			Timeout: cfg.KeepaliveTimeout,
+		}))
+	}
+
 	conn, err := grpc.NewClient(cfg.URL, opts...)
 	if err != nil {
 		return nil, secure, errors.Wrap(errGrpcConnect, err)
@@ -147,3 +164,13 @@ func connect(cfg Config) (*grpc.ClientConn, security, error) {
 
 	return conn, secure, nil
 }
+
+// requestIDInterceptor passes the request ID from the context to the called
+// service, so the calls it makes can be correlated with the original request.
+func requestIDInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if id := middleware.GetReqID(ctx); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, grpcserver.RequestIDKey, id)
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
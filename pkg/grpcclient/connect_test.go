@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/absmach/supermq/pkg/errors"
+	"github.com/caarlos0/env/v11"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,6 +51,17 @@ func TestHandler(t *testing.T) {
 			err:    nil,
 			secure: "with mTLS",
 		},
+		{
+			desc: "successful with keepalive",
+			config: Config{
+				URL:              "localhost:8080",
+				Timeout:          time.Second,
+				KeepaliveTime:    30 * time.Second,
+				KeepaliveTimeout: 10 * time.Second,
+			},
+			err:    nil,
+			secure: "without TLS",
+		},
 		{
 			desc: "failed with empty URL",
 			config: Config{
@@ -112,3 +124,17 @@ func TestHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMethodTimeouts(t *testing.T) {
+	var cfg Config
+	err := env.ParseWithOptions(&cfg, env.Options{
+		Prefix: "SMQ_AUTH_GRPC_",
+		Environment: map[string]string{
+			"SMQ_AUTH_GRPC_TIMEOUT":         "1s",
+			"SMQ_AUTH_GRPC_METHOD_TIMEOUTS": "Authorize:500ms,Issue:5s",
+		},
+	})
+	assert.Nil(t, err, fmt.Sprintf("parsing config: unexpected error %s", err))
+	assert.Equal(t, time.Second, cfg.Timeout)
+	assert.Equal(t, map[string]time.Duration{"Authorize": 500 * time.Millisecond, "Issue": 5 * time.Second}, cfg.MethodTimeouts)
+}
@@ -0,0 +1,5 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache contains the Redis implementation of the OAuth2 state store.
+package cache
@@ -0,0 +1,71 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/oauth2"
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "oauth2:state"
+
+// ErrEmptyState indicates that the OAuth2 state is empty.
+var ErrEmptyState = errors.New("state is empty")
+
+var _ oauth2.StateStore = (*stateStore)(nil)
+
+type stateStore struct {
+	client   *redis.Client
+	duration time.Duration
+}
+
+// NewStateStore returns a Redis OAuth2 state store which keeps states
+// for the given duration.
+func NewStateStore(client *redis.Client, duration time.Duration) oauth2.StateStore {
+	return &stateStore{
+		client:   client,
+		duration: duration,
+	}
+}
+
+func (ss *stateStore) Save(ctx context.Context, provider, state string) error {
+	if state == "" {
+		return errors.Wrap(repoerr.ErrCreateEntity, ErrEmptyState)
+	}
+	if err := ss.client.Set(ctx, key(state), provider, ss.duration).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (ss *stateStore) Consume(ctx context.Context, provider, state string) error {
+	if state == "" {
+		return oauth2.ErrInvalidState
+	}
+
+	// GETDEL reads and removes the state atomically, so concurrent
+	// callbacks can't use the same state twice.
+	p, err := ss.client.GetDel(ctx, key(state)).Result()
+	switch {
+	case err == redis.Nil:
+		return oauth2.ErrInvalidState
+	case err != nil:
+		return errors.Wrap(repoerr.ErrNotFound, err)
+	case p != provider:
+		return oauth2.ErrInvalidState
+	default:
+		return nil
+	}
+}
+
+func key(state string) string {
+	return fmt.Sprintf("%s:%s", keyPrefix, state)
+}
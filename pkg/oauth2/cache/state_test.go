@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/oauth2"
+	"github.com/absmach/supermq/pkg/oauth2/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	provider = "google"
+	duration = 10 * time.Minute
+)
+
+func TestSave(t *testing.T) {
+	ss := cache.NewStateStore(redisClient, duration)
+
+	state, err := oauth2.GenerateState()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error generating state: %s", err))
+
+	cases := []struct {
+		desc  string
+		state string
+		err   error
+	}{
+		{
+			desc:  "save valid state",
+			state: state,
+			err:   nil,
+		},
+		{
+			desc:  "save empty state",
+			state: "",
+			err:   cache.ErrEmptyState,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ss.Save(context.Background(), provider, tc.state)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestConsume(t *testing.T) {
+	ss := cache.NewStateStore(redisClient, duration)
+
+	state, err := oauth2.GenerateState()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error generating state: %s", err))
+	err = ss.Save(context.Background(), provider, state)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error saving state: %s", err))
+
+	otherState, err := oauth2.GenerateState()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error generating state: %s", err))
+	err = ss.Save(context.Background(), provider, otherState)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error saving state: %s", err))
+
+	cases := []struct {
+		desc     string
+		provider string
+		state    string
+		err      error
+	}{
+		{
+			desc:     "consume state of a different provider",
+			provider: "github",
+			state:    otherState,
+			err:      oauth2.ErrInvalidState,
+		},
+		{
+			desc:     "consume valid state",
+			provider: provider,
+			state:    state,
+			err:      nil,
+		},
+		{
+			desc:     "consume already consumed state",
+			provider: provider,
+			state:    state,
+			err:      oauth2.ErrInvalidState,
+		},
+		{
+			desc:     "consume unknown state",
+			provider: provider,
+			state:    "unknown",
+			err:      oauth2.ErrInvalidState,
+		},
+		{
+			desc:     "consume empty state",
+			provider: provider,
+			state:    "",
+			err:      oauth2.ErrInvalidState,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ss.Consume(context.Background(), tc.provider, tc.state)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestConsumeExpired(t *testing.T) {
+	ss := cache.NewStateStore(redisClient, 100*time.Millisecond)
+
+	state, err := oauth2.GenerateState()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error generating state: %s", err))
+	err = ss.Save(context.Background(), provider, state)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error saving state: %s", err))
+
+	time.Sleep(200 * time.Millisecond)
+
+	err = ss.Consume(context.Background(), provider, state)
+	assert.True(t, errors.Contains(err, oauth2.ErrInvalidState), fmt.Sprintf("expected %s got %s", oauth2.ErrInvalidState, err))
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package github contains the domain concept definitions needed to support
+// SuperMQ services for GitHub OAuth2 functionality.
+package github
@@ -0,0 +1,202 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgoauth2 "github.com/absmach/supermq/pkg/oauth2"
+	uclient "github.com/absmach/supermq/users"
+	"golang.org/x/oauth2"
+	githuboauth2 "golang.org/x/oauth2/github"
+)
+
+const (
+	providerName = "github"
+	defTimeout   = 1 * time.Minute
+	userPath     = "/user"
+	emailsPath   = "/user/emails"
+)
+
+var scopes = []string{
+	"read:user",
+	"user:email",
+}
+
+// apiURL is the GitHub REST API base URL.
+var apiURL = "https://api.github.com"
+
+var errNoVerifiedEmail = errors.New("github account has no verified primary email")
+
+var httpClient = &http.Client{
+	Timeout: defTimeout,
+}
+
+var _ mgoauth2.Provider = (*config)(nil)
+
+type config struct {
+	config        *oauth2.Config
+	state         string
+	uiRedirectURL string
+	errorURL      string
+}
+
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// NewProvider returns a new GitHub OAuth provider.
+func NewProvider(cfg mgoauth2.Config, uiRedirectURL, errorURL string) mgoauth2.Provider {
+	return &config{
+		config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			Endpoint:     githuboauth2.Endpoint,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       scopes,
+		},
+		state:         cfg.State,
+		uiRedirectURL: uiRedirectURL,
+		errorURL:      errorURL,
+	}
+}
+
+func (cfg *config) Name() string {
+	return providerName
+}
+
+// State returns the configured static state.
+//
+// Deprecated: the state is generated per authorization request.
+func (cfg *config) State() string {
+	return cfg.state
+}
+
+func (cfg *config) AuthURL(state string) string {
+	return cfg.config.AuthCodeURL(state)
+}
+
+func (cfg *config) RedirectURL() string {
+	return cfg.uiRedirectURL
+}
+
+func (cfg *config) ErrorURL() string {
+	return cfg.errorURL
+}
+
+func (cfg *config) IsEnabled() bool {
+	return cfg.config.ClientID != "" && cfg.config.ClientSecret != ""
+}
+
+func (cfg *config) Exchange(ctx context.Context, code string) (oauth2.Token, error) {
+	token, err := cfg.config.Exchange(ctx, code)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	return *token, nil
+}
+
+func (cfg *config) UserInfo(accessToken string) (uclient.User, error) {
+	var gu githubUser
+	if err := get(accessToken, userPath, &gu); err != nil {
+		return uclient.User{}, err
+	}
+
+	// The profile email is empty when the user keeps it private, so the
+	// primary email is read from the emails endpoint instead.
+	if gu.Email == "" {
+		var emails []githubEmail
+		if err := get(accessToken, emailsPath, &emails); err != nil {
+			return uclient.User{}, err
+		}
+		gu.Email = primaryEmail(emails)
+		if gu.Email == "" {
+			return uclient.User{}, errors.Wrap(svcerr.ErrAuthentication, errNoVerifiedEmail)
+		}
+	}
+
+	firstName, lastName := splitName(gu.Name, gu.Login)
+	data, err := json.Marshal(map[string]string{
+		"id":         strconv.FormatInt(gu.ID, 10),
+		"first_name": firstName,
+		"last_name":  lastName,
+		"username":   gu.Login,
+		"email":      gu.Email,
+		"picture":    gu.AvatarURL,
+	})
+	if err != nil {
+		return uclient.User{}, err
+	}
+
+	user, err := mgoauth2.NormalizeUser(data, providerName)
+	if err != nil {
+		return uclient.User{}, errors.Wrap(err, svcerr.ErrAuthentication)
+	}
+
+	return user, nil
+}
+
+func get(accessToken, path string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return svcerr.ErrAuthentication
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func primaryEmail(emails []githubEmail) string {
+	for _, e := range emails {
+		if e.Primary && e.Verified {
+			return e.Email
+		}
+	}
+
+	return ""
+}
+
+// splitName splits the GitHub display name into first and last name. The
+// display name is optional and often a single word, so the login is used for
+// the missing parts.
+func splitName(name, login string) (string, string) {
+	fields := strings.Fields(name)
+	switch len(fields) {
+	case 0:
+		return login, login
+	case 1:
+		return fields[0], login
+	default:
+		return strings.Join(fields[:len(fields)-1], " "), fields[len(fields)-1]
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgoauth2 "github.com/absmach/supermq/pkg/oauth2"
+	uclient "github.com/absmach/supermq/users"
+	"github.com/stretchr/testify/assert"
+)
+
+const validToken = "valid-token"
+
+func newServer(user, emails string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(userPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, user)
+	})
+	mux.HandleFunc(emailsPath, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, emails)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestUserInfo(t *testing.T) {
+	provider := NewProvider(mgoauth2.Config{ClientID: "id", ClientSecret: "secret"}, "", "")
+
+	cases := []struct {
+		desc   string
+		token  string
+		user   string
+		emails string
+		res    uclient.User
+		err    error
+	}{
+		{
+			desc:  "user info with public email",
+			token: validToken,
+			user:  `{"id": 1, "login": "octocat", "name": "Mona Lisa Octocat", "email": "octocat@example.com", "avatar_url": "avatar"}`,
+			res: uclient.User{
+				ID:             "1",
+				FirstName:      "Mona Lisa",
+				LastName:       "Octocat",
+				Email:          "octocat@example.com",
+				ProfilePicture: "avatar",
+				Metadata:       uclient.Metadata{"oauth_provider": providerName},
+			},
+		},
+		{
+			desc:   "user info with private email",
+			token:  validToken,
+			user:   `{"id": 2, "login": "octocat", "name": "Mona", "email": null}`,
+			emails: `[{"email": "other@example.com", "primary": false, "verified": true}, {"email": "primary@example.com", "primary": true, "verified": true}]`,
+			res: uclient.User{
+				ID:        "2",
+				FirstName: "Mona",
+				LastName:  "octocat",
+				Email:     "primary@example.com",
+				Metadata:  uclient.Metadata{"oauth_provider": providerName},
+			},
+		},
+		{
+			desc:   "user info without verified primary email",
+			token:  validToken,
+			user:   `{"id": 3, "login": "octocat", "email": null}`,
+			emails: `[{"email": "primary@example.com", "primary": true, "verified": false}]`,
+			err:    errNoVerifiedEmail,
+		},
+		{
+			desc:  "user info with invalid token",
+			token: "invalid",
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			srv := newServer(tc.user, tc.emails)
+			defer srv.Close()
+			apiURL = srv.URL
+
+			user, err := provider.UserInfo(tc.token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.res, user, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, user))
+			}
+		})
+	}
+}
@@ -63,10 +63,17 @@ func (cfg *config) Name() string {
 	return providerName
 }
 
+// State returns the configured static state.
+//
+// Deprecated: the state is generated per authorization request.
 func (cfg *config) State() string {
 	return cfg.state
 }
 
+func (cfg *config) AuthURL(state string) string {
+	return cfg.config.AuthCodeURL(state)
+}
+
 func (cfg *config) RedirectURL() string {
 	return cfg.uiRedirectURL
 }
@@ -43,6 +43,57 @@ func (_m *Provider) EXPECT() *Provider_Expecter {
 	return &Provider_Expecter{mock: &_m.Mock}
 }
 
+// AuthURL provides a mock function for the type Provider
+func (_mock *Provider) AuthURL(state string) string {
+	ret := _mock.Called(state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthURL")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(state)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Provider_AuthURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthURL'
+type Provider_AuthURL_Call struct {
+	*mock.Call
+}
+
+// AuthURL is a helper method to define mock.On call
+//   - state string
+func (_e *Provider_Expecter) AuthURL(state interface{}) *Provider_AuthURL_Call {
+	return &Provider_AuthURL_Call{Call: _e.mock.On("AuthURL", state)}
+}
+
+func (_c *Provider_AuthURL_Call) Run(run func(state string)) *Provider_AuthURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Provider_AuthURL_Call) Return(s string) *Provider_AuthURL_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Provider_AuthURL_Call) RunAndReturn(run func(state string) string) *Provider_AuthURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ErrorURL provides a mock function for the type Provider
 func (_mock *Provider) ErrorURL() string {
 	ret := _mock.Called()
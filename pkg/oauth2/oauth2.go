@@ -5,17 +5,30 @@ package oauth2
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 
+	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/users"
 	"golang.org/x/oauth2"
 )
 
+const stateSize = 32
+
+// ErrInvalidState indicates that the OAuth2 state is missing, expired or
+// has already been used.
+var ErrInvalidState = errors.New("invalid state")
+
 // Config is the configuration for the OAuth2 provider.
 type Config struct {
 	ClientID     string `env:"CLIENT_ID"       envDefault:""`
 	ClientSecret string `env:"CLIENT_SECRET"   envDefault:""`
-	State        string `env:"STATE"           envDefault:""`
 	RedirectURL  string `env:"REDIRECT_URL"    envDefault:""`
+
+	// Deprecated: State is ignored, a single-use state is generated for
+	// every authorization request. It is kept so that existing
+	// configurations keep loading.
+	State string `env:"STATE" envDefault:""`
 }
 
 // Provider is an interface that provides the OAuth2 flow for a specific provider
@@ -24,9 +37,15 @@ type Provider interface {
 	// Name returns the name of the OAuth2 provider.
 	Name() string
 
-	// State returns the current state for the OAuth2 flow.
+	// State returns the statically configured state for the OAuth2 flow.
+	//
+	// Deprecated: the state is generated per authorization request and
+	// passed to AuthURL; State is no longer used to verify callbacks.
 	State() string
 
+	// AuthURL returns the provider consent page URL carrying the given state.
+	AuthURL(state string) string
+
 	// RedirectURL returns the URL to redirect the user to after completing the OAuth2 flow.
 	RedirectURL() string
 
@@ -42,3 +61,25 @@ type Provider interface {
 	// UserInfo retrieves the user's information using the access token.
 	UserInfo(accessToken string) (users.User, error)
 }
+
+// StateStore keeps the issued OAuth2 states until they are used by the
+// callback, so that every state is valid only once and for a limited time.
+type StateStore interface {
+	// Save stores the state issued for the provider.
+	Save(ctx context.Context, provider, state string) error
+
+	// Consume verifies that the state was issued for the provider and
+	// removes it. It returns ErrInvalidState if the state is missing,
+	// expired or already consumed.
+	Consume(ctx context.Context, provider, state string) error
+}
+
+// GenerateState returns a new random OAuth2 state.
+func GenerateState() (string, error) {
+	b := make([]byte, stateSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2
+
+// Registry holds the configured OAuth2 providers indexed by name.
+type Registry struct {
+	names     []string
+	providers map[string]Provider
+}
+
+// NewRegistry returns a registry of the given providers. If several providers
+// share a name, the last one is kept.
+func NewRegistry(providers ...Provider) Registry {
+	r := Registry{
+		providers: make(map[string]Provider, len(providers)),
+	}
+	for _, p := range providers {
+		if _, ok := r.providers[p.Name()]; !ok {
+			r.names = append(r.names, p.Name())
+		}
+		r.providers[p.Name()] = p
+	}
+
+	return r
+}
+
+// Get returns the provider with the given name.
+func (r Registry) Get(name string) (Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// All returns all registered providers in registration order.
+func (r Registry) All() []Provider {
+	providers := make([]Provider, 0, len(r.names))
+	for _, name := range r.names {
+		providers = append(providers, r.providers[name])
+	}
+
+	return providers
+}
+
+// Enabled returns the enabled providers in registration order.
+func (r Registry) Enabled() []Provider {
+	var providers []Provider
+	for _, name := range r.names {
+		if p := r.providers[name]; p.IsEnabled() {
+			providers = append(providers, p)
+		}
+	}
+
+	return providers
+}
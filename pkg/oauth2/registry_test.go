@@ -0,0 +1,77 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oauth2_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/oauth2"
+	"github.com/absmach/supermq/pkg/oauth2/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newProvider(name string, enabled bool) *mocks.Provider {
+	p := new(mocks.Provider)
+	p.On("Name").Return(name)
+	p.On("IsEnabled").Return(enabled)
+
+	return p
+}
+
+func TestRegistry(t *testing.T) {
+	google := newProvider("google", true)
+	github := newProvider("github", false)
+	gitlab := newProvider("gitlab", true)
+
+	registry := oauth2.NewRegistry(google, github, gitlab)
+
+	cases := []struct {
+		desc     string
+		name     string
+		provider oauth2.Provider
+		ok       bool
+	}{
+		{
+			desc:     "get enabled provider",
+			name:     "google",
+			provider: google,
+			ok:       true,
+		},
+		{
+			desc:     "get disabled provider",
+			name:     "github",
+			provider: github,
+			ok:       true,
+		},
+		{
+			desc: "get unknown provider",
+			name: "unknown",
+			ok:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, ok := registry.Get(tc.name)
+			assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.ok, ok))
+			assert.Equal(t, tc.provider, p, fmt.Sprintf("%s: unexpected provider\n", tc.desc))
+		})
+	}
+
+	assert.Equal(t, []oauth2.Provider{google, github, gitlab}, registry.All(), "expected all providers in registration order")
+	assert.Equal(t, []oauth2.Provider{google, gitlab}, registry.Enabled(), "expected only enabled providers in registration order")
+}
+
+func TestRegistryDuplicateName(t *testing.T) {
+	first := newProvider("google", true)
+	second := newProvider("google", false)
+
+	registry := oauth2.NewRegistry(first, second)
+
+	p, ok := registry.Get("google")
+	assert.True(t, ok, "expected provider to be registered")
+	assert.Equal(t, oauth2.Provider(second), p, "expected the last provider with the same name")
+	assert.Len(t, registry.All(), 1, "expected providers with the same name to be registered once")
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package export contains JSON and CSV encoding and decoding of policy lists
+// used by bulk loading and migration tooling.
+package export
@@ -0,0 +1,153 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/policies"
+)
+
+const (
+	// JSON format encodes policies as a JSON array.
+	JSON = "json"
+	// CSV format encodes policies as CSV with a header row.
+	CSV = "csv"
+)
+
+var (
+	// ErrUnsupportedFormat indicates that the export format is not supported.
+	ErrUnsupportedFormat = errors.New("unsupported export format")
+
+	// ErrInvalidHeader indicates that the CSV header doesn't match the expected columns.
+	ErrInvalidHeader = errors.New("invalid CSV header")
+
+	errInvalidRecord = errors.New("invalid CSV record")
+)
+
+// Columns is the order of the CSV columns. It must stay stable so
+// previously exported files can be decoded.
+var Columns = []string{
+	"domain",
+	"subject_type",
+	"subject_kind",
+	"subject_relation",
+	"subject",
+	"relation",
+	"permission",
+	"object_type",
+	"object_kind",
+	"object_prefix",
+	"object",
+	"token_type",
+}
+
+// Encode writes policies to w in the given format.
+func Encode(w io.Writer, format string, prs []policies.Policy) error {
+	switch format {
+	case JSON:
+		if prs == nil {
+			prs = []policies.Policy{}
+		}
+		return json.NewEncoder(w).Encode(prs)
+	case CSV:
+		return encodeCSV(w, prs)
+	default:
+		return errors.Wrap(ErrUnsupportedFormat, fmt.Errorf("format %q", format))
+	}
+}
+
+// Decode reads policies in the given format from r.
+func Decode(r io.Reader, format string) ([]policies.Policy, error) {
+	switch format {
+	case JSON:
+		var prs []policies.Policy
+		if err := json.NewDecoder(r).Decode(&prs); err != nil {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		return prs, nil
+	case CSV:
+		return decodeCSV(r)
+	default:
+		return nil, errors.Wrap(ErrUnsupportedFormat, fmt.Errorf("format %q", format))
+	}
+}
+
+func encodeCSV(w io.Writer, prs []policies.Policy) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Columns); err != nil {
+		return err
+	}
+	for _, pr := range prs {
+		record := []string{
+			pr.Domain,
+			pr.SubjectType,
+			pr.SubjectKind,
+			pr.SubjectRelation,
+			pr.Subject,
+			pr.Relation,
+			pr.Permission,
+			pr.ObjectType,
+			pr.ObjectKind,
+			pr.ObjectPrefix,
+			pr.Object,
+			strconv.FormatUint(uint64(pr.TokenType), 10),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func decodeCSV(r io.Reader) ([]policies.Policy, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(Columns)
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidHeader, err)
+	}
+	for i, col := range Columns {
+		if header[i] != col {
+			return nil, errors.Wrap(ErrInvalidHeader, fmt.Errorf("expected column %d to be %q, got %q", i+1, col, header[i]))
+		}
+	}
+
+	prs := []policies.Policy{}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return prs, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(errInvalidRecord, err)
+		}
+		tokenType, err := strconv.ParseUint(record[11], 10, 32)
+		if err != nil {
+			return nil, errors.Wrap(errInvalidRecord, err)
+		}
+		prs = append(prs, policies.Policy{
+			Domain:          record[0],
+			SubjectType:     record[1],
+			SubjectKind:     record[2],
+			SubjectRelation: record[3],
+			Subject:         record[4],
+			Relation:        record[5],
+			Permission:      record[6],
+			ObjectType:      record[7],
+			ObjectKind:      record[8],
+			ObjectPrefix:    record[9],
+			Object:          record[10],
+			TokenType:       uint32(tokenType),
+		})
+	}
+}
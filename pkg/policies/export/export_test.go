@@ -0,0 +1,145 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package export_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/policies/export"
+	"github.com/stretchr/testify/assert"
+)
+
+var prs = []policies.Policy{
+	{
+		Domain:      "domain",
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     "user, with comma",
+		Relation:    policies.AdministratorRelation,
+		ObjectType:  policies.ClientType,
+		Object:      "client",
+	},
+	{
+		SubjectType:     policies.RoleType,
+		SubjectRelation: policies.MemberRelation,
+		Subject:         "role \"quoted\"",
+		Permission:      policies.ViewPermission,
+		ObjectType:      policies.GroupType,
+		ObjectKind:      policies.ChannelsKind,
+		ObjectPrefix:    "prefix",
+		Object:          "group",
+		TokenType:       1,
+	},
+}
+
+func TestEncodeDecode(t *testing.T) {
+	cases := []struct {
+		desc   string
+		format string
+		prs    []policies.Policy
+		err    error
+	}{
+		{
+			desc:   "round trip JSON",
+			format: export.JSON,
+			prs:    prs,
+		},
+		{
+			desc:   "round trip CSV",
+			format: export.CSV,
+			prs:    prs,
+		},
+		{
+			desc:   "round trip empty JSON",
+			format: export.JSON,
+			prs:    []policies.Policy{},
+		},
+		{
+			desc:   "round trip empty CSV",
+			format: export.CSV,
+			prs:    []policies.Policy{},
+		},
+		{
+			desc:   "encode unsupported format",
+			format: "xml",
+			prs:    prs,
+			err:    export.ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := export.Encode(&buf, tc.format, tc.prs)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err != nil {
+				return
+			}
+			got, err := export.Decode(&buf, tc.format)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.prs, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.prs, got))
+		})
+	}
+}
+
+func TestCSVHeader(t *testing.T) {
+	var buf bytes.Buffer
+	err := export.Encode(&buf, export.CSV, nil)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	expected := "domain,subject_type,subject_kind,subject_relation,subject,relation,permission,object_type,object_kind,object_prefix,object,token_type\n"
+	assert.Equal(t, expected, buf.String(), fmt.Sprintf("expected header %q got %q", expected, buf.String()))
+}
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		desc   string
+		format string
+		data   string
+		err    error
+	}{
+		{
+			desc:   "decode CSV with reordered header",
+			format: export.CSV,
+			data:   "subject_type,domain,subject_kind,subject_relation,subject,relation,permission,object_type,object_kind,object_prefix,object,token_type\n",
+			err:    export.ErrInvalidHeader,
+		},
+		{
+			desc:   "decode CSV with missing columns",
+			format: export.CSV,
+			data:   "domain,subject_type\n",
+			err:    export.ErrInvalidHeader,
+		},
+		{
+			desc:   "decode empty CSV",
+			format: export.CSV,
+			data:   "",
+			err:    export.ErrInvalidHeader,
+		},
+		{
+			desc:   "decode malformed JSON",
+			format: export.JSON,
+			data:   "{",
+			err:    errors.ErrMalformedEntity,
+		},
+		{
+			desc:   "decode unsupported format",
+			format: "xml",
+			data:   "",
+			err:    export.ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := export.Decode(strings.NewReader(tc.data), tc.format)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
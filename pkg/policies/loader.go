@@ -0,0 +1,118 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	defLoadChunkSize  = 1000
+	defLoadMaxRetries = 3
+	defLoadBackoff    = 500 * time.Millisecond
+)
+
+var errLoadPolicies = errors.New("failed to load policies")
+
+// ProgressFunc is called after each successfully loaded chunk with the
+// number of loaded policies and the total number of policies.
+type ProgressFunc func(done, total int)
+
+type loadOptions struct {
+	chunkSize  int
+	maxRetries uint64
+	backoff    time.Duration
+	progress   ProgressFunc
+}
+
+// LoadOption configures LoadPolicies.
+type LoadOption func(*loadOptions)
+
+// WithChunkSize sets the number of policies added in a single request.
+func WithChunkSize(size int) LoadOption {
+	return func(o *loadOptions) {
+		if size > 0 {
+			o.chunkSize = size
+		}
+	}
+}
+
+// WithRetries sets the number of retries of a failed chunk and the initial
+// backoff between them. The backoff grows exponentially.
+func WithRetries(maxRetries uint64, initial time.Duration) LoadOption {
+	return func(o *loadOptions) {
+		o.maxRetries = maxRetries
+		o.backoff = initial
+	}
+}
+
+// WithProgress sets the callback invoked after every loaded chunk.
+func WithProgress(fn ProgressFunc) LoadOption {
+	return func(o *loadOptions) {
+		o.progress = fn
+	}
+}
+
+// LoadPolicies adds policies in chunks, retrying failed chunks with
+// exponential backoff. Invalid policies are not retried. Policies which
+// already exist are skipped, so loading is safe to retry. Chunks loaded
+// before a failure are not rolled back.
+func LoadPolicies(ctx context.Context, svc Service, prs []Policy, opts ...LoadOption) error {
+	o := loadOptions{
+		chunkSize:  defLoadChunkSize,
+		maxRetries: defLoadMaxRetries,
+		backoff:    defLoadBackoff,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	total := len(prs)
+	for start := 0; start < total; start += o.chunkSize {
+		end := min(start+o.chunkSize, total)
+		chunk := prs[start:end]
+
+		add := func() error {
+			err := svc.AddPolicies(ctx, chunk)
+			if errors.Contains(err, repoerr.ErrConflict) {
+				// Some of the policies exist, e.g. a previous attempt has
+				// been written without reporting success, so the chunk is
+				// added one policy at a time, skipping existing policies.
+				err = addPoliciesIfAbsent(ctx, svc, chunk)
+			}
+			if errors.Contains(err, svcerr.ErrInvalidPolicy) || errors.Contains(err, errors.ErrMalformedEntity) {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+		eb := backoff.NewExponentialBackOff()
+		eb.InitialInterval = o.backoff
+		eb.MaxElapsedTime = 0
+		if err := backoff.Retry(add, backoff.WithContext(backoff.WithMaxRetries(eb, o.maxRetries), ctx)); err != nil {
+			return errors.Wrap(errLoadPolicies, err)
+		}
+
+		if o.progress != nil {
+			o.progress(end, total)
+		}
+	}
+
+	return nil
+}
+
+func addPoliciesIfAbsent(ctx context.Context, svc Service, prs []Policy) error {
+	for _, pr := range prs {
+		if err := svc.AddPolicyIfAbsent(ctx, pr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/policies/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func generatePolicies(n int) []policies.Policy {
+	prs := make([]policies.Policy, n)
+	for i := range prs {
+		prs[i] = policies.Policy{
+			SubjectType: policies.UserType,
+			Subject:     fmt.Sprintf("user-%d", i),
+			Relation:    policies.MemberRelation,
+			ObjectType:  policies.GroupType,
+			Object:      "group",
+		}
+	}
+
+	return prs
+}
+
+func TestLoadPolicies(t *testing.T) {
+	prs := generatePolicies(25)
+
+	svc := new(mocks.Service)
+	var chunks [][]policies.Policy
+	svc.On("AddPolicies", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		chunks = append(chunks, args.Get(1).([]policies.Policy))
+	})
+
+	var progress [][2]int
+	err := policies.LoadPolicies(context.Background(), svc, prs, policies.WithChunkSize(10), policies.WithProgress(func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	}))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	expectedChunks := [][]policies.Policy{prs[0:10], prs[10:20], prs[20:25]}
+	assert.Equal(t, expectedChunks, chunks, "expected chunks to cover all policies, including the partial last chunk")
+	expectedProgress := [][2]int{{10, 25}, {20, 25}, {25, 25}}
+	assert.Equal(t, expectedProgress, progress, fmt.Sprintf("expected progress %v got %v", expectedProgress, progress))
+}
+
+func TestLoadPoliciesRetry(t *testing.T) {
+	prs := generatePolicies(5)
+
+	cases := []struct {
+		desc     string
+		errs     []error
+		attempts int
+		err      error
+	}{
+		{
+			desc:     "load policies after transient failure",
+			errs:     []error{svcerr.ErrCreateEntity, nil},
+			attempts: 2,
+			err:      nil,
+		},
+		{
+			desc:     "load policies with exhausted retries",
+			errs:     []error{svcerr.ErrCreateEntity, svcerr.ErrCreateEntity, svcerr.ErrCreateEntity},
+			attempts: 3,
+			err:      svcerr.ErrCreateEntity,
+		},
+		{
+			desc:     "load invalid policies without retries",
+			errs:     []error{svcerr.ErrInvalidPolicy},
+			attempts: 1,
+			err:      svcerr.ErrInvalidPolicy,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := new(mocks.Service)
+			for _, e := range tc.errs {
+				svc.On("AddPolicies", mock.Anything, prs).Return(e).Once()
+			}
+			err := policies.LoadPolicies(context.Background(), svc, prs, policies.WithRetries(2, time.Millisecond))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			svc.AssertNumberOfCalls(t, "AddPolicies", tc.attempts)
+		})
+	}
+}
+
+func TestLoadPoliciesExisting(t *testing.T) {
+	prs := generatePolicies(3)
+
+	cases := []struct {
+		desc        string
+		addErrs     []error
+		ifAbsentErr error
+		err         error
+	}{
+		{
+			desc:    "load existing policies",
+			addErrs: []error{errors.Wrap(repoerr.ErrConflict, errors.New("relationship already exists"))},
+			err:     nil,
+		},
+		{
+			desc:    "load policies written by a failed attempt",
+			addErrs: []error{svcerr.ErrCreateEntity, errors.Wrap(repoerr.ErrConflict, errors.New("relationship already exists"))},
+			err:     nil,
+		},
+		{
+			desc:        "load existing policies with invalid policy",
+			addErrs:     []error{errors.Wrap(repoerr.ErrConflict, errors.New("relationship already exists"))},
+			ifAbsentErr: svcerr.ErrInvalidPolicy,
+			err:         svcerr.ErrInvalidPolicy,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc := new(mocks.Service)
+			for _, e := range tc.addErrs {
+				svc.On("AddPolicies", mock.Anything, prs).Return(e).Once()
+			}
+			svc.On("AddPolicyIfAbsent", mock.Anything, mock.Anything).Return(tc.ifAbsentErr)
+			err := policies.LoadPolicies(context.Background(), svc, prs, policies.WithRetries(2, time.Millisecond))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			svc.AssertNumberOfCalls(t, "AddPolicies", len(tc.addErrs))
+			if tc.err == nil {
+				for _, pr := range prs {
+					svc.AssertCalled(t, "AddPolicyIfAbsent", mock.Anything, pr)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadPoliciesEmpty(t *testing.T) {
+	svc := new(mocks.Service)
+	err := policies.LoadPolicies(context.Background(), svc, nil)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	svc.AssertNotCalled(t, "AddPolicies", mock.Anything, mock.Anything)
+}
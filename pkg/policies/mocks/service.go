@@ -156,6 +156,63 @@ func (_c *Service_AddPolicy_Call) RunAndReturn(run func(ctx context.Context, pr
 	return _c
 }
 
+// AddPolicyIfAbsent provides a mock function for the type Service
+func (_mock *Service) AddPolicyIfAbsent(ctx context.Context, pr policies.Policy) error {
+	ret := _mock.Called(ctx, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPolicyIfAbsent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, policies.Policy) error); ok {
+		r0 = returnFunc(ctx, pr)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_AddPolicyIfAbsent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPolicyIfAbsent'
+type Service_AddPolicyIfAbsent_Call struct {
+	*mock.Call
+}
+
+// AddPolicyIfAbsent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr policies.Policy
+func (_e *Service_Expecter) AddPolicyIfAbsent(ctx interface{}, pr interface{}) *Service_AddPolicyIfAbsent_Call {
+	return &Service_AddPolicyIfAbsent_Call{Call: _e.mock.On("AddPolicyIfAbsent", ctx, pr)}
+}
+
+func (_c *Service_AddPolicyIfAbsent_Call) Run(run func(ctx context.Context, pr policies.Policy)) *Service_AddPolicyIfAbsent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 policies.Policy
+		if args[1] != nil {
+			arg1 = args[1].(policies.Policy)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_AddPolicyIfAbsent_Call) Return(err error) *Service_AddPolicyIfAbsent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_AddPolicyIfAbsent_Call) RunAndReturn(run func(ctx context.Context, pr policies.Policy) error) *Service_AddPolicyIfAbsent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountObjects provides a mock function for the type Service
 func (_mock *Service) CountObjects(ctx context.Context, pr policies.Policy) (uint64, error) {
 	ret := _mock.Called(ctx, pr)
@@ -222,6 +279,84 @@ func (_c *Service_CountObjects_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// CountObjectsWithLimit provides a mock function for the type Service
+func (_mock *Service) CountObjectsWithLimit(ctx context.Context, pr policies.Policy, limit uint64) (uint64, bool, error) {
+	ret := _mock.Called(ctx, pr, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountObjectsWithLimit")
+	}
+
+	var r0 uint64
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, policies.Policy, uint64) (uint64, bool, error)); ok {
+		return returnFunc(ctx, pr, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, policies.Policy, uint64) uint64); ok {
+		r0 = returnFunc(ctx, pr, limit)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, policies.Policy, uint64) bool); ok {
+		r1 = returnFunc(ctx, pr, limit)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, policies.Policy, uint64) error); ok {
+		r2 = returnFunc(ctx, pr, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// Service_CountObjectsWithLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountObjectsWithLimit'
+type Service_CountObjectsWithLimit_Call struct {
+	*mock.Call
+}
+
+// CountObjectsWithLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr policies.Policy
+//   - limit uint64
+func (_e *Service_Expecter) CountObjectsWithLimit(ctx interface{}, pr interface{}, limit interface{}) *Service_CountObjectsWithLimit_Call {
+	return &Service_CountObjectsWithLimit_Call{Call: _e.mock.On("CountObjectsWithLimit", ctx, pr, limit)}
+}
+
+func (_c *Service_CountObjectsWithLimit_Call) Run(run func(ctx context.Context, pr policies.Policy, limit uint64)) *Service_CountObjectsWithLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 policies.Policy
+		if args[1] != nil {
+			arg1 = args[1].(policies.Policy)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_CountObjectsWithLimit_Call) Return(v uint64, b bool, err error) *Service_CountObjectsWithLimit_Call {
+	_c.Call.Return(v, b, err)
+	return _c
+}
+
+func (_c *Service_CountObjectsWithLimit_Call) RunAndReturn(run func(ctx context.Context, pr policies.Policy, limit uint64) (uint64, bool, error)) *Service_CountObjectsWithLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountSubjects provides a mock function for the type Service
 func (_mock *Service) CountSubjects(ctx context.Context, pr policies.Policy) (uint64, error) {
 	ret := _mock.Called(ctx, pr)
@@ -402,6 +537,57 @@ func (_c *Service_DeletePolicyFilter_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// Health provides a mock function for the type Service
+func (_mock *Service) Health(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Health")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_Health_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Health'
+type Service_Health_Call struct {
+	*mock.Call
+}
+
+// Health is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Service_Expecter) Health(ctx interface{}) *Service_Health_Call {
+	return &Service_Health_Call{Call: _e.mock.On("Health", ctx)}
+}
+
+func (_c *Service_Health_Call) Run(run func(ctx context.Context)) *Service_Health_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_Health_Call) Return(err error) *Service_Health_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_Health_Call) RunAndReturn(run func(ctx context.Context) error) *Service_Health_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListAllObjects provides a mock function for the type Service
 func (_mock *Service) ListAllObjects(ctx context.Context, pr policies.Policy) (policies.PolicyPage, error) {
 	ret := _mock.Called(ctx, pr)
@@ -763,3 +949,192 @@ func (_c *Service_ListSubjects_Call) RunAndReturn(run func(ctx context.Context,
 	_c.Call.Return(run)
 	return _c
 }
+
+// RemoveObjectPolicies provides a mock function for the type Service
+func (_mock *Service) RemoveObjectPolicies(ctx context.Context, objectType string, objectID string) error {
+	ret := _mock.Called(ctx, objectType, objectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveObjectPolicies")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, objectType, objectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_RemoveObjectPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveObjectPolicies'
+type Service_RemoveObjectPolicies_Call struct {
+	*mock.Call
+}
+
+// RemoveObjectPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - objectType string
+//   - objectID string
+func (_e *Service_Expecter) RemoveObjectPolicies(ctx interface{}, objectType interface{}, objectID interface{}) *Service_RemoveObjectPolicies_Call {
+	return &Service_RemoveObjectPolicies_Call{Call: _e.mock.On("RemoveObjectPolicies", ctx, objectType, objectID)}
+}
+
+func (_c *Service_RemoveObjectPolicies_Call) Run(run func(ctx context.Context, objectType string, objectID string)) *Service_RemoveObjectPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RemoveObjectPolicies_Call) Return(err error) *Service_RemoveObjectPolicies_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_RemoveObjectPolicies_Call) RunAndReturn(run func(ctx context.Context, objectType string, objectID string) error) *Service_RemoveObjectPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamObjects provides a mock function for the type Service
+func (_mock *Service) StreamObjects(ctx context.Context, pr policies.Policy, fn func(objects []string) error) error {
+	ret := _mock.Called(ctx, pr, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamObjects")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, policies.Policy, func(objects []string) error) error); ok {
+		r0 = returnFunc(ctx, pr, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_StreamObjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamObjects'
+type Service_StreamObjects_Call struct {
+	*mock.Call
+}
+
+// StreamObjects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr policies.Policy
+//   - fn func(objects []string) error
+func (_e *Service_Expecter) StreamObjects(ctx interface{}, pr interface{}, fn interface{}) *Service_StreamObjects_Call {
+	return &Service_StreamObjects_Call{Call: _e.mock.On("StreamObjects", ctx, pr, fn)}
+}
+
+func (_c *Service_StreamObjects_Call) Run(run func(ctx context.Context, pr policies.Policy, fn func(objects []string) error)) *Service_StreamObjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 policies.Policy
+		if args[1] != nil {
+			arg1 = args[1].(policies.Policy)
+		}
+		var arg2 func(objects []string) error
+		if args[2] != nil {
+			arg2 = args[2].(func(objects []string) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_StreamObjects_Call) Return(err error) *Service_StreamObjects_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_StreamObjects_Call) RunAndReturn(run func(ctx context.Context, pr policies.Policy, fn func(objects []string) error) error) *Service_StreamObjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamSubjects provides a mock function for the type Service
+func (_mock *Service) StreamSubjects(ctx context.Context, pr policies.Policy, fn func(subjects []string) error) error {
+	ret := _mock.Called(ctx, pr, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamSubjects")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, policies.Policy, func(subjects []string) error) error); ok {
+		r0 = returnFunc(ctx, pr, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_StreamSubjects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamSubjects'
+type Service_StreamSubjects_Call struct {
+	*mock.Call
+}
+
+// StreamSubjects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr policies.Policy
+//   - fn func(subjects []string) error
+func (_e *Service_Expecter) StreamSubjects(ctx interface{}, pr interface{}, fn interface{}) *Service_StreamSubjects_Call {
+	return &Service_StreamSubjects_Call{Call: _e.mock.On("StreamSubjects", ctx, pr, fn)}
+}
+
+func (_c *Service_StreamSubjects_Call) Run(run func(ctx context.Context, pr policies.Policy, fn func(subjects []string) error)) *Service_StreamSubjects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 policies.Policy
+		if args[1] != nil {
+			arg1 = args[1].(policies.Policy)
+		}
+		var arg2 func(subjects []string) error
+		if args[2] != nil {
+			arg2 = args[2].(func(subjects []string) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_StreamSubjects_Call) Return(err error) *Service_StreamSubjects_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_StreamSubjects_Call) RunAndReturn(run func(ctx context.Context, pr policies.Policy, fn func(subjects []string) error) error) *Service_StreamSubjects_Call {
+	_c.Call.Return(run)
+	return _c
+}
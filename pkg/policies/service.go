@@ -59,6 +59,26 @@ func (pr Policy) String() string {
 	return string(data)
 }
 
+const (
+	// DefaultLimit is the number of policies listed when no limit is given.
+	DefaultLimit uint64 = 100
+	// MaxLimit is the maximum number of policies listed at once.
+	MaxLimit uint64 = 1000
+)
+
+// Limit returns the limit of a policies listing clamped to [1, MaxLimit].
+// Limit 0 means DefaultLimit.
+func Limit(limit uint64) uint64 {
+	switch {
+	case limit == 0:
+		return DefaultLimit
+	case limit > MaxLimit:
+		return MaxLimit
+	default:
+		return limit
+	}
+}
+
 type PolicyPage struct {
 	Policies      []string
 	NextPageToken string
@@ -74,6 +94,11 @@ type Service interface {
 	// error in case of failures.
 	AddPolicy(ctx context.Context, pr Policy) error
 
+	// AddPolicyIfAbsent creates a policy like AddPolicy, but it succeeds
+	// without changes if the exact policy already exists. It is meant for
+	// provisioning that may be retried.
+	AddPolicyIfAbsent(ctx context.Context, pr Policy) error
+
 	// AddPolicies adds new policies for given subjects. This method is
 	// only allowed to use as an admin.
 	AddPolicies(ctx context.Context, prs []Policy) error
@@ -85,26 +110,48 @@ type Service interface {
 	// only allowed to use as an admin.
 	DeletePolicies(ctx context.Context, prs []Policy) error
 
+	// RemoveObjectPolicies removes all policies referencing the given
+	// entity, both as an object and as a subject.
+	RemoveObjectPolicies(ctx context.Context, objectType, objectID string) error
+
 	// ListObjects lists policies based on the given Policy structure.
+	// The limit is clamped using Limit, so 0 lists DefaultLimit objects.
 	ListObjects(ctx context.Context, pr Policy, nextPageToken string, limit uint64) (PolicyPage, error)
 
 	// ListAllObjects lists all policies based on the given Policy structure.
 	ListAllObjects(ctx context.Context, pr Policy) (PolicyPage, error)
 
+	// StreamObjects lists all objects based on the given Policy structure
+	// page by page, calling fn with each page instead of collecting them.
+	StreamObjects(ctx context.Context, pr Policy, fn func(objects []string) error) error
+
 	// CountObjects count policies based on the given Policy structure.
 	CountObjects(ctx context.Context, pr Policy) (uint64, error)
 
+	// CountObjectsWithLimit counts policies based on the given Policy structure,
+	// stopping once the count exceeds limit. The returned flag is true when the
+	// count has been capped, meaning there are more than limit objects.
+	CountObjectsWithLimit(ctx context.Context, pr Policy, limit uint64) (uint64, bool, error)
+
 	// ListSubjects lists subjects based on the given Policy structure.
+	// The limit is clamped using Limit, so 0 lists DefaultLimit subjects.
 	ListSubjects(ctx context.Context, pr Policy, nextPageToken string, limit uint64) (PolicyPage, error)
 
 	// ListAllSubjects lists all subjects based on the given Policy structure.
 	ListAllSubjects(ctx context.Context, pr Policy) (PolicyPage, error)
 
+	// StreamSubjects lists all subjects based on the given Policy structure
+	// page by page, calling fn with each page instead of collecting them.
+	StreamSubjects(ctx context.Context, pr Policy, fn func(subjects []string) error) error
+
 	// CountSubjects count policies based on the given Policy structure.
 	CountSubjects(ctx context.Context, pr Policy) (uint64, error)
 
 	// ListPermissions lists permission betweeen given subject and object .
 	ListPermissions(ctx context.Context, pr Policy, permissionsFilter []string) (Permissions, error)
+
+	// Health checks whether the policy backend is reachable.
+	Health(ctx context.Context) error
 }
 
 func EncodeDomainUserID(domainID, userID string) string {
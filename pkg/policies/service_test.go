@@ -0,0 +1,48 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package policies_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimit(t *testing.T) {
+	cases := []struct {
+		desc     string
+		limit    uint64
+		expected uint64
+	}{
+		{
+			desc:     "zero limit uses default",
+			limit:    0,
+			expected: policies.DefaultLimit,
+		},
+		{
+			desc:     "limit within bounds",
+			limit:    10,
+			expected: 10,
+		},
+		{
+			desc:     "max limit",
+			limit:    policies.MaxLimit,
+			expected: policies.MaxLimit,
+		},
+		{
+			desc:     "limit above max is clamped",
+			limit:    1e9,
+			expected: policies.MaxLimit,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			limit := policies.Limit(tc.limit)
+			assert.Equal(t, tc.expected, limit, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.expected, limit))
+		})
+	}
+}
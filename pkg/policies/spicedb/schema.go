@@ -0,0 +1,172 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package spicedb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+)
+
+var (
+	errReadSchema  = errors.New("failed to read spicedb schema")
+	errWriteSchema = errors.New("failed to write spicedb schema")
+
+	// ErrDestructiveSchema indicates that the new schema removes definitions,
+	// relations or permissions present in the current one.
+	ErrDestructiveSchema = errors.New("schema change removes existing definitions, relations or permissions")
+
+	// ErrSchemaDowngrade indicates that the schema has been applied before
+	// and has since been replaced by a newer schema.
+	ErrSchemaDowngrade = errors.New("schema has been replaced by a newer schema")
+
+	errSchemaVersion = errors.New("failed to access spicedb schema versions")
+)
+
+// SchemaVersion identifies an applied schema.
+type SchemaVersion struct {
+	// Hash contains the SHA-256 hash of the schema.
+	Hash string
+	// Token contains the consistency token of the schema write. It is
+	// empty if the schema was already up to date.
+	Token string
+	// AppliedAt contains the time the schema was last applied.
+	AppliedAt time.Time
+}
+
+// SchemaVersionRepository persists the versions of applied schemas.
+type SchemaVersionRepository interface {
+	// Save stores the version of the applied schema. Saving a version
+	// which has been applied before updates its token and time.
+	Save(ctx context.Context, version SchemaVersion) error
+
+	// Retrieve retrieves the version with the given schema hash.
+	Retrieve(ctx context.Context, hash string) (SchemaVersion, error)
+
+	// RetrieveLatest retrieves the most recently applied version.
+	RetrieveLatest(ctx context.Context) (SchemaVersion, error)
+}
+
+// ApplySchema writes the schema to SpiceDB and records its version. The
+// current schema is read first: if it is the same, nothing is written, and
+// if the new schema removes any of its definitions, relations or
+// permissions, the schema is applied only if force is set. A schema which
+// has been replaced by a newer one is applied again only if force is set,
+// so an outdated instance doesn't downgrade the schema.
+func ApplySchema(ctx context.Context, client v1.SchemaServiceClient, versions SchemaVersionRepository, schema string, force bool) (SchemaVersion, error) {
+	sum := sha256.Sum256([]byte(schema))
+	version := SchemaVersion{Hash: hex.EncodeToString(sum[:])}
+
+	latest, err := versions.RetrieveLatest(ctx)
+	if err != nil && !errors.Contains(err, repoerr.ErrNotFound) {
+		return SchemaVersion{}, errors.Wrap(errSchemaVersion, err)
+	}
+	if latest.Hash != "" && latest.Hash != version.Hash && !force {
+		previous, err := versions.Retrieve(ctx, version.Hash)
+		switch {
+		case err == nil:
+			return SchemaVersion{}, errors.Wrap(ErrSchemaDowngrade, fmt.Errorf("schema %s applied at %s was replaced by %s applied at %s", previous.Hash, previous.AppliedAt.Format(time.RFC3339), latest.Hash, latest.AppliedAt.Format(time.RFC3339)))
+		case !errors.Contains(err, repoerr.ErrNotFound):
+			return SchemaVersion{}, errors.Wrap(errSchemaVersion, err)
+		}
+	}
+
+	current := ""
+	res, err := client.ReadSchema(ctx, &v1.ReadSchemaRequest{})
+	switch err := handleSpicedbError(err); {
+	case err == nil:
+		current = res.GetSchemaText()
+	case errors.Contains(err, repoerr.ErrNotFound):
+		// No schema has been written yet.
+	default:
+		return SchemaVersion{}, errors.Wrap(errReadSchema, err)
+	}
+
+	if normalizeSchema(current) == normalizeSchema(schema) {
+		if latest.Hash == version.Hash {
+			version.AppliedAt = latest.AppliedAt
+			return version, nil
+		}
+		return saveVersion(ctx, versions, version)
+	}
+	if removed := removedElements(schemaElements(current), schemaElements(schema)); len(removed) > 0 && !force {
+		return SchemaVersion{}, errors.Wrap(ErrDestructiveSchema, fmt.Errorf("removed %s", strings.Join(removed, ", ")))
+	}
+
+	wres, err := client.WriteSchema(ctx, &v1.WriteSchemaRequest{Schema: schema})
+	if err != nil {
+		return SchemaVersion{}, errors.Wrap(errWriteSchema, handleSpicedbError(err))
+	}
+	version.Token = wres.GetWrittenAt().GetToken()
+
+	return saveVersion(ctx, versions, version)
+}
+
+func saveVersion(ctx context.Context, versions SchemaVersionRepository, version SchemaVersion) (SchemaVersion, error) {
+	version.AppliedAt = time.Now().UTC()
+	if err := versions.Save(ctx, version); err != nil {
+		return SchemaVersion{}, errors.Wrap(errSchemaVersion, err)
+	}
+
+	return version, nil
+}
+
+// schemaElements returns the sorted definitions, relations and permissions
+// of the schema in the "definition#name" format.
+func schemaElements(schema string) []string {
+	var elems []string
+	definition := ""
+	for line := range strings.Lines(schema) {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "definition":
+			definition = strings.TrimSuffix(strings.TrimSuffix(fields[1], "{}"), "{")
+			elems = append(elems, definition)
+		case "relation", "permission":
+			name, _, _ := strings.Cut(fields[1], ":")
+			elems = append(elems, definition+"#"+name)
+		}
+	}
+	slices.Sort(elems)
+
+	return elems
+}
+
+func removedElements(current, updated []string) []string {
+	var removed []string
+	for _, elem := range current {
+		if _, found := slices.BinarySearch(updated, elem); !found {
+			removed = append(removed, elem)
+		}
+	}
+
+	return removed
+}
+
+// normalizeSchema removes comments and whitespace differences, since the
+// schema read from SpiceDB is formatted differently than the written one.
+func normalizeSchema(schema string) string {
+	var lines []string
+	for line := range strings.Lines(schema) {
+		line, _, _ = strings.Cut(line, "//")
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	slices.Sort(lines)
+
+	return strings.Join(lines, "\n")
+}
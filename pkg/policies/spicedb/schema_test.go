@@ -0,0 +1,203 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package spicedb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const schema = `definition user {}
+
+definition client {
+	relation domain: domain // owning domain
+	relation read: user
+
+	permission read_permission = read + domain->read_permission
+}
+`
+
+// schemaClient stores the schema in memory, and, like SpiceDB, returns
+// the NotFound code when no schema has been written.
+type schemaClient struct {
+	v1.SchemaServiceClient
+	schema string
+	writes int
+}
+
+func (sc *schemaClient) ReadSchema(_ context.Context, _ *v1.ReadSchemaRequest, _ ...grpc.CallOption) (*v1.ReadSchemaResponse, error) {
+	if sc.schema == "" {
+		return nil, status.Error(codes.NotFound, "no schema has been defined")
+	}
+
+	return &v1.ReadSchemaResponse{SchemaText: sc.schema}, nil
+}
+
+func (sc *schemaClient) WriteSchema(_ context.Context, req *v1.WriteSchemaRequest, _ ...grpc.CallOption) (*v1.WriteSchemaResponse, error) {
+	sc.writes++
+	sc.schema = req.GetSchema()
+
+	return &v1.WriteSchemaResponse{WrittenAt: &v1.ZedToken{Token: fmt.Sprintf("token-%d", sc.writes)}}, nil
+}
+
+// schemaVersions stores the schema versions in memory.
+type schemaVersions struct {
+	versions []SchemaVersion
+}
+
+func (sv *schemaVersions) Save(_ context.Context, version SchemaVersion) error {
+	sv.versions = append(sv.versions, version)
+
+	return nil
+}
+
+func (sv *schemaVersions) Retrieve(_ context.Context, hash string) (SchemaVersion, error) {
+	for _, version := range sv.versions {
+		if version.Hash == hash {
+			return version, nil
+		}
+	}
+
+	return SchemaVersion{}, repoerr.ErrNotFound
+}
+
+func (sv *schemaVersions) RetrieveLatest(context.Context) (SchemaVersion, error) {
+	if len(sv.versions) == 0 {
+		return SchemaVersion{}, repoerr.ErrNotFound
+	}
+
+	return sv.versions[len(sv.versions)-1], nil
+}
+
+func TestApplySchema(t *testing.T) {
+	cases := []struct {
+		desc    string
+		current string
+		schema  string
+		force   bool
+		written bool
+		err     error
+	}{
+		{
+			desc:    "apply schema without current schema",
+			schema:  schema,
+			written: true,
+		},
+		{
+			desc:    "apply the same schema",
+			current: schema,
+			schema:  schema,
+		},
+		{
+			desc:    "apply the same schema formatted differently",
+			current: "definition user {}\ndefinition client {\n    relation domain: domain\n    relation read: user\n    permission read_permission = read + domain->read_permission\n}\n",
+			schema:  schema,
+		},
+		{
+			desc:    "apply schema adding a permission",
+			current: schema,
+			schema:  schema + "\ndefinition channel {\n\trelation read: user\n}\n",
+			written: true,
+		},
+		{
+			desc:    "apply schema removing a permission",
+			current: schema + "\ndefinition channel {\n\trelation read: user\n}\n",
+			schema:  schema,
+			err:     ErrDestructiveSchema,
+		},
+		{
+			desc:    "force schema removing a permission",
+			current: schema + "\ndefinition channel {\n\trelation read: user\n}\n",
+			schema:  schema,
+			force:   true,
+			written: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sc := &schemaClient{schema: tc.current}
+			sv := &schemaVersions{}
+			version, err := ApplySchema(context.Background(), sc, sv, tc.schema, tc.force)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.written, sc.writes == 1, fmt.Sprintf("%s: expected written %t got %d writes", tc.desc, tc.written, sc.writes))
+			if err == nil {
+				assert.NotEmpty(t, version.Hash, fmt.Sprintf("%s: expected schema hash", tc.desc))
+				assert.Equal(t, tc.written, version.Token != "", fmt.Sprintf("%s: expected token %t got %s", tc.desc, tc.written, version.Token))
+				latest, err := sv.RetrieveLatest(context.Background())
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error retrieving schema version: %s", tc.desc, err))
+				assert.Equal(t, version, latest, fmt.Sprintf("%s: expected recorded version %v got %v", tc.desc, version, latest))
+			}
+		})
+	}
+}
+
+func TestApplySchemaDowngrade(t *testing.T) {
+	newer := schema + "\ndefinition channel {\n\trelation read: user\n}\n"
+	hash := func(schema string) string {
+		sc := &schemaClient{schema: schema}
+		version, err := ApplySchema(context.Background(), sc, &schemaVersions{}, schema, false)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error hashing schema: %s", err))
+		return version.Hash
+	}
+	applied := time.Now().UTC().Add(-time.Hour)
+	versions := []SchemaVersion{
+		{Hash: hash(schema), Token: "token-1", AppliedAt: applied},
+		{Hash: hash(newer), Token: "token-2", AppliedAt: applied.Add(time.Minute)},
+	}
+
+	cases := []struct {
+		desc    string
+		current string
+		schema  string
+		force   bool
+		written bool
+		err     error
+	}{
+		{
+			desc:    "apply the latest schema",
+			current: newer,
+			schema:  newer,
+		},
+		{
+			desc:    "apply a replaced schema",
+			current: newer,
+			schema:  schema,
+			err:     ErrSchemaDowngrade,
+		},
+		{
+			desc:    "force a replaced schema",
+			current: newer,
+			schema:  schema,
+			force:   true,
+			written: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sc := &schemaClient{schema: tc.current}
+			sv := &schemaVersions{versions: slices.Clone(versions)}
+			_, err := ApplySchema(context.Background(), sc, sv, tc.schema, tc.force)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.written, sc.writes == 1, fmt.Sprintf("%s: expected written %t got %d writes", tc.desc, tc.written, sc.writes))
+			if err == nil {
+				latest, err := sv.RetrieveLatest(context.Background())
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error retrieving schema version: %s", tc.desc, err))
+				assert.Equal(t, hash(tc.schema), latest.Hash, fmt.Sprintf("%s: expected latest version %s got %s", tc.desc, hash(tc.schema), latest.Hash))
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ var (
 	errNoPolicies       = errors.New("no policies provided")
 	errInternal         = errors.New("spicedb internal error")
 	errPlatform         = errors.New("invalid platform id")
+	errUnavailable      = errors.New("spicedb is unavailable")
 )
 
 var (
@@ -106,6 +107,84 @@ func (ps *policyService) AddPolicy(ctx context.Context, pr policies.Policy) erro
 	return nil
 }
 
+func (ps *policyService) AddPolicyIfAbsent(ctx context.Context, pr policies.Policy) error {
+	if err := ps.policyValidation(pr); err != nil {
+		return errors.Wrap(svcerr.ErrInvalidPolicy, err)
+	}
+	rel := &v1.Relationship{
+		Resource: &v1.ObjectReference{ObjectType: pr.ObjectType, ObjectId: pr.Object},
+		Relation: pr.Relation,
+		Subject:  &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: pr.SubjectType, ObjectId: pr.Subject}, OptionalRelation: pr.SubjectRelation},
+	}
+
+	// Preconditions reject relations which already exist, so the existing
+	// relation has to be detected before they are evaluated.
+	exists, err := ps.relationshipExists(ctx, rel)
+	if err != nil {
+		return errors.Wrap(errAddPolicies, err)
+	}
+	if exists {
+		return nil
+	}
+
+	precond, err := ps.addPolicyPreCondition(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	// TOUCH keeps the write idempotent if a concurrent retry created the
+	// relation in the meantime.
+	updates := []*v1.RelationshipUpdate{
+		{
+			Operation:    v1.RelationshipUpdate_OPERATION_TOUCH,
+			Relationship: rel,
+		},
+	}
+	if _, err := ps.permissionClient.WriteRelationships(ctx, &v1.WriteRelationshipsRequest{Updates: updates, OptionalPreconditions: precond}); err != nil {
+		return errors.Wrap(errAddPolicies, handleSpicedbError(err))
+	}
+
+	return nil
+}
+
+func (ps *policyService) relationshipExists(ctx context.Context, rel *v1.Relationship) (bool, error) {
+	filter := &v1.RelationshipFilter{
+		ResourceType:       rel.Resource.ObjectType,
+		OptionalResourceId: rel.Resource.ObjectId,
+		OptionalRelation:   rel.Relation,
+		OptionalSubjectFilter: &v1.SubjectFilter{
+			SubjectType:       rel.Subject.Object.ObjectType,
+			OptionalSubjectId: rel.Subject.Object.ObjectId,
+		},
+	}
+	if rel.Subject.OptionalRelation != "" {
+		filter.OptionalSubjectFilter.OptionalRelation = &v1.SubjectFilter_RelationFilter{Relation: rel.Subject.OptionalRelation}
+	}
+
+	stream, err := ps.permissionClient.ReadRelationships(ctx, &v1.ReadRelationshipsRequest{
+		Consistency: &v1.Consistency{
+			Requirement: &v1.Consistency_FullyConsistent{
+				FullyConsistent: true,
+			},
+		},
+		RelationshipFilter: filter,
+		OptionalLimit:      1,
+	})
+	if err != nil {
+		return false, handleSpicedbError(err)
+	}
+
+	_, err = stream.Recv()
+	switch {
+	case err == io.EOF:
+		return false, nil
+	case err != nil:
+		return false, handleSpicedbError(err)
+	default:
+		return true, nil
+	}
+}
+
 func (ps *policyService) AddPolicies(ctx context.Context, prs []policies.Policy) error {
 	updates := []*v1.RelationshipUpdate{}
 	var preconds []*v1.Precondition
@@ -138,6 +217,31 @@ func (ps *policyService) AddPolicies(ctx context.Context, prs []policies.Policy)
 	return nil
 }
 
+func (ps *policyService) RemoveObjectPolicies(ctx context.Context, objectType, objectID string) error {
+	if objectType == "" || objectID == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, errNoPolicies)
+	}
+	filters := []*v1.RelationshipFilter{
+		{
+			ResourceType:       objectType,
+			OptionalResourceId: objectID,
+		},
+		{
+			OptionalSubjectFilter: &v1.SubjectFilter{
+				SubjectType:       objectType,
+				OptionalSubjectId: objectID,
+			},
+		},
+	}
+	for _, filter := range filters {
+		if _, err := ps.permissionClient.DeleteRelationships(ctx, &v1.DeleteRelationshipsRequest{RelationshipFilter: filter}); err != nil {
+			return errors.Wrap(errRemovePolicies, handleSpicedbError(err))
+		}
+	}
+
+	return nil
+}
+
 func (ps *policyService) DeletePolicyFilter(ctx context.Context, pr policies.Policy) error {
 	req := &v1.DeleteRelationshipsRequest{
 		RelationshipFilter: &v1.RelationshipFilter{
@@ -199,10 +303,7 @@ func (ps *policyService) DeletePolicies(ctx context.Context, prs []policies.Poli
 }
 
 func (ps *policyService) ListObjects(ctx context.Context, pr policies.Policy, nextPageToken string, limit uint64) (policies.PolicyPage, error) {
-	if limit <= 0 {
-		limit = 100
-	}
-	res, npt, err := ps.retrieveObjects(ctx, pr, nextPageToken, limit)
+	res, npt, err := ps.retrieveObjects(ctx, pr, nextPageToken, policies.Limit(limit))
 	if err != nil {
 		return policies.PolicyPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
@@ -228,29 +329,40 @@ func (ps *policyService) ListAllObjects(ctx context.Context, pr policies.Policy)
 	return page, nil
 }
 
-func (ps *policyService) CountObjects(ctx context.Context, pr policies.Policy) (uint64, error) {
-	var count uint64
+func (ps *policyService) StreamObjects(ctx context.Context, pr policies.Policy, fn func(objects []string) error) error {
 	nextPageToken := ""
 	for {
-		relationTuples, npt, err := ps.retrieveObjects(ctx, pr, nextPageToken, defRetrieveAllLimit)
+		res, npt, err := ps.retrieveObjects(ctx, pr, nextPageToken, defRetrieveAllLimit)
 		if err != nil {
-			return count, err
+			return errors.Wrap(svcerr.ErrViewEntity, err)
 		}
-		count = count + uint64(len(relationTuples))
-		if npt == "" {
-			break
+		if len(res) > 0 {
+			objects := make([]string, 0, len(res))
+			for _, tuple := range res {
+				objects = append(objects, tuple.Object)
+			}
+			if err := fn(objects); err != nil {
+				return err
+			}
+		}
+		if npt == "" || len(res) < defRetrieveAllLimit {
+			return nil
 		}
 		nextPageToken = npt
 	}
+}
 
-	return count, nil
+func (ps *policyService) CountObjects(ctx context.Context, pr policies.Policy) (uint64, error) {
+	count, _, err := ps.countObjects(ctx, pr, 0)
+	return count, err
+}
+
+func (ps *policyService) CountObjectsWithLimit(ctx context.Context, pr policies.Policy, limit uint64) (uint64, bool, error) {
+	return ps.countObjects(ctx, pr, limit)
 }
 
 func (ps *policyService) ListSubjects(ctx context.Context, pr policies.Policy, nextPageToken string, limit uint64) (policies.PolicyPage, error) {
-	if limit <= 0 {
-		limit = 100
-	}
-	res, npt, err := ps.retrieveSubjects(ctx, pr, nextPageToken, limit)
+	res, npt, err := ps.retrieveSubjects(ctx, pr, nextPageToken, policies.Limit(limit))
 	if err != nil {
 		return policies.PolicyPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
@@ -276,6 +388,29 @@ func (ps *policyService) ListAllSubjects(ctx context.Context, pr policies.Policy
 	return page, nil
 }
 
+func (ps *policyService) StreamSubjects(ctx context.Context, pr policies.Policy, fn func(subjects []string) error) error {
+	nextPageToken := ""
+	for {
+		res, npt, err := ps.retrieveSubjects(ctx, pr, nextPageToken, defRetrieveAllLimit)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if len(res) > 0 {
+			subjects := make([]string, 0, len(res))
+			for _, tuple := range res {
+				subjects = append(subjects, tuple.Subject)
+			}
+			if err := fn(subjects); err != nil {
+				return err
+			}
+		}
+		if npt == "" || len(res) < defRetrieveAllLimit {
+			return nil
+		}
+		nextPageToken = npt
+	}
+}
+
 func (ps *policyService) CountSubjects(ctx context.Context, pr policies.Policy) (uint64, error) {
 	var count uint64
 	nextPageToken := ""
@@ -294,6 +429,14 @@ func (ps *policyService) CountSubjects(ctx context.Context, pr policies.Policy)
 	return count, nil
 }
 
+func (ps *policyService) Health(ctx context.Context) error {
+	if _, err := ps.client.SchemaServiceClient.ReadSchema(ctx, &v1.ReadSchemaRequest{}); err != nil {
+		return errors.Wrap(errUnavailable, handleSpicedbError(err))
+	}
+
+	return nil
+}
+
 func (ps *policyService) ListPermissions(ctx context.Context, pr policies.Policy, permissionsFilter []string) (policies.Permissions, error) {
 	if len(permissionsFilter) == 0 {
 		switch pr.ObjectType {
@@ -646,6 +789,57 @@ func (ps *policyService) retrieveObjects(ctx context.Context, pr policies.Policy
 	}
 }
 
+// countObjects tallies the resources returned by LookupResources page by page
+// without materializing them. If limit is greater than zero, counting stops
+// as soon as more than limit objects are found, and the returned flag reports
+// that the count has been capped at limit.
+func (ps *policyService) countObjects(ctx context.Context, pr policies.Policy, limit uint64) (uint64, bool, error) {
+	// Cancel the lookup stream when returning before it's fully consumed.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var count uint64
+	var cursor *v1.Cursor
+	for {
+		resourceReq := &v1.LookupResourcesRequest{
+			Consistency: &v1.Consistency{
+				Requirement: &v1.Consistency_FullyConsistent{
+					FullyConsistent: true,
+				},
+			},
+			ResourceObjectType: pr.ObjectType,
+			Permission:         pr.Permission,
+			Subject:            &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: pr.SubjectType, ObjectId: pr.Subject}, OptionalRelation: pr.SubjectRelation},
+			OptionalLimit:      defRetrieveAllLimit,
+			OptionalCursor:     cursor,
+		}
+		stream, err := ps.permissionClient.LookupResources(ctx, resourceReq)
+		if err != nil {
+			return count, false, errors.Wrap(errRetrievePolicies, handleSpicedbError(err))
+		}
+		var pageCount uint64
+		cursor = nil
+		for {
+			resp, err := stream.Recv()
+			if errors.Contains(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return count, false, errors.Wrap(errRetrievePolicies, handleSpicedbError(err))
+			}
+			pageCount++
+			count++
+			cursor = resp.GetAfterResultCursor()
+			if limit > 0 && count > limit {
+				return limit, true, nil
+			}
+		}
+		if pageCount < defRetrieveAllLimit || cursor == nil {
+			return count, false, nil
+		}
+	}
+}
+
 func (ps *policyService) retrieveAllObjects(ctx context.Context, pr policies.Policy) ([]policies.Policy, error) {
 	resourceReq := &v1.LookupResourcesRequest{
 		Consistency: &v1.Consistency{
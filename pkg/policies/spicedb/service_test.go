@@ -0,0 +1,243 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package spicedb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/policies"
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var policy = policies.Policy{
+	SubjectType: policies.GroupType,
+	Subject:     "channel",
+	Relation:    policies.GroupRelation,
+	ObjectType:  policies.ClientType,
+	Object:      "client",
+}
+
+// permissionsClient is an in-memory relationship store which, like SpiceDB,
+// rejects creating a relationship that already exists.
+type permissionsClient struct {
+	v1.PermissionsServiceClient
+	relationships map[string]bool
+	writes        int
+}
+
+func newPermissionsClient() *permissionsClient {
+	return &permissionsClient{relationships: make(map[string]bool)}
+}
+
+func (pc *permissionsClient) WriteRelationships(_ context.Context, req *v1.WriteRelationshipsRequest, _ ...grpc.CallOption) (*v1.WriteRelationshipsResponse, error) {
+	pc.writes++
+	for _, u := range req.GetUpdates() {
+		rel := u.GetRelationship()
+		key := relationshipKey(rel.GetResource().GetObjectType(), rel.GetResource().GetObjectId(), rel.GetRelation(), rel.GetSubject().GetObject().GetObjectType(), rel.GetSubject().GetObject().GetObjectId())
+		if u.GetOperation() == v1.RelationshipUpdate_OPERATION_CREATE && pc.relationships[key] {
+			return nil, status.Error(codes.AlreadyExists, "relationship already exists")
+		}
+		pc.relationships[key] = true
+	}
+
+	return &v1.WriteRelationshipsResponse{}, nil
+}
+
+func (pc *permissionsClient) ReadRelationships(_ context.Context, req *v1.ReadRelationshipsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[v1.ReadRelationshipsResponse], error) {
+	f := req.GetRelationshipFilter()
+	key := relationshipKey(f.GetResourceType(), f.GetOptionalResourceId(), f.GetOptionalRelation(), f.GetOptionalSubjectFilter().GetSubjectType(), f.GetOptionalSubjectFilter().GetOptionalSubjectId())
+
+	stream := &relationshipsStream{}
+	if pc.relationships[key] {
+		stream.res = []*v1.ReadRelationshipsResponse{{}}
+	}
+
+	return stream, nil
+}
+
+type relationshipsStream struct {
+	grpc.ClientStream
+	res []*v1.ReadRelationshipsResponse
+}
+
+func (rs *relationshipsStream) Recv() (*v1.ReadRelationshipsResponse, error) {
+	if len(rs.res) == 0 {
+		return nil, io.EOF
+	}
+	res := rs.res[0]
+	rs.res = rs.res[1:]
+
+	return res, nil
+}
+
+// lookupClient returns the objects in pages of at most OptionalLimit
+// objects, using the object index as the cursor.
+type lookupClient struct {
+	v1.PermissionsServiceClient
+	objects []string
+	ctxs    []context.Context
+}
+
+func (lc *lookupClient) LookupResources(ctx context.Context, req *v1.LookupResourcesRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[v1.LookupResourcesResponse], error) {
+	lc.ctxs = append(lc.ctxs, ctx)
+	start := 0
+	if req.GetOptionalCursor() != nil {
+		fmt.Sscan(req.GetOptionalCursor().GetToken(), &start)
+	}
+	end := min(start+int(req.GetOptionalLimit()), len(lc.objects))
+
+	stream := &lookupStream{}
+	for i := start; i < end; i++ {
+		stream.res = append(stream.res, &v1.LookupResourcesResponse{
+			ResourceObjectId:  lc.objects[i],
+			AfterResultCursor: &v1.Cursor{Token: fmt.Sprint(i + 1)},
+		})
+	}
+
+	return stream, nil
+}
+
+type lookupStream struct {
+	grpc.ClientStream
+	res []*v1.LookupResourcesResponse
+}
+
+func (ls *lookupStream) Recv() (*v1.LookupResourcesResponse, error) {
+	if len(ls.res) == 0 {
+		return nil, io.EOF
+	}
+	res := ls.res[0]
+	ls.res = ls.res[1:]
+
+	return res, nil
+}
+
+func relationshipKey(objectType, object, relation, subjectType, subject string) string {
+	return fmt.Sprintf("%s:%s#%s@%s:%s", objectType, object, relation, subjectType, subject)
+}
+
+func newService(pc *permissionsClient) *policyService {
+	return &policyService{
+		permissionClient: pc,
+		logger:           smqlog.NewMock(),
+	}
+}
+
+func TestAddPolicyIfAbsent(t *testing.T) {
+	pc := newPermissionsClient()
+	svc := newService(pc)
+
+	err := svc.AddPolicyIfAbsent(context.Background(), policy)
+	assert.Nil(t, err, fmt.Sprintf("adding new policy: expected nil got %s", err))
+
+	err = svc.AddPolicyIfAbsent(context.Background(), policy)
+	assert.Nil(t, err, fmt.Sprintf("adding the same policy twice: expected nil got %s", err))
+	assert.Equal(t, 1, pc.writes, fmt.Sprintf("expected %d writes got %d", 1, pc.writes))
+
+	err = svc.AddPolicy(context.Background(), policy)
+	assert.True(t, errors.Contains(err, errAddPolicies), fmt.Sprintf("adding existing policy without idempotency: expected %s got %s", errAddPolicies, err))
+}
+
+func TestAddPolicyIfAbsentAfterAddPolicy(t *testing.T) {
+	pc := newPermissionsClient()
+	svc := newService(pc)
+
+	err := svc.AddPolicy(context.Background(), policy)
+	assert.Nil(t, err, fmt.Sprintf("adding new policy: expected nil got %s", err))
+
+	err = svc.AddPolicyIfAbsent(context.Background(), policy)
+	assert.Nil(t, err, fmt.Sprintf("retrying existing policy: expected nil got %s", err))
+	assert.Equal(t, 1, pc.writes, fmt.Sprintf("expected %d writes got %d", 1, pc.writes))
+}
+
+func TestAddPolicyIfAbsentInvalidPolicy(t *testing.T) {
+	svc := newService(newPermissionsClient())
+
+	pr := policies.Policy{
+		SubjectType: policies.UserType,
+		Subject:     "user",
+		Relation:    policies.AdministratorRelation,
+		ObjectType:  policies.PlatformType,
+		Object:      "invalid",
+	}
+	err := svc.AddPolicyIfAbsent(context.Background(), pr)
+	assert.True(t, errors.Contains(err, errPlatform), fmt.Sprintf("adding invalid policy: expected %s got %s", errPlatform, err))
+}
+
+func TestCountObjectsWithLimit(t *testing.T) {
+	objects := make([]string, 2*defRetrieveAllLimit+10)
+	for i := range objects {
+		objects[i] = fmt.Sprintf("client-%d", i)
+	}
+
+	cases := []struct {
+		desc    string
+		objects []string
+		limit   uint64
+		count   uint64
+		capped  bool
+		lookups int
+	}{
+		{
+			desc:    "count without limit",
+			objects: objects,
+			count:   uint64(len(objects)),
+			lookups: 3,
+		},
+		{
+			desc:    "count below limit",
+			objects: objects[:10],
+			limit:   100,
+			count:   10,
+			lookups: 1,
+		},
+		{
+			desc:    "count equal to limit",
+			objects: objects[:100],
+			limit:   100,
+			count:   100,
+			lookups: 1,
+		},
+		{
+			desc:    "count above limit",
+			objects: objects[:101],
+			limit:   100,
+			count:   100,
+			capped:  true,
+			lookups: 1,
+		},
+		{
+			desc:    "count above limit spanning pages",
+			objects: objects,
+			limit:   defRetrieveAllLimit + 5,
+			count:   defRetrieveAllLimit + 5,
+			capped:  true,
+			lookups: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc := &lookupClient{objects: tc.objects}
+			svc := &policyService{permissionClient: lc, logger: smqlog.NewMock()}
+			count, capped, err := svc.CountObjectsWithLimit(context.Background(), policy, tc.limit)
+			assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s", tc.desc, err))
+			assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected count %d got %d", tc.desc, tc.count, count))
+			assert.Equal(t, tc.capped, capped, fmt.Sprintf("%s: expected capped %t got %t", tc.desc, tc.capped, capped))
+			assert.Equal(t, tc.lookups, len(lc.ctxs), fmt.Sprintf("%s: expected %d lookups got %d", tc.desc, tc.lookups, len(lc.ctxs)))
+			for _, ctx := range lc.ctxs {
+				assert.NotNil(t, ctx.Err(), fmt.Sprintf("%s: expected lookup stream to be canceled", tc.desc))
+			}
+		})
+	}
+}
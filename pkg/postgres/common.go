@@ -7,6 +7,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/jmoiron/sqlx"
 )
 
 // CreateMetadataQuery creates a query to filter by metadata.
@@ -30,6 +34,18 @@ func CreateMetadataQuery(entity string, um map[string]any) (string, []byte, erro
 	return query, param, nil
 }
 
+// EscapeLike escapes the LIKE and ILIKE pattern wildcards in the given
+// string, so user input is matched literally.
+//
+// For example:
+//
+//	name := EscapeLike("50%_off") // 50\%\_off
+func EscapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 // Total returns the total number of rows.
 //
 // For example:
@@ -51,3 +67,77 @@ func Total(ctx context.Context, db Database, query string, params any) (uint64,
 
 	return total, nil
 }
+
+// EstimatedTotal returns the planner's estimate of the number of rows returned
+// by the query. Unlike COUNT(*), it doesn't run the query, so it should be used
+// for listings of large tables where an approximate total is acceptable. The
+// estimate relies on the table statistics, so it may be off for filters that
+// the statistics don't cover well.
+//
+// For example:
+//
+//	total, err := EstimatedTotal(ctx, db, "SELECT id FROM table WHERE column = :column", params)
+func EstimatedTotal(ctx context.Context, db Database, query string, params any) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, "EXPLAIN (FORMAT JSON) "+query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var plan []byte
+	if rows.Next() {
+		if err := rows.Scan(&plan); err != nil {
+			return 0, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, errEmptyPlan
+	}
+
+	return uint64(plans[0].Plan.Rows), nil
+}
+
+// WithinTx runs fn within a transaction. The transaction is committed when fn
+// succeeds and rolled back when fn returns an error or panics. Errors are
+// returned as they are, so the caller can map them to domain errors with its
+// error handler.
+//
+// For example:
+//
+//	err := WithinTx(ctx, db, func(tx *sqlx.Tx) error {
+//		_, err := tx.ExecContext(ctx, "UPDATE table SET column = $1", value)
+//		return err
+//	})
+func WithinTx(ctx context.Context, db Database, fn func(tx *sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			return errors.Wrap(err, errors.Wrap(errors.ErrRollbackTx, errRollback))
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -5,6 +5,7 @@ package postgres
 
 import (
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -32,6 +33,9 @@ func NewErrorHandler(opts ...errors.HandlerOption) errors.Handler {
 
 // Handle handles the error.
 func (eh errHandler) HandleError(wrapper, err error) error {
+	if isQueryCanceled(err) {
+		return errors.Wrap(wrapper, errors.Wrap(repoerr.ErrDeadlineExceeded, err))
+	}
 	pqErr, ok := err.(*pgconn.PgError)
 	if ok {
 		switch pqErr.Code {
@@ -4,6 +4,9 @@
 package postgres
 
 import (
+	"context"
+	stderrors "errors"
+
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -18,11 +21,15 @@ const (
 	errInvalid        = "22P02" // invalid_text_representation
 	errUntranslatable = "22P05" // untranslatable_character
 	errInvalidChar    = "22021" // character_not_in_repertoire
+	errQueryCanceled  = "57014" // query_canceled
 )
 
 // HandleError handles the error and returns a wrapped error.
 // It checks the error code and returns a specific error.
 func HandleError(wrapper, err error) error {
+	if isQueryCanceled(err) {
+		return errors.Wrap(wrapper, errors.Wrap(repoerr.ErrDeadlineExceeded, err))
+	}
 	pqErr, ok := err.(*pgconn.PgError)
 	if ok {
 		switch pqErr.Code {
@@ -37,3 +44,13 @@ func HandleError(wrapper, err error) error {
 
 	return errors.Wrap(wrapper, err)
 }
+
+// isQueryCanceled checks if the query was canceled because it exceeded
+// either the statement timeout or the deadline of its context.
+func isQueryCanceled(err error) bool {
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var pqErr *pgconn.PgError
+	return stderrors.As(err, &pqErr) && pqErr.Code == errQueryCanceled
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsQueryCanceled(t *testing.T) {
+	cases := []struct {
+		desc     string
+		err      error
+		canceled bool
+	}{
+		{
+			desc:     "statement timeout",
+			err:      &pgconn.PgError{Code: errQueryCanceled},
+			canceled: true,
+		},
+		{
+			desc:     "wrapped statement timeout",
+			err:      fmt.Errorf("failed to retrieve: %w", &pgconn.PgError{Code: errQueryCanceled}),
+			canceled: true,
+		},
+		{
+			desc:     "context deadline exceeded",
+			err:      context.DeadlineExceeded,
+			canceled: true,
+		},
+		{
+			desc:     "wrapped context deadline exceeded",
+			err:      fmt.Errorf("failed to retrieve: %w", context.DeadlineExceeded),
+			canceled: true,
+		},
+		{
+			desc:     "context canceled",
+			err:      context.Canceled,
+			canceled: false,
+		},
+		{
+			desc:     "other postgres error",
+			err:      &pgconn.PgError{Code: errDuplicate},
+			canceled: false,
+		},
+		{
+			desc:     "other error",
+			err:      errors.New("connection refused"),
+			canceled: false,
+		},
+		{
+			desc:     "nil error",
+			err:      nil,
+			canceled: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			canceled := isQueryCanceled(tc.err)
+			assert.Equal(t, tc.canceled, canceled, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.canceled, canceled))
+		})
+	}
+}
+
+func TestHandleQueryCanceled(t *testing.T) {
+	cases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			desc: "handle statement timeout",
+			err:  HandleError(repoerr.ErrViewEntity, &pgconn.PgError{Code: errQueryCanceled}),
+		},
+		{
+			desc: "handle statement timeout with error handler",
+			err:  NewErrorHandler().HandleError(repoerr.ErrViewEntity, &pgconn.PgError{Code: errQueryCanceled}),
+		},
+		{
+			desc: "handle context deadline exceeded with error handler",
+			err:  NewErrorHandler().HandleError(repoerr.ErrViewEntity, context.DeadlineExceeded),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.True(t, errors.Contains(tc.err, repoerr.ErrDeadlineExceeded), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, repoerr.ErrDeadlineExceeded, tc.err))
+			assert.True(t, errors.Contains(tc.err, repoerr.ErrViewEntity), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, repoerr.ErrViewEntity, tc.err))
+			_, ok := tc.err.(*errors.TimeoutError)
+			assert.True(t, ok, fmt.Sprintf("%s: expected timeout error got %T\n", tc.desc, tc.err))
+		})
+	}
+}
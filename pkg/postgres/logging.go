@@ -0,0 +1,192 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	_ Database = (*loggingDatabase)(nil)
+
+	// namedParamRegExp matches named query parameters, skipping PostgreSQL "::" casts.
+	namedParamRegExp = regexp.MustCompile(`(?:^|[^:]):(\w+)`)
+
+	// sensitiveParams lists substrings of parameter names whose values are
+	// never logged. Metadata is included since it may hold arbitrary user
+	// data, such as credentials of external systems.
+	sensitiveParams = []string{"secret", "key", "pass", "token", "metadata"}
+
+	mapper = reflectx.NewMapperFunc("db", sqlx.NameMapper)
+)
+
+type loggingDatabase struct {
+	db     Database
+	logger *slog.Logger
+}
+
+// NewLoggingDatabase returns a Database decorator that logs executed
+// queries, their named parameters, duration and number of affected rows at
+// debug level. Values of parameters that hold secrets, keys or metadata are
+// redacted.
+func NewLoggingDatabase(db Database, logger *slog.Logger) Database {
+	return &loggingDatabase{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (ld *loggingDatabase) NamedQueryContext(ctx context.Context, query string, args any) (rows *sqlx.Rows, err error) {
+	defer func(begin time.Time) {
+		ld.log(ctx, query, namedParams(query, args), begin, nil, err)
+	}(time.Now())
+
+	return ld.db.NamedQueryContext(ctx, query, args)
+}
+
+func (ld *loggingDatabase) NamedExecContext(ctx context.Context, query string, args any) (res sql.Result, err error) {
+	defer func(begin time.Time) {
+		ld.log(ctx, query, namedParams(query, args), begin, res, err)
+	}(time.Now())
+
+	return ld.db.NamedExecContext(ctx, query, args)
+}
+
+func (ld *loggingDatabase) QueryRowxContext(ctx context.Context, query string, args ...any) (row *sqlx.Row) {
+	defer func(begin time.Time) {
+		ld.log(ctx, query, positionalParams(args), begin, nil, row.Err())
+	}(time.Now())
+
+	return ld.db.QueryRowxContext(ctx, query, args...)
+}
+
+func (ld *loggingDatabase) QueryxContext(ctx context.Context, query string, args ...any) (rows *sqlx.Rows, err error) {
+	defer func(begin time.Time) {
+		ld.log(ctx, query, positionalParams(args), begin, nil, err)
+	}(time.Now())
+
+	return ld.db.QueryxContext(ctx, query, args...)
+}
+
+func (ld *loggingDatabase) QueryContext(ctx context.Context, query string, args ...any) (rows *sql.Rows, err error) {
+	defer func(begin time.Time) {
+		ld.log(ctx, query, positionalParams(args), begin, nil, err)
+	}(time.Now())
+
+	return ld.db.QueryContext(ctx, query, args...)
+}
+
+func (ld *loggingDatabase) ExecContext(ctx context.Context, query string, args ...any) (res sql.Result, err error) {
+	defer func(begin time.Time) {
+		ld.log(ctx, query, positionalParams(args), begin, res, err)
+	}(time.Now())
+
+	return ld.db.ExecContext(ctx, query, args...)
+}
+
+func (ld *loggingDatabase) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return ld.db.BeginTxx(ctx, opts)
+}
+
+func (ld *loggingDatabase) log(ctx context.Context, query string, params slog.Attr, begin time.Time, res sql.Result, err error) {
+	if !ld.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	args := []any{
+		slog.String("query", strings.Join(strings.Fields(query), " ")),
+		params,
+		slog.String("duration", time.Since(begin).String()),
+	}
+	if res != nil {
+		if n, err := res.RowsAffected(); err == nil {
+			args = append(args, slog.Int64("rows_affected", n))
+		}
+	}
+	if err != nil {
+		args = append(args, slog.String("error", err.Error()))
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			args = append(args, slog.String("sqlstate", pgErr.Code))
+		}
+		ld.logger.Debug("Query failed", args...)
+		return
+	}
+	ld.logger.Debug("Query executed", args...)
+}
+
+// namedParams returns the values of named parameters used in the query.
+func namedParams(query string, arg any) slog.Attr {
+	values := func(string) (any, bool) { return nil, false }
+	switch v := reflect.Indirect(reflect.ValueOf(arg)); {
+	case !v.IsValid():
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		values = func(name string) (any, bool) {
+			val := v.MapIndex(reflect.ValueOf(name))
+			if !val.IsValid() {
+				return nil, false
+			}
+			return val.Interface(), true
+		}
+	case v.Kind() == reflect.Struct:
+		fields := mapper.FieldMap(v)
+		values = func(name string) (any, bool) {
+			val, ok := fields[name]
+			if !ok {
+				return nil, false
+			}
+			return val.Interface(), true
+		}
+	}
+
+	var attrs []any
+	seen := make(map[string]bool)
+	for _, match := range namedParamRegExp.FindAllStringSubmatch(query, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		val, ok := values(name)
+		if !ok {
+			continue
+		}
+		if sensitive(name) {
+			val = redacted
+		}
+		attrs = append(attrs, slog.Any(name, val))
+	}
+
+	return slog.Group("params", attrs...)
+}
+
+// positionalParams returns only the number of positional parameters,
+// since their values can't be matched to the columns they are bound to.
+func positionalParams(args []any) slog.Attr {
+	return slog.Int("params", len(args))
+}
+
+func sensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveParams {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+
+	return false
+}
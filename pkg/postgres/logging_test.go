@@ -0,0 +1,135 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// execDatabase implements only the statements executed by the tests.
+type execDatabase struct {
+	Database
+}
+
+func (execDatabase) NamedExecContext(context.Context, string, any) (sql.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (execDatabase) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+type loggedClient struct {
+	ID              string         `db:"id"`
+	Name            string         `db:"name"`
+	Secret          string         `db:"secret"`
+	Metadata        map[string]any `db:"metadata"`
+	PrivateMetadata map[string]any `db:"private_metadata"`
+}
+
+func TestLoggingDatabase(t *testing.T) {
+	client := loggedClient{
+		ID:              "client-id",
+		Name:            "client-name",
+		Secret:          "client-secret",
+		Metadata:        map[string]any{"location": "office"},
+		PrivateMetadata: map[string]any{"api_token": "external-token"},
+	}
+
+	cases := []struct {
+		desc   string
+		exec   func(db Database) error
+		params any
+		hidden []string
+		rows   float64
+	}{
+		{
+			desc: "log named query with struct params",
+			exec: func(db Database) error {
+				_, err := db.NamedExecContext(context.Background(), `UPDATE clients SET name = :name, secret = :secret, metadata = :metadata, private_metadata = :private_metadata WHERE id = :id`, client)
+				return err
+			},
+			params: map[string]any{
+				"id":               "client-id",
+				"name":             "client-name",
+				"secret":           redacted,
+				"metadata":         redacted,
+				"private_metadata": redacted,
+			},
+			hidden: []string{"client-secret", "office", "external-token"},
+			rows:   1,
+		},
+		{
+			desc: "log named query with map params",
+			exec: func(db Database) error {
+				params := map[string]any{
+					"id":       "key-id",
+					"key":      "key-value",
+					"password": "user-password",
+					"token":    "refresh-token",
+				}
+				_, err := db.NamedExecContext(context.Background(), `UPDATE keys SET value = :key, password = :password, token = :token WHERE id = :id AND created_at > :created_at::timestamp`, params)
+				return err
+			},
+			params: map[string]any{
+				"id":       "key-id",
+				"key":      redacted,
+				"password": redacted,
+				"token":    redacted,
+			},
+			hidden: []string{"key-value", "user-password", "refresh-token"},
+			rows:   1,
+		},
+		{
+			desc: "log positional query",
+			exec: func(db Database) error {
+				_, err := db.ExecContext(context.Background(), `UPDATE clients SET secret = $1 WHERE id = $2`, "client-secret", "client-id")
+				return err
+			},
+			params: float64(2),
+			hidden: []string{"client-secret", "client-id"},
+			rows:   1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			db := NewLoggingDatabase(execDatabase{}, logger)
+
+			err := tc.exec(db)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+			var entry map[string]any
+			err = json.Unmarshal(buf.Bytes(), &entry)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding log entry: %s", tc.desc, err))
+			assert.Equal(t, tc.params, entry["params"], fmt.Sprintf("%s: expected params %v got %v\n", tc.desc, tc.params, entry["params"]))
+			assert.Equal(t, tc.rows, entry["rows_affected"], fmt.Sprintf("%s: expected rows affected %v got %v\n", tc.desc, tc.rows, entry["rows_affected"]))
+			for _, value := range tc.hidden {
+				assert.NotContains(t, buf.String(), value, fmt.Sprintf("%s: expected %s not to be logged", tc.desc, value))
+			}
+		})
+	}
+}
+
+func TestLoggingDatabaseDisabled(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	db := NewLoggingDatabase(execDatabase{}, logger)
+
+	_, err := db.ExecContext(context.Background(), `DELETE FROM clients WHERE id = $1`, "client-id")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, buf.String(), "expected no queries to be logged above debug level")
+}
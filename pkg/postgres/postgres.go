@@ -10,24 +10,36 @@ import (
 	"github.com/absmach/supermq/pkg/errors"
 	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
 	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	migrate "github.com/rubenv/sql-migrate"
 )
 
 var (
-	errConnect   = errors.New("failed to connect to postgresql server")
-	errMigration = errors.New("failed to apply migrations")
+	errConnect        = errors.New("failed to connect to postgresql server")
+	errConnectReplica = errors.New("failed to connect to postgresql replica")
+	errMigration      = errors.New("failed to apply migrations")
+	errRegisterStats  = errors.New("failed to register connection pool stats")
+	errEmptyPlan      = errors.New("query plan is empty")
 )
 
 type Config struct {
-	Host        string `env:"HOST"           envDefault:"localhost"`
-	Port        string `env:"PORT"           envDefault:"5432"`
-	User        string `env:"USER"           envDefault:"supermq"`
-	Pass        string `env:"PASS"           envDefault:"supermq"`
-	Name        string `env:"NAME"           envDefault:""`
-	SSLMode     string `env:"SSL_MODE"       envDefault:"disable"`
-	SSLCert     string `env:"SSL_CERT"       envDefault:""`
-	SSLKey      string `env:"SSL_KEY"        envDefault:""`
-	SSLRootCert string `env:"SSL_ROOT_CERT"  envDefault:""`
+	Host             string        `env:"HOST"               envDefault:"localhost"`
+	Port             string        `env:"PORT"               envDefault:"5432"`
+	User             string        `env:"USER"               envDefault:"supermq"`
+	Pass             string        `env:"PASS"               envDefault:"supermq"`
+	Name             string        `env:"NAME"               envDefault:""`
+	SSLMode          string        `env:"SSL_MODE"           envDefault:"disable"`
+	SSLCert          string        `env:"SSL_CERT"           envDefault:""`
+	SSLKey           string        `env:"SSL_KEY"            envDefault:""`
+	SSLRootCert      string        `env:"SSL_ROOT_CERT"      envDefault:""`
+	StatementTimeout time.Duration `env:"STATEMENT_TIMEOUT"  envDefault:"30s"`
+	MaxOpenConns     int           `env:"MAX_OPEN_CONNS"     envDefault:"1000"`
+	MaxIdleConns     int           `env:"MAX_IDLE_CONNS"     envDefault:"10"`
+	ConnMaxLifetime  time.Duration `env:"CONN_MAX_LIFETIME"  envDefault:"1h"`
+	ConnMaxIdleTime  time.Duration `env:"CONN_MAX_IDLE_TIME" envDefault:"5m"`
+	ReplicaHosts     []string      `env:"REPLICA_HOSTS"      envDefault:""`
+	LogQueries       bool          `env:"LOG_QUERIES"        envDefault:"false"`
 }
 
 // Setup creates a connection to the PostgreSQL instance and applies any
@@ -37,16 +49,21 @@ type Config struct {
 //
 //	db, err := postgres.Setup(postgres.Config{}, migrate.MemoryMigrationSource{})
 func Setup(cfg Config, migrations migrate.MemoryMigrationSource) (*sqlx.DB, error) {
-	db, err := Connect(cfg)
+	// Migrations may run longer than the statement timeout, so they are
+	// applied using a separate connection without it.
+	mcfg := cfg
+	mcfg.StatementTimeout = 0
+	mdb, err := Connect(mcfg)
 	if err != nil {
 		return nil, err
 	}
+	defer mdb.Close()
 
-	if _, err = migrate.Exec(db.DB, "postgres", migrations, migrate.Up); err != nil {
+	if _, err = migrate.Exec(mdb.DB, "postgres", migrations, migrate.Up); err != nil {
 		return nil, errors.Wrap(errMigration, err)
 	}
 
-	return db, nil
+	return Connect(cfg)
 }
 
 // Connect creates a connection to the PostgreSQL instance.
@@ -56,14 +73,42 @@ func Setup(cfg Config, migrations migrate.MemoryMigrationSource) (*sqlx.DB, erro
 //	db, err := postgres.Connect(postgres.Config{})
 func Connect(cfg Config) (*sqlx.DB, error) {
 	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+	// Statement timeout is sent as a runtime parameter, so the server cancels
+	// any statement running longer than that on every pooled connection.
+	if cfg.StatementTimeout > 0 {
+		url = fmt.Sprintf("%s statement_timeout=%d", url, cfg.StatementTimeout.Milliseconds())
+	}
 
 	db, err := sqlx.Open("pgx", url)
 	if err != nil {
 		return nil, errors.Wrap(errConnect, err)
 	}
-	db.SetConnMaxLifetime(time.Hour)
-	db.SetConnMaxIdleTime(time.Minute)
-	db.SetMaxIdleConns(10)
-	db.SetMaxOpenConns(20)
+	// Zero values keep the database/sql defaults.
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
 	return db, nil
 }
+
+// RegisterStats registers a Prometheus collector exposing the connection
+// pool statistics of the given database, labeled with the database name.
+//
+// For example:
+//
+//	err := postgres.RegisterStats(db, postgres.Config{Name: "clients"})
+func RegisterStats(db *sqlx.DB, cfg Config) error {
+	if err := prometheus.Register(collectors.NewDBStatsCollector(db.DB, cfg.Name)); err != nil {
+		return errors.Wrap(errRegisterStats, err)
+	}
+
+	return nil
+}
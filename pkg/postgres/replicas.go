@@ -0,0 +1,135 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	_ Database = (*replicatedDatabase)(nil)
+
+	// writeRegExp matches statements that modify data or lock rows.
+	writeRegExp = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE|MERGE|TRUNCATE)\b`)
+)
+
+type primaryKey struct{}
+
+// WithPrimary returns a context which pins the read-only queries made with it
+// to the primary. It is meant for lookups that must observe the latest writes,
+// such as authentication by key or secret: on a lagging replica a revoked key
+// would keep authenticating and a just-issued one would be rejected.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+type replicatedDatabase struct {
+	primary  Database
+	replicas []Database
+	next     atomic.Uint64
+}
+
+// NewReplicatedDatabase returns a Database that sends read-only queries to the
+// replicas in round-robin order and all the other statements to the primary.
+// Transactions are always started on the primary, so reads within them are
+// served by the primary too. Since replicas may lag behind the primary, it
+// should be used only where reading slightly stale data is acceptable, and
+// lookups that must not be stale should use a context from WithPrimary.
+func NewReplicatedDatabase(primary *sqlx.DB, replicas []*sqlx.DB, config Config, tracer trace.Tracer) Database {
+	rdb := &replicatedDatabase{
+		primary: NewDatabase(primary, config, tracer),
+	}
+	for i, replica := range replicas {
+		cfg := config
+		if i < len(config.ReplicaHosts) {
+			cfg.Host = config.ReplicaHosts[i]
+		}
+		rdb.replicas = append(rdb.replicas, NewDatabase(replica, cfg, tracer))
+	}
+
+	return rdb
+}
+
+// ConnectReplicas creates a connection to each of the replicas listed in
+// the configuration. Replicas use the same port, credentials and database
+// name as the primary.
+func ConnectReplicas(cfg Config) ([]*sqlx.DB, error) {
+	var replicas []*sqlx.DB
+	for _, host := range cfg.ReplicaHosts {
+		replicaCfg := cfg
+		replicaCfg.Host = host
+		db, err := Connect(replicaCfg)
+		if err != nil {
+			for _, replica := range replicas {
+				replica.Close()
+			}
+			return nil, errors.Wrap(errConnectReplica, err)
+		}
+		replicas = append(replicas, db)
+	}
+
+	return replicas, nil
+}
+
+func (rdb *replicatedDatabase) NamedQueryContext(ctx context.Context, query string, args any) (*sqlx.Rows, error) {
+	return rdb.route(ctx, query).NamedQueryContext(ctx, query, args)
+}
+
+func (rdb *replicatedDatabase) NamedExecContext(ctx context.Context, query string, args any) (sql.Result, error) {
+	return rdb.primary.NamedExecContext(ctx, query, args)
+}
+
+func (rdb *replicatedDatabase) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	return rdb.route(ctx, query).QueryRowxContext(ctx, query, args...)
+}
+
+func (rdb *replicatedDatabase) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	return rdb.route(ctx, query).QueryxContext(ctx, query, args...)
+}
+
+func (rdb *replicatedDatabase) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return rdb.route(ctx, query).QueryContext(ctx, query, args...)
+}
+
+func (rdb *replicatedDatabase) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return rdb.primary.ExecContext(ctx, query, args...)
+}
+
+func (rdb *replicatedDatabase) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return rdb.primary.BeginTxx(ctx, opts)
+}
+
+// route returns the replica that should serve the query if it is read-only
+// and not pinned to the primary, and the primary otherwise. Queries are used
+// for INSERT and UPDATE statements with RETURNING clause too, so only SELECT
+// statements are sent to replicas.
+func (rdb *replicatedDatabase) route(ctx context.Context, query string) Database {
+	if pinned, _ := ctx.Value(primaryKey{}).(bool); pinned {
+		return rdb.primary
+	}
+	if len(rdb.replicas) == 0 || !isReadQuery(query) {
+		return rdb.primary
+	}
+	n := rdb.next.Add(1)
+
+	return rdb.replicas[n%uint64(len(rdb.replicas))]
+}
+
+func isReadQuery(query string) bool {
+	q := strings.ToUpper(strings.TrimSpace(query))
+	if !strings.HasPrefix(q, "SELECT") && !strings.HasPrefix(q, "WITH") {
+		return false
+	}
+
+	// Covers data-modifying CTEs and SELECT ... FOR UPDATE.
+	return !writeRegExp.MatchString(q)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// namedDatabase is used only to tell the databases apart when routing.
+type namedDatabase struct {
+	Database
+	name string
+}
+
+func TestIsReadQuery(t *testing.T) {
+	cases := []struct {
+		desc  string
+		query string
+		read  bool
+	}{
+		{
+			desc:  "select",
+			query: "SELECT id, name FROM clients WHERE id = :id",
+			read:  true,
+		},
+		{
+			desc:  "select with leading whitespace and lowercase",
+			query: "\n\t  select id from clients",
+			read:  true,
+		},
+		{
+			desc:  "read-only CTE",
+			query: "WITH RECURSIVE g AS (SELECT id FROM groups) SELECT id FROM g",
+			read:  true,
+		},
+		{
+			desc:  "insert",
+			query: "INSERT INTO clients (id) VALUES (:id)",
+			read:  false,
+		},
+		{
+			desc:  "update with returning",
+			query: "UPDATE clients SET name = :name WHERE id = :id RETURNING id",
+			read:  false,
+		},
+		{
+			desc:  "delete",
+			query: "DELETE FROM clients WHERE id = :id",
+			read:  false,
+		},
+		{
+			desc:  "data-modifying CTE",
+			query: "WITH d AS (DELETE FROM clients WHERE id = :id RETURNING id) SELECT id FROM d",
+			read:  false,
+		},
+		{
+			desc:  "select for update",
+			query: "SELECT id FROM clients WHERE id = :id FOR UPDATE",
+			read:  false,
+		},
+		{
+			desc:  "empty query",
+			query: "",
+			read:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			read := isReadQuery(tc.query)
+			assert.Equal(t, tc.read, read, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.read, read))
+		})
+	}
+}
+
+func TestRoute(t *testing.T) {
+	primary := &namedDatabase{name: "primary"}
+	replica1 := &namedDatabase{name: "replica1"}
+	replica2 := &namedDatabase{name: "replica2"}
+	rdb := &replicatedDatabase{
+		primary:  primary,
+		replicas: []Database{replica1, replica2},
+	}
+	single := &replicatedDatabase{primary: primary}
+
+	read := "SELECT id FROM clients"
+	write := "UPDATE clients SET name = :name"
+
+	cases := []struct {
+		desc     string
+		rdb      *replicatedDatabase
+		ctx      context.Context
+		query    string
+		expected []*namedDatabase
+	}{
+		{
+			desc:     "reads are balanced across replicas",
+			rdb:      rdb,
+			ctx:      context.Background(),
+			query:    read,
+			expected: []*namedDatabase{replica2, replica1, replica2},
+		},
+		{
+			desc:     "writes go to the primary",
+			rdb:      rdb,
+			ctx:      context.Background(),
+			query:    write,
+			expected: []*namedDatabase{primary, primary},
+		},
+		{
+			desc:     "reads pinned to the primary",
+			rdb:      rdb,
+			ctx:      WithPrimary(context.Background()),
+			query:    read,
+			expected: []*namedDatabase{primary, primary},
+		},
+		{
+			desc:     "reads without replicas",
+			rdb:      single,
+			ctx:      context.Background(),
+			query:    read,
+			expected: []*namedDatabase{primary},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.rdb.next.Store(0)
+			for _, expected := range tc.expected {
+				db := tc.rdb.route(tc.ctx, tc.query)
+				assert.Equal(t, expected.name, db.(*namedDatabase).name, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, expected.name, db.(*namedDatabase).name))
+			}
+		})
+	}
+}
@@ -47,11 +47,32 @@ type Database interface {
 	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
 }
 
+type statementTimeoutKey struct{}
+
+// WithStatementTimeout returns a context which overrides the configured
+// statement timeout for the transactions begun with it, such as those run
+// by WithinTx. The timeout is set with SET LOCAL, so it is reset once the
+// transaction ends and doesn't affect the other users of the connection.
+// Statements run outside of a transaction use the configured timeout.
+func WithStatementTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, statementTimeoutKey{}, timeout)
+}
+
+// setStatementTimeout applies the statement timeout set by
+// WithStatementTimeout to the transaction.
+func setStatementTimeout(ctx context.Context, tx *sqlx.Tx) error {
+	timeout, ok := ctx.Value(statementTimeoutKey{}).(time.Duration)
+	if !ok || timeout <= 0 {
+		return nil
+	}
+	// SET doesn't accept query parameters, the value is formatted as integer.
+	_, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout.Milliseconds()))
+
+	return err
+}
+
 // NewDatabase creates a Clients'Database instance.
 func NewDatabase(db *sqlx.DB, config Config, tracer trace.Tracer) Database {
-	db.SetMaxIdleConns(10)
-	db.SetConnMaxIdleTime(5 * time.Minute)
-	db.SetMaxOpenConns(1000)
 	database := &database{
 		Config: config,
 		db:     db,
@@ -106,7 +127,16 @@ func (d database) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx,
 	ctx, span := d.addSpanTags(ctx, "BeginTxx")
 	defer span.End()
 
-	return d.db.BeginTxx(ctx, opts)
+	tx, err := d.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := setStatementTimeout(ctx, tx); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (d *database) addSpanTags(ctx context.Context, query string) (context.Context, trace.Span) {
@@ -13,19 +13,27 @@ import (
 //
 //	counter, latency := metrics.MakeMetrics("demo-service", "api")
 func MakeMetrics(namespace, subsystem string) (*kitprometheus.Counter, *kitprometheus.Summary) {
+	return MakeLabeledMetrics(namespace, subsystem, "method")
+}
+
+// MakeLabeledMetrics returns a request counter and a request latency summary
+// partitioned by the given labels.
+//
+//	counter, latency := metrics.MakeLabeledMetrics("users", "oauth", "provider", "result")
+func MakeLabeledMetrics(namespace, subsystem string, labels ...string) (*kitprometheus.Counter, *kitprometheus.Summary) {
 	counter := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
 		Namespace: namespace,
 		Subsystem: subsystem,
 		Name:      "request_count",
 		Help:      "Number of requests received.",
-	}, []string{"method"})
+	}, labels)
 	latency := kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
 		Namespace:  namespace,
 		Subsystem:  subsystem,
 		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 		Name:       "request_latency_microseconds",
 		Help:       "Total duration of requests in microseconds.",
-	}, []string{"method"})
+	}, labels)
 
 	return counter, latency
 }
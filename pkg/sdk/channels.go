@@ -16,8 +16,9 @@ import (
 )
 
 const (
-	channelsEndpoint = "channels"
-	parentEndpoint   = "parent"
+	channelsEndpoint    = "channels"
+	parentEndpoint      = "parent"
+	connectionsEndpoint = "connections"
 )
 
 // Channel represents supermq channel.
@@ -37,6 +38,16 @@ type Channel struct {
 	Roles       []roles.MemberRoleActions `json:"roles,omitempty"`
 }
 
+// ChannelConnection represents a client connected to a channel, together
+// with the metadata stored on the connection.
+type ChannelConnection struct {
+	ClientID  string   `json:"client_id"`
+	ChannelID string   `json:"channel_id"`
+	DomainID  string   `json:"domain_id"`
+	Type      string   `json:"type"`
+	Metadata  Metadata `json:"metadata,omitempty"`
+}
+
 func (sdk mgSDK) CreateChannel(ctx context.Context, c Channel, domainID, token string) (Channel, errors.SDKError) {
 	data, err := json.Marshal(c)
 	if err != nil {
@@ -223,6 +234,25 @@ func (sdk mgSDK) DisconnectClients(ctx context.Context, channelID string, client
 	return sdkErr
 }
 
+func (sdk mgSDK) ChannelConnections(ctx context.Context, channelID, domainID, token string) (ChannelConnectionsPage, errors.SDKError) {
+	if channelID == "" {
+		return ChannelConnectionsPage{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.channelsURL, domainID, channelsEndpoint, channelID, connectionsEndpoint)
+
+	_, body, sdkErr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkErr != nil {
+		return ChannelConnectionsPage{}, sdkErr
+	}
+
+	var cp ChannelConnectionsPage
+	if err := json.Unmarshal(body, &cp); err != nil {
+		return ChannelConnectionsPage{}, errors.NewSDKError(err)
+	}
+
+	return cp, nil
+}
+
 func (sdk mgSDK) EnableChannel(ctx context.Context, id, domainID, token string) (Channel, errors.SDKError) {
 	return sdk.changeChannelStatus(ctx, id, enableEndpoint, domainID, token)
 }
@@ -1493,6 +1493,108 @@ func TestDeleteChannel(t *testing.T) {
 	}
 }
 
+func TestChannelConnections(t *testing.T) {
+	ts, gsvc, auth := setupChannels()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		ChannelsURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	conns := []channels.Connection{
+		{
+			ClientID:  validID,
+			ChannelID: channel.ID,
+			DomainID:  domainID,
+			Type:      connections.Publish,
+			Metadata:  channels.Metadata{"alias": "sensor"},
+		},
+	}
+
+	cases := []struct {
+		desc            string
+		domainID        string
+		token           string
+		session         smqauthn.Session
+		channelID       string
+		svcRes          []channels.Connection
+		svcErr          error
+		authenticateErr error
+		response        sdk.ChannelConnectionsPage
+		err             errors.SDKError
+	}{
+		{
+			desc:      "list channel connections successfully",
+			domainID:  domainID,
+			token:     validToken,
+			channelID: channel.ID,
+			svcRes:    conns,
+			response: sdk.ChannelConnectionsPage{
+				Connections: []sdk.ChannelConnection{
+					{
+						ClientID:  validID,
+						ChannelID: channel.ID,
+						DomainID:  domainID,
+						Type:      connections.Publish.String(),
+						Metadata:  sdk.Metadata{"alias": "sensor"},
+					},
+				},
+				PageRes: sdk.PageRes{Total: 1},
+			},
+			err: nil,
+		},
+		{
+			desc:            "list channel connections with invalid token",
+			domainID:        domainID,
+			token:           invalidToken,
+			channelID:       channel.ID,
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:      "list channel connections with empty token",
+			domainID:  domainID,
+			token:     "",
+			channelID: channel.ID,
+			err:       errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+		{
+			desc:      "list channel connections with service error",
+			domainID:  domainID,
+			token:     validToken,
+			channelID: wrongID,
+			svcErr:    svcerr.ErrViewEntity,
+			err:       errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusUnprocessableEntity),
+		},
+		{
+			desc:      "list channel connections with empty channel id",
+			domainID:  domainID,
+			token:     validToken,
+			channelID: "",
+			err:       errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := gsvc.On("ListConnections", mock.Anything, tc.session, tc.channelID).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.ChannelConnections(context.Background(), tc.channelID, tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ListConnections", mock.Anything, tc.session, tc.channelID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func TestConnect(t *testing.T) {
 	ts, gsvc, auth := setupChannels()
 	defer ts.Close()
@@ -1599,11 +1701,11 @@ func TestConnect(t *testing.T) {
 				connTypes = append(connTypes, connType)
 			}
 			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
-			svcCall := gsvc.On("Connect", mock.Anything, tc.session, tc.connection.ChannelIDs, tc.connection.ClientIDs, connTypes).Return(tc.svcErr)
+			svcCall := gsvc.On("Connect", mock.Anything, tc.session, tc.connection.ChannelIDs, tc.connection.ClientIDs, connTypes, channels.Metadata(tc.connection.Metadata)).Return(tc.svcErr)
 			err := mgsdk.Connect(context.Background(), tc.connection, tc.domainID, tc.token)
 			assert.Equal(t, tc.err, err)
 			if tc.err == nil {
-				ok := svcCall.Parent.AssertCalled(t, "Connect", mock.Anything, tc.session, tc.connection.ChannelIDs, tc.connection.ClientIDs, connTypes)
+				ok := svcCall.Parent.AssertCalled(t, "Connect", mock.Anything, tc.session, tc.connection.ChannelIDs, tc.connection.ClientIDs, connTypes, channels.Metadata(tc.connection.Metadata))
 				assert.True(t, ok)
 			}
 			svcCall.Unset()
@@ -1823,11 +1925,11 @@ func TestConnectClients(t *testing.T) {
 			connType, err := connections.ParseConnType(tc.connType)
 			assert.Nil(t, err, fmt.Sprintf("error parsing connection type %s", tc.connType))
 			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
-			svcCall := gsvc.On("Connect", mock.Anything, tc.session, []string{tc.channelID}, []string{tc.clientID}, []connections.ConnType{connType}).Return(tc.svcErr)
+			svcCall := gsvc.On("Connect", mock.Anything, tc.session, []string{tc.channelID}, []string{tc.clientID}, []connections.ConnType{connType}, channels.Metadata(nil)).Return(tc.svcErr)
 			err = mgsdk.ConnectClients(context.Background(), tc.channelID, []string{tc.clientID}, []string{tc.connType}, tc.domainID, tc.token)
 			assert.Equal(t, tc.err, err)
 			if tc.err == nil {
-				ok := svcCall.Parent.AssertCalled(t, "Connect", mock.Anything, tc.session, []string{tc.channelID}, []string{tc.clientID}, []connections.ConnType{connType})
+				ok := svcCall.Parent.AssertCalled(t, "Connect", mock.Anything, tc.session, []string{tc.channelID}, []string{tc.clientID}, []connections.ConnType{connType}, channels.Metadata(nil))
 				assert.True(t, ok)
 			}
 			svcCall.Unset()
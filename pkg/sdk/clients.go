@@ -25,6 +25,14 @@ const (
 	actionsEndpoint     = "actions"
 )
 
+var (
+	errRollbackProvision = errors.New("failed to roll back client provisioning")
+
+	// defProvisionTypes are the connection types used for provisioning
+	// when none are specified.
+	defProvisionTypes = []string{"publish", "subscribe"}
+)
+
 // Client represents supermq client.
 type Client struct {
 	ID              string                    `json:"id,omitempty"`
@@ -197,6 +205,18 @@ func (sdk mgSDK) UpdateClientSecret(ctx context.Context, id, secret, domainID, t
 	return t, nil
 }
 
+func (sdk mgSDK) UpdateClientOwner(ctx context.Context, id, ownerID, domainID, token string) errors.SDKError {
+	data, err := json.Marshal(updateClientOwnerReq{OwnerID: ownerID})
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/owner", sdk.clientsURL, domainID, clientsEndpoint, id)
+
+	_, _, sdkErr := sdk.processRequest(ctx, http.MethodPatch, url, token, data, nil, http.StatusNoContent)
+	return sdkErr
+}
+
 func (sdk mgSDK) EnableClient(ctx context.Context, id, domainID, token string) (Client, errors.SDKError) {
 	return sdk.changeClientStatus(ctx, id, enableEndpoint, domainID, token)
 }
@@ -256,6 +276,57 @@ func (sdk mgSDK) DeleteClient(ctx context.Context, id, domainID, token string) e
 	return sdkErr
 }
 
+func (sdk mgSDK) ProvisionClient(ctx context.Context, spec ProvisionSpec, domainID, token string) (Provision, errors.SDKError) {
+	client, sdkErr := sdk.CreateClient(ctx, spec.Client, domainID, token)
+	if sdkErr != nil {
+		return Provision{}, sdkErr
+	}
+	prov := Provision{Client: client}
+	if spec.Channel == nil {
+		return prov, nil
+	}
+
+	channel, sdkErr := sdk.CreateChannel(ctx, *spec.Channel, domainID, token)
+	if sdkErr != nil {
+		return Provision{}, sdk.rollbackProvision(ctx, prov, domainID, token, sdkErr)
+	}
+	prov.Channel = &channel
+
+	types := spec.Types
+	if len(types) == 0 {
+		types = defProvisionTypes
+	}
+	conn := Connection{
+		ClientIDs:  []string{client.ID},
+		ChannelIDs: []string{channel.ID},
+		Types:      types,
+	}
+	if sdkErr := sdk.Connect(ctx, conn, domainID, token); sdkErr != nil {
+		return Provision{}, sdk.rollbackProvision(ctx, prov, domainID, token, sdkErr)
+	}
+
+	return prov, nil
+}
+
+// rollbackProvision deletes the provisioned entities. The original error is
+// returned, wrapping the rollback error if the rollback failed.
+func (sdk mgSDK) rollbackProvision(ctx context.Context, prov Provision, domainID, token string, sdkErr errors.SDKError) errors.SDKError {
+	var err error
+	if prov.Channel != nil {
+		if rerr := sdk.DeleteChannel(ctx, prov.Channel.ID, domainID, token); rerr != nil {
+			err = errors.Wrap(errRollbackProvision, rerr)
+		}
+	}
+	if rerr := sdk.DeleteClient(ctx, prov.Client.ID, domainID, token); rerr != nil {
+		err = errors.Wrap(errRollbackProvision, rerr)
+	}
+	if err != nil {
+		return errors.NewSDKErrorWithStatus(errors.Wrap(sdkErr, err), sdkErr.StatusCode())
+	}
+
+	return sdkErr
+}
+
 func (sdk mgSDK) CreateClientRole(ctx context.Context, id, domainID string, rq RoleReq, token string) (Role, errors.SDKError) {
 	return sdk.createRole(ctx, sdk.clientsURL, clientsEndpoint, id, domainID, rq, token)
 }
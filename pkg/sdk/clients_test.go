@@ -13,6 +13,7 @@ import (
 	"time"
 
 	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/channels"
 	"github.com/absmach/supermq/clients"
 	api "github.com/absmach/supermq/clients/api/http"
 	"github.com/absmach/supermq/clients/mocks"
@@ -20,6 +21,7 @@ import (
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/roles"
@@ -1184,6 +1186,83 @@ func TestUpdateClientSecret(t *testing.T) {
 	}
 }
 
+func TestUpdateClientOwner(t *testing.T) {
+	ts, tsvc, auth := setupClients()
+	defer ts.Close()
+
+	client := generateTestClient(t, false)
+	ownerID := generateUUID(t)
+
+	conf := sdk.Config{
+		ClientsURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		domainID        string
+		token           string
+		session         smqauthn.Session
+		clientID        string
+		ownerID         string
+		svcErr          error
+		authenticateErr error
+		err             errors.SDKError
+	}{
+		{
+			desc:     "update client owner successfully",
+			domainID: domainID,
+			token:    validToken,
+			clientID: client.ID,
+			ownerID:  ownerID,
+			err:      nil,
+		},
+		{
+			desc:            "update client owner with an invalid token",
+			domainID:        domainID,
+			token:           invalidToken,
+			clientID:        client.ID,
+			ownerID:         ownerID,
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "update client owner with empty owner id",
+			domainID: domainID,
+			token:    validToken,
+			clientID: client.ID,
+			ownerID:  "",
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrMissingUserID, http.StatusBadRequest),
+		},
+		{
+			desc:     "update client owner with service error",
+			domainID: domainID,
+			token:    validToken,
+			clientID: client.ID,
+			ownerID:  ownerID,
+			svcErr:   svcerr.ErrAuthorization,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authenticateErr)
+			svcCall := tsvc.On("UpdateOwner", mock.Anything, tc.session, tc.clientID, tc.ownerID).Return(tc.svcErr)
+			err := mgsdk.UpdateClientOwner(context.Background(), tc.clientID, tc.ownerID, tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "UpdateOwner", mock.Anything, tc.session, tc.clientID, tc.ownerID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func TestEnableClient(t *testing.T) {
 	ts, tsvc, auth := setupClients()
 	defer ts.Close()
@@ -1471,6 +1550,107 @@ func TestDeleteClient(t *testing.T) {
 	}
 }
 
+func TestProvisionClient(t *testing.T) {
+	cts, tsvc, cauth := setupClients()
+	defer cts.Close()
+	chts, gsvc, chauth := setupChannels()
+	defer chts.Close()
+
+	conf := sdk.Config{
+		ClientsURL:  cts.URL,
+		ChannelsURL: chts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	client := generateTestClient(t, false)
+	clientReq := sdk.Client{
+		Name:        client.Name,
+		Credentials: client.Credentials,
+		Status:      client.Status,
+	}
+	channelReq := sdk.Channel{
+		Name:   channel.Name,
+		Status: channels.EnabledStatus.String(),
+	}
+	session := smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+	connTypes := []connections.ConnType{connections.Publish, connections.Subscribe}
+
+	cases := []struct {
+		desc             string
+		spec             sdk.ProvisionSpec
+		createClientErr  error
+		createChannelErr error
+		connectErr       error
+		deleteClientErr  error
+		deleted          bool
+		response         sdk.Provision
+		err              errors.SDKError
+	}{
+		{
+			desc:     "provision client without channel successfully",
+			spec:     sdk.ProvisionSpec{Client: clientReq},
+			response: sdk.Provision{Client: client},
+		},
+		{
+			desc:     "provision client with channel successfully",
+			spec:     sdk.ProvisionSpec{Client: clientReq, Channel: &channelReq},
+			response: sdk.Provision{Client: client, Channel: &channel},
+		},
+		{
+			desc:            "provision client with failed client creation",
+			spec:            sdk.ProvisionSpec{Client: clientReq, Channel: &channelReq},
+			createClientErr: svcerr.ErrCreateEntity,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrCreateEntity, http.StatusUnprocessableEntity),
+		},
+		{
+			desc:             "provision client with failed channel creation",
+			spec:             sdk.ProvisionSpec{Client: clientReq, Channel: &channelReq},
+			createChannelErr: svcerr.ErrCreateEntity,
+			deleted:          true,
+			err:              errors.NewSDKErrorWithStatus(svcerr.ErrCreateEntity, http.StatusUnprocessableEntity),
+		},
+		{
+			desc:       "provision client with failed connection",
+			spec:       sdk.ProvisionSpec{Client: clientReq, Channel: &channelReq},
+			connectErr: svcerr.ErrAuthorization,
+			deleted:    true,
+			err:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cauthCall := cauth.On("Authenticate", mock.Anything, validToken).Return(session, nil)
+			chauthCall := chauth.On("Authenticate", mock.Anything, validToken).Return(session, nil)
+			createClientCall := tsvc.On("CreateClients", mock.Anything, session, mock.Anything).Return([]clients.Client{convertClient(client)}, []roles.RoleProvision{}, tc.createClientErr)
+			createChannelCall := gsvc.On("CreateChannels", mock.Anything, session, mock.Anything).Return([]channels.Channel{convertChannel(channel)}, []roles.RoleProvision{}, tc.createChannelErr)
+			connectCall := gsvc.On("Connect", mock.Anything, session, []string{channel.ID}, []string{client.ID}, connTypes, channels.Metadata(nil)).Return(tc.connectErr)
+			removeChannelCall := gsvc.On("RemoveChannel", mock.Anything, session, channel.ID).Return(nil)
+			deleteClientCall := tsvc.On("Delete", mock.Anything, session, client.ID).Return(tc.deleteClientErr)
+			resp, err := mgsdk.ProvisionClient(context.Background(), tc.spec, domainID, validToken)
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, resp, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.response, resp))
+			if tc.deleted {
+				ok := deleteClientCall.Parent.AssertCalled(t, "Delete", mock.Anything, session, client.ID)
+				assert.True(t, ok)
+			} else {
+				ok := deleteClientCall.Parent.AssertNotCalled(t, "Delete", mock.Anything, session, client.ID)
+				assert.True(t, ok)
+			}
+			if tc.connectErr != nil {
+				ok := removeChannelCall.Parent.AssertCalled(t, "RemoveChannel", mock.Anything, session, channel.ID)
+				assert.True(t, ok)
+			}
+			deleteClientCall.Unset()
+			removeChannelCall.Unset()
+			connectCall.Unset()
+			createChannelCall.Unset()
+			createClientCall.Unset()
+			chauthCall.Unset()
+			cauthCall.Unset()
+		})
+	}
+}
+
 func TestSetClientParent(t *testing.T) {
 	ts, csvc, auth := setupClients()
 	defer ts.Close()
@@ -1037,6 +1037,86 @@ func (_c *SDK_Channel_Call) RunAndReturn(run func(ctx context.Context, id string
 	return _c
 }
 
+// ChannelConnections provides a mock function for the type SDK
+func (_mock *SDK) ChannelConnections(ctx context.Context, channelID string, domainID string, token string) (sdk.ChannelConnectionsPage, errors.SDKError) {
+	ret := _mock.Called(ctx, channelID, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelConnections")
+	}
+
+	var r0 sdk.ChannelConnectionsPage
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (sdk.ChannelConnectionsPage, errors.SDKError)); ok {
+		return returnFunc(ctx, channelID, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) sdk.ChannelConnectionsPage); ok {
+		r0 = returnFunc(ctx, channelID, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ChannelConnectionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, channelID, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ChannelConnections_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChannelConnections'
+type SDK_ChannelConnections_Call struct {
+	*mock.Call
+}
+
+// ChannelConnections is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channelID string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ChannelConnections(ctx interface{}, channelID interface{}, domainID interface{}, token interface{}) *SDK_ChannelConnections_Call {
+	return &SDK_ChannelConnections_Call{Call: _e.mock.On("ChannelConnections", ctx, channelID, domainID, token)}
+}
+
+func (_c *SDK_ChannelConnections_Call) Run(run func(ctx context.Context, channelID string, domainID string, token string)) *SDK_ChannelConnections_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ChannelConnections_Call) Return(channelConnectionsPage sdk.ChannelConnectionsPage, sDKError errors.SDKError) *SDK_ChannelConnections_Call {
+	_c.Call.Return(channelConnectionsPage, sDKError)
+	return _c
+}
+
+func (_c *SDK_ChannelConnections_Call) RunAndReturn(run func(ctx context.Context, channelID string, domainID string, token string) (sdk.ChannelConnectionsPage, errors.SDKError)) *SDK_ChannelConnections_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Channels provides a mock function for the type SDK
 func (_mock *SDK) Channels(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.ChannelsPage, errors.SDKError) {
 	ret := _mock.Called(ctx, pm, domainID, token)
@@ -6004,6 +6084,86 @@ func (_c *SDK_ListGroupMembers_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// ProvisionClient provides a mock function for the type SDK
+func (_mock *SDK) ProvisionClient(ctx context.Context, spec sdk.ProvisionSpec, domainID string, token string) (sdk.Provision, errors.SDKError) {
+	ret := _mock.Called(ctx, spec, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProvisionClient")
+	}
+
+	var r0 sdk.Provision
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.ProvisionSpec, string, string) (sdk.Provision, errors.SDKError)); ok {
+		return returnFunc(ctx, spec, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.ProvisionSpec, string, string) sdk.Provision); ok {
+		r0 = returnFunc(ctx, spec, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Provision)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sdk.ProvisionSpec, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, spec, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ProvisionClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProvisionClient'
+type SDK_ProvisionClient_Call struct {
+	*mock.Call
+}
+
+// ProvisionClient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - spec sdk.ProvisionSpec
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ProvisionClient(ctx interface{}, spec interface{}, domainID interface{}, token interface{}) *SDK_ProvisionClient_Call {
+	return &SDK_ProvisionClient_Call{Call: _e.mock.On("ProvisionClient", ctx, spec, domainID, token)}
+}
+
+func (_c *SDK_ProvisionClient_Call) Run(run func(ctx context.Context, spec sdk.ProvisionSpec, domainID string, token string)) *SDK_ProvisionClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sdk.ProvisionSpec
+		if args[1] != nil {
+			arg1 = args[1].(sdk.ProvisionSpec)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ProvisionClient_Call) Return(provision sdk.Provision, sDKError errors.SDKError) *SDK_ProvisionClient_Call {
+	_c.Call.Return(provision, sDKError)
+	return _c
+}
+
+func (_c *SDK_ProvisionClient_Call) RunAndReturn(run func(ctx context.Context, spec sdk.ProvisionSpec, domainID string, token string) (sdk.Provision, errors.SDKError)) *SDK_ProvisionClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RefreshToken provides a mock function for the type SDK
 func (_mock *SDK) RefreshToken(ctx context.Context, token string) (sdk.Token, errors.SDKError) {
 	ret := _mock.Called(ctx, token)
@@ -8377,6 +8537,83 @@ func (_c *SDK_UpdateClient_Call) RunAndReturn(run func(ctx context.Context, clie
 	return _c
 }
 
+// UpdateClientOwner provides a mock function for the type SDK
+func (_mock *SDK) UpdateClientOwner(ctx context.Context, id string, ownerID string, domainID string, token string) errors.SDKError {
+	ret := _mock.Called(ctx, id, ownerID, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateClientOwner")
+	}
+
+	var r0 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) errors.SDKError); ok {
+		r0 = returnFunc(ctx, id, ownerID, domainID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+	return r0
+}
+
+// SDK_UpdateClientOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateClientOwner'
+type SDK_UpdateClientOwner_Call struct {
+	*mock.Call
+}
+
+// UpdateClientOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) UpdateClientOwner(ctx interface{}, id interface{}, ownerID interface{}, domainID interface{}, token interface{}) *SDK_UpdateClientOwner_Call {
+	return &SDK_UpdateClientOwner_Call{Call: _e.mock.On("UpdateClientOwner", ctx, id, ownerID, domainID, token)}
+}
+
+func (_c *SDK_UpdateClientOwner_Call) Run(run func(ctx context.Context, id string, ownerID string, domainID string, token string)) *SDK_UpdateClientOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_UpdateClientOwner_Call) Return(sDKError errors.SDKError) *SDK_UpdateClientOwner_Call {
+	_c.Call.Return(sDKError)
+	return _c
+}
+
+func (_c *SDK_UpdateClientOwner_Call) RunAndReturn(run func(ctx context.Context, id string, ownerID string, domainID string, token string) errors.SDKError) *SDK_UpdateClientOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateClientRole provides a mock function for the type SDK
 func (_mock *SDK) UpdateClientRole(ctx context.Context, id string, roleID string, newName string, domainID string, token string) (sdk.Role, errors.SDKError) {
 	ret := _mock.Called(ctx, id, roleID, newName, domainID, token)
@@ -24,6 +24,10 @@ type updateClientSecretReq struct {
 	Secret string `json:"secret,omitempty"`
 }
 
+type updateClientOwnerReq struct {
+	OwnerID string `json:"owner_id"`
+}
+
 // updateUserEmailReq is used to update the user email.
 type updateUserEmailReq struct {
 	token string
@@ -39,9 +43,19 @@ type UserPasswordReq struct {
 
 // Connection contains clients and channel IDs that are connected.
 type Connection struct {
-	ClientIDs  []string `json:"client_ids,omitempty"`
-	ChannelIDs []string `json:"channel_ids,omitempty"`
-	Types      []string `json:"types,omitempty"`
+	ClientIDs  []string       `json:"client_ids,omitempty"`
+	ChannelIDs []string       `json:"channel_ids,omitempty"`
+	Types      []string       `json:"types,omitempty"`
+	Metadata   map[string]any `json:"metadata,omitempty"`
+}
+
+// ProvisionSpec describes a client to provision, with an optional channel
+// the client is connected to.
+type ProvisionSpec struct {
+	Client  Client
+	Channel *Channel
+	// Types contains the connection types; publish and subscribe by default.
+	Types []string
 }
 
 type UsersRelationRequest struct {
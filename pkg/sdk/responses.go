@@ -31,12 +31,24 @@ type ChannelsPage struct {
 	PageRes
 }
 
+// ChannelConnectionsPage contains list of channel connections with proper metadata.
+type ChannelConnectionsPage struct {
+	Connections []ChannelConnection `json:"connections"`
+	PageRes
+}
+
 // MessagesPage contains list of messages in a page with proper metadata.
 type MessagesPage struct {
 	Messages []senml.Message `json:"messages,omitempty"`
 	PageRes
 }
 
+// Provision contains the entities created by client provisioning.
+type Provision struct {
+	Client  Client   `json:"client"`
+	Channel *Channel `json:"channel,omitempty"`
+}
+
 type GroupsPage struct {
 	Groups []Group `json:"groups"`
 	PageRes
@@ -164,6 +164,8 @@ type PageMetadata struct {
 	EndLevel        int64     `json:"end_level,omitempty"`
 	CreatedFrom     time.Time `json:"created_from,omitempty"`
 	CreatedTo       time.Time `json:"created_to,omitempty"`
+	ApproxCount     bool      `json:"approx_count,omitempty"`
+	NamePrefix      bool      `json:"name_prefix,omitempty"`
 }
 
 type Role struct {
@@ -506,6 +508,14 @@ type SDK interface {
 	//  fmt.Println(client)
 	UpdateClientSecret(ctx context.Context, id, secret, domainID, token string) (Client, errors.SDKError)
 
+	// UpdateClientOwner transfers ownership of the client to the given user.
+	//
+	// example:
+	//  ctx := context.Background()
+	//  err := sdk.UpdateClientOwner(ctx, "clientID", "ownerID", "domainID", "token")
+	//  fmt.Println(err)
+	UpdateClientOwner(ctx context.Context, id, ownerID, domainID, token string) errors.SDKError
+
 	// EnableClient changes client status to enabled.
 	//
 	// example:
@@ -530,6 +540,20 @@ type SDK interface {
 	//  fmt.Println(err)
 	DeleteClient(ctx context.Context, id, domainID, token string) errors.SDKError
 
+	// ProvisionClient creates a client and, if the spec contains a channel,
+	// creates the channel and connects the client to it. If any step fails,
+	// the created entities are deleted.
+	//
+	// example:
+	//  ctx := context.Background()
+	//  spec := sdk.ProvisionSpec{
+	//    Client:  sdk.Client{Name: "My Client"},
+	//    Channel: &sdk.Channel{Name: "My Channel"},
+	//  }
+	//  prov, _ := sdk.ProvisionClient(ctx, spec, "domainID", "token")
+	//  fmt.Println(prov.Client.ID, prov.Channel.ID)
+	ProvisionClient(ctx context.Context, spec ProvisionSpec, domainID, token string) (Provision, errors.SDKError)
+
 	// SetClientParent sets the parent group of a client.
 	//
 	// example:
@@ -1149,6 +1173,15 @@ type SDK interface {
 	//  fmt.Println(err)
 	DisconnectClients(ctx context.Context, channelID string, clientIDs, connTypes []string, domainID, token string) errors.SDKError
 
+	// ChannelConnections lists the clients connected to the channel, with
+	// the connection type and metadata of every connection.
+	//
+	// example:
+	//  ctx := context.Background()
+	//  page, _ := sdk.ChannelConnections(ctx, "channelID", "domainID", "token")
+	//  fmt.Println(page)
+	ChannelConnections(ctx context.Context, channelID, domainID, token string) (ChannelConnectionsPage, errors.SDKError)
+
 	// ListChannelMembers list all members from all roles in a channel .
 	//
 	// example:
@@ -1710,6 +1743,12 @@ func (pm PageMetadata) query() (string, error) {
 	if !pm.CreatedTo.IsZero() {
 		q.Add("created_to", pm.CreatedTo.Format(time.RFC3339))
 	}
+	if pm.ApproxCount {
+		q.Add("approx_count", strconv.FormatBool(pm.ApproxCount))
+	}
+	if pm.NamePrefix {
+		q.Add("name_prefix", strconv.FormatBool(pm.NamePrefix))
+	}
 	q.Add("with_attributes", strconv.FormatBool(pm.WithAttributes))
 	q.Add("with_metadata", strconv.FormatBool(pm.WithMetadata))
 
@@ -28,6 +28,7 @@ import (
 	httpapi "github.com/absmach/supermq/users/api"
 	umocks "github.com/absmach/supermq/users/mocks"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-kit/kit/metrics/discard"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -47,7 +48,7 @@ func setupUsers() (*httptest.Server, *umocks.Service, *authnmocks.Authentication
 	authn := new(authnmocks.Authentication)
 	am := smqauthn.NewAuthNMiddleware(authn, smqauthn.WithDomainCheck(false), smqauthn.WithAllowUnverifiedUser(true))
 	token := new(authmocks.TokenServiceClient)
-	httpapi.MakeHandler(usvc, am, token, true, mux, logger, "", passRegex, idp, provider)
+	httpapi.MakeHandler(usvc, am, token, true, mux, logger, "", passRegex, idp, 1024, new(oauth2mocks.StateStore), discard.NewCounter(), discard.NewHistogram(), provider)
 
 	return httptest.NewServer(mux), usvc, authn
 }
@@ -28,16 +28,20 @@ type grpcServer struct {
 	server          *grpc.Server
 	registerService serviceRegister
 	health          *health.Server
+	options         []grpc.ServerOption
 }
 
 var _ server.Server = (*grpcServer)(nil)
 
-func NewServer(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, registerService serviceRegister, logger *slog.Logger) server.Server {
+// NewServer returns a gRPC server. Options, such as additional interceptors,
+// are applied after the default ones.
+func NewServer(ctx context.Context, cancel context.CancelFunc, name string, config server.Config, registerService serviceRegister, logger *slog.Logger, opts ...grpc.ServerOption) server.Server {
 	baseServer := server.NewBaseServer(ctx, cancel, name, config, logger)
 
 	return &grpcServer{
 		BaseServer:      baseServer,
 		registerService: registerService,
+		options:         opts,
 	}
 }
 
@@ -45,6 +49,7 @@ func (s *grpcServer) Start() error {
 	errCh := make(chan error)
 	grpcServerOptions := []grpc.ServerOption{
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(requestIDInterceptor),
 	}
 
 	listener, err := net.Listen("tcp", s.Address)
@@ -104,6 +109,7 @@ func (s *grpcServer) Start() error {
 	}
 
 	grpcServerOptions = append(grpcServerOptions, creds)
+	grpcServerOptions = append(grpcServerOptions, s.options...)
 
 	s.server = grpc.NewServer(grpcServerOptions...)
 	s.health = health.NewServer()
@@ -0,0 +1,29 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDKey is the gRPC metadata key used to pass the request ID
+// between services.
+const RequestIDKey = "x-request-id"
+
+// requestIDInterceptor stores the request ID received in the call metadata
+// in the context, so a call made while handling an HTTP request is logged
+// with the same request ID as the HTTP request itself.
+func requestIDInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 && ids[0] != "" {
+			ctx = context.WithValue(ctx, middleware.RequestIDKey, ids[0])
+		}
+	}
+
+	return handler(ctx, req)
+}
@@ -11,7 +11,9 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -31,6 +33,8 @@ import (
 	usersapi "github.com/absmach/supermq/users/api"
 	"github.com/absmach/supermq/users/mocks"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -60,7 +64,10 @@ var (
 	validTimeStamp  = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 )
 
-const contentType = "application/json"
+const (
+	contentType      = "application/json"
+	oauthMaxBodySize = 1024
+)
 
 type testRequest struct {
 	user        *http.Client
@@ -101,7 +108,7 @@ func newUsersServer() (*httptest.Server, *mocks.Service, *authnmocks.Authenticat
 	authn := new(authnmocks.Authentication)
 	am := smqauthn.NewAuthNMiddleware(authn)
 	token := new(authmocks.TokenServiceClient)
-	usersapi.MakeHandler(svc, am, token, true, mux, logger, "", passRegex, idp, provider)
+	usersapi.MakeHandler(svc, am, token, true, mux, logger, "", passRegex, idp, oauthMaxBodySize, new(oauth2mocks.StateStore), discard.NewCounter(), discard.NewHistogram(), provider)
 
 	return httptest.NewServer(mux), svc, authn
 }
@@ -361,6 +368,14 @@ func TestViewProfile(t *testing.T) {
 			authnRes: verifiedSession,
 			err:      nil,
 		},
+		{
+			desc:     "view profile with recovery token",
+			token:    validToken,
+			id:       user.ID,
+			status:   http.StatusUnauthorized,
+			authnRes: smqauthn.Session{Type: smqauthn.RecoveryToken, UserID: validID},
+			err:      apiutil.ErrTokenTypeNotAllowed,
+		},
 		{
 			desc:     "view profile with invalid token",
 			token:    inValidToken,
@@ -2619,6 +2634,24 @@ func TestRefreshToken(t *testing.T) {
 			status:      http.StatusCreated,
 			err:         nil,
 		},
+		{
+			desc:        "refresh token with refresh token type",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{Type: smqauthn.RefreshToken, UserID: validID, Verified: true},
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "refresh token with recovery token type",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{Type: smqauthn.RecoveryToken, UserID: validID},
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrTokenTypeNotAllowed,
+		},
 		{
 			desc:        "refresh token with invalid token",
 			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, inValidToken, validID),
@@ -3171,3 +3204,142 @@ type respBody struct {
 	Role    users.Role   `json:"role"`
 	Status  users.Status `json:"status"`
 }
+
+// labeledCounter counts additions by their label values.
+type labeledCounter struct {
+	mu     *sync.Mutex
+	counts map[string]float64
+	labels []string
+}
+
+func newLabeledCounter() *labeledCounter {
+	return &labeledCounter{mu: &sync.Mutex{}, counts: make(map[string]float64)}
+}
+
+func (c *labeledCounter) With(labelValues ...string) metrics.Counter {
+	return &labeledCounter{mu: c.mu, counts: c.counts, labels: append(slices.Clone(c.labels), labelValues...)}
+}
+
+func (c *labeledCounter) Add(delta float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[strings.Join(c.labels, ",")] += delta
+}
+
+func (c *labeledCounter) value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[strings.Join(labelValues, ",")]
+}
+
+func newOAuthServer() (*httptest.Server, *oauth2mocks.Provider, *oauth2mocks.StateStore, *labeledCounter) {
+	svc := new(mocks.Service)
+	logger := smqlog.NewMock()
+	mux := chi.NewRouter()
+	provider := new(oauth2mocks.Provider)
+	provider.On("Name").Return("test")
+	provider.On("IsEnabled").Return(true)
+	provider.On("ErrorURL").Return("http://localhost/error")
+	states := new(oauth2mocks.StateStore)
+	am := smqauthn.NewAuthNMiddleware(new(authnmocks.Authentication))
+	counter := newLabeledCounter()
+	usersapi.MakeHandler(svc, am, new(authmocks.TokenServiceClient), true, mux, logger, "", passRegex, uuid.NewMock(), oauthMaxBodySize, states, counter, discard.NewHistogram(), provider)
+
+	return httptest.NewServer(mux), provider, states, counter
+}
+
+func TestOAuthAuthorize(t *testing.T) {
+	us, provider, states, _ := newOAuthServer()
+	defer us.Close()
+
+	var state string
+	saveCall := states.On("Save", mock.Anything, "test", mock.Anything).Run(func(args mock.Arguments) {
+		state = args.String(2)
+	}).Return(nil)
+	provider.On("AuthURL", mock.Anything).Return("http://provider/consent")
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err := client.Get(us.URL + "/oauth/authorize/test")
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusFound, res.StatusCode)
+	assert.Equal(t, "http://provider/consent", res.Header.Get("Location"))
+	var cookie *http.Cookie
+	for _, c := range res.Cookies() {
+		if c.Name == "oauth_state" {
+			cookie = c
+		}
+	}
+	assert.NotNil(t, cookie, "expected oauth_state cookie")
+	if cookie != nil {
+		assert.Equal(t, state, cookie.Value)
+		assert.True(t, cookie.HttpOnly)
+		assert.True(t, cookie.Secure)
+		assert.Equal(t, http.SameSiteNoneMode, cookie.SameSite)
+	}
+	provider.AssertCalled(t, "AuthURL", state)
+	saveCall.Unset()
+}
+
+func TestOAuthCallbackState(t *testing.T) {
+	us, _, states, counter := newOAuthServer()
+	defer us.Close()
+
+	const state = "state"
+	cases := []struct {
+		desc       string
+		cookie     string
+		consumed   bool
+		consumeErr error
+		location   string
+	}{
+		{
+			desc:     "callback without state cookie",
+			location: "http://localhost/error?error=invalid%20state",
+		},
+		{
+			desc:     "callback with state cookie from another flow",
+			cookie:   "other",
+			location: "http://localhost/error?error=invalid%20state",
+		},
+		{
+			desc:       "callback with unknown state",
+			cookie:     state,
+			consumed:   true,
+			consumeErr: errors.New("invalid state"),
+			location:   "http://localhost/error?error=invalid%20state",
+		},
+		{
+			desc:     "callback with matching state and no code",
+			cookie:   state,
+			consumed: true,
+			location: "http://localhost/error?error=empty%20code",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			consumeCall := states.On("Consume", mock.Anything, "test", state).Return(tc.consumeErr)
+			failures := counter.value("provider", "test", "result", "error")
+			req, err := http.NewRequest(http.MethodGet, us.URL+"/oauth/callback/test?state="+state, nil)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "oauth_state", Value: tc.cookie})
+			}
+			client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+			res, err := client.Do(req)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			defer res.Body.Close()
+			assert.Equal(t, http.StatusSeeOther, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, http.StatusSeeOther, res.StatusCode))
+			assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+			if tc.consumed {
+				states.AssertCalled(t, "Consume", mock.Anything, "test", state)
+			} else {
+				states.AssertNotCalled(t, "Consume", mock.Anything, "test", state)
+			}
+			assert.Equal(t, failures+1, counter.value("provider", "test", "result", "error"), fmt.Sprintf("%s: expected failed callback to be counted", tc.desc))
+			consumeCall.Unset()
+		})
+	}
+}
@@ -14,12 +14,13 @@ import (
 	"github.com/absmach/supermq/pkg/oauth2"
 	"github.com/absmach/supermq/users"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-kit/kit/metrics"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // MakeHandler returns a HTTP handler for Users and Groups API endpoints.
-func MakeHandler(cls users.Service, authn smqauthn.AuthNMiddleware, tokensvc grpcTokenV1.TokenServiceClient, selfRegister bool, mux *chi.Mux, logger *slog.Logger, instanceID string, pr *regexp.Regexp, idp supermq.IDProvider, providers ...oauth2.Provider) http.Handler {
-	mux = usersHandler(cls, authn, tokensvc, selfRegister, mux, logger, pr, idp, providers...)
+func MakeHandler(cls users.Service, authn smqauthn.AuthNMiddleware, tokensvc grpcTokenV1.TokenServiceClient, selfRegister bool, mux *chi.Mux, logger *slog.Logger, instanceID string, pr *regexp.Regexp, idp supermq.IDProvider, oauthMaxBodySize int64, states oauth2.StateStore, oauthCounter metrics.Counter, oauthLatency metrics.Histogram, providers ...oauth2.Provider) http.Handler {
+	mux = usersHandler(cls, authn, tokensvc, selfRegister, mux, logger, pr, idp, oauthMaxBodySize, states, oauthCounter, oauthLatency, providers...)
 
 	mux.Get("/health", supermq.Health("users", instanceID))
 	mux.Handle("/metrics", promhttp.Handler())
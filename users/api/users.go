@@ -5,6 +5,7 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -22,14 +23,24 @@ import (
 	"github.com/absmach/supermq/pkg/oauth2"
 	"github.com/absmach/supermq/users"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-kit/kit/metrics"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+const (
+	oauthStateCookie = "oauth_state"
+	oauthStatePath   = "/oauth"
+
+	// Results of OAuth callbacks.
+	oauthSuccess = "success"
+	oauthError   = "error"
+)
+
 var passRegex = regexp.MustCompile("^.{8,}$")
 
 // usersHandler returns a HTTP handler for API endpoints.
-func usersHandler(svc users.Service, authn smqauthn.AuthNMiddleware, tokenClient grpcTokenV1.TokenServiceClient, selfRegister bool, r *chi.Mux, logger *slog.Logger, pr *regexp.Regexp, idp supermq.IDProvider, providers ...oauth2.Provider) *chi.Mux {
+func usersHandler(svc users.Service, authn smqauthn.AuthNMiddleware, tokenClient grpcTokenV1.TokenServiceClient, selfRegister bool, r *chi.Mux, logger *slog.Logger, pr *regexp.Regexp, idp supermq.IDProvider, oauthMaxBodySize int64, states oauth2.StateStore, oauthCounter metrics.Counter, oauthLatency metrics.Histogram, providers ...oauth2.Provider) *chi.Mux {
 	passRegex = pr
 
 	opts := []kithttp.ServerOption{
@@ -57,6 +68,16 @@ func usersHandler(svc users.Service, authn smqauthn.AuthNMiddleware, tokenClient
 				opts...,
 			), "register_user").ServeHTTP)
 		}
+		// Refresh token is sent as the bearer token
+		r.Group(func(r chi.Router) {
+			r.Use(authn.WithOptions(smqauthn.WithAllowUnverifiedUser(true), smqauthn.WithAllowedTokenTypes(smqauthn.AccessToken, smqauthn.RefreshToken)).Middleware())
+			r.Post("/tokens/refresh", otelhttp.NewHandler(kithttp.NewServer(
+				refreshTokenEndpoint(svc),
+				decodeRefreshToken,
+				api.EncodeResponse,
+				opts...,
+			), "refresh_token").ServeHTTP)
+		})
 		// Endpoints which are allowed for unverified user
 		r.Group(func(r chi.Router) {
 			r.Use(authn.WithOptions(smqauthn.WithAllowUnverifiedUser(true)).Middleware())
@@ -73,12 +94,6 @@ func usersHandler(svc users.Service, authn smqauthn.AuthNMiddleware, tokenClient
 				api.EncodeResponse,
 				opts...,
 			), "view_profile").ServeHTTP)
-			r.Post("/tokens/refresh", otelhttp.NewHandler(kithttp.NewServer(
-				refreshTokenEndpoint(svc),
-				decodeRefreshToken,
-				api.EncodeResponse,
-				opts...,
-			), "refresh_token").ServeHTTP)
 			r.Post("/tokens/revoke", otelhttp.NewHandler(kithttp.NewServer(
 				revokeRefreshTokenEndpoint(svc),
 				decodeRevokeRefreshToken,
@@ -189,7 +204,7 @@ func usersHandler(svc users.Service, authn smqauthn.AuthNMiddleware, tokenClient
 	})
 
 	r.Group(func(r chi.Router) {
-		r.Use(authn.WithOptions(smqauthn.WithAllowUnverifiedUser(true)).Middleware())
+		r.Use(authn.WithOptions(smqauthn.WithAllowUnverifiedUser(true), smqauthn.WithAllowedTokenTypes(smqauthn.AccessToken, smqauthn.RecoveryToken)).Middleware())
 		r.Put("/password/reset", otelhttp.NewHandler(kithttp.NewServer(
 			passwordResetEndpoint(svc),
 			decodePasswordReset,
@@ -219,9 +234,10 @@ func usersHandler(svc users.Service, authn smqauthn.AuthNMiddleware, tokenClient
 		opts...,
 	), "verify_email").ServeHTTP)
 
-	for _, provider := range providers {
-		r.HandleFunc("/oauth/callback/"+provider.Name(), oauth2CallbackHandler(provider, svc, tokenClient))
-	}
+	registry := oauth2.NewRegistry(providers...)
+	r.Get("/oauth/authorize/{provider}", oauth2AuthorizeHandler(registry, states))
+	r.With(api.LimitBodyMiddleware(oauthMaxBodySize, "application/x-www-form-urlencoded")).
+		HandleFunc("/oauth/callback/{provider}", oauth2CallbackHandler(registry, states, svc, tokenClient, oauthCounter, oauthLatency))
 
 	return r
 }
@@ -606,76 +622,150 @@ func decodeChangeUserStatus(_ context.Context, r *http.Request) (any, error) {
 	return req, nil
 }
 
-// oauth2CallbackHandler is a http.HandlerFunc that handles OAuth2 callbacks.
-func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service, tokenClient grpcTokenV1.TokenServiceClient) http.HandlerFunc {
+// oauth2AuthorizeHandler is a http.HandlerFunc that starts the OAuth2 flow of
+// the provider named in the request path. It issues a single-use state, binds
+// it to the browser with a cookie and redirects to the provider consent page.
+func oauth2AuthorizeHandler(providers oauth2.Registry, states oauth2.StateStore) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		oauth, ok := providers.Get(chi.URLParam(r, "provider"))
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
 		if !oauth.IsEnabled() {
 			http.Redirect(w, r, oauth.ErrorURL()+"?error=oauth%20provider%20is%20disabled", http.StatusSeeOther)
 			return
 		}
+
+		state, err := oauth2.GenerateState()
+		if err != nil {
+			http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
+			return
+		}
+		if err := states.Save(r.Context(), oauth.Name(), state); err != nil {
+			http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
+			return
+		}
+
+		// SameSite must be None: the callback is a cross-site request from
+		// the provider, which is a POST when response_mode=form_post is used,
+		// and Lax cookies are not sent with cross-site POST requests.
+		http.SetCookie(w, &http.Cookie{
+			Name:     oauthStateCookie,
+			Value:    state,
+			Path:     oauthStatePath,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteNoneMode,
+		})
+		http.Redirect(w, r, oauth.AuthURL(state), http.StatusFound)
+	}
+}
+
+// oauth2CallbackHandler is a http.HandlerFunc that handles OAuth2 callbacks
+// of the provider named in the request path. The state must match both the
+// cookie set by oauth2AuthorizeHandler in the same browser and a state issued
+// by the store, so that a callback started elsewhere cannot log the user in.
+// Every callback of a known provider is counted by the provider and result.
+func oauth2CallbackHandler(providers oauth2.Registry, states oauth2.StateStore, svc users.Service, tokenClient grpcTokenV1.TokenServiceClient, counter metrics.Counter, latency metrics.Histogram) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		oauth, ok := providers.Get(chi.URLParam(r, "provider"))
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		result := oauthSuccess
+		defer func(begin time.Time) {
+			counter.With("provider", oauth.Name(), "result", result).Add(1)
+			latency.With("provider", oauth.Name(), "result", result).Observe(time.Since(begin).Seconds())
+		}(time.Now())
+		redirectError := func(msg string) {
+			result = oauthError
+			http.Redirect(w, r, oauth.ErrorURL()+"?error="+msg, http.StatusSeeOther)
+		}
+
+		if !oauth.IsEnabled() {
+			redirectError("oauth%20provider%20is%20disabled")
+			return
+		}
 		state := r.FormValue("state")
-		if state != oauth.State() {
-			http.Redirect(w, r, oauth.ErrorURL()+"?error=invalid%20state", http.StatusSeeOther)
+		cookie, err := r.Cookie(oauthStateCookie)
+		http.SetCookie(w, &http.Cookie{
+			Name:     oauthStateCookie,
+			Path:     oauthStatePath,
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteNoneMode,
+		})
+		if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+			redirectError("invalid%20state")
+			return
+		}
+		if err := states.Consume(r.Context(), oauth.Name(), state); err != nil {
+			redirectError("invalid%20state")
+			return
+		}
+
+		code := r.FormValue("code")
+		if code == "" {
+			redirectError("empty%20code")
+			return
+		}
+
+		token, err := oauth.Exchange(r.Context(), code)
+		if err != nil {
+			redirectError(err.Error())
+			return
+		}
+
+		user, err := oauth.UserInfo(token.AccessToken)
+		if err != nil {
+			redirectError(err.Error())
 			return
 		}
 
-		if code := r.FormValue("code"); code != "" {
-			token, err := oauth.Exchange(r.Context(), code)
-			if err != nil {
-				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
-				return
-			}
-
-			user, err := oauth.UserInfo(token.AccessToken)
-			if err != nil {
-				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
-				return
-			}
-
-			user.AuthProvider = oauth.Name()
-			if user.AuthProvider == "" {
-				user.AuthProvider = "oauth"
-			}
-			user, err = svc.OAuthCallback(r.Context(), user)
-			if err != nil {
-				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
-				return
-			}
-			if err := svc.OAuthAddUserPolicy(r.Context(), user); err != nil {
-				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
-				return
-			}
-
-			jwt, err := tokenClient.Issue(r.Context(), &grpcTokenV1.IssueReq{
-				UserId:   user.ID,
-				Type:     uint32(smqauth.AccessKey),
-				UserRole: uint32(smqauth.UserRole),
-				Verified: !user.VerifiedAt.IsZero(),
-			})
-			if err != nil {
-				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
-				return
-			}
-
-			http.SetCookie(w, &http.Cookie{
-				Name:     "access_token",
-				Value:    jwt.GetAccessToken(),
-				Path:     "/",
-				HttpOnly: true,
-				Secure:   true,
-			})
-			http.SetCookie(w, &http.Cookie{
-				Name:     "refresh_token",
-				Value:    jwt.GetRefreshToken(),
-				Path:     "/",
-				HttpOnly: true,
-				Secure:   true,
-			})
-
-			http.Redirect(w, r, oauth.RedirectURL(), http.StatusFound)
+		user.AuthProvider = oauth.Name()
+		if user.AuthProvider == "" {
+			user.AuthProvider = "oauth"
+		}
+		user, err = svc.OAuthCallback(r.Context(), user)
+		if err != nil {
+			redirectError(err.Error())
+			return
+		}
+		if err := svc.OAuthAddUserPolicy(r.Context(), user); err != nil {
+			redirectError(err.Error())
 			return
 		}
 
-		http.Redirect(w, r, oauth.ErrorURL()+"?error=empty%20code", http.StatusSeeOther)
+		jwt, err := tokenClient.Issue(r.Context(), &grpcTokenV1.IssueReq{
+			UserId:   user.ID,
+			Type:     uint32(smqauth.AccessKey),
+			UserRole: uint32(smqauth.UserRole),
+			Verified: !user.VerifiedAt.IsZero(),
+		})
+		if err != nil {
+			redirectError(err.Error())
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     "access_token",
+			Value:    jwt.GetAccessToken(),
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   true,
+		})
+		http.SetCookie(w, &http.Cookie{
+			Name:     "refresh_token",
+			Value:    jwt.GetRefreshToken(),
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   true,
+		})
+
+		http.Redirect(w, r, oauth.RedirectURL(), http.StatusFound)
 	}
 }
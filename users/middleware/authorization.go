@@ -10,6 +10,7 @@ import (
 	"github.com/absmach/supermq/auth"
 	"github.com/absmach/supermq/pkg/authn"
 	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/users"
@@ -17,6 +18,8 @@ import (
 
 var _ users.Service = (*authorizationMiddleware)(nil)
 
+var errScopedSession = errors.New("operation not allowed with scoped API keys")
+
 type authorizationMiddleware struct {
 	svc          users.Service
 	authz        smqauthz.Authorization
@@ -29,6 +32,10 @@ func NewAuthorization(svc users.Service, authz smqauthz.Authorization, selfRegis
 }
 
 func (am *authorizationMiddleware) SendVerification(ctx context.Context, session authn.Session) error {
+	if err := checkScopes(session); err != nil {
+		return err
+	}
+
 	return am.svc.SendVerification(ctx, session)
 }
 
@@ -37,6 +44,10 @@ func (am *authorizationMiddleware) VerifyEmail(ctx context.Context, verification
 }
 
 func (am *authorizationMiddleware) Register(ctx context.Context, session authn.Session, user users.User, selfRegister bool) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if selfRegister {
 		if err := am.checkSuperAdmin(ctx, session); err == nil {
 			session.SuperAdmin = true
@@ -47,6 +58,10 @@ func (am *authorizationMiddleware) Register(ctx context.Context, session authn.S
 }
 
 func (am *authorizationMiddleware) View(ctx context.Context, session authn.Session, id string) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -55,10 +70,18 @@ func (am *authorizationMiddleware) View(ctx context.Context, session authn.Sessi
 }
 
 func (am *authorizationMiddleware) ViewProfile(ctx context.Context, session authn.Session) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	return am.svc.ViewProfile(ctx, session)
 }
 
 func (am *authorizationMiddleware) ListUsers(ctx context.Context, session authn.Session, pm users.Page) (users.UsersPage, error) {
+	if err := checkScopes(session); err != nil {
+		return users.UsersPage{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -71,6 +94,10 @@ func (am *authorizationMiddleware) SearchUsers(ctx context.Context, pm users.Pag
 }
 
 func (am *authorizationMiddleware) Update(ctx context.Context, session authn.Session, id string, user users.UserReq) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -79,6 +106,10 @@ func (am *authorizationMiddleware) Update(ctx context.Context, session authn.Ses
 }
 
 func (am *authorizationMiddleware) UpdateTags(ctx context.Context, session authn.Session, id string, user users.UserReq) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -87,6 +118,10 @@ func (am *authorizationMiddleware) UpdateTags(ctx context.Context, session authn
 }
 
 func (am *authorizationMiddleware) UpdateEmail(ctx context.Context, session authn.Session, id, email string) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -95,6 +130,10 @@ func (am *authorizationMiddleware) UpdateEmail(ctx context.Context, session auth
 }
 
 func (am *authorizationMiddleware) UpdateUsername(ctx context.Context, session authn.Session, id, username string) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -103,6 +142,10 @@ func (am *authorizationMiddleware) UpdateUsername(ctx context.Context, session a
 }
 
 func (am *authorizationMiddleware) UpdateProfilePicture(ctx context.Context, session authn.Session, id string, usr users.UserReq) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -115,14 +158,26 @@ func (am *authorizationMiddleware) SendPasswordReset(ctx context.Context, email
 }
 
 func (am *authorizationMiddleware) UpdateSecret(ctx context.Context, session authn.Session, oldSecret, newSecret string) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	return am.svc.UpdateSecret(ctx, session, oldSecret, newSecret)
 }
 
 func (am *authorizationMiddleware) ResetSecret(ctx context.Context, session authn.Session, secret string) error {
+	if err := checkScopes(session); err != nil {
+		return err
+	}
+
 	return am.svc.ResetSecret(ctx, session, secret)
 }
 
 func (am *authorizationMiddleware) UpdateRole(ctx context.Context, session authn.Session, user users.User) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err != nil {
 		return users.User{}, err
 	}
@@ -135,6 +190,10 @@ func (am *authorizationMiddleware) UpdateRole(ctx context.Context, session authn
 }
 
 func (am *authorizationMiddleware) Enable(ctx context.Context, session authn.Session, id string) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -143,6 +202,10 @@ func (am *authorizationMiddleware) Enable(ctx context.Context, session authn.Ses
 }
 
 func (am *authorizationMiddleware) Disable(ctx context.Context, session authn.Session, id string) (users.User, error) {
+	if err := checkScopes(session); err != nil {
+		return users.User{}, err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -151,6 +214,10 @@ func (am *authorizationMiddleware) Disable(ctx context.Context, session authn.Se
 }
 
 func (am *authorizationMiddleware) Delete(ctx context.Context, session authn.Session, id string) error {
+	if err := checkScopes(session); err != nil {
+		return err
+	}
+
 	if err := am.checkSuperAdmin(ctx, session); err == nil {
 		session.SuperAdmin = true
 	}
@@ -167,14 +234,26 @@ func (am *authorizationMiddleware) IssueToken(ctx context.Context, username, sec
 }
 
 func (am *authorizationMiddleware) RefreshToken(ctx context.Context, session authn.Session, refreshToken string) (*grpcTokenV1.Token, error) {
+	if err := checkScopes(session); err != nil {
+		return nil, err
+	}
+
 	return am.svc.RefreshToken(ctx, session, refreshToken)
 }
 
 func (am *authorizationMiddleware) RevokeRefreshToken(ctx context.Context, session authn.Session, tokenID string) error {
+	if err := checkScopes(session); err != nil {
+		return err
+	}
+
 	return am.svc.RevokeRefreshToken(ctx, session, tokenID)
 }
 
 func (am *authorizationMiddleware) ListActiveRefreshTokens(ctx context.Context, session authn.Session) (*grpcTokenV1.ListUserRefreshTokensRes, error) {
+	if err := checkScopes(session); err != nil {
+		return nil, err
+	}
+
 	return am.svc.ListActiveRefreshTokens(ctx, session)
 }
 
@@ -189,7 +268,22 @@ func (am *authorizationMiddleware) OAuthAddUserPolicy(ctx context.Context, user
 	return am.svc.OAuthAddUserPolicy(ctx, user)
 }
 
+// checkScopes rejects sessions of scoped API keys. Scopes cover only the
+// entities of domains, so a scoped key must not act as its user on the users
+// service, e.g. to update the user's profile or credentials.
+func checkScopes(session authn.Session) error {
+	if len(session.Scopes) > 0 {
+		return errors.Wrap(svcerr.ErrAuthorization, errScopedSession)
+	}
+
+	return nil
+}
+
 func (am *authorizationMiddleware) checkSuperAdmin(ctx context.Context, session authn.Session) error {
+	if err := checkScopes(session); err != nil {
+		return err
+	}
+
 	if session.Role != authn.SuperAdminRole {
 		return svcerr.ErrSuperAdminAction
 	}
@@ -206,6 +300,10 @@ func (am *authorizationMiddleware) checkSuperAdmin(ctx context.Context, session
 }
 
 func (am *authorizationMiddleware) authorize(ctx context.Context, session authn.Session, domain, subjType, subjKind, subj, perm, objType, obj string) error {
+	if err := checkScopes(session); err != nil {
+		return err
+	}
+
 	req := smqauthz.PolicyReq{
 		Domain:      domain,
 		SubjectType: subjType,
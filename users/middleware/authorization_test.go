@@ -0,0 +1,107 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/authn"
+	authzmocks "github.com/absmach/supermq/pkg/authz/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/users"
+	"github.com/absmach/supermq/users/middleware"
+	"github.com/absmach/supermq/users/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const userID = "6e5e10b3-d4df-4758-b426-4929d55ad740"
+
+func TestScopedSession(t *testing.T) {
+	cases := []struct {
+		desc    string
+		session authn.Session
+		err     error
+	}{
+		{
+			desc:    "call with session without scopes",
+			session: authn.Session{UserID: userID},
+			err:     nil,
+		},
+		{
+			desc:    "call with scoped session",
+			session: authn.Session{UserID: userID, Scopes: []string{"clients:read"}},
+			err:     svcerr.ErrAuthorization,
+		},
+	}
+
+	calls := []struct {
+		desc string
+		call func(svc users.Service, session authn.Session) error
+	}{
+		{
+			desc: "view profile",
+			call: func(svc users.Service, session authn.Session) error {
+				_, err := svc.ViewProfile(context.Background(), session)
+				return err
+			},
+		},
+		{
+			desc: "update user",
+			call: func(svc users.Service, session authn.Session) error {
+				_, err := svc.Update(context.Background(), session, userID, users.UserReq{})
+				return err
+			},
+		},
+		{
+			desc: "update secret",
+			call: func(svc users.Service, session authn.Session) error {
+				_, err := svc.UpdateSecret(context.Background(), session, "old", "new")
+				return err
+			},
+		},
+		{
+			desc: "delete user",
+			call: func(svc users.Service, session authn.Session) error {
+				return svc.Delete(context.Background(), session, userID)
+			},
+		},
+		{
+			desc: "refresh token",
+			call: func(svc users.Service, session authn.Session) error {
+				_, err := svc.RefreshToken(context.Background(), session, "refresh")
+				return err
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		for _, c := range calls {
+			t.Run(fmt.Sprintf("%s %s", c.desc, tc.desc), func(t *testing.T) {
+				svc := new(mocks.Service)
+				authz := new(authzmocks.Authorization)
+				authz.On("Authorize", mock.Anything, mock.Anything, mock.Anything).Return(svcerr.ErrAuthorization)
+				am := middleware.NewAuthorization(svc, authz, false)
+				svcCall := svc.On("ViewProfile", mock.Anything, mock.Anything).Return(users.User{}, nil)
+				svcCall1 := svc.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(users.User{}, nil)
+				svcCall2 := svc.On("UpdateSecret", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(users.User{}, nil)
+				svcCall3 := svc.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				svcCall4 := svc.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+				err := c.call(am, tc.session)
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+				if tc.err != nil {
+					assert.Empty(t, svc.Calls, fmt.Sprintf("%s: expected the service not to be called", tc.desc))
+				}
+				svcCall.Unset()
+				svcCall1.Unset()
+				svcCall2.Unset()
+				svcCall3.Unset()
+				svcCall4.Unset()
+			})
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// ErrEmailDomainNotAllowed indicates that the email domain of the user
+// signing in with OAuth2 is not permitted.
+var ErrEmailDomainNotAllowed = errors.NewAuthZError("email domain is not allowed to sign in")
+
+// EmailDomains restricts the email domains of users signing in with OAuth2.
+// Denied domains take precedence over allowed ones. An empty allowlist
+// permits every domain which is not denied.
+type EmailDomains struct {
+	Allowed []string
+	Denied  []string
+}
+
+// Permits reports whether the domain of the given email is permitted.
+func (ed EmailDomains) Permits(email string) bool {
+	i := strings.LastIndex(email, "@")
+	if i < 0 || i == len(email)-1 {
+		return false
+	}
+	domain := email[i+1:]
+
+	if containsDomain(ed.Denied, domain) {
+		return false
+	}
+
+	return len(ed.Allowed) == 0 || containsDomain(ed.Allowed, domain)
+}
+
+func containsDomain(domains []string, domain string) bool {
+	for _, d := range domains {
+		if strings.EqualFold(strings.TrimSpace(d), domain) {
+			return true
+		}
+	}
+
+	return false
+}
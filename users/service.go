@@ -38,23 +38,25 @@ var (
 )
 
 type service struct {
-	token      grpcTokenV1.TokenServiceClient
-	users      Repository
-	idProvider supermq.IDProvider
-	policies   policies.Service
-	hasher     Hasher
-	email      Emailer
+	token        grpcTokenV1.TokenServiceClient
+	users        Repository
+	idProvider   supermq.IDProvider
+	policies     policies.Service
+	hasher       Hasher
+	email        Emailer
+	emailDomains EmailDomains
 }
 
 // NewService returns a new Users service implementation.
-func NewService(token grpcTokenV1.TokenServiceClient, urepo Repository, policyService policies.Service, emailer Emailer, hasher Hasher, idp supermq.IDProvider) Service {
+func NewService(token grpcTokenV1.TokenServiceClient, urepo Repository, policyService policies.Service, emailer Emailer, hasher Hasher, idp supermq.IDProvider, emailDomains EmailDomains) Service {
 	return service{
-		token:      token,
-		users:      urepo,
-		policies:   policyService,
-		hasher:     hasher,
-		email:      emailer,
-		idProvider: idp,
+		token:        token,
+		users:        urepo,
+		policies:     policyService,
+		hasher:       hasher,
+		email:        emailer,
+		idProvider:   idp,
+		emailDomains: emailDomains,
 	}
 }
 
@@ -637,6 +639,10 @@ func (svc *service) checkSuperAdmin(ctx context.Context, session authn.Session)
 }
 
 func (svc service) OAuthCallback(ctx context.Context, user User) (User, error) {
+	if !svc.emailDomains.Permits(user.Email) {
+		return User{}, ErrEmailDomainNotAllowed
+	}
+
 	u, err := svc.users.RetrieveByEmail(ctx, user.Email)
 
 	if errors.Contains(err, repoerr.ErrNotFound) {
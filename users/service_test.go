@@ -63,7 +63,7 @@ func newService() (users.Service, *authmocks.TokenServiceClient, *mocks.Reposito
 	policies := new(policymocks.Service)
 	e := new(mocks.Emailer)
 	tokenClient := new(authmocks.TokenServiceClient)
-	return users.NewService(tokenClient, cRepo, policies, e, phasher, idProvider), tokenClient, cRepo, policies, e
+	return users.NewService(tokenClient, cRepo, policies, e, phasher, idProvider, users.EmailDomains{}), tokenClient, cRepo, policies, e
 }
 
 func newServiceMinimal() (users.Service, *mocks.Repository) {
@@ -71,7 +71,7 @@ func newServiceMinimal() (users.Service, *mocks.Repository) {
 	policies := new(policymocks.Service)
 	e := new(mocks.Emailer)
 	tokenUser := new(authmocks.TokenServiceClient)
-	return users.NewService(tokenUser, cRepo, policies, e, phasher, idProvider), cRepo
+	return users.NewService(tokenUser, cRepo, policies, e, phasher, idProvider, users.EmailDomains{}), cRepo
 }
 
 func TestRegister(t *testing.T) {
@@ -2159,6 +2159,72 @@ func TestOAuthCallback(t *testing.T) {
 	}
 }
 
+func TestOAuthCallbackEmailDomains(t *testing.T) {
+	cRepo := new(mocks.Repository)
+	policies := new(policymocks.Service)
+	emailDomains := users.EmailDomains{
+		Allowed: []string{"example.com", "blocked.com"},
+		Denied:  []string{"blocked.com"},
+	}
+	svc := users.NewService(new(authmocks.TokenServiceClient), cRepo, policies, new(mocks.Emailer), phasher, idProvider, emailDomains)
+
+	existingUser := users.User{
+		ID:         testsutil.GenerateUUID(t),
+		Role:       users.UserRole,
+		VerifiedAt: time.Now(),
+	}
+
+	cases := []struct {
+		desc  string
+		email string
+		err   error
+	}{
+		{
+			desc:  "oauth callback with allowed email domain",
+			email: "test@example.com",
+			err:   nil,
+		},
+		{
+			desc:  "oauth callback with allowed email domain in different case",
+			email: "test@EXAMPLE.com",
+			err:   nil,
+		},
+		{
+			desc:  "oauth callback with email domain not in allowlist",
+			email: "test@other.com",
+			err:   users.ErrEmailDomainNotAllowed,
+		},
+		{
+			desc:  "oauth callback with denied email domain",
+			email: "test@blocked.com",
+			err:   users.ErrEmailDomainNotAllowed,
+		},
+		{
+			desc:  "oauth callback with subdomain of allowed email domain",
+			email: "test@sub.example.com",
+			err:   users.ErrEmailDomainNotAllowed,
+		},
+		{
+			desc:  "oauth callback with invalid email",
+			email: "test",
+			err:   users.ErrEmailDomainNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveByEmail", context.Background(), tc.email).Return(existingUser, nil)
+			_, err := svc.OAuthCallback(context.Background(), users.User{Email: tc.email})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				cRepo.AssertNotCalled(t, "RetrieveByEmail", context.Background(), tc.email)
+				policies.AssertNotCalled(t, "AddPolicies", mock.Anything, mock.Anything)
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
 func TestSendVerification(t *testing.T) {
 	svc, _, cRepo, _, e := newService()
 
@@ -281,6 +281,7 @@ type Service interface {
 
 	// OAuthCallback handles the callback from any supported OAuth provider.
 	// It processes the OAuth tokens and either signs in or signs up the user based on the provided state.
+	// Users whose email domain is not permitted are rejected.
 	OAuthCallback(ctx context.Context, user User) (User, error)
 
 	// OAuthAddUserPolicy adds a policy to the user for an OAuth request.